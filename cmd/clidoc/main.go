@@ -47,6 +47,7 @@ func init() {
 		"NewInfoNodeLabelSave":                       text.NewInfoNodeLabelSave(),
 		"NewInfoNodeLabelSubmit":                     text.NewInfoNodeLabelSubmit(),
 		"NewInfoNodeLabelID":                         text.NewInfoNodeLabelID(),
+		"NewInfoNodeLabelRememberDevice":             text.NewInfoNodeLabelRememberDevice(),
 		"NewErrorValidationSettingsFlowExpired":      text.NewErrorValidationSettingsFlowExpired(aSecondAgo),
 		"NewInfoSelfServiceSettingsTOTPQRCode":       text.NewInfoSelfServiceSettingsTOTPQRCode(),
 		"NewInfoSelfServiceSettingsTOTPSecret":       text.NewInfoSelfServiceSettingsTOTPSecret("{secret}"),
@@ -68,6 +69,18 @@ func init() {
 		"NewInfoSelfServiceSettingsUpdateUnlinkOIDC":              text.NewInfoSelfServiceSettingsUpdateUnlinkOIDC("{provider}"),
 		"NewInfoSelfServiceRegisterWebAuthn":                      text.NewInfoSelfServiceSettingsRegisterWebAuthn(),
 		"NewInfoSelfServiceRegisterWebAuthnDisplayName":           text.NewInfoSelfServiceRegisterWebAuthnDisplayName(),
+		"NewInfoSelfServiceRevokeTrustedDevice":                   text.NewInfoSelfServiceRevokeTrustedDevice("{user_agent}", "{ip_address}", aSecondAgo, aSecondAgo),
+		"NewInfoSelfServiceRemoveWebAuthnAuthenticator":           text.NewInfoSelfServiceRemoveWebAuthnAuthenticator("{name}", "{authenticator}", aSecondAgo),
+		"NewErrorValidationWebAuthnAuthenticatorNotAllowed":       text.NewErrorValidationWebAuthnAuthenticatorNotAllowed(),
+		"NewErrorValidationWebAuthnCloneDetected":                 text.NewErrorValidationWebAuthnCloneDetected(),
+		"NewInfoSelfServiceSettingsEnableOTP":                     text.NewInfoSelfServiceSettingsEnableOTP("{address}"),
+		"NewInfoSelfServiceSettingsDisableOTP":                    text.NewInfoSelfServiceSettingsDisableOTP("{address}"),
+		"NewInfoSelfServiceSettingsMFAEnrollmentPending":          text.NewInfoSelfServiceSettingsMFAEnrollmentPending(aSecondAgo),
+		"NewInfoSelfServiceSettingsMFAEnrollmentOverdue":          text.NewInfoSelfServiceSettingsMFAEnrollmentOverdue(aSecondAgo),
+		"NewInfoSelfServiceSettingsUpdateUnlinkHOTP":              text.NewInfoSelfServiceSettingsUpdateUnlinkHOTP(),
+		"NewErrorValidationNoOTPAddress":                          text.NewErrorValidationNoOTPAddress(),
+		"NewErrorValidationOTPCodeInvalid":                        text.NewErrorValidationOTPCodeInvalid(),
+		"NewErrorValidationOTPResendThrottled":                    text.NewErrorValidationOTPResendThrottled(30),
 		"NewInfoSelfServiceRemoveWebAuthn":                        text.NewInfoSelfServiceRemoveWebAuthn("{name}", aSecondAgo),
 		"NewErrorValidationVerificationFlowExpired":               text.NewErrorValidationVerificationFlowExpired(aSecondAgo),
 		"NewInfoSelfServiceVerificationSuccessful":                text.NewInfoSelfServiceVerificationSuccessful(),
@@ -97,6 +110,11 @@ func init() {
 		"NewErrorValidationDuplicateCredentials":                  text.NewErrorValidationDuplicateCredentials(),
 		"NewErrorValidationDuplicateCredentialsOnOIDCLink":        text.NewErrorValidationDuplicateCredentialsOnOIDCLink(),
 		"NewErrorValidationTOTPVerifierWrong":                     text.NewErrorValidationTOTPVerifierWrong(),
+		"NewErrorValidationTOTPCodeAlreadyUsed":                   text.NewErrorValidationTOTPCodeAlreadyUsed(),
+		"NewErrorValidationNoHOTPDevice":                          text.NewErrorValidationNoHOTPDevice(),
+		"NewErrorValidationHOTPResyncRequired":                    text.NewErrorValidationHOTPResyncRequired(),
+		"NewErrorValidationAccountNotFound":                       text.NewErrorValidationAccountNotFound(),
+		"NewErrorValidationOIDCRequirementsNotMet":                text.NewErrorValidationOIDCRequirementsNotMet("{provider}", "{reason}"),
 		"NewErrorValidationLookupAlreadyUsed":                     text.NewErrorValidationLookupAlreadyUsed(),
 		"NewErrorValidationLookupInvalid":                         text.NewErrorValidationLookupInvalid(),
 		"NewErrorValidationIdentifierMissing":                     text.NewErrorValidationIdentifierMissing(),
@@ -111,6 +129,8 @@ func init() {
 		"NewInfoLogin":                                            text.NewInfoLogin(),
 		"NewInfoLoginTOTP":                                        text.NewInfoLoginTOTP(),
 		"NewInfoLoginLookup":                                      text.NewInfoLoginLookup(),
+		"NewInfoLoginHOTPLabel":                                   text.NewInfoLoginHOTPLabel(),
+		"NewInfoLoginHOTP":                                        text.NewInfoLoginHOTP(),
 		"NewInfoLoginVerify":                                      text.NewInfoLoginVerify(),
 		"NewInfoLoginWith":                                        text.NewInfoLoginWith("{provider}"),
 		"NewErrorValidationLoginFlowExpired":                      text.NewErrorValidationLoginFlowExpired(aSecondAgo),
@@ -120,6 +140,9 @@ func init() {
 		"NewErrorValidationRecoveryNoStrategyFound":               text.NewErrorValidationRecoveryNoStrategyFound(),
 		"NewErrorValidationVerificationNoStrategyFound":           text.NewErrorValidationVerificationNoStrategyFound(),
 		"NewInfoSelfServiceLoginWebAuthn":                         text.NewInfoSelfServiceLoginWebAuthn(),
+		"NewInfoSelfServiceLoginPasskey":                          text.NewInfoSelfServiceLoginPasskey(),
+		"NewInfoSelfServiceLoginOTPSend":                          text.NewInfoSelfServiceLoginOTPSend("{address}"),
+		"NewInfoSelfServiceLoginOTPSent":                          text.NewInfoSelfServiceLoginOTPSent("{address}"),
 		"NewInfoRegistration":                                     text.NewInfoRegistration(),
 		"NewInfoRegistrationWith":                                 text.NewInfoRegistrationWith("{provider}"),
 		"NewInfoRegistrationContinue":                             text.NewInfoRegistrationContinue(),
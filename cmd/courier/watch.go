@@ -51,6 +51,12 @@ func StartCourier(ctx context.Context, r driver.Registry) error {
 		return Watch(ctx, r)
 	})
 
+	if len(r.Config().SessionRevocationNotificationReceivers(ctx)) > 0 {
+		eg.Go(func() error {
+			return WatchRevocationNotifications(ctx, r)
+		})
+	}
+
 	return eg.Wait()
 }
 
@@ -110,3 +116,22 @@ func Watch(ctx context.Context, r driver.Registry) error {
 	r.Logger().Println("Courier worker was shutdown gracefully.")
 	return nil
 }
+
+// WatchRevocationNotifications delivers the session revocation notifications to the back-channel receivers.
+func WatchRevocationNotifications(ctx context.Context, r driver.Registry) error {
+	ctx, cancel := context.WithCancel(ctx)
+
+	r.Logger().Println("Session revocation notification worker started.")
+	if err := graceful.Graceful(func() error {
+		return r.SessionRevocationNotifier().Work(ctx)
+	}, func(_ context.Context) error {
+		cancel()
+		return nil
+	}); err != nil {
+		r.Logger().WithError(err).Error("Failed to run session revocation notification worker.")
+		return err
+	}
+
+	r.Logger().Println("Session revocation notification worker was shutdown gracefully.")
+	return nil
+}
@@ -294,7 +294,16 @@ func bgTasks(d driver.Registry, cmd *cobra.Command, args []string, slOpts *servi
 	ctx := modifiers.ctx
 
 	if d.Config().IsBackgroundCourierEnabled(ctx) {
-		return courier.Watch(ctx, d)
+		eg, ctx := errgroup.WithContext(ctx)
+		eg.Go(func() error {
+			return courier.Watch(ctx, d)
+		})
+		if len(d.Config().SessionRevocationNotificationReceivers(ctx)) > 0 {
+			eg.Go(func() error {
+				return courier.WatchRevocationNotifications(ctx, d)
+			})
+		}
+		return eg.Wait()
 	}
 
 	return nil
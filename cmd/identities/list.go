@@ -17,6 +17,7 @@ func NewListCmd() *cobra.Command {
 		Short:   "List resources",
 	}
 	c.AddCommand(NewListIdentitiesCmd())
+	c.AddCommand(NewListSessionsCmd())
 	cliclient.RegisterClientFlags(c.PersistentFlags())
 	cmdx.RegisterFormatFlags(c.PersistentFlags())
 	return c
@@ -57,3 +58,77 @@ func NewListIdentitiesCmd() *cobra.Command {
 		},
 	}
 }
+
+func NewListSessionsCmd() *cobra.Command {
+	var filter *sessionFilterFlags
+
+	cmd := &cobra.Command{
+		Use:   "sessions",
+		Short: "List sessions",
+		Long:  "List sessions of all identities (paginated). The result can be narrowed down using the filter flags.",
+		Example: `To list all active sessions created from the network 10.0.0.0/8, run:
+
+	{{ .CommandPath }} --active --ip 10.0.0.0/8`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := cliclient.NewClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageToken, pageSize, err := cmdx.ParseTokenPaginationArgs(cmd)
+			if err != nil {
+				return err
+			}
+
+			req := c.IdentityApi.ListSessions(cmd.Context()).
+				PageSize(int64(pageSize)).
+				Expand([]string{"identity"})
+			if pageToken != "" {
+				req = req.PageToken(pageToken)
+			}
+			if filter.isActiveSet() {
+				req = req.Active(filter.active)
+			}
+			if filter.identity != "" {
+				req = req.IdentityId(filter.identity)
+			}
+			if filter.aal != "" {
+				req = req.Aal(filter.aal)
+			}
+			if filter.method != "" {
+				req = req.AuthenticationMethod(filter.method)
+			}
+			if filter.createdAfter != "" {
+				req = req.CreatedAfter(filter.createdAfter)
+			}
+			if filter.createdBefore != "" {
+				req = req.CreatedBefore(filter.createdBefore)
+			}
+			if filter.authenticatedAfter != "" {
+				req = req.AuthenticatedAfter(filter.authenticatedAfter)
+			}
+			if filter.authenticatedBefore != "" {
+				req = req.AuthenticatedBefore(filter.authenticatedBefore)
+			}
+			if filter.ipAddress != "" {
+				req = req.IpAddress(filter.ipAddress)
+			}
+			if filter.userAgent != "" {
+				req = req.UserAgent(filter.userAgent)
+			}
+
+			sessions, _, err := req.Execute()
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			cmdx.PrintTable(cmd, &outputSessionCollection{sessions: sessions})
+			return nil
+		},
+	}
+
+	cmdx.RegisterTokenPaginationFlags(cmd)
+	filter = registerSessionFilterFlags(cmd.Flags())
+	return cmd
+}
@@ -0,0 +1,94 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identities
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/x/cmdx"
+)
+
+func NewRevokeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke resources",
+	}
+	cmd.AddCommand(NewRevokeSessionsCmd())
+	cliclient.RegisterClientFlags(cmd.PersistentFlags())
+	cmdx.RegisterFormatFlags(cmd.PersistentFlags())
+	return cmd
+}
+
+func NewRevokeSessionsCmd() *cobra.Command {
+	var filter *sessionFilterFlags
+
+	cmd := &cobra.Command{
+		Use:   "sessions",
+		Short: "Revoke all sessions matching a filter",
+		Long: `This command revokes all sessions matching the given filter flags. At least one filter flag besides --active must be provided.
+
+Revoked sessions are not deleted. Use the list command with the same filter flags to check which sessions will be affected.`,
+		Example: `To revoke all sessions created from the network 203.0.113.0/24 in the last 24 hours, run:
+
+	{{ .CommandPath }} --ip 203.0.113.0/24 --created-after $(date -u -d '-1 day' +%Y-%m-%dT%H:%M:%SZ)`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if filter.isEmpty() {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "At least one filter flag besides --active must be provided to revoke sessions.")
+				return cmdx.FailSilently(cmd)
+			}
+
+			c, err := cliclient.NewClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			req := c.IdentityApi.RevokeSessions(cmd.Context())
+			if filter.isActiveSet() {
+				req = req.Active(filter.active)
+			}
+			if filter.identity != "" {
+				req = req.IdentityId(filter.identity)
+			}
+			if filter.aal != "" {
+				req = req.Aal(filter.aal)
+			}
+			if filter.method != "" {
+				req = req.AuthenticationMethod(filter.method)
+			}
+			if filter.createdAfter != "" {
+				req = req.CreatedAfter(filter.createdAfter)
+			}
+			if filter.createdBefore != "" {
+				req = req.CreatedBefore(filter.createdBefore)
+			}
+			if filter.authenticatedAfter != "" {
+				req = req.AuthenticatedAfter(filter.authenticatedAfter)
+			}
+			if filter.authenticatedBefore != "" {
+				req = req.AuthenticatedBefore(filter.authenticatedBefore)
+			}
+			if filter.ipAddress != "" {
+				req = req.IpAddress(filter.ipAddress)
+			}
+			if filter.userAgent != "" {
+				req = req.UserAgent(filter.userAgent)
+			}
+
+			revoked, _, err := req.Execute()
+			if err != nil {
+				return cmdx.PrintOpenAPIError(cmd, err)
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%d\n", revoked.GetCount())
+			return nil
+		},
+	}
+
+	filter = registerSessionFilterFlags(cmd.Flags())
+	return cmd
+}
@@ -0,0 +1,157 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identities
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	kratos "github.com/ory/kratos/internal/httpclient"
+	"github.com/ory/x/cmdx"
+)
+
+const (
+	FlagSessionActive              = "active"
+	FlagSessionIdentity            = "identity"
+	FlagSessionAAL                 = "aal"
+	FlagSessionMethod              = "method"
+	FlagSessionCreatedAfter        = "created-after"
+	FlagSessionCreatedBefore       = "created-before"
+	FlagSessionAuthenticatedAfter  = "authenticated-after"
+	FlagSessionAuthenticatedBefore = "authenticated-before"
+	FlagSessionIPAddress           = "ip"
+	FlagSessionUserAgent           = "user-agent"
+)
+
+// sessionFilterFlags holds the values of the flags shared by all commands which filter sessions.
+type sessionFilterFlags struct {
+	flags *pflag.FlagSet
+
+	active              bool
+	identity            string
+	aal                 string
+	method              string
+	createdAfter        string
+	createdBefore       string
+	authenticatedAfter  string
+	authenticatedBefore string
+	ipAddress           string
+	userAgent           string
+}
+
+func registerSessionFilterFlags(flags *pflag.FlagSet) *sessionFilterFlags {
+	f := &sessionFilterFlags{flags: flags}
+	flags.BoolVar(&f.active, FlagSessionActive, false, "Only match active (true) or inactive (false) sessions.")
+	flags.StringVar(&f.identity, FlagSessionIdentity, "", "Only match sessions of the identity with this ID.")
+	flags.StringVar(&f.aal, FlagSessionAAL, "", "Only match sessions with this authenticator assurance level (aal1, aal2, aal3).")
+	flags.StringVar(&f.method, FlagSessionMethod, "", "Only match sessions which completed this authentication method (e.g. password, oidc, webauthn).")
+	flags.StringVar(&f.createdAfter, FlagSessionCreatedAfter, "", "Only match sessions created at or after this RFC 3339 timestamp.")
+	flags.StringVar(&f.createdBefore, FlagSessionCreatedBefore, "", "Only match sessions created before this RFC 3339 timestamp.")
+	flags.StringVar(&f.authenticatedAfter, FlagSessionAuthenticatedAfter, "", "Only match sessions authenticated at or after this RFC 3339 timestamp.")
+	flags.StringVar(&f.authenticatedBefore, FlagSessionAuthenticatedBefore, "", "Only match sessions authenticated before this RFC 3339 timestamp.")
+	flags.StringVar(&f.ipAddress, FlagSessionIPAddress, "", "Only match sessions with a device using this IP address or CIDR range (e.g. 10.0.0.0/8).")
+	flags.StringVar(&f.userAgent, FlagSessionUserAgent, "", "Only match sessions with a device whose user agent contains this string.")
+	return f
+}
+
+// isActiveSet returns true if the active flag was set explicitly.
+func (f *sessionFilterFlags) isActiveSet() bool {
+	return f.flags.Changed(FlagSessionActive)
+}
+
+// isEmpty returns true if none of the filter flags narrowing down the sessions
+// beyond their state were set.
+func (f *sessionFilterFlags) isEmpty() bool {
+	for _, name := range []string{
+		FlagSessionIdentity,
+		FlagSessionAAL,
+		FlagSessionMethod,
+		FlagSessionCreatedAfter,
+		FlagSessionCreatedBefore,
+		FlagSessionAuthenticatedAfter,
+		FlagSessionAuthenticatedBefore,
+		FlagSessionIPAddress,
+		FlagSessionUserAgent,
+	} {
+		if f.flags.Changed(name) {
+			return false
+		}
+	}
+	return true
+}
+
+type (
+	outputSession           kratos.Session
+	outputSessionCollection struct {
+		sessions []kratos.Session
+	}
+)
+
+func (outputSession) Header() []string {
+	return []string{"ID", "IDENTITY ID", "ACTIVE", "AAL", "METHODS", "AUTHENTICATED AT", "EXPIRES AT"}
+}
+
+func (s outputSession) Columns() []string {
+	data := [7]string{
+		s.Id,
+		cmdx.None,
+		cmdx.None,
+		cmdx.None,
+		cmdx.None,
+		cmdx.None,
+		cmdx.None,
+	}
+
+	if s.Identity.Id != "" {
+		data[1] = s.Identity.Id
+	}
+	if s.Active != nil {
+		data[2] = strconv.FormatBool(*s.Active)
+	}
+	if s.AuthenticatorAssuranceLevel != nil {
+		data[3] = string(*s.AuthenticatorAssuranceLevel)
+	}
+	if len(s.AuthenticationMethods) > 0 {
+		methods := make([]string, 0, len(s.AuthenticationMethods))
+		for _, m := range s.AuthenticationMethods {
+			methods = append(methods, m.GetMethod())
+		}
+		data[4] = strings.Join(methods, ", ")
+	}
+	if s.AuthenticatedAt != nil {
+		data[5] = s.AuthenticatedAt.Format(time.RFC3339)
+	}
+	if s.ExpiresAt != nil {
+		data[6] = s.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return data[:]
+}
+
+func (s outputSession) Interface() interface{} {
+	return s
+}
+
+func (outputSessionCollection) Header() []string {
+	return outputSession{}.Header()
+}
+
+func (c outputSessionCollection) Table() [][]string {
+	rows := make([][]string, len(c.sessions))
+	for i, s := range c.sessions {
+		rows[i] = outputSession(s).Columns()
+	}
+	return rows
+}
+
+func (c outputSessionCollection) Interface() interface{} {
+	return c.sessions
+}
+
+func (c *outputSessionCollection) Len() int {
+	return len(c.sessions)
+}
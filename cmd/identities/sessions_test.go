@@ -0,0 +1,87 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identities_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/cmd/identities"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+	"github.com/ory/x/pointerx"
+)
+
+func makeSessions(t *testing.T, reg driver.Registry, i *identity.Identity, ips ...string) (ss []*session.Session) {
+	for _, ip := range ips {
+		s := session.NewInactiveSession()
+		s.Identity = i
+		s.IdentityID = i.ID
+		s.Active = true
+		s.ExpiresAt = time.Now().Add(time.Hour)
+		s.AuthenticatedAt = time.Now()
+		s.Devices = []session.Device{{IPAddress: pointerx.String(ip)}}
+		require.NoError(t, reg.SessionPersister().UpsertSession(context.Background(), s))
+		ss = append(ss, s)
+	}
+	return
+}
+
+func TestListSessionsCmd(t *testing.T) {
+	c := identities.NewListSessionsCmd()
+	reg := setup(t, c)
+
+	is, _ := makeIdentities(t, reg, 1)
+	ss := makeSessions(t, reg, is[0], "10.1.0.1", "10.1.0.2", "192.0.2.1")
+
+	t.Run("case=lists sessions in cidr range", func(t *testing.T) {
+		stdOut := execNoErr(t, c, "--identity", is[0].ID.String(), "--ip", "10.1.0.0/16")
+
+		assert.Contains(t, stdOut, ss[0].ID.String())
+		assert.Contains(t, stdOut, ss[1].ID.String())
+		assert.NotContains(t, stdOut, ss[2].ID.String())
+		assert.Equal(t, is[0].ID.String(), gjson.Get(stdOut, "0.identity.id").String(), stdOut)
+	})
+
+	t.Run("case=fails with invalid filter", func(t *testing.T) {
+		stdErr := execErr(t, c, "--ip", "not-an-ip")
+		assert.Contains(t, stdErr, "could not parse IP address", stdErr)
+	})
+}
+
+func TestRevokeSessionsCmd(t *testing.T) {
+	c := identities.NewRevokeSessionsCmd()
+	reg := setup(t, c)
+
+	is, _ := makeIdentities(t, reg, 1)
+	ss := makeSessions(t, reg, is[0], "10.2.0.1", "192.0.2.2")
+
+	t.Run("case=fails without filter", func(t *testing.T) {
+		stdErr := execErr(t, c)
+		assert.Contains(t, stdErr, "At least one filter flag besides --active must be provided")
+
+		stdErr = execErr(t, c, "--active=true")
+		assert.Contains(t, stdErr, "At least one filter flag besides --active must be provided")
+	})
+
+	t.Run("case=revokes sessions in cidr range", func(t *testing.T) {
+		stdOut := execNoErr(t, c, "--identity", is[0].ID.String(), "--ip", "10.2.0.0/16")
+		assert.Equal(t, "1", strings.TrimSpace(stdOut))
+
+		actual, err := reg.SessionPersister().GetSession(context.Background(), ss[0].ID, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.False(t, actual.Active)
+
+		actual, err = reg.SessionPersister().GetSession(context.Background(), ss[1].ID, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.True(t, actual.Active)
+	})
+}
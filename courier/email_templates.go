@@ -39,6 +39,10 @@ const (
 	TypeVerificationCodeInvalid TemplateType = "verification_code_invalid"
 	TypeVerificationCodeValid   TemplateType = "verification_code_valid"
 	TypeOTP                     TemplateType = "otp"
+	TypeLoginNewDevice          TemplateType = "login_new_device"
+	TypeWebAuthnCloneWarning    TemplateType = "webauthn_clone_warning"
+	TypeLookupSecretLow         TemplateType = "lookup_secret_low"
+	TypeOIDCConnectionChanged   TemplateType = "oidc_connection_changed"
 	TypeTestStub                TemplateType = "stub"
 )
 
@@ -60,6 +64,16 @@ func GetEmailTemplateType(t EmailTemplate) (TemplateType, error) {
 		return TypeVerificationCodeInvalid, nil
 	case *email.VerificationCodeValid:
 		return TypeVerificationCodeValid, nil
+	case *email.LoginNewDevice:
+		return TypeLoginNewDevice, nil
+	case *email.WebAuthnCloneWarning:
+		return TypeWebAuthnCloneWarning, nil
+	case *email.LookupSecretLow:
+		return TypeLookupSecretLow, nil
+	case *email.OIDCConnectionChanged:
+		return TypeOIDCConnectionChanged, nil
+	case *email.OTPMessage:
+		return TypeOTP, nil
 	case *email.TestStub:
 		return TypeTestStub, nil
 	default:
@@ -117,6 +131,36 @@ func NewEmailTemplateFromMessage(d template.Dependencies, msg Message) (EmailTem
 			return nil, err
 		}
 		return email.NewVerificationCodeValid(d, &t), nil
+	case TypeLoginNewDevice:
+		var t email.LoginNewDeviceModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewLoginNewDevice(d, &t), nil
+	case TypeWebAuthnCloneWarning:
+		var t email.WebAuthnCloneWarningModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewWebAuthnCloneWarning(d, &t), nil
+	case TypeLookupSecretLow:
+		var t email.LookupSecretLowModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewLookupSecretLow(d, &t), nil
+	case TypeOIDCConnectionChanged:
+		var t email.OIDCConnectionChangedModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewOIDCConnectionChanged(d, &t), nil
+	case TypeOTP:
+		var t email.OTPMessageModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewOTPMessage(d, &t), nil
 	case TypeTestStub:
 		var t email.TestStubModel
 		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
@@ -26,6 +26,11 @@ func TestGetTemplateType(t *testing.T) {
 		courier.TypeVerificationValid:       &email.VerificationValid{},
 		courier.TypeVerificationCodeInvalid: &email.VerificationCodeInvalid{},
 		courier.TypeVerificationCodeValid:   &email.VerificationCodeValid{},
+		courier.TypeLoginNewDevice:          &email.LoginNewDevice{},
+		courier.TypeWebAuthnCloneWarning:    &email.WebAuthnCloneWarning{},
+		courier.TypeLookupSecretLow:         &email.LookupSecretLow{},
+		courier.TypeOIDCConnectionChanged:   &email.OIDCConnectionChanged{},
+		courier.TypeOTP:                     &email.OTPMessage{},
 		courier.TypeTestStub:                &email.TestStub{},
 	} {
 		t.Run(fmt.Sprintf("case=%s", expectedType), func(t *testing.T) {
@@ -49,6 +54,11 @@ func TestNewEmailTemplateFromMessage(t *testing.T) {
 		courier.TypeVerificationValid:       email.NewVerificationValid(reg, &email.VerificationValidModel{To: "faz", VerificationURL: "http://bar.foo"}),
 		courier.TypeVerificationCodeInvalid: email.NewVerificationCodeInvalid(reg, &email.VerificationCodeInvalidModel{To: "baz"}),
 		courier.TypeVerificationCodeValid:   email.NewVerificationCodeValid(reg, &email.VerificationCodeValidModel{To: "faz", VerificationURL: "http://bar.foo", VerificationCode: "123456678"}),
+		courier.TypeLoginNewDevice:          email.NewLoginNewDevice(reg, &email.LoginNewDeviceModel{To: "far", IPAddress: "127.0.0.1", UserAgent: "Mozilla/5.0", UnrecognizedLoginURL: "http://foo.bar"}),
+		courier.TypeWebAuthnCloneWarning:    email.NewWebAuthnCloneWarning(reg, &email.WebAuthnCloneWarningModel{To: "far", DisplayName: "YubiKey"}),
+		courier.TypeLookupSecretLow:         email.NewLookupSecretLow(reg, &email.LookupSecretLowModel{To: "far", Remaining: 2}),
+		courier.TypeOIDCConnectionChanged:   email.NewOIDCConnectionChanged(reg, &email.OIDCConnectionChangedModel{To: "far", Provider: "google", Linked: true}),
+		courier.TypeOTP:                     email.NewOTPMessage(reg, &email.OTPMessageModel{To: "far", Code: "123456"}),
 		courier.TypeTestStub:                email.NewTestStub(reg, &email.TestStubModel{To: "far", Subject: "test subject", Body: "test body"}),
 	} {
 		t.Run(fmt.Sprintf("case=%s", tmplType), func(t *testing.T) {
@@ -18,8 +18,9 @@ import (
 
 func TestSMSTemplateType(t *testing.T) {
 	for expectedType, tmpl := range map[courier.TemplateType]courier.SMSTemplate{
-		courier.TypeOTP:      &sms.OTPMessage{},
-		courier.TypeTestStub: &sms.TestStub{},
+		courier.TypeOTP:            &sms.OTPMessage{},
+		courier.TypeLoginNewDevice: &sms.LoginNewDevice{},
+		courier.TypeTestStub:       &sms.TestStub{},
 	} {
 		t.Run(fmt.Sprintf("case=%s", expectedType), func(t *testing.T) {
 			actualType, err := courier.SMSTemplateType(tmpl)
@@ -34,8 +35,9 @@ func TestNewSMSTemplateFromMessage(t *testing.T) {
 	ctx := context.Background()
 
 	for tmplType, expectedTmpl := range map[courier.TemplateType]courier.SMSTemplate{
-		courier.TypeOTP:      sms.NewOTPMessage(reg, &sms.OTPMessageModel{To: "+12345678901"}),
-		courier.TypeTestStub: sms.NewTestStub(reg, &sms.TestStubModel{To: "+12345678901", Body: "test body"}),
+		courier.TypeOTP:            sms.NewOTPMessage(reg, &sms.OTPMessageModel{To: "+12345678901"}),
+		courier.TypeLoginNewDevice: sms.NewLoginNewDevice(reg, &sms.LoginNewDeviceModel{To: "+12345678901", UnrecognizedLoginURL: "http://foo.bar"}),
+		courier.TypeTestStub:       sms.NewTestStub(reg, &sms.TestStubModel{To: "+12345678901", Body: "test body"}),
 	} {
 		t.Run(fmt.Sprintf("case=%s", tmplType), func(t *testing.T) {
 			tmplData, err := json.Marshal(expectedTmpl)
@@ -0,0 +1,76 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	LoginNewDevice struct {
+		d template.Dependencies
+		m *LoginNewDeviceModel
+	}
+	LoginNewDeviceModel struct {
+		To                   string
+		IPAddress            string
+		UserAgent            string
+		Location             string
+		UnrecognizedLoginURL string
+		Identity             map[string]interface{}
+	}
+)
+
+func NewLoginNewDevice(d template.Dependencies, m *LoginNewDeviceModel) *LoginNewDevice {
+	return &LoginNewDevice{d: d, m: m}
+}
+
+func (t *LoginNewDevice) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *LoginNewDevice) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadText(
+		ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"login_new_device/email.subject.gotmpl",
+		"login_new_device/email.subject*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesLoginNewDevice(ctx).Subject,
+	)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *LoginNewDevice) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadHTML(ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"login_new_device/email.body.gotmpl",
+		"login_new_device/email.body*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesLoginNewDevice(ctx).Body.HTML,
+	)
+}
+
+func (t *LoginNewDevice) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadText(ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"login_new_device/email.body.plaintext.gotmpl",
+		"login_new_device/email.body.plaintext*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesLoginNewDevice(ctx).Body.PlainText,
+	)
+}
+
+func (t *LoginNewDevice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
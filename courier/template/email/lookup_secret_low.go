@@ -0,0 +1,73 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	LookupSecretLow struct {
+		d template.Dependencies
+		m *LookupSecretLowModel
+	}
+	LookupSecretLowModel struct {
+		To        string
+		Remaining int
+		Identity  map[string]interface{}
+	}
+)
+
+func NewLookupSecretLow(d template.Dependencies, m *LookupSecretLowModel) *LookupSecretLow {
+	return &LookupSecretLow{d: d, m: m}
+}
+
+func (t *LookupSecretLow) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *LookupSecretLow) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadText(
+		ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"lookup_secret_low/email.subject.gotmpl",
+		"lookup_secret_low/email.subject*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesLookupSecretLow(ctx).Subject,
+	)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *LookupSecretLow) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadHTML(ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"lookup_secret_low/email.body.gotmpl",
+		"lookup_secret_low/email.body*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesLookupSecretLow(ctx).Body.HTML,
+	)
+}
+
+func (t *LookupSecretLow) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadText(ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"lookup_secret_low/email.body.plaintext.gotmpl",
+		"lookup_secret_low/email.body.plaintext*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesLookupSecretLow(ctx).Body.PlainText,
+	)
+}
+
+func (t *LookupSecretLow) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
@@ -0,0 +1,75 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	OIDCConnectionChanged struct {
+		d template.Dependencies
+		m *OIDCConnectionChangedModel
+	}
+	OIDCConnectionChangedModel struct {
+		To       string
+		Provider string
+		// Linked is true if the provider was linked and false if it was unlinked.
+		Linked   bool
+		Identity map[string]interface{}
+	}
+)
+
+func NewOIDCConnectionChanged(d template.Dependencies, m *OIDCConnectionChangedModel) *OIDCConnectionChanged {
+	return &OIDCConnectionChanged{d: d, m: m}
+}
+
+func (t *OIDCConnectionChanged) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *OIDCConnectionChanged) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadText(
+		ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"oidc_connection_changed/email.subject.gotmpl",
+		"oidc_connection_changed/email.subject*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesOIDCConnectionChanged(ctx).Subject,
+	)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *OIDCConnectionChanged) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadHTML(ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"oidc_connection_changed/email.body.gotmpl",
+		"oidc_connection_changed/email.body*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesOIDCConnectionChanged(ctx).Body.HTML,
+	)
+}
+
+func (t *OIDCConnectionChanged) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadText(ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"oidc_connection_changed/email.body.plaintext.gotmpl",
+		"oidc_connection_changed/email.body.plaintext*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesOIDCConnectionChanged(ctx).Body.PlainText,
+	)
+}
+
+func (t *OIDCConnectionChanged) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
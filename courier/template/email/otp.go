@@ -0,0 +1,73 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	OTPMessage struct {
+		d template.Dependencies
+		m *OTPMessageModel
+	}
+	OTPMessageModel struct {
+		To       string
+		Code     string
+		Identity map[string]interface{}
+	}
+)
+
+func NewOTPMessage(d template.Dependencies, m *OTPMessageModel) *OTPMessage {
+	return &OTPMessage{d: d, m: m}
+}
+
+func (t *OTPMessage) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *OTPMessage) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadText(
+		ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"otp/email.subject.gotmpl",
+		"otp/email.subject*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesOTP(ctx).Subject,
+	)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *OTPMessage) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadHTML(ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"otp/email.body.gotmpl",
+		"otp/email.body*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesOTP(ctx).Body.HTML,
+	)
+}
+
+func (t *OTPMessage) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadText(ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"otp/email.body.plaintext.gotmpl",
+		"otp/email.body.plaintext*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesOTP(ctx).Body.PlainText,
+	)
+}
+
+func (t *OTPMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
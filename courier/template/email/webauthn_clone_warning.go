@@ -0,0 +1,73 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	WebAuthnCloneWarning struct {
+		d template.Dependencies
+		m *WebAuthnCloneWarningModel
+	}
+	WebAuthnCloneWarningModel struct {
+		To          string
+		DisplayName string
+		Identity    map[string]interface{}
+	}
+)
+
+func NewWebAuthnCloneWarning(d template.Dependencies, m *WebAuthnCloneWarningModel) *WebAuthnCloneWarning {
+	return &WebAuthnCloneWarning{d: d, m: m}
+}
+
+func (t *WebAuthnCloneWarning) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *WebAuthnCloneWarning) EmailSubject(ctx context.Context) (string, error) {
+	subject, err := template.LoadText(
+		ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"webauthn_clone_warning/email.subject.gotmpl",
+		"webauthn_clone_warning/email.subject*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesWebAuthnCloneWarning(ctx).Subject,
+	)
+
+	return strings.TrimSpace(subject), err
+}
+
+func (t *WebAuthnCloneWarning) EmailBody(ctx context.Context) (string, error) {
+	return template.LoadHTML(ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"webauthn_clone_warning/email.body.gotmpl",
+		"webauthn_clone_warning/email.body*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesWebAuthnCloneWarning(ctx).Body.HTML,
+	)
+}
+
+func (t *WebAuthnCloneWarning) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	return template.LoadText(ctx,
+		t.d,
+		os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)),
+		"webauthn_clone_warning/email.body.plaintext.gotmpl",
+		"webauthn_clone_warning/email.body.plaintext*",
+		t.m,
+		t.d.CourierConfig().CourierTemplatesWebAuthnCloneWarning(ctx).Body.PlainText,
+	)
+}
+
+func (t *WebAuthnCloneWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
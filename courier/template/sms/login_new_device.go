@@ -0,0 +1,44 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	LoginNewDevice struct {
+		d template.Dependencies
+		m *LoginNewDeviceModel
+	}
+
+	LoginNewDeviceModel struct {
+		To                   string
+		IPAddress            string
+		UserAgent            string
+		Location             string
+		UnrecognizedLoginURL string
+		Identity             map[string]interface{}
+	}
+)
+
+func NewLoginNewDevice(d template.Dependencies, m *LoginNewDeviceModel) *LoginNewDevice {
+	return &LoginNewDevice{d: d, m: m}
+}
+
+func (t *LoginNewDevice) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *LoginNewDevice) SMSBody(ctx context.Context) (string, error) {
+	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig().CourierTemplatesRoot(ctx)), "login_new_device/sms.body.gotmpl", "login_new_device/sms.body*", t.m, "")
+}
+
+func (t *LoginNewDevice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
@@ -0,0 +1,36 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sms_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/internal"
+)
+
+func TestNewLoginNewDevice(t *testing.T) {
+	_, reg := internal.NewFastRegistryWithMocks(t)
+
+	const (
+		expectedPhone = "+12345678901"
+		link          = "https://www.ory.sh/sessions/unrecognized?token=foo"
+	)
+
+	tpl := sms.NewLoginNewDevice(reg, &sms.LoginNewDeviceModel{To: expectedPhone, IPAddress: "127.0.0.1", UnrecognizedLoginURL: link})
+
+	expectedBody := "New sign-in to your account from 127.0.0.1. Not you? Sign out the device and recover your account: " + link + "\n"
+
+	actualBody, err := tpl.SMSBody(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expectedBody, actualBody)
+
+	actualPhone, err := tpl.PhoneNumber()
+	require.NoError(t, err)
+	assert.Equal(t, expectedPhone, actualPhone)
+}
@@ -83,6 +83,16 @@ func TestRemoteTemplates(t *testing.T, basePath string, tmplType courier.Templat
 			return email.NewVerificationCodeInvalid(d, &email.VerificationCodeInvalidModel{})
 		case courier.TypeVerificationCodeValid:
 			return email.NewVerificationCodeValid(d, &email.VerificationCodeValidModel{})
+		case courier.TypeLoginNewDevice:
+			return email.NewLoginNewDevice(d, &email.LoginNewDeviceModel{})
+		case courier.TypeWebAuthnCloneWarning:
+			return email.NewWebAuthnCloneWarning(d, &email.WebAuthnCloneWarningModel{})
+		case courier.TypeLookupSecretLow:
+			return email.NewLookupSecretLow(d, &email.LookupSecretLowModel{})
+		case courier.TypeOIDCConnectionChanged:
+			return email.NewOIDCConnectionChanged(d, &email.OIDCConnectionChangedModel{})
+		case courier.TypeOTP:
+			return email.NewOTPMessage(d, &email.OTPMessageModel{})
 		default:
 			return nil
 		}
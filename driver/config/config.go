@@ -78,6 +78,11 @@ const (
 	ViperKeyCourierTemplatesVerificationValidEmail           = "courier.templates.verification.valid.email"
 	ViperKeyCourierTemplatesVerificationCodeInvalidEmail     = "courier.templates.verification_code.invalid.email"
 	ViperKeyCourierTemplatesVerificationCodeValidEmail       = "courier.templates.verification_code.valid.email"
+	ViperKeyCourierTemplatesLoginNewDeviceEmail              = "courier.templates.login_new_device.email"
+	ViperKeyCourierTemplatesWebAuthnCloneWarningEmail        = "courier.templates.webauthn_clone_warning.email"
+	ViperKeyCourierTemplatesLookupSecretLowEmail             = "courier.templates.lookup_secret_low.email"
+	ViperKeyCourierTemplatesOIDCConnectionChangedEmail       = "courier.templates.oidc_connection_changed.email"
+	ViperKeyCourierTemplatesOTPEmail                         = "courier.templates.otp.email"
 	ViperKeyCourierSMTPFrom                                  = "courier.smtp.from_address"
 	ViperKeyCourierSMTPFromName                              = "courier.smtp.from_name"
 	ViperKeyCourierSMTPHeaders                               = "courier.smtp.headers"
@@ -120,6 +125,9 @@ const (
 	ViperKeySessionWhoAmIAAL                                 = "session.whoami.required_aal"
 	ViperKeySessionWhoAmICaching                             = "feature_flags.cacheable_sessions"
 	ViperKeySessionRefreshMinTimeLeft                        = "session.earliest_possible_extend"
+	ViperKeySessionRevocationNotificationReceivers           = "session.revocation_notifications.receivers"
+	ViperKeySessionRevocationNotificationJWKSURL             = "session.revocation_notifications.jwks_url"
+	ViperKeySessionRevocationNotificationRetries             = "session.revocation_notifications.max_retries"
 	ViperKeyCookieSameSite                                   = "cookies.same_site"
 	ViperKeyCookieDomain                                     = "cookies.domain"
 	ViperKeyCookiePath                                       = "cookies.path"
@@ -143,6 +151,11 @@ const (
 	ViperKeySelfServiceSettingsRequestLifespan               = "selfservice.flows.settings.lifespan"
 	ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter = "selfservice.flows.settings.privileged_session_max_age"
 	ViperKeySelfServiceSettingsRequiredAAL                   = "selfservice.flows.settings.required_aal"
+	ViperKeySelfServiceSettingsMFAEnrollmentRequired         = "selfservice.flows.settings.mfa_enrollment.required"
+	ViperKeySelfServiceSettingsMFAEnrollmentIdentitySchemas  = "selfservice.flows.settings.mfa_enrollment.identity_schemas"
+	ViperKeySelfServiceSettingsMFAEnrollmentMetadataFlag     = "selfservice.flows.settings.mfa_enrollment.metadata_admin_flag"
+	ViperKeySelfServiceSettingsMFAEnrollmentMinFactors       = "selfservice.flows.settings.mfa_enrollment.min_factors"
+	ViperKeySelfServiceSettingsMFAEnrollmentGracePeriod      = "selfservice.flows.settings.mfa_enrollment.grace_period"
 	ViperKeySelfServiceRecoveryAfter                         = "selfservice.flows.recovery.after"
 	ViperKeySelfServiceRecoveryBeforeHooks                   = "selfservice.flows.recovery.before.hooks"
 	ViperKeySelfServiceRecoveryEnabled                       = "selfservice.flows.recovery.enabled"
@@ -184,12 +197,29 @@ const (
 	ViperKeyPasswordIdentifierSimilarityCheckEnabled         = "selfservice.methods.password.config.identifier_similarity_check_enabled"
 	ViperKeyIgnoreNetworkErrors                              = "selfservice.methods.password.config.ignore_network_errors"
 	ViperKeyTOTPIssuer                                       = "selfservice.methods.totp.config.issuer"
+	ViperKeyTOTPAlgorithm                                    = "selfservice.methods.totp.config.algorithm"
+	ViperKeyTOTPDigits                                       = "selfservice.methods.totp.config.digits"
+	ViperKeyTOTPPeriod                                       = "selfservice.methods.totp.config.period"
+	ViperKeyTOTPSkew                                         = "selfservice.methods.totp.config.skew"
 	ViperKeyOIDCBaseRedirectURL                              = "selfservice.methods.oidc.config.base_redirect_uri"
 	ViperKeyWebAuthnRPDisplayName                            = "selfservice.methods.webauthn.config.rp.display_name"
 	ViperKeyWebAuthnRPID                                     = "selfservice.methods.webauthn.config.rp.id"
 	ViperKeyWebAuthnRPOrigin                                 = "selfservice.methods.webauthn.config.rp.origin"
 	ViperKeyWebAuthnRPIcon                                   = "selfservice.methods.webauthn.config.rp.issuer"
 	ViperKeyWebAuthnPasswordless                             = "selfservice.methods.webauthn.config.passwordless"
+	ViperKeyWebAuthnAttestationConveyance                    = "selfservice.methods.webauthn.config.attestation.conveyance"
+	ViperKeyWebAuthnAttestationAllowedAAGUIDs                = "selfservice.methods.webauthn.config.attestation.aaguids.allow"
+	ViperKeyWebAuthnAttestationDeniedAAGUIDs                 = "selfservice.methods.webauthn.config.attestation.aaguids.deny"
+	ViperKeyWebAuthnAttestationMetadataBlob                  = "selfservice.methods.webauthn.config.attestation.metadata.blob"
+	ViperKeyWebAuthnAttestationMetadataTrustAnchor           = "selfservice.methods.webauthn.config.attestation.metadata.trust_anchor"
+	ViperKeyWebAuthnAttestationMetadataRequireCertified      = "selfservice.methods.webauthn.config.attestation.metadata.require_certified"
+	ViperKeyWebAuthnCloneDetection                           = "selfservice.methods.webauthn.config.clone_detection"
+	ViperKeyLookupSecretLowRemainingThreshold                = "selfservice.methods.lookup_secret.config.low_remaining_threshold"
+	ViperKeyTrustedDeviceLifespan                            = "selfservice.methods.trusted_device.config.lifespan"
+	ViperKeyOTPLifespan                                      = "selfservice.methods.otp.config.lifespan"
+	ViperKeyOTPResendInterval                                = "selfservice.methods.otp.config.resend_interval"
+	ViperKeyHOTPLookAhead                                    = "selfservice.methods.hotp.config.look_ahead"
+	ViperKeyHOTPResyncWindow                                 = "selfservice.methods.hotp.config.resync_window"
 	ViperKeyOAuth2ProviderURL                                = "oauth2_provider.url"
 	ViperKeyOAuth2ProviderHeader                             = "oauth2_provider.headers"
 	ViperKeyClientHTTPNoPrivateIPRanges                      = "clients.http.disallow_private_ip_ranges"
@@ -209,6 +239,12 @@ const (
 	BcryptDefaultCost            uint32 = 12
 )
 
+const (
+	WebAuthnCloneDetectionIgnore = "ignore"
+	WebAuthnCloneDetectionWarn   = "warn"
+	WebAuthnCloneDetectionReject = "reject"
+)
+
 // DefaultSessionCookieName returns the default cookie name for the kratos session.
 const DefaultSessionCookieName = "ory_kratos_session"
 
@@ -246,6 +282,21 @@ type (
 		MinPasswordLength                uint   `json:"min_password_length"`
 		IdentifierSimilarityCheckEnabled bool   `json:"identifier_similarity_check_enabled"`
 	}
+	WebAuthnAttestation struct {
+		Conveyance          protocol.ConveyancePreference `json:"conveyance"`
+		AllowedAAGUIDs      []string                      `json:"allowed_aaguids"`
+		DeniedAAGUIDs       []string                      `json:"denied_aaguids"`
+		MetadataBlob        string                        `json:"metadata_blob"`
+		MetadataTrustAnchor string                        `json:"metadata_trust_anchor"`
+		RequireCertified    bool                          `json:"require_certified"`
+	}
+	MFAEnrollment struct {
+		Required          bool          `json:"required"`
+		IdentitySchemas   []string      `json:"identity_schemas"`
+		MetadataAdminFlag string        `json:"metadata_admin_flag"`
+		MinFactors        int           `json:"min_factors"`
+		GracePeriod       time.Duration `json:"grace_period"`
+	}
 	Schemas                  []Schema
 	CourierEmailBodyTemplate struct {
 		PlainText string `json:"plaintext"`
@@ -285,6 +336,11 @@ type (
 		CourierTemplatesRecoveryCodeValid(ctx context.Context) *CourierEmailTemplate
 		CourierTemplatesVerificationCodeInvalid(ctx context.Context) *CourierEmailTemplate
 		CourierTemplatesVerificationCodeValid(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesLoginNewDevice(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesWebAuthnCloneWarning(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesLookupSecretLow(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesOIDCConnectionChanged(ctx context.Context) *CourierEmailTemplate
+		CourierTemplatesOTP(ctx context.Context) *CourierEmailTemplate
 		CourierMessageRetries(ctx context.Context) int
 	}
 )
@@ -561,6 +617,26 @@ func (p *Config) TOTPIssuer(ctx context.Context) string {
 	return p.GetProvider(ctx).StringF(ViperKeyTOTPIssuer, p.SelfPublicURL(ctx).Hostname())
 }
 
+// TOTPAlgorithm returns the hashing algorithm for newly enrolled TOTP devices. One of `sha1`, `sha256`, or `sha512`.
+func (p *Config) TOTPAlgorithm(ctx context.Context) string {
+	return p.GetProvider(ctx).StringF(ViperKeyTOTPAlgorithm, "sha1")
+}
+
+// TOTPDigits returns the number of digits of codes generated by newly enrolled TOTP devices.
+func (p *Config) TOTPDigits(ctx context.Context) int {
+	return p.GetProvider(ctx).IntF(ViperKeyTOTPDigits, 6)
+}
+
+// TOTPPeriod returns how long a code of a newly enrolled TOTP device is valid.
+func (p *Config) TOTPPeriod(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyTOTPPeriod, time.Second*30)
+}
+
+// TOTPSkew returns the number of periods before and after the current one for which codes are still accepted.
+func (p *Config) TOTPSkew(ctx context.Context) uint {
+	return uint(p.GetProvider(ctx).IntF(ViperKeyTOTPSkew, 1))
+}
+
 func (p *Config) OIDCRedirectURIBase(ctx context.Context) *url.URL {
 	return p.GetProvider(ctx).URIF(ViperKeyOIDCBaseRedirectURL, p.SelfPublicURL(ctx))
 }
@@ -1070,6 +1146,26 @@ func (p *Config) CourierTemplatesVerificationCodeValid(ctx context.Context) *Cou
 	return p.CourierTemplatesHelper(ctx, ViperKeyCourierTemplatesVerificationCodeValidEmail)
 }
 
+func (p *Config) CourierTemplatesLoginNewDevice(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierTemplatesHelper(ctx, ViperKeyCourierTemplatesLoginNewDeviceEmail)
+}
+
+func (p *Config) CourierTemplatesWebAuthnCloneWarning(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierTemplatesHelper(ctx, ViperKeyCourierTemplatesWebAuthnCloneWarningEmail)
+}
+
+func (p *Config) CourierTemplatesLookupSecretLow(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierTemplatesHelper(ctx, ViperKeyCourierTemplatesLookupSecretLowEmail)
+}
+
+func (p *Config) CourierTemplatesOIDCConnectionChanged(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierTemplatesHelper(ctx, ViperKeyCourierTemplatesOIDCConnectionChangedEmail)
+}
+
+func (p *Config) CourierTemplatesOTP(ctx context.Context) *CourierEmailTemplate {
+	return p.CourierTemplatesHelper(ctx, ViperKeyCourierTemplatesOTPEmail)
+}
+
 func (p *Config) CourierMessageRetries(ctx context.Context) int {
 	return p.GetProvider(ctx).IntF(ViperKeyCourierMessageRetries, 5)
 }
@@ -1271,10 +1367,48 @@ func (p *Config) SessionRefreshMinTimeLeft(ctx context.Context) time.Duration {
 	return p.GetProvider(ctx).DurationF(ViperKeySessionRefreshMinTimeLeft, p.SessionLifespan(ctx))
 }
 
+// SessionRevocationNotificationReceivers returns the URLs which are notified when a session is revoked.
+func (p *Config) SessionRevocationNotificationReceivers(ctx context.Context) (us []url.URL) {
+	for k, u := range p.GetProvider(ctx).Strings(ViperKeySessionRevocationNotificationReceivers) {
+		parsed, err := url.ParseRequestURI(u)
+		if err != nil {
+			p.l.WithError(err).Warnf("Ignoring URL \"%s\" from configuration key \"%s.%d\".", u, ViperKeySessionRevocationNotificationReceivers, k)
+			continue
+		}
+		us = append(us, *parsed)
+	}
+	return us
+}
+
+func (p *Config) SessionRevocationNotificationJWKSURL(ctx context.Context) string {
+	return p.GetProvider(ctx).String(ViperKeySessionRevocationNotificationJWKSURL)
+}
+
+func (p *Config) SessionRevocationNotificationRetries(ctx context.Context) int {
+	return p.GetProvider(ctx).IntF(ViperKeySessionRevocationNotificationRetries, 5)
+}
+
 func (p *Config) SelfServiceSettingsRequiredAAL(ctx context.Context) string {
 	return p.GetProvider(ctx).String(ViperKeySelfServiceSettingsRequiredAAL)
 }
 
+// SelfServiceSettingsMFAEnrollment returns the policy which requires identities to set up second factors.
+func (p *Config) SelfServiceSettingsMFAEnrollment(ctx context.Context) *MFAEnrollment {
+	pp := p.GetProvider(ctx)
+	return &MFAEnrollment{
+		Required:          pp.Bool(ViperKeySelfServiceSettingsMFAEnrollmentRequired),
+		IdentitySchemas:   pp.Strings(ViperKeySelfServiceSettingsMFAEnrollmentIdentitySchemas),
+		MetadataAdminFlag: pp.String(ViperKeySelfServiceSettingsMFAEnrollmentMetadataFlag),
+		MinFactors:        pp.IntF(ViperKeySelfServiceSettingsMFAEnrollmentMinFactors, 1),
+		GracePeriod:       pp.DurationF(ViperKeySelfServiceSettingsMFAEnrollmentGracePeriod, time.Hour*24*7),
+	}
+}
+
+// Enabled returns true if the policy applies to at least some identities.
+func (e *MFAEnrollment) Enabled() bool {
+	return e.Required || len(e.IdentitySchemas) > 0 || len(e.MetadataAdminFlag) > 0
+}
+
 func (p *Config) CookieSameSiteMode(ctx context.Context) http.SameSite {
 	switch p.GetProvider(ctx).StringF(ViperKeyCookieSameSite, "Lax") {
 	case "Lax":
@@ -1343,6 +1477,29 @@ func (p *Config) WebAuthnForPasswordless(ctx context.Context) bool {
 	return p.GetProvider(ctx).BoolF(ViperKeyWebAuthnPasswordless, false)
 }
 
+func (p *Config) TrustedDeviceLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyTrustedDeviceLifespan, time.Hour*24*30)
+}
+
+func (p *Config) OTPLifespan(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyOTPLifespan, time.Minute*15)
+}
+
+func (p *Config) OTPResendInterval(ctx context.Context) time.Duration {
+	return p.GetProvider(ctx).DurationF(ViperKeyOTPResendInterval, time.Second*30)
+}
+
+// HOTPLookAhead returns the number of counter values after the expected one for which HOTP codes are still accepted.
+func (p *Config) HOTPLookAhead(ctx context.Context) uint64 {
+	return uint64(p.GetProvider(ctx).IntF(ViperKeyHOTPLookAhead, 10))
+}
+
+// HOTPResyncWindow returns the number of counter values after the expected one which are searched for two
+// consecutive HOTP codes when resynchronising a hardware token.
+func (p *Config) HOTPResyncWindow(ctx context.Context) uint64 {
+	return uint64(p.GetProvider(ctx).IntF(ViperKeyHOTPResyncWindow, 100))
+}
+
 func (p *Config) WebAuthnConfig(ctx context.Context) *webauthn.Config {
 	return &webauthn.Config{
 		RPDisplayName: p.GetProvider(ctx).String(ViperKeyWebAuthnRPDisplayName),
@@ -1352,9 +1509,34 @@ func (p *Config) WebAuthnConfig(ctx context.Context) *webauthn.Config {
 		AuthenticatorSelection: protocol.AuthenticatorSelection{
 			UserVerification: protocol.VerificationDiscouraged,
 		},
+		AttestationPreference: p.WebAuthnAttestation(ctx).Conveyance,
+	}
+}
+
+func (p *Config) WebAuthnAttestation(ctx context.Context) *WebAuthnAttestation {
+	pp := p.GetProvider(ctx)
+	return &WebAuthnAttestation{
+		Conveyance:          protocol.ConveyancePreference(pp.StringF(ViperKeyWebAuthnAttestationConveyance, string(protocol.PreferNoAttestation))),
+		AllowedAAGUIDs:      pp.Strings(ViperKeyWebAuthnAttestationAllowedAAGUIDs),
+		DeniedAAGUIDs:       pp.Strings(ViperKeyWebAuthnAttestationDeniedAAGUIDs),
+		MetadataBlob:        pp.String(ViperKeyWebAuthnAttestationMetadataBlob),
+		MetadataTrustAnchor: pp.String(ViperKeyWebAuthnAttestationMetadataTrustAnchor),
+		RequireCertified:    pp.Bool(ViperKeyWebAuthnAttestationMetadataRequireCertified),
 	}
 }
 
+// WebAuthnCloneDetection returns how signature counter regressions, which indicate a cloned
+// authenticator, are handled. One of `ignore`, `warn`, or `reject`.
+func (p *Config) WebAuthnCloneDetection(ctx context.Context) string {
+	return p.GetProvider(ctx).StringF(ViperKeyWebAuthnCloneDetection, WebAuthnCloneDetectionWarn)
+}
+
+// LookupSecretLowRemainingThreshold returns the number of unused lookup secrets below which the identity is
+// notified to regenerate them. Zero disables the notification.
+func (p *Config) LookupSecretLowRemainingThreshold(ctx context.Context) int {
+	return p.GetProvider(ctx).IntF(ViperKeyLookupSecretLowRemainingThreshold, 0)
+}
+
 func (p *Config) HasherPasswordHashingAlgorithm(ctx context.Context) string {
 	configValue := p.GetProvider(ctx).StringF(ViperKeyHasherAlgorithm, DefaultPasswordHashingAlgorithm)
 	switch configValue {
@@ -214,7 +214,7 @@ func TestViperProvider(t *testing.T) {
 			}{
 				{id: "password", enabled: true, config: `{"haveibeenpwned_host":"api.pwnedpasswords.com","haveibeenpwned_enabled":true,"ignore_network_errors":true,"max_breaches":0,"min_password_length":8,"identifier_similarity_check_enabled":true}`},
 				{id: "oidc", enabled: true, config: `{"providers":[{"client_id":"a","client_secret":"b","id":"github","provider":"github","mapper_url":"http://test.kratos.ory.sh/default-identity.schema.json"}]}`},
-				{id: "totp", enabled: true, config: `{"issuer":"issuer.ory.sh"}`},
+				{id: "totp", enabled: true, config: `{"issuer":"issuer.ory.sh","algorithm":"sha1","digits":6,"period":"30s","skew":1}`},
 			} {
 				strategy := p.SelfServiceStrategy(ctx, tc.id)
 				assert.Equal(t, tc.enabled, strategy.Enabled)
@@ -111,6 +111,7 @@ type Registry interface {
 	session.HandlerProvider
 	session.ManagementProvider
 	session.PersistenceProvider
+	session.RevocationNotifierProvider
 
 	settings.HandlerProvider
 	settings.ErrorHandlerProvider
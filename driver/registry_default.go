@@ -21,8 +21,10 @@ import (
 	"github.com/ory/x/httpx"
 	"github.com/ory/x/otelx"
 	otelsql "github.com/ory/x/otelx/sql"
+	"github.com/ory/x/pointerx"
 
 	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
 
 	"github.com/ory/nosurf"
 
@@ -30,9 +32,13 @@ import (
 	"github.com/ory/kratos/selfservice/strategy/code"
 	"github.com/ory/kratos/selfservice/strategy/webauthn"
 
+	"github.com/ory/kratos/selfservice/strategy/hotp"
+	"github.com/ory/kratos/selfservice/strategy/idfirst"
 	"github.com/ory/kratos/selfservice/strategy/lookup"
+	"github.com/ory/kratos/selfservice/strategy/otp"
 
 	"github.com/ory/kratos/selfservice/strategy/totp"
+	"github.com/ory/kratos/selfservice/strategy/trusteddevice"
 
 	"github.com/luna-duclos/instrumentedsql"
 
@@ -96,10 +102,11 @@ type RegistryDefault struct {
 	persister       persistence.Persister
 	migrationStatus popx.MigrationStatuses
 
-	hookVerifier         *hook.Verifier
-	hookSessionIssuer    *hook.SessionIssuer
-	hookSessionDestroyer *hook.SessionDestroyer
-	hookAddressVerifier  *hook.AddressVerifier
+	hookVerifier          *hook.Verifier
+	hookSessionIssuer     *hook.SessionIssuer
+	hookSessionDestroyer  *hook.SessionDestroyer
+	hookAddressVerifier   *hook.AddressVerifier
+	hookNewDeviceNotifier *hook.NewDeviceNotifier
 
 	identityHandler   *identity.Handler
 	identityValidator *identity.Validator
@@ -111,8 +118,9 @@ type RegistryDefault struct {
 
 	schemaHandler *schema.Handler
 
-	sessionHandler *session.Handler
-	sessionManager session.Manager
+	sessionHandler            *session.Handler
+	sessionRevocationNotifier *session.RevocationNotifier
+	sessionManager            session.Manager
 
 	passwordHasher    hash.Hasher
 	passwordValidator password2.Validator
@@ -193,6 +201,12 @@ func (m *RegistryDefault) RegisterPublicRoutes(ctx context.Context, router *x.Ro
 	m.VerificationHandler().RegisterPublicRoutes(router)
 	m.AllVerificationStrategies().RegisterPublicRoutes(router)
 
+	for _, strategy := range m.selfServiceStrategies() {
+		if s, ok := strategy.(*oidc.Strategy); ok {
+			s.RegisterPublicAdminRoutes(router)
+		}
+	}
+
 	m.HealthHandler(ctx).SetHealthRoutes(router.Router, false)
 }
 
@@ -213,6 +227,12 @@ func (m *RegistryDefault) RegisterAdminRoutes(ctx context.Context, router *x.Rou
 	m.VerificationHandler().RegisterAdminRoutes(router)
 	m.AllVerificationStrategies().RegisterAdminRoutes(router)
 
+	for _, strategy := range m.selfServiceStrategies() {
+		if s, ok := strategy.(*oidc.Strategy); ok {
+			s.RegisterAdminRoutes(router)
+		}
+	}
+
 	m.HealthHandler(ctx).SetHealthRoutes(router, true)
 	m.HealthHandler(ctx).SetVersionRoutes(router)
 	m.MetricsHandler().SetRoutes(router)
@@ -319,6 +339,10 @@ func (m *RegistryDefault) selfServiceStrategies() []interface{} {
 			totp.NewStrategy(m),
 			webauthn.NewStrategy(m),
 			lookup.NewStrategy(m),
+			otp.NewStrategy(m),
+			hotp.NewStrategy(m),
+			trusteddevice.NewStrategy(m),
+			idfirst.NewStrategy(m),
 		}
 	}
 
@@ -377,6 +401,32 @@ func (m *RegistryDefault) ActiveCredentialsCounterStrategies(_ context.Context)
 	return
 }
 
+func (m *RegistryDefault) RevokeSessionsByAuthenticationMethod(ctx context.Context, identityID uuid.UUID, method identity.CredentialsType) (int, error) {
+	return m.SessionPersister().RevokeSessions(ctx, &session.ListSessionsFilter{
+		Active:               pointerx.Bool(true),
+		IdentityID:           identityID,
+		AuthenticationMethod: method,
+	})
+}
+
+func (m *RegistryDefault) UpstreamTokens(ctx context.Context, identityID uuid.UUID, provider string) (*identity.UpstreamTokens, error) {
+	for _, strategy := range m.selfServiceStrategies() {
+		if s, ok := strategy.(*oidc.Strategy); ok {
+			return s.UpstreamTokens(ctx, identityID, provider)
+		}
+	}
+	return nil, errors.WithStack(herodot.ErrNotFound.WithReason("The OpenID Connect strategy is not available."))
+}
+
+func (m *RegistryDefault) ValidateOIDCProvider(ctx context.Context, provider string) error {
+	for _, strategy := range m.selfServiceStrategies() {
+		if s, ok := strategy.(*oidc.Strategy); ok {
+			return s.ValidateProvider(ctx, provider)
+		}
+	}
+	return errors.WithStack(herodot.ErrBadRequest.WithReason("The OpenID Connect strategy is not available."))
+}
+
 func (m *RegistryDefault) IdentityValidator() *identity.Validator {
 	if m.identityValidator == nil {
 		m.identityValidator = identity.NewValidator(m)
@@ -432,6 +482,13 @@ func (m *RegistryDefault) SessionHandler() *session.Handler {
 	return m.sessionHandler
 }
 
+func (m *RegistryDefault) SessionRevocationNotifier() *session.RevocationNotifier {
+	if m.sessionRevocationNotifier == nil {
+		m.sessionRevocationNotifier = session.NewRevocationNotifier(m)
+	}
+	return m.sessionRevocationNotifier
+}
+
 func (m *RegistryDefault) Cipher(ctx context.Context) cipher.Cipher {
 	if m.crypter == nil {
 		switch m.c.CipherAlgorithm(ctx) {
@@ -729,6 +786,14 @@ func (m *RegistryDefault) VerificationCodePersister() code.VerificationCodePersi
 	return m.Persister()
 }
 
+func (m *RegistryDefault) OIDCProviderPersister() oidc.ProviderPersister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) OTPCodeCounterPersister() otp.CodeCounterPersister {
+	return m.Persister()
+}
+
 func (m *RegistryDefault) Persister() persistence.Persister {
 	return m.persister
 }
@@ -36,6 +36,13 @@ func (m *RegistryDefault) HookAddressVerifier() *hook.AddressVerifier {
 	return m.hookAddressVerifier
 }
 
+func (m *RegistryDefault) HookNewDeviceNotifier() *hook.NewDeviceNotifier {
+	if m.hookNewDeviceNotifier == nil {
+		m.hookNewDeviceNotifier = hook.NewNewDeviceNotifier(m)
+	}
+	return m.hookNewDeviceNotifier
+}
+
 func (m *RegistryDefault) WithHooks(hooks map[string]func(config.SelfServiceHook) interface{}) {
 	m.injectedSelfserviceHooks = hooks
 }
@@ -51,6 +58,8 @@ func (m *RegistryDefault) getHooks(credentialsType string, configs []config.Self
 			i = append(i, hook.NewWebHook(m, h.Config))
 		case hook.KeyAddressVerifier:
 			i = append(i, m.HookAddressVerifier())
+		case hook.KeyNewDeviceNotifier:
+			i = append(i, m.HookNewDeviceNotifier())
 		default:
 			var found bool
 			for name, m := range m.injectedSelfserviceHooks {
@@ -59,6 +59,10 @@ func (c CredentialsType) ToUiNodeGroup() node.UiNodeGroup {
 		return node.WebAuthnGroup
 	case CredentialsTypeLookup:
 		return node.LookupGroup
+	case CredentialsTypeOTP:
+		return node.OTPGroup
+	case CredentialsTypeHOTP:
+		return node.HOTPGroup
 	default:
 		return node.DefaultGroup
 	}
@@ -71,6 +75,8 @@ const (
 	CredentialsTypeTOTP     CredentialsType = "totp"
 	CredentialsTypeLookup   CredentialsType = "lookup_secret"
 	CredentialsTypeWebAuthn CredentialsType = "webauthn"
+	CredentialsTypeOTP      CredentialsType = "otp"
+	CredentialsTypeHOTP     CredentialsType = "hotp"
 )
 
 const (
@@ -78,8 +84,37 @@ const (
 	// It is not used within the credentials object itself.
 	CredentialsTypeRecoveryLink CredentialsType = "link_recovery"
 	CredentialsTypeRecoveryCode CredentialsType = "code_recovery"
+
+	// CredentialsTypeTrustedDevice is a special credential type used when the second factor was skipped
+	// because the browser was trusted. It is not used within the credentials object itself.
+	CredentialsTypeTrustedDevice CredentialsType = "trusted_device"
+
+	// CredentialsTypeIdentifierFirst is a special credential type linked to the identifier-first login step. It
+	// is not used within the credentials object itself.
+	CredentialsTypeIdentifierFirst CredentialsType = "identifier_first"
 )
 
+// ParseCredentialsType parses a string into a known credentials type.
+func ParseCredentialsType(in string) (CredentialsType, bool) {
+	for _, t := range []CredentialsType{
+		CredentialsTypePassword,
+		CredentialsTypeOIDC,
+		CredentialsTypeTOTP,
+		CredentialsTypeLookup,
+		CredentialsTypeWebAuthn,
+		CredentialsTypeOTP,
+		CredentialsTypeHOTP,
+		CredentialsTypeRecoveryLink,
+		CredentialsTypeRecoveryCode,
+		CredentialsTypeTrustedDevice,
+	} {
+		if t.String() == in {
+			return t, true
+		}
+	}
+	return "", false
+}
+
 // Credentials represents a specific credential type
 //
 // swagger:model identityCredentials
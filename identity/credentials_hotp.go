@@ -0,0 +1,28 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+// CredentialsHOTPConfig is the struct that is being used as part of the identity credentials.
+type CredentialsHOTPConfig struct {
+	// Secret is the base32 encoded shared secret of the hardware token.
+	Secret string `json:"secret"`
+
+	// Counter is the counter value the next code is expected to be generated for.
+	//
+	// Codes generated for an earlier counter value are rejected to prevent replay attacks.
+	Counter uint64 `json:"counter"`
+
+	// Algorithm is the hashing algorithm used to generate codes, one of `SHA1`, `SHA256`, or `SHA512`.
+	//
+	// If empty, `SHA1` is used.
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Digits is the number of digits of a code.
+	//
+	// If empty, six digits are used.
+	Digits int `json:"digits,omitempty"`
+
+	// Serial is the serial number of the hardware token and helps to tell tokens apart.
+	Serial string `json:"serial,omitempty"`
+}
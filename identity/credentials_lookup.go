@@ -9,15 +9,38 @@ import (
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/ui/node"
 
+	"github.com/ory/x/randx"
 	"github.com/ory/x/sqlxx"
 )
 
+// LookupSecretsCount is the number of lookup secrets which are generated at once.
+const LookupSecretsCount = 12
+
 // CredentialsConfig is the struct that is being used as part of the identity credentials.
 type CredentialsLookupConfig struct {
 	// List of recovery codes
 	RecoveryCodes []RecoveryCode `json:"recovery_codes"`
 }
 
+// NewCredentialsLookupConfig returns a configuration with freshly generated recovery codes.
+func NewCredentialsLookupConfig() *CredentialsLookupConfig {
+	codes := make([]RecoveryCode, LookupSecretsCount)
+	for k := range codes {
+		codes[k] = RecoveryCode{Code: randx.MustString(8, randx.AlphaLowerNum)}
+	}
+	return &CredentialsLookupConfig{RecoveryCodes: codes}
+}
+
+// RemainingCodes returns the number of recovery codes which have not been used yet.
+func (c *CredentialsLookupConfig) RemainingCodes() (remaining int) {
+	for _, code := range c.RecoveryCodes {
+		if time.Time(code.UsedAt).IsZero() {
+			remaining++
+		}
+	}
+	return remaining
+}
+
 func (c *CredentialsLookupConfig) ToNode() *node.Node {
 	messages := make([]text.Message, len(c.RecoveryCodes))
 	formatted := make([]string, len(c.RecoveryCodes))
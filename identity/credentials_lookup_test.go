@@ -8,6 +8,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal/testhelpers"
 
@@ -24,3 +27,12 @@ func TestToNode(t *testing.T) {
 
 	testhelpers.SnapshotTExcept(t, c.ToNode(), []string{})
 }
+
+func TestRemainingCodes(t *testing.T) {
+	c := identity.NewCredentialsLookupConfig()
+	require.Len(t, c.RecoveryCodes, identity.LookupSecretsCount)
+	assert.Equal(t, identity.LookupSecretsCount, c.RemainingCodes())
+
+	c.RecoveryCodes[0].UsedAt = sqlxx.NullTime(time.Now())
+	assert.Equal(t, identity.LookupSecretsCount-1, c.RemainingCodes())
+}
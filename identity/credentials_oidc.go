@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -29,10 +30,71 @@ type CredentialsOIDCProvider struct {
 	InitialIDToken      string `json:"initial_id_token"`
 	InitialAccessToken  string `json:"initial_access_token"`
 	InitialRefreshToken string `json:"initial_refresh_token"`
+
+	// IDToken, AccessToken and RefreshToken are the latest (encrypted) tokens issued by the provider. Unlike the
+	// initial tokens, they are updated on every login and whenever the access token is refreshed.
+	IDToken        string     `json:"id_token,omitempty"`
+	AccessToken    string     `json:"access_token,omitempty"`
+	RefreshToken   string     `json:"refresh_token,omitempty"`
+	TokenExpiresAt *time.Time `json:"token_expires_at,omitempty"`
+}
+
+// CredentialsOIDCEncryptedTokens are the encrypted tokens issued by an OpenID Connect provider.
+type CredentialsOIDCEncryptedTokens struct {
+	IDToken      string
+	AccessToken  string
+	RefreshToken string
+
+	// ExpiresAt is the expiry of the access token. It is zero if the provider did not tell.
+	ExpiresAt time.Time
+}
+
+// SetTokens replaces the latest tokens of the provider. Providers do not always issue a new ID or refresh
+// token when the access token is refreshed, in which case the previous ones are kept.
+func (p *CredentialsOIDCProvider) SetTokens(tokens *CredentialsOIDCEncryptedTokens) {
+	if tokens == nil {
+		return
+	}
+
+	p.AccessToken = tokens.AccessToken
+	if tokens.IDToken != "" {
+		p.IDToken = tokens.IDToken
+	}
+	if tokens.RefreshToken != "" {
+		p.RefreshToken = tokens.RefreshToken
+	}
+
+	p.TokenExpiresAt = nil
+	if !tokens.ExpiresAt.IsZero() {
+		expiresAt := tokens.ExpiresAt.UTC()
+		p.TokenExpiresAt = &expiresAt
+	}
+}
+
+// LatestTokens returns the latest encrypted tokens, falling back to the initial tokens for credentials which
+// were created before the latest tokens were tracked.
+func (p *CredentialsOIDCProvider) LatestTokens() *CredentialsOIDCEncryptedTokens {
+	if p.AccessToken == "" {
+		return &CredentialsOIDCEncryptedTokens{
+			IDToken:      p.InitialIDToken,
+			AccessToken:  p.InitialAccessToken,
+			RefreshToken: p.InitialRefreshToken,
+		}
+	}
+
+	tokens := &CredentialsOIDCEncryptedTokens{
+		IDToken:      p.IDToken,
+		AccessToken:  p.AccessToken,
+		RefreshToken: p.RefreshToken,
+	}
+	if p.TokenExpiresAt != nil {
+		tokens.ExpiresAt = *p.TokenExpiresAt
+	}
+	return tokens
 }
 
 // NewCredentialsOIDC creates a new OIDC credential.
-func NewCredentialsOIDC(idToken, accessToken, refreshToken, provider, subject string) (*Credentials, error) {
+func NewCredentialsOIDC(tokens *CredentialsOIDCEncryptedTokens, provider, subject string) (*Credentials, error) {
 	if provider == "" {
 		return nil, errors.New("received empty provider in oidc credentials")
 	}
@@ -41,16 +103,22 @@ func NewCredentialsOIDC(idToken, accessToken, refreshToken, provider, subject st
 		return nil, errors.New("received empty provider in oidc credentials")
 	}
 
+	if tokens == nil {
+		tokens = new(CredentialsOIDCEncryptedTokens)
+	}
+
+	p := CredentialsOIDCProvider{
+		Subject:             subject,
+		Provider:            provider,
+		InitialIDToken:      tokens.IDToken,
+		InitialAccessToken:  tokens.AccessToken,
+		InitialRefreshToken: tokens.RefreshToken,
+	}
+	p.SetTokens(tokens)
+
 	var b bytes.Buffer
 	if err := json.NewEncoder(&b).Encode(CredentialsOIDC{
-		Providers: []CredentialsOIDCProvider{
-			{
-				Subject:             subject,
-				Provider:            provider,
-				InitialIDToken:      idToken,
-				InitialAccessToken:  accessToken,
-				InitialRefreshToken: refreshToken,
-			}},
+		Providers: []CredentialsOIDCProvider{p},
 	}); err != nil {
 		return nil, errors.WithStack(x.PseudoPanic.
 			WithDebugf("Unable to encode password options to JSON: %s", err))
@@ -4,16 +4,50 @@
 package identity
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestNewCredentialsOIDC(t *testing.T) {
-	_, err := NewCredentialsOIDC("", "", "", "", "not-empty")
+	_, err := NewCredentialsOIDC(nil, "", "not-empty")
 	require.Error(t, err)
-	_, err = NewCredentialsOIDC("", "", "", "not-empty", "")
+	_, err = NewCredentialsOIDC(nil, "not-empty", "")
 	require.Error(t, err)
-	_, err = NewCredentialsOIDC("", "", "", "not-empty", "not-empty")
+	_, err = NewCredentialsOIDC(nil, "not-empty", "not-empty")
 	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	c, err := NewCredentialsOIDC(&CredentialsOIDCEncryptedTokens{IDToken: "id", AccessToken: "access", RefreshToken: "refresh", ExpiresAt: expiresAt}, "provider", "subject")
+	require.NoError(t, err)
+
+	var conf CredentialsOIDC
+	require.NoError(t, json.Unmarshal(c.Config, &conf))
+	require.Len(t, conf.Providers, 1)
+	p := conf.Providers[0]
+	assert.Equal(t, "access", p.InitialAccessToken)
+	assert.Equal(t, "access", p.AccessToken)
+	assert.Equal(t, "refresh", p.RefreshToken)
+	assert.Equal(t, expiresAt, *p.TokenExpiresAt)
+}
+
+func TestCredentialsOIDCProviderTokens(t *testing.T) {
+	t.Run("case=falls back to the initial tokens", func(t *testing.T) {
+		p := CredentialsOIDCProvider{InitialIDToken: "id", InitialAccessToken: "access", InitialRefreshToken: "refresh"}
+		assert.Equal(t, &CredentialsOIDCEncryptedTokens{IDToken: "id", AccessToken: "access", RefreshToken: "refresh"}, p.LatestTokens())
+	})
+
+	t.Run("case=keeps previous tokens which were not reissued", func(t *testing.T) {
+		p := CredentialsOIDCProvider{InitialAccessToken: "initial"}
+		p.SetTokens(&CredentialsOIDCEncryptedTokens{IDToken: "id", AccessToken: "access", RefreshToken: "refresh"})
+
+		expiresAt := time.Now().Add(time.Hour).UTC()
+		p.SetTokens(&CredentialsOIDCEncryptedTokens{AccessToken: "refreshed", ExpiresAt: expiresAt})
+
+		assert.Equal(t, "initial", p.InitialAccessToken)
+		assert.Equal(t, &CredentialsOIDCEncryptedTokens{IDToken: "id", AccessToken: "refreshed", RefreshToken: "refresh", ExpiresAt: expiresAt}, p.LatestTokens())
+	})
 }
@@ -0,0 +1,28 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+// CredentialsOTPConfig is the struct that is being used as part of the identity credentials.
+type CredentialsOTPConfig struct {
+	// List of verified addresses which receive one-time codes
+	Addresses []CredentialsOTPAddress `json:"addresses"`
+}
+
+type CredentialsOTPAddress struct {
+	// The channel the code is sent through, either `email` or `phone`.
+	Via VerifiableAddressType `json:"via"`
+
+	// The address the code is sent to.
+	Value string `json:"value"`
+}
+
+// HasAddress returns true if one-time codes are sent to the given address.
+func (c *CredentialsOTPConfig) HasAddress(value string) bool {
+	for _, a := range c.Addresses {
+		if a.Value == value {
+			return true
+		}
+	}
+	return false
+}
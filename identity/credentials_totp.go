@@ -9,4 +9,24 @@ type CredentialsTOTPConfig struct {
 	//
 	// For more details see: https://github.com/google/google-authenticator/wiki/Key-Uri-Format
 	TOTPURL string `json:"totp_url"`
+
+	// Algorithm is the hashing algorithm used to generate codes, one of `SHA1`, `SHA256`, or `SHA512`.
+	//
+	// If empty, the algorithm of the TOTP URL is used.
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Digits is the number of digits of a code.
+	//
+	// If empty, the digits of the TOTP URL are used.
+	Digits int `json:"digits,omitempty"`
+
+	// Period is the number of seconds a code is valid.
+	//
+	// If empty, the period of the TOTP URL is used.
+	Period uint `json:"period,omitempty"`
+
+	// LastUsedStep is the time step of the last accepted code.
+	//
+	// Codes generated for this or any earlier time step are rejected to prevent replay attacks.
+	LastUsedStep uint64 `json:"last_used_step,omitempty"`
 }
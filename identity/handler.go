@@ -19,6 +19,7 @@ import (
 
 	"github.com/ory/herodot"
 
+	"github.com/gofrs/uuid"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 
@@ -34,6 +35,10 @@ import (
 const RouteCollection = "/identities"
 const RouteItem = RouteCollection + "/:id"
 const RouteCredentialItem = RouteItem + "/credentials/:type"
+const RouteCredentialRegenerate = RouteCredentialItem + "/regenerate"
+const RouteCredentialEntries = RouteCredentialItem + "/items"
+const RouteCredentialEntry = RouteCredentialEntries + "/:item"
+const RouteCredentialTokens = RouteCredentialItem + "/tokens/:provider"
 
 type (
 	handlerDependencies interface {
@@ -45,6 +50,29 @@ type (
 		x.CSRFProvider
 		cipher.Provider
 		hash.HashProvider
+		x.LoggingProvider
+		SessionRevokerProvider
+		UpstreamTokenProvider
+		OIDCProviderValidator
+	}
+	// SessionRevokerProvider revokes sessions on behalf of the identity handler. Sessions depend on
+	// identities, so the registry implements this instead of the session package.
+	SessionRevokerProvider interface {
+		// RevokeSessionsByAuthenticationMethod marks all active sessions of the identity inactive which
+		// completed the given authentication method and returns their number.
+		RevokeSessionsByAuthenticationMethod(ctx context.Context, identityID uuid.UUID, method CredentialsType) (int, error)
+	}
+	// UpstreamTokenProvider returns valid tokens of linked social sign in providers on behalf of the identity
+	// handler. Refreshing them requires the OpenID Connect strategy, so the registry implements this.
+	UpstreamTokenProvider interface {
+		// UpstreamTokens returns a currently valid access token issued to the identity by the provider.
+		UpstreamTokens(ctx context.Context, identityID uuid.UUID, provider string) (*UpstreamTokens, error)
+	}
+	// OIDCProviderValidator validates social sign in providers on behalf of the identity handler. Providers are
+	// configured in the OpenID Connect strategy, so the registry implements this.
+	OIDCProviderValidator interface {
+		// ValidateOIDCProvider returns a bad request error unless a provider with the given ID is configured.
+		ValidateOIDCProvider(ctx context.Context, provider string) error
 	}
 	HandlerProvider interface {
 		IdentityHandler() *Handler
@@ -69,9 +97,13 @@ func NewHandler(r handlerDependencies) *Handler {
 func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 	h.r.CSRFHandler().IgnoreGlobs(
 		RouteCollection, RouteCollection+"/*",
-		RouteCollection+"/*/credentials/*",
+		RouteCollection+"/*/credentials/*", RouteCollection+"/*/credentials/*/regenerate",
+		RouteCollection+"/*/credentials/*/items", RouteCollection+"/*/credentials/*/items/*",
+		RouteCollection+"/*/credentials/*/tokens/*",
 		x.AdminPrefix+RouteCollection, x.AdminPrefix+RouteCollection+"/*",
-		x.AdminPrefix+RouteCollection+"/*/credentials/*",
+		x.AdminPrefix+RouteCollection+"/*/credentials/*", x.AdminPrefix+RouteCollection+"/*/credentials/*/regenerate",
+		x.AdminPrefix+RouteCollection+"/*/credentials/*/items", x.AdminPrefix+RouteCollection+"/*/credentials/*/items/*",
+		x.AdminPrefix+RouteCollection+"/*/credentials/*/tokens/*",
 	)
 
 	public.GET(RouteCollection, x.RedirectToAdminRoute(h.r))
@@ -81,6 +113,12 @@ func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 	public.PUT(RouteItem, x.RedirectToAdminRoute(h.r))
 	public.PATCH(RouteItem, x.RedirectToAdminRoute(h.r))
 	public.DELETE(RouteCredentialItem, x.RedirectToAdminRoute(h.r))
+	public.POST(RouteCredentialRegenerate, x.RedirectToAdminRoute(h.r))
+	public.GET(RouteCredentialEntries, x.RedirectToAdminRoute(h.r))
+	public.POST(RouteCredentialEntries, x.RedirectToAdminRoute(h.r))
+	public.PATCH(RouteCredentialEntry, x.RedirectToAdminRoute(h.r))
+	public.DELETE(RouteCredentialEntry, x.RedirectToAdminRoute(h.r))
+	public.GET(RouteCredentialTokens, x.RedirectToAdminRoute(h.r))
 
 	public.GET(x.AdminPrefix+RouteCollection, x.RedirectToAdminRoute(h.r))
 	public.GET(x.AdminPrefix+RouteItem, x.RedirectToAdminRoute(h.r))
@@ -89,6 +127,12 @@ func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 	public.PUT(x.AdminPrefix+RouteItem, x.RedirectToAdminRoute(h.r))
 	public.PATCH(x.AdminPrefix+RouteItem, x.RedirectToAdminRoute(h.r))
 	public.DELETE(x.AdminPrefix+RouteCredentialItem, x.RedirectToAdminRoute(h.r))
+	public.POST(x.AdminPrefix+RouteCredentialRegenerate, x.RedirectToAdminRoute(h.r))
+	public.GET(x.AdminPrefix+RouteCredentialEntries, x.RedirectToAdminRoute(h.r))
+	public.POST(x.AdminPrefix+RouteCredentialEntries, x.RedirectToAdminRoute(h.r))
+	public.PATCH(x.AdminPrefix+RouteCredentialEntry, x.RedirectToAdminRoute(h.r))
+	public.DELETE(x.AdminPrefix+RouteCredentialEntry, x.RedirectToAdminRoute(h.r))
+	public.GET(x.AdminPrefix+RouteCredentialTokens, x.RedirectToAdminRoute(h.r))
 }
 
 func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
@@ -101,6 +145,14 @@ func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 	admin.PUT(RouteItem, h.update)
 
 	admin.DELETE(RouteCredentialItem, h.deleteIdentityCredentials)
+	admin.POST(RouteCredentialRegenerate, h.regenerateIdentityCredentials)
+
+	admin.GET(RouteCredentialEntries, h.listIdentityCredentialItems)
+	admin.POST(RouteCredentialEntries, h.createIdentityCredentialItem)
+	admin.PATCH(RouteCredentialEntry, h.updateIdentityCredentialItem)
+	admin.DELETE(RouteCredentialEntry, h.deleteIdentityCredentialItem)
+
+	admin.GET(RouteCredentialTokens, h.getIdentityUpstreamTokens)
 }
 
 // Paginated Identity List Response
@@ -199,8 +251,9 @@ type getIdentity struct {
 
 	// Include Credentials in Response
 	//
-	// Currently, only `oidc` is supported. This will return the initial OAuth 2.0 Access,
-	// Refresh and (optionally) OpenID Connect ID Token.
+	// Currently, `oidc` and `webauthn` are supported. For `oidc`, this will return the initial OAuth 2.0 Access,
+	// Refresh and (optionally) OpenID Connect ID Token. For `webauthn`, this will return the security keys
+	// including their signature counter and whether they were flagged as cloned.
 	//
 	// required: false
 	// in: query
@@ -244,6 +297,9 @@ func (h *Handler) get(w http.ResponseWriter, r *http.Request, ps httprouter.Para
 		}
 		h.r.Writer().Write(w, r, WithCredentialsAndAdminMetadataInJSON(*emit))
 		return
+	} else if declassify == "webauthn" {
+		h.r.Writer().Write(w, r, WithCredentialsAndAdminMetadataInJSON(*i.WithDeclassifiedCredentialsWebAuthn()))
+		return
 	} else if len(declassify) > 0 {
 		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `include_credential`.", declassify)))
 		return
@@ -321,6 +377,9 @@ type IdentityWithCredentials struct {
 
 	// OIDC if set will import an OIDC credential.
 	OIDC *AdminIdentityImportCredentialsOIDC `json:"oidc"`
+
+	// HOTP if set will import a HOTP hardware token credential.
+	HOTP *AdminIdentityImportCredentialsHOTP `json:"hotp"`
 }
 
 // Create Identity and Import Password Credentials
@@ -373,6 +432,36 @@ type AdminCreateIdentityImportCredentialsOidcProvider struct {
 	Provider string `json:"provider"`
 }
 
+// Create Identity and Import HOTP Hardware Token Credentials
+//
+// swagger:model identityWithCredentialsHotp
+type AdminIdentityImportCredentialsHOTP struct {
+	// Configuration options for the import.
+	Config AdminIdentityImportCredentialsHOTPConfig `json:"config"`
+}
+
+// Create Identity and Import HOTP Hardware Token Credentials Configuration
+//
+// swagger:model identityWithCredentialsHotpConfig
+type AdminIdentityImportCredentialsHOTPConfig struct {
+	// The base32 encoded shared secret of the hardware token.
+	//
+	// required: true
+	Secret string `json:"secret"`
+
+	// The current counter value of the hardware token. Defaults to zero.
+	Counter uint64 `json:"counter"`
+
+	// The hashing algorithm of the hardware token, one of `SHA1`, `SHA256`, or `SHA512`. Defaults to `SHA1`.
+	Algorithm string `json:"algorithm"`
+
+	// The number of digits of the codes generated by the hardware token, either 6 or 8. Defaults to 6.
+	Digits int `json:"digits"`
+
+	// The serial number of the hardware token.
+	Serial string `json:"serial"`
+}
+
 // swagger:route POST /admin/identities identity createIdentity
 //
 // # Create an Identity
@@ -779,9 +868,9 @@ type deleteIdentityCredentials struct {
 	ID string `json:"id"`
 
 	// Type is the credential's Type.
-	// One of totp, webauthn, lookup
+	// One of totp, webauthn, lookup, hotp
 	//
-	// enum: totp,webauthn,lookup
+	// enum: totp,webauthn,lookup,hotp
 	// required: true
 	// in: path
 	Type string `json:"type"`
@@ -825,6 +914,8 @@ func (h *Handler) deleteIdentityCredentials(w http.ResponseWriter, r *http.Reque
 	switch cred.Type {
 	case CredentialsTypeLookup:
 		fallthrough
+	case CredentialsTypeHOTP:
+		fallthrough
 	case CredentialsTypeTOTP:
 		identity.DeleteCredentialsType(cred.Type)
 	case CredentialsTypeWebAuthn:
@@ -854,3 +945,100 @@ func (h *Handler) deleteIdentityCredentials(w http.ResponseWriter, r *http.Reque
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// Regenerate Credential Parameters
+//
+// swagger:parameters regenerateIdentityCredentials
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type regenerateIdentityCredentials struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Type is the credential's Type.
+	// Currently, only lookup_secret is supported.
+	//
+	// enum: lookup_secret
+	// required: true
+	// in: path
+	Type string `json:"type"`
+}
+
+// Regenerated Identity Credentials
+//
+// swagger:model identityRegeneratedCredentials
+type RegeneratedCredentials struct {
+	// LookupSecrets contains the newly generated lookup secrets (recovery codes).
+	//
+	// They are only returned once and can not be retrieved again.
+	LookupSecrets []string `json:"lookup_secrets,omitempty"`
+}
+
+// swagger:route POST /admin/identities/{id}/credentials/{type}/regenerate identity regenerateIdentityCredentials
+//
+// # Regenerate a credential for a specific identity
+//
+// Regenerate an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) credential by its type.
+// Currently, only lookup secrets (recovery codes) can be regenerated. The previous lookup secrets are invalidated
+// and the new ones are returned once in the response.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: identityRegeneratedCredentials
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) regenerateIdentityCredentials(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	identity, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	var regenerated RegeneratedCredentials
+	switch ct := CredentialsType(ps.ByName("type")); ct {
+	case CredentialsTypeLookup:
+		conf := NewCredentialsLookupConfig()
+		encoded, err := json.Marshal(conf)
+		if err != nil {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode lookup secrets.").WithDebug(err.Error())))
+			return
+		}
+
+		// We do not really need the identifier, so we add the identity's ID
+		identity.SetCredentials(ct, Credentials{Type: ct, Identifiers: []string{identity.ID.String()}, Config: encoded})
+
+		regenerated.LookupSecrets = make([]string, len(conf.RecoveryCodes))
+		for k, code := range conf.RecoveryCodes {
+			regenerated.LookupSecrets[k] = code.Code
+		}
+	default:
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Credentials of type %s can not be regenerated.", ct)))
+		return
+	}
+
+	if err := h.r.IdentityManager().Update(
+		r.Context(),
+		identity,
+		ManagerAllowWriteProtectedTraits,
+	); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, &regenerated)
+}
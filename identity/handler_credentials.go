@@ -0,0 +1,711 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/urlx"
+)
+
+// Identity Credential Item
+//
+// A single authenticator of a credential, for example one of several security keys or one of
+// several linked social sign in providers.
+//
+// swagger:model identityCredentialItem
+type CredentialItem struct {
+	// ID identifies the item within the credential.
+	//
+	// For WebAuthn this is the URL-safe base64 encoded credential ID, for OpenID Connect
+	// this is `provider:subject`.
+	//
+	// required: true
+	ID string `json:"id"`
+
+	// Type is the credential's type.
+	//
+	// required: true
+	Type CredentialsType `json:"type"`
+
+	// DisplayName is the name of the security key.
+	DisplayName string `json:"display_name,omitempty"`
+
+	// IsPasswordless is true if the security key can be used as a first factor.
+	IsPasswordless bool `json:"is_passwordless,omitempty"`
+
+	// CloneWarning is true if the signature counter of the security key did not increase, which
+	// indicates that the security key was cloned.
+	CloneWarning bool `json:"clone_warning,omitempty"`
+
+	// AddedAt is the time the security key was registered.
+	AddedAt *time.Time `json:"added_at,omitempty"`
+
+	// Provider is the ID of the linked OpenID Connect provider.
+	Provider string `json:"provider,omitempty"`
+
+	// Subject is the subject of the identity at the linked OpenID Connect provider.
+	Subject string `json:"subject,omitempty"`
+}
+
+// Identity Credential Item Parameters
+//
+// swagger:parameters listIdentityCredentialItems
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listIdentityCredentialItems struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Type is the credential's Type.
+	// One of webauthn, oidc
+	//
+	// enum: webauthn,oidc
+	// required: true
+	// in: path
+	Type string `json:"type"`
+}
+
+// List Identity Credential Items Response
+//
+// swagger:response listIdentityCredentialItems
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listIdentityCredentialItemsResponse struct {
+	// in: body
+	Body []CredentialItem
+}
+
+// swagger:route GET /admin/identities/{id}/credentials/{type}/items identity listIdentityCredentialItems
+//
+// # List the items of an identity's credential
+//
+// Lists the security keys (webauthn) or linked social sign in providers (oidc) of an identity.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listIdentityCredentialItems
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) listIdentityCredentialItems(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	items, err := credentialItems(i, CredentialsType(ps.ByName("type")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, items)
+}
+
+// Create Identity Credential Item Body
+//
+// swagger:model createIdentityCredentialItemBody
+type CreateCredentialItemBody struct {
+	// Provider is the ID of the OpenID Connect provider to link.
+	//
+	// required: true
+	Provider string `json:"provider"`
+
+	// Subject is the subject of the identity at the OpenID Connect provider.
+	//
+	// required: true
+	Subject string `json:"subject"`
+}
+
+// Create Identity Credential Item Parameters
+//
+// swagger:parameters createIdentityCredentialItem
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createIdentityCredentialItem struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Type is the credential's Type.
+	// Currently, only oidc is supported.
+	//
+	// enum: oidc
+	// required: true
+	// in: path
+	Type string `json:"type"`
+
+	// in: body
+	// required: true
+	Body CreateCredentialItemBody
+}
+
+// swagger:route POST /admin/identities/{id}/credentials/{type}/items identity createIdentityCredentialItem
+//
+// # Add an item to an identity's credential
+//
+// Links a social sign in provider (oidc) to an identity without user interaction, for example when importing
+// identities whose subject at the provider is already known. The identity can then sign in with the provider.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: identityCredentialItem
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) createIdentityCredentialItem(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	var p CreateCredentialItemBody
+	if err := h.dx.Decode(r, &p,
+		decoderx.HTTPJSONDecoder(),
+		decoderx.HTTPDecoderAllowedMethods("POST")); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	ct := CredentialsType(ps.ByName("type"))
+	if ct != CredentialsTypeOIDC {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Items of credentials of type %s can not be added individually.", ct)))
+		return
+	}
+
+	if len(p.Provider) == 0 || len(p.Subject) == 0 {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("The provider and subject must not be empty.")))
+		return
+	}
+
+	i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.ValidateOIDCProvider(ctx, p.Provider); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	item := OIDCUniqueID(p.Provider, p.Subject)
+	if existing, _, err := h.r.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, CredentialsTypeOIDC, item); err == nil {
+		if existing.ID != i.ID {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict.WithReason("The provider and subject are already linked to another identity.")))
+			return
+		}
+	} else if !errors.Is(err, sqlcon.ErrNoRows) {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := addOIDCProvider(i, p.Provider, p.Subject); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.IdentityManager().Update(ctx, i, ManagerAllowWriteProtectedTraits); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Audit().
+		WithRequest(r).
+		WithField("identity_id", i.ID).
+		WithField("credentials_type", ct).
+		WithField("credential_item", item).
+		Info("A credential item was added to the identity by an administrator.")
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(h.r.Config().SelfAdminURL(ctx), "identities", i.ID.String(), "credentials", string(ct), "items").String(),
+		&CredentialItem{ID: item, Type: CredentialsTypeOIDC, Provider: p.Provider, Subject: p.Subject})
+}
+
+// Update Identity Credential Item Body
+//
+// swagger:model updateIdentityCredentialItemBody
+type UpdateCredentialItemBody struct {
+	// DisplayName is the new name of the security key.
+	DisplayName string `json:"display_name,omitempty"`
+
+	// ClearCloneWarning resets the clone warning of the security key, for example once the key was
+	// confirmed to be legitimate.
+	ClearCloneWarning bool `json:"clear_clone_warning,omitempty"`
+}
+
+// Update Identity Credential Item Parameters
+//
+// swagger:parameters updateIdentityCredentialItem
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type updateIdentityCredentialItem struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Type is the credential's Type.
+	// Currently, only webauthn is supported.
+	//
+	// enum: webauthn
+	// required: true
+	// in: path
+	Type string `json:"type"`
+
+	// Item is the ID of the credential item.
+	//
+	// required: true
+	// in: path
+	Item string `json:"item"`
+
+	// in: body
+	// required: true
+	Body UpdateCredentialItemBody
+}
+
+// swagger:route PATCH /admin/identities/{id}/credentials/{type}/items/{item} identity updateIdentityCredentialItem
+//
+// # Update an item of an identity's credential
+//
+// Renames a single security key (webauthn) of an identity or clears its clone warning. Security keys
+// flagged as cloned can be deleted using deleteIdentityCredentialItem.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: identityCredentialItem
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) updateIdentityCredentialItem(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var p UpdateCredentialItemBody
+	if err := h.dx.Decode(r, &p,
+		decoderx.HTTPJSONDecoder(),
+		decoderx.HTTPDecoderAllowedMethods("PATCH")); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if len(p.DisplayName) == 0 && !p.ClearCloneWarning {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("Either the display name or clear_clone_warning must be set.")))
+		return
+	}
+
+	i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	ct := CredentialsType(ps.ByName("type"))
+	if ct != CredentialsTypeWebAuthn {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Items of credentials of type %s can not be updated.", ct)))
+		return
+	}
+
+	id, cc, err := findWebAuthnCredential(i, ps.ByName("item"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if len(p.DisplayName) > 0 {
+		cc.Credentials[id].DisplayName = p.DisplayName
+	}
+	if p.ClearCloneWarning {
+		cc.Credentials[id].Authenticator.CloneWarning = false
+	}
+	if err := setWebAuthnCredentials(i, cc); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.IdentityManager().Update(r.Context(), i, ManagerAllowWriteProtectedTraits); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Audit().
+		WithRequest(r).
+		WithField("identity_id", i.ID).
+		WithField("credentials_type", ct).
+		WithField("credential_item", ps.ByName("item")).
+		WithField("clear_clone_warning", p.ClearCloneWarning).
+		Info("A credential item of the identity was updated by an administrator.")
+
+	h.r.Writer().Write(w, r, webAuthnCredentialItem(&cc.Credentials[id]))
+}
+
+// Delete Identity Credential Item Parameters
+//
+// swagger:parameters deleteIdentityCredentialItem
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type deleteIdentityCredentialItem struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Type is the credential's Type.
+	// One of webauthn, oidc
+	//
+	// enum: webauthn,oidc
+	// required: true
+	// in: path
+	Type string `json:"type"`
+
+	// Item is the ID of the credential item.
+	//
+	// required: true
+	// in: path
+	Item string `json:"item"`
+
+	// RevokeSessions revokes the identity's active sessions which were authenticated using this credential type.
+	//
+	// Sessions do not record which security key or provider was used, so all sessions which completed the
+	// credential type are revoked.
+	//
+	// Sessions are revoked before the item is deleted. If deleting the item fails afterwards, the error contains
+	// the number of revoked sessions in `details.revoked_sessions`.
+	//
+	// in: query
+	RevokeSessions bool `json:"revoke_sessions"`
+}
+
+// swagger:route DELETE /admin/identities/{id}/credentials/{type}/items/{item} identity deleteIdentityCredentialItem
+//
+// # Delete an item of an identity's credential
+//
+// Deletes a single security key (webauthn) or unlinks a single social sign in provider (oidc) of an identity. The
+// last first factor credential of an identity can not be deleted.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) deleteIdentityCredentialItem(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	before, err := h.r.IdentityManager().CountActiveFirstFactorCredentials(ctx, i)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	ct := CredentialsType(ps.ByName("type"))
+	switch ct {
+	case CredentialsTypeWebAuthn:
+		err = deleteWebAuthnCredential(i, ps.ByName("item"))
+	case CredentialsTypeOIDC:
+		err = deleteOIDCProvider(i, ps.ByName("item"))
+	default:
+		err = errors.WithStack(herodot.ErrBadRequest.WithReasonf("Items of credentials of type %s can not be deleted individually.", ct))
+	}
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	after, err := h.r.IdentityManager().CountActiveFirstFactorCredentials(ctx, i)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if before > 0 && after == 0 {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("The credential item can not be deleted because it is the last first factor credential of the identity.")))
+		return
+	}
+
+	// Sessions are revoked first so that a failed revocation never leaves sessions of a deleted credential
+	// item active.
+	var revoked int
+	if revoke, _ := strconv.ParseBool(r.URL.Query().Get("revoke_sessions")); revoke {
+		revoked, err = h.r.RevokeSessionsByAuthenticationMethod(ctx, i.ID, ct)
+		if err != nil {
+			h.r.Writer().WriteError(w, r, err)
+			return
+		}
+	}
+
+	if err := h.r.IdentityManager().Update(ctx, i, ManagerAllowWriteProtectedTraits); err != nil {
+		if revoked > 0 {
+			h.r.Audit().
+				WithRequest(r).
+				WithError(err).
+				WithField("identity_id", i.ID).
+				WithField("credentials_type", ct).
+				WithField("credential_item", ps.ByName("item")).
+				WithField("revoked_sessions", revoked).
+				Info("Sessions of the identity were revoked by an administrator but the credential item could not be deleted.")
+			de := herodot.ToDefaultError(err, "")
+			err = errors.WithStack(de.
+				WithDetail("revoked_sessions", revoked).
+				WithReasonf("%d sessions of the identity were revoked but the credential item could not be deleted: %s", revoked, de.Reason()))
+		}
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Audit().
+		WithRequest(r).
+		WithField("identity_id", i.ID).
+		WithField("credentials_type", ct).
+		WithField("credential_item", ps.ByName("item")).
+		WithField("revoked_sessions", revoked).
+		Info("A credential item of the identity was deleted by an administrator.")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func credentialItems(i *Identity, ct CredentialsType) ([]CredentialItem, error) {
+	items := make([]CredentialItem, 0)
+	switch ct {
+	case CredentialsTypeWebAuthn:
+		var cc CredentialsWebAuthnConfig
+		if c, ok := i.GetCredentials(ct); !ok {
+			return items, nil
+		} else if err := json.Unmarshal(c.Config, &cc); err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode identity credentials.").WithDebug(err.Error()))
+		}
+
+		for k := range cc.Credentials {
+			items = append(items, *webAuthnCredentialItem(&cc.Credentials[k]))
+		}
+	case CredentialsTypeOIDC:
+		var cc CredentialsOIDC
+		if c, ok := i.GetCredentials(ct); !ok {
+			return items, nil
+		} else if err := json.Unmarshal(c.Config, &cc); err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode identity credentials.").WithDebug(err.Error()))
+		}
+
+		for _, p := range cc.Providers {
+			items = append(items, CredentialItem{
+				ID:       OIDCUniqueID(p.Provider, p.Subject),
+				Type:     CredentialsTypeOIDC,
+				Provider: p.Provider,
+				Subject:  p.Subject,
+			})
+		}
+	default:
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Credentials of type %s have no items.", ct))
+	}
+
+	return items, nil
+}
+
+func webAuthnCredentialItem(c *CredentialWebAuthn) *CredentialItem {
+	item := &CredentialItem{
+		ID:             base64.RawURLEncoding.EncodeToString(c.ID),
+		Type:           CredentialsTypeWebAuthn,
+		DisplayName:    c.DisplayName,
+		IsPasswordless: c.IsPasswordless,
+		CloneWarning:   c.Authenticator.CloneWarning,
+	}
+	if !c.AddedAt.IsZero() {
+		addedAt := c.AddedAt
+		item.AddedAt = &addedAt
+	}
+	return item
+}
+
+func findWebAuthnCredential(i *Identity, item string) (int, *CredentialsWebAuthnConfig, error) {
+	notFound := errors.WithStack(herodot.ErrNotFound.WithReasonf("The identity has no security key with ID %s.", item))
+
+	c, ok := i.GetCredentials(CredentialsTypeWebAuthn)
+	if !ok {
+		return 0, nil, notFound
+	}
+
+	id, err := base64.RawURLEncoding.DecodeString(item)
+	if err != nil {
+		return 0, nil, notFound
+	}
+
+	var cc CredentialsWebAuthnConfig
+	if err := json.Unmarshal(c.Config, &cc); err != nil {
+		return 0, nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode identity credentials.").WithDebug(err.Error()))
+	}
+
+	for k := range cc.Credentials {
+		if bytes.Equal(cc.Credentials[k].ID, id) {
+			return k, &cc, nil
+		}
+	}
+
+	return 0, nil, notFound
+}
+
+func setWebAuthnCredentials(i *Identity, cc *CredentialsWebAuthnConfig) error {
+	if len(cc.Credentials) == 0 {
+		i.DeleteCredentialsType(CredentialsTypeWebAuthn)
+		return nil
+	}
+
+	c, _ := i.GetCredentials(CredentialsTypeWebAuthn)
+	encoded, err := json.Marshal(cc)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode identity credentials.").WithDebug(err.Error()))
+	}
+
+	c.Config = encoded
+	i.SetCredentials(CredentialsTypeWebAuthn, *c)
+	return nil
+}
+
+func deleteWebAuthnCredential(i *Identity, item string) error {
+	id, cc, err := findWebAuthnCredential(i, item)
+	if err != nil {
+		return err
+	}
+
+	cc.Credentials = append(cc.Credentials[:id], cc.Credentials[id+1:]...)
+	return setWebAuthnCredentials(i, cc)
+}
+
+func deleteOIDCProvider(i *Identity, item string) error {
+	notFound := errors.WithStack(herodot.ErrNotFound.WithReasonf("The identity has no linked provider with ID %s.", item))
+
+	c, ok := i.GetCredentials(CredentialsTypeOIDC)
+	if !ok {
+		return notFound
+	}
+
+	var cc CredentialsOIDC
+	if err := json.Unmarshal(c.Config, &cc); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode identity credentials.").WithDebug(err.Error()))
+	}
+
+	var found bool
+	var providers []CredentialsOIDCProvider
+	var identifiers []string
+	for _, p := range cc.Providers {
+		if OIDCUniqueID(p.Provider, p.Subject) == item {
+			found = true
+			continue
+		}
+		providers = append(providers, p)
+		identifiers = append(identifiers, OIDCUniqueID(p.Provider, p.Subject))
+	}
+
+	if !found {
+		return notFound
+	}
+
+	if len(providers) == 0 {
+		i.DeleteCredentialsType(CredentialsTypeOIDC)
+		return nil
+	}
+
+	encoded, err := json.Marshal(&CredentialsOIDC{Providers: providers})
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode identity credentials.").WithDebug(err.Error()))
+	}
+
+	c.Identifiers = identifiers
+	c.Config = encoded
+	i.SetCredentials(CredentialsTypeOIDC, *c)
+	return nil
+}
+
+func addOIDCProvider(i *Identity, provider, subject string) error {
+	c, ok := i.GetCredentials(CredentialsTypeOIDC)
+	if !ok {
+		created, err := NewCredentialsOIDC(nil, provider, subject)
+		if err != nil {
+			return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode identity credentials.").WithDebug(err.Error()))
+		}
+		i.SetCredentials(CredentialsTypeOIDC, *created)
+		return nil
+	}
+
+	var cc CredentialsOIDC
+	if err := json.Unmarshal(c.Config, &cc); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode identity credentials.").WithDebug(err.Error()))
+	}
+
+	for _, p := range cc.Providers {
+		if p.Provider == provider {
+			return errors.WithStack(herodot.ErrConflict.WithReasonf("The identity has already linked the provider %s.", provider))
+		}
+	}
+
+	cc.Providers = append(cc.Providers, CredentialsOIDCProvider{Provider: provider, Subject: subject})
+	encoded, err := json.Marshal(&cc)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode identity credentials.").WithDebug(err.Error()))
+	}
+
+	c.Identifiers = append(c.Identifiers, OIDCUniqueID(provider, subject))
+	c.Config = encoded
+	i.SetCredentials(CredentialsTypeOIDC, *c)
+	return nil
+}
@@ -5,8 +5,11 @@ package identity
 
 import (
 	"context"
+	"encoding/base32"
 	"encoding/json"
+	"strings"
 
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
 	"github.com/ory/herodot"
@@ -31,6 +34,12 @@ func (h *Handler) importCredentials(ctx context.Context, i *Identity, creds *Ide
 		}
 	}
 
+	if creds.HOTP != nil {
+		if err := h.importHOTPCredentials(ctx, i, creds.HOTP); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -89,3 +98,42 @@ func (h *Handler) importOIDCCredentials(_ context.Context, i *Identity, creds *A
 	}
 	return i.SetCredentialsWithConfig(CredentialsTypeOIDC, *c, &target)
 }
+
+func (h *Handler) importHOTPCredentials(_ context.Context, i *Identity, creds *AdminIdentityImportCredentialsHOTP) error {
+	secret := strings.TrimRight(strings.ToUpper(strings.TrimSpace(creds.Config.Secret)), "=")
+	if len(secret) == 0 {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The imported HOTP secret must not be empty."))
+	} else if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret); err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The imported HOTP secret must be a base32 encoded string.").WithDebug(err.Error()))
+	}
+
+	algorithm := strings.ToUpper(creds.Config.Algorithm)
+	switch algorithm {
+	case "", "SHA1", "SHA256", "SHA512":
+	default:
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The imported HOTP algorithm must be one of SHA1, SHA256, or SHA512 but got: %s", creds.Config.Algorithm))
+	}
+
+	switch creds.Config.Digits {
+	case 0, 6, 8:
+	default:
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The imported HOTP codes must have either 6 or 8 digits but got: %d", creds.Config.Digits))
+	}
+
+	// The credentials are looked up by the identity's ID, so we need to know it before the identity is created.
+	if i.ID == uuid.Nil {
+		i.ID = x.NewUUID()
+	}
+
+	return i.SetCredentialsWithConfig(
+		CredentialsTypeHOTP,
+		Credentials{Identifiers: []string{i.ID.String()}},
+		CredentialsHOTPConfig{
+			Secret:    secret,
+			Counter:   creds.Config.Counter,
+			Algorithm: algorithm,
+			Digits:    creds.Config.Digits,
+			Serial:    creds.Config.Serial,
+		},
+	)
+}
@@ -6,6 +6,7 @@ package identity_test
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,7 +27,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
 
+	"github.com/ory/x/sqlcon"
 	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 
@@ -35,6 +38,7 @@ import (
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/internal/testhelpers"
 	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
 
@@ -272,6 +276,36 @@ func TestHandler(t *testing.T) {
 				})
 			}
 		})
+		t.Run("with hotp credentials", func(t *testing.T) {
+			res := send(t, adminTS, "POST", "/identities", http.StatusCreated, identity.CreateIdentityBody{Traits: []byte(`{"email": "import-hotp@ory.sh"}`),
+				Credentials: &identity.IdentityWithCredentials{HOTP: &identity.AdminIdentityImportCredentialsHOTP{
+					Config: identity.AdminIdentityImportCredentialsHOTPConfig{Secret: "jbswy3dpehpk3pxp==", Counter: 42, Algorithm: "SHA256", Digits: 8, Serial: "token-1"}}}})
+			actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, uuid.FromStringOrNil(res.Get("id").String()))
+			require.NoError(t, err)
+
+			c, ok := actual.GetCredentials(identity.CredentialsTypeHOTP)
+			require.True(t, ok)
+			assert.Equal(t, []string{actual.ID.String()}, c.Identifiers)
+
+			var conf identity.CredentialsHOTPConfig
+			require.NoError(t, json.Unmarshal(c.Config, &conf))
+			assert.Equal(t, identity.CredentialsHOTPConfig{Secret: "JBSWY3DPEHPK3PXP", Counter: 42, Algorithm: "SHA256", Digits: 8, Serial: "token-1"}, conf)
+		})
+
+		t.Run("with invalid hotp credentials", func(t *testing.T) {
+			for name, config := range map[string]identity.AdminIdentityImportCredentialsHOTPConfig{
+				"empty secret":      {},
+				"invalid secret":    {Secret: "not base32!"},
+				"invalid algorithm": {Secret: "JBSWY3DPEHPK3PXP", Algorithm: "MD5"},
+				"invalid digits":    {Secret: "JBSWY3DPEHPK3PXP", Digits: 7},
+			} {
+				t.Run("case="+name, func(t *testing.T) {
+					res := send(t, adminTS, "POST", "/identities", http.StatusBadRequest, identity.CreateIdentityBody{Traits: []byte(`{"email": "import-hotp-invalid@ory.sh"}`),
+						Credentials: &identity.IdentityWithCredentials{HOTP: &identity.AdminIdentityImportCredentialsHOTP{Config: config}}})
+					assert.Contains(t, res.Get("error.reason").String(), "HOTP", "%s", res.Raw)
+				})
+			}
+		})
 	})
 
 	t.Run("case=unable to set ID itself", func(t *testing.T) {
@@ -1128,6 +1162,22 @@ func TestHandler(t *testing.T) {
 				require.NoError(t, err)
 				snapshotx.SnapshotT(t, identity.WithCredentialsAndAdminMetadataInJSON(*actual), snapshotx.ExceptNestedKeys(append(ignoreDefault, "hashed_password")...), snapshotx.ExceptPaths("credentials.oidc.identifiers"))
 			})
+			t.Run("type=remove webauthn keeps passwordless flagged as cloned/"+name, func(t *testing.T) {
+				i := createIdentity(map[identity.CredentialsType]string{identity.CredentialsTypeWebAuthn: `{"credentials":[` +
+					`{"id":"THTndqZP5Mjvae1BFvJMaMfEMm7O7HE1ju+7PBaYA7Y=","display_name":"cloned","authenticator":{"aaguid":"rc4AAjW8xgpkiwsl8fBVAw==","sign_count":12,"clone_warning":true},"is_passwordless":true},` +
+					`{"id":"THTndqZP5Mjvae1BFvJMaMfEMm7O7HE2ju+7PBaYA7Y=","display_name":"trusted","authenticator":{"aaguid":"rc4AAjW8xgpkiwsl8fBVAw==","sign_count":3,"clone_warning":false},"is_passwordless":true}` +
+					`],"user_handle":"Ef5JiMpMRwuzauWs/9J0gQ=="}`})(t)
+
+				res := get(t, ts, "/identities/"+i.ID.String()+"?include_credential=webauthn", http.StatusOK)
+				assert.True(t, res.Get("credentials.webauthn.config.credentials.#(display_name==cloned).authenticator.clone_warning").Bool(), "%s", res.Raw)
+				assert.EqualValues(t, 12, res.Get("credentials.webauthn.config.credentials.#(display_name==cloned).authenticator.sign_count").Int(), "%s", res.Raw)
+
+				remove(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn", http.StatusNoContent)
+
+				res = get(t, ts, "/identities/"+i.ID.String()+"?include_credential=webauthn", http.StatusOK)
+				assert.EqualValues(t, 2, res.Get("credentials.webauthn.config.credentials.#").Int(), "%s", res.Raw)
+				assert.True(t, res.Get("credentials.webauthn.config.credentials.#(display_name==cloned).authenticator.clone_warning").Bool(), "%s", res.Raw)
+			})
 			t.Run("type=remove webauthn passwordless and multiple fido mfa type/"+name, func(t *testing.T) {
 				var config = identity.CredentialsWebAuthnConfig{
 					Credentials: identity.CredentialsWebAuthn{{
@@ -1270,4 +1320,272 @@ func TestHandler(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("case=should manage individual credential items", func(t *testing.T) {
+		keyID := func(id string) string {
+			raw, err := base64.StdEncoding.DecodeString(id)
+			require.NoError(t, err)
+			return base64.RawURLEncoding.EncodeToString(raw)
+		}
+		passwordless, mfa := "THTndqZP5Mjvae1BFvJMaMfEMm7O7HE1ju+7PBaYA7Y=", "THTndqZP5Mjvae1BFvJMaMfEMm7O7HE2ju+7PBaYA7Y="
+
+		createIdentity := func(t *testing.T, withPassword bool, providers ...string) *identity.Identity {
+			i := identity.NewIdentity("")
+			i.Traits = identity.Traits("{}")
+			if withPassword {
+				i.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{
+					Type:        identity.CredentialsTypePassword,
+					Identifiers: []string{x.NewUUID().String() + "@ory.sh"},
+					Config:      sqlxx.JSONRawMessage(`{"hashed_password":"$2a$08$.cOYmAd.vCpDOoiVJrO5B.hjTLKQQ6cAK40u8uB.FnZDyPvVvQ9Q."}`),
+				})
+			}
+			i.SetCredentials(identity.CredentialsTypeWebAuthn, identity.Credentials{
+				Type:        identity.CredentialsTypeWebAuthn,
+				Identifiers: []string{x.NewUUID().String()},
+				Config: sqlxx.JSONRawMessage(`{"credentials":[` +
+					`{"id":"` + passwordless + `","display_name":"passwordless","added_at":"2023-01-01T00:00:00Z","is_passwordless":true},` +
+					`{"id":"` + mfa + `","display_name":"mfa","added_at":"2023-01-02T00:00:00Z","is_passwordless":false}` +
+					`],"user_handle":"Ef5JiMpMRwuzauWs/9J0gQ=="}`),
+			})
+
+			var links identity.CredentialsOIDC
+			var identifiers []string
+			subject := x.NewUUID().String()
+			for _, provider := range providers {
+				links.Providers = append(links.Providers, identity.CredentialsOIDCProvider{Provider: provider, Subject: subject})
+				identifiers = append(identifiers, identity.OIDCUniqueID(provider, subject))
+			}
+			if len(providers) > 0 {
+				config, err := json.Marshal(links)
+				require.NoError(t, err)
+				i.SetCredentials(identity.CredentialsTypeOIDC, identity.Credentials{Type: identity.CredentialsTypeOIDC, Identifiers: identifiers, Config: config})
+			}
+
+			require.NoError(t, reg.Persister().CreateIdentity(context.Background(), i))
+			return i
+		}
+
+		createSession := func(t *testing.T, i *identity.Identity, method identity.CredentialsType) *session.Session {
+			s, err := session.NewActiveSession(&http.Request{}, i, conf, time.Now(), method, identity.AuthenticatorAssuranceLevel1)
+			require.NoError(t, err)
+			require.NoError(t, reg.SessionPersister().UpsertSession(context.Background(), s))
+			return s
+		}
+
+		isActive := func(t *testing.T, s *session.Session) bool {
+			actual, err := reg.SessionPersister().GetSession(context.Background(), s.ID, session.ExpandNothing)
+			require.NoError(t, err)
+			return actual.Active
+		}
+
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("type=unknown identity/"+name, func(t *testing.T) {
+				get(t, ts, "/identities/"+x.NewUUID().String()+"/credentials/webauthn/items", http.StatusNotFound)
+				remove(t, ts, "/identities/"+x.NewUUID().String()+"/credentials/webauthn/items/"+keyID(mfa), http.StatusNotFound)
+			})
+
+			t.Run("type=unsupported type/"+name, func(t *testing.T) {
+				i := createIdentity(t, true)
+				get(t, ts, "/identities/"+i.ID.String()+"/credentials/totp/items", http.StatusBadRequest)
+				remove(t, ts, "/identities/"+i.ID.String()+"/credentials/password/items/foo", http.StatusBadRequest)
+			})
+
+			t.Run("type=list items/"+name, func(t *testing.T) {
+				i := createIdentity(t, true, "google", "github")
+
+				res := get(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn/items", http.StatusOK)
+				require.Len(t, res.Array(), 2, "%s", res.Raw)
+				assert.Equal(t, keyID(passwordless), res.Get("0.id").String(), "%s", res.Raw)
+				assert.Equal(t, "passwordless", res.Get("0.display_name").String(), "%s", res.Raw)
+				assert.True(t, res.Get("0.is_passwordless").Bool(), "%s", res.Raw)
+				assert.Equal(t, keyID(mfa), res.Get("1.id").String(), "%s", res.Raw)
+				assert.False(t, res.Get("1.is_passwordless").Bool(), "%s", res.Raw)
+
+				res = get(t, ts, "/identities/"+i.ID.String()+"/credentials/oidc/items", http.StatusOK)
+				require.Len(t, res.Array(), 2, "%s", res.Raw)
+				assert.Equal(t, "google", res.Get("0.provider").String(), "%s", res.Raw)
+				assert.Equal(t, "google:"+res.Get("0.subject").String(), res.Get("0.id").String(), "%s", res.Raw)
+				assert.Equal(t, "github", res.Get("1.provider").String(), "%s", res.Raw)
+			})
+
+			t.Run("type=rename security key/"+name, func(t *testing.T) {
+				i := createIdentity(t, true, "google")
+
+				res := send(t, ts, "PATCH", "/identities/"+i.ID.String()+"/credentials/webauthn/items/"+keyID(mfa), http.StatusOK, &identity.UpdateCredentialItemBody{DisplayName: "renamed"})
+				assert.Equal(t, "renamed", res.Get("display_name").String(), "%s", res.Raw)
+
+				res = get(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn/items", http.StatusOK)
+				assert.Equal(t, "passwordless", res.Get("0.display_name").String(), "%s", res.Raw)
+				assert.Equal(t, "renamed", res.Get("1.display_name").String(), "%s", res.Raw)
+
+				send(t, ts, "PATCH", "/identities/"+i.ID.String()+"/credentials/webauthn/items/"+keyID(mfa), http.StatusBadRequest, &identity.UpdateCredentialItemBody{})
+				send(t, ts, "PATCH", "/identities/"+i.ID.String()+"/credentials/webauthn/items/unknown", http.StatusNotFound, &identity.UpdateCredentialItemBody{DisplayName: "renamed"})
+				send(t, ts, "PATCH", "/identities/"+i.ID.String()+"/credentials/oidc/items/google:foo", http.StatusBadRequest, &identity.UpdateCredentialItemBody{DisplayName: "renamed"})
+			})
+
+			t.Run("type=clear clone warning of security key/"+name, func(t *testing.T) {
+				i := createIdentity(t, true)
+				c, ok := i.GetCredentials(identity.CredentialsTypeWebAuthn)
+				require.True(t, ok)
+				config, err := sjson.SetBytes(c.Config, "credentials.0.authenticator.clone_warning", true)
+				require.NoError(t, err)
+				c.Config = config
+				i.SetCredentials(identity.CredentialsTypeWebAuthn, *c)
+				require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(context.Background(), i))
+
+				res := get(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn/items", http.StatusOK)
+				assert.True(t, res.Get("0.clone_warning").Bool(), "%s", res.Raw)
+				assert.False(t, res.Get("1.clone_warning").Bool(), "%s", res.Raw)
+
+				res = send(t, ts, "PATCH", "/identities/"+i.ID.String()+"/credentials/webauthn/items/"+keyID(passwordless), http.StatusOK, &identity.UpdateCredentialItemBody{ClearCloneWarning: true})
+				assert.False(t, res.Get("clone_warning").Bool(), "%s", res.Raw)
+				assert.Equal(t, "passwordless", res.Get("display_name").String(), "%s", res.Raw)
+
+				res = get(t, ts, "/identities/"+i.ID.String()+"?include_credential=webauthn", http.StatusOK)
+				assert.False(t, res.Get("credentials.webauthn.config.credentials.0.authenticator.clone_warning").Bool(), "%s", res.Raw)
+			})
+
+			t.Run("type=delete security key/"+name, func(t *testing.T) {
+				i := createIdentity(t, true)
+				webauthnSession := createSession(t, i, identity.CredentialsTypeWebAuthn)
+				passwordSession := createSession(t, i, identity.CredentialsTypePassword)
+
+				remove(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn/items/"+keyID(mfa), http.StatusNoContent)
+				remove(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn/items/"+keyID(mfa), http.StatusNotFound)
+				assert.True(t, isActive(t, webauthnSession))
+
+				res := get(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn/items", http.StatusOK)
+				require.Len(t, res.Array(), 1, "%s", res.Raw)
+				assert.Equal(t, keyID(passwordless), res.Get("0.id").String(), "%s", res.Raw)
+
+				remove(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn/items/"+keyID(passwordless)+"?revoke_sessions=true", http.StatusNoContent)
+				assert.False(t, isActive(t, webauthnSession))
+				assert.True(t, isActive(t, passwordSession))
+
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+				require.NoError(t, err)
+				_, ok := actual.GetCredentials(identity.CredentialsTypeWebAuthn)
+				assert.False(t, ok)
+			})
+
+			t.Run("type=unlink provider/"+name, func(t *testing.T) {
+				i := createIdentity(t, false, "google", "github")
+				remove(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn/items/"+keyID(mfa), http.StatusNoContent)
+
+				res := get(t, ts, "/identities/"+i.ID.String()+"/credentials/oidc/items", http.StatusOK)
+				google := res.Get("0.id").String()
+				github := res.Get("1.id").String()
+
+				remove(t, ts, "/identities/"+i.ID.String()+"/credentials/oidc/items/"+google, http.StatusNoContent)
+				remove(t, ts, "/identities/"+i.ID.String()+"/credentials/oidc/items/"+google, http.StatusNotFound)
+
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+				require.NoError(t, err)
+				c, ok := actual.GetCredentials(identity.CredentialsTypeOIDC)
+				require.True(t, ok)
+				assert.Equal(t, []string{github}, c.Identifiers)
+
+				// The passwordless security key is the remaining first factor.
+				remove(t, ts, "/identities/"+i.ID.String()+"/credentials/oidc/items/"+github, http.StatusNoContent)
+				remove(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn/items/"+keyID(passwordless), http.StatusBadRequest)
+
+				res = get(t, ts, "/identities/"+i.ID.String()+"/credentials/webauthn/items", http.StatusOK)
+				assert.Len(t, res.Array(), 1, "%s", res.Raw)
+			})
+
+			t.Run("type=link provider/"+name, func(t *testing.T) {
+				conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".config", map[string]interface{}{
+					"providers": []map[string]interface{}{
+						{"id": "google", "provider": "google", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.jsonnet"},
+						{"id": "github", "provider": "github", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.jsonnet"},
+					},
+				})
+				t.Cleanup(func() {
+					conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".config", map[string]interface{}{})
+				})
+
+				i := createIdentity(t, true)
+				subject := x.NewUUID().String()
+				href := "/identities/" + i.ID.String() + "/credentials/oidc/items"
+
+				res := send(t, ts, "POST", href, http.StatusCreated, &identity.CreateCredentialItemBody{Provider: "google", Subject: subject})
+				assert.Equal(t, "google:"+subject, res.Get("id").String(), "%s", res.Raw)
+				assert.Equal(t, "google", res.Get("provider").String(), "%s", res.Raw)
+				assert.Equal(t, subject, res.Get("subject").String(), "%s", res.Raw)
+
+				send(t, ts, "POST", href, http.StatusCreated, &identity.CreateCredentialItemBody{Provider: "github", Subject: subject})
+
+				found, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypeOIDC, "github:"+subject)
+				require.NoError(t, err)
+				assert.Equal(t, i.ID, found.ID)
+
+				res = get(t, ts, href, http.StatusOK)
+				require.Len(t, res.Array(), 2, "%s", res.Raw)
+				assert.Equal(t, "google:"+subject, res.Get("0.id").String(), "%s", res.Raw)
+				assert.Equal(t, "github:"+subject, res.Get("1.id").String(), "%s", res.Raw)
+
+				t.Run("case=rejects invalid requests", func(t *testing.T) {
+					send(t, ts, "POST", href, http.StatusBadRequest, &identity.CreateCredentialItemBody{Provider: "unknown", Subject: subject})
+					send(t, ts, "POST", href, http.StatusBadRequest, &identity.CreateCredentialItemBody{Provider: "google"})
+					send(t, ts, "POST", "/identities/"+i.ID.String()+"/credentials/webauthn/items", http.StatusBadRequest, &identity.CreateCredentialItemBody{Provider: "google", Subject: subject})
+					send(t, ts, "POST", "/identities/"+x.NewUUID().String()+"/credentials/oidc/items", http.StatusNotFound, &identity.CreateCredentialItemBody{Provider: "google", Subject: subject})
+				})
+
+				t.Run("case=rejects providers which are already linked", func(t *testing.T) {
+					send(t, ts, "POST", href, http.StatusConflict, &identity.CreateCredentialItemBody{Provider: "google", Subject: x.NewUUID().String()})
+
+					other := createIdentity(t, true)
+					send(t, ts, "POST", "/identities/"+other.ID.String()+"/credentials/oidc/items", http.StatusConflict, &identity.CreateCredentialItemBody{Provider: "google", Subject: subject})
+				})
+
+				t.Run("case=unlinks the provider", func(t *testing.T) {
+					remove(t, ts, href+"/google:"+subject, http.StatusNoContent)
+
+					_, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, identity.CredentialsTypeOIDC, "google:"+subject)
+					assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+				})
+			})
+		}
+	})
+
+	t.Run("case=should regenerate lookup secrets of a specific user", func(t *testing.T) {
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("type=unknown identity/"+name, func(t *testing.T) {
+				send(t, ts, "POST", "/identities/"+x.NewUUID().String()+"/credentials/lookup_secret/regenerate", http.StatusNotFound, nil)
+			})
+
+			t.Run("type=unsupported type/"+name, func(t *testing.T) {
+				i := identity.NewIdentity("")
+				require.NoError(t, reg.Persister().CreateIdentity(context.Background(), i))
+				send(t, ts, "POST", "/identities/"+i.ID.String()+"/credentials/totp/regenerate", http.StatusBadRequest, nil)
+			})
+
+			t.Run("type=lookup_secret/"+name, func(t *testing.T) {
+				i := identity.NewIdentity("")
+				i.SetCredentials(identity.CredentialsTypeLookup, identity.Credentials{
+					Type:        identity.CredentialsTypeLookup,
+					Identifiers: []string{x.NewUUID().String()},
+					Config:      sqlxx.JSONRawMessage(`{"recovery_codes":[{"code":"old","used_at":"2023-01-01T00:00:00Z"}]}`),
+				})
+				require.NoError(t, reg.Persister().CreateIdentity(context.Background(), i))
+				res := get(t, ts, "/identities/"+i.ID.String(), http.StatusOK)
+				assert.EqualValues(t, 0, res.Get("lookup_secrets_remaining").Int(), "%s", res.Raw)
+
+				res = send(t, ts, "POST", "/identities/"+i.ID.String()+"/credentials/lookup_secret/regenerate", http.StatusOK, nil)
+				codes := res.Get("lookup_secrets").Array()
+				require.Len(t, codes, identity.LookupSecretsCount, "%s", res.Raw)
+
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+				require.NoError(t, err)
+				c, ok := actual.GetCredentials(identity.CredentialsTypeLookup)
+				require.True(t, ok)
+				for k, code := range codes {
+					assert.Equal(t, code.String(), gjson.GetBytes(c.Config, fmt.Sprintf("recovery_codes.%d.code", k)).String())
+				}
+
+				res = get(t, ts, "/identities/"+i.ID.String(), http.StatusOK)
+				assert.EqualValues(t, identity.LookupSecretsCount, res.Get("lookup_secrets_remaining").Int(), "%s", res.Raw)
+			})
+		}
+	})
 }
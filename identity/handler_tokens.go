@@ -0,0 +1,115 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/x"
+)
+
+// Identity Upstream Tokens
+//
+// The tokens issued to an identity by a linked social sign in provider.
+//
+// swagger:model identityUpstreamTokens
+type UpstreamTokens struct {
+	// Provider is the ID of the linked OpenID Connect provider.
+	//
+	// required: true
+	Provider string `json:"provider"`
+
+	// Subject is the subject of the identity at the linked OpenID Connect provider.
+	//
+	// required: true
+	Subject string `json:"subject"`
+
+	// AccessToken is a currently valid access token issued by the provider.
+	//
+	// required: true
+	AccessToken string `json:"access_token"`
+
+	// IDToken is the latest ID token issued by the provider, if any.
+	IDToken string `json:"id_token,omitempty"`
+
+	// ExpiresAt is the time the access token expires. It is not set if the provider did not tell.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Get Identity Upstream Tokens Parameters
+//
+// swagger:parameters getIdentityUpstreamTokens
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getIdentityUpstreamTokens struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Type is the credential's Type.
+	// Currently, only oidc is supported.
+	//
+	// enum: oidc
+	// required: true
+	// in: path
+	Type string `json:"type"`
+
+	// Provider is the ID of the linked OpenID Connect provider.
+	//
+	// required: true
+	// in: path
+	Provider string `json:"provider"`
+}
+
+// swagger:route GET /admin/identities/{id}/credentials/{type}/tokens/{provider} identity getIdentityUpstreamTokens
+//
+// # Get a valid upstream access token of an identity
+//
+// Returns a currently valid access token issued to the identity by a linked social sign in provider. This allows
+// calling the provider's APIs on behalf of the identity. If the stored access token expired, it is refreshed
+// using the refresh token, which requires the provider to have issued one (for example by requesting the
+// `offline_access` scope).
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: identityUpstreamTokens
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) getIdentityUpstreamTokens(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if ct := CredentialsType(ps.ByName("type")); ct != CredentialsTypeOIDC {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Credentials of type %s do not have upstream tokens.", ct)))
+		return
+	}
+
+	tokens, err := h.r.UpstreamTokens(r.Context(), x.ParseUUID(ps.ByName("id")), ps.ByName("provider"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Audit().
+		WithRequest(r).
+		WithField("identity_id", ps.ByName("id")).
+		WithField("provider", tokens.Provider).
+		Info("An upstream access token of the identity was retrieved by an administrator.")
+
+	h.r.Writer().Write(w, r, tokens)
+}
@@ -91,6 +91,17 @@ type Identity struct {
 	// StateChangedAt contains the last time when the identity's state changed.
 	StateChangedAt *sqlxx.NullTime `json:"state_changed_at,omitempty" faker:"-" db:"state_changed_at"`
 
+	// MFAEnrollmentDeadline is the time until which the identity has to set up the second factors required
+	// by the MFA enrollment policy.
+	//
+	// It is only set while the identity has not set up enough second factors.
+	MFAEnrollmentDeadline *sqlxx.NullTime `json:"mfa_enrollment_deadline,omitempty" faker:"-" db:"mfa_enrollment_deadline"`
+
+	// LookupSecretsRemaining is the number of lookup secrets (recovery codes) which the identity has not used yet.
+	//
+	// It is only set if the identity has set up lookup secrets.
+	LookupSecretsRemaining *int `json:"lookup_secrets_remaining,omitempty" faker:"-" db:"lookup_secrets_remaining"`
+
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits
 	// in a self-service manner. The input will always be validated against the JSON Schema defined
 	// in `schema_url`.
@@ -298,6 +309,36 @@ func (i *Identity) ParseCredentials(t CredentialsType, config interface{}) (*Cre
 	return nil, herodot.ErrNotFound.WithReasonf("identity does not have credential type %s", t)
 }
 
+// MFAEnrollmentOverdue returns true if the identity did not set up the second factors required by the
+// MFA enrollment policy before the deadline.
+func (i *Identity) MFAEnrollmentOverdue() bool {
+	return i.MFAEnrollmentDeadline != nil && time.Now().After(time.Time(*i.MFAEnrollmentDeadline))
+}
+
+// UpdateLookupSecretsRemaining counts the unused lookup secrets of the identity's credentials. It must be called
+// whenever the credentials of the identity are persisted.
+func (i *Identity) UpdateLookupSecretsRemaining() error {
+	i.LookupSecretsRemaining = nil
+
+	c, ok := i.GetCredentials(CredentialsTypeLookup)
+	if !ok || len(c.Config) == 0 {
+		return nil
+	}
+
+	var conf CredentialsLookupConfig
+	if err := json.Unmarshal(c.Config, &conf); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("The lookup secrets could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	if len(conf.RecoveryCodes) == 0 {
+		return nil
+	}
+
+	remaining := conf.RemainingCodes()
+	i.LookupSecretsRemaining = &remaining
+	return nil
+}
+
 func (i *Identity) CopyWithoutCredentials() *Identity {
 	i.lock().RLock()
 	defer i.lock().RUnlock()
@@ -403,6 +444,23 @@ func (i *Identity) Validate() error {
 	return nil
 }
 
+// WithDeclassifiedCredentialsWebAuthn returns a copy of the identity which includes the configuration
+// of the WebAuthn credentials, such as the signature counter and clone warning of each security key.
+func (i *Identity) WithDeclassifiedCredentialsWebAuthn() *Identity {
+	credsToPublish := make(map[CredentialsType]Credentials)
+	for ct, original := range i.Credentials {
+		toPublish := original
+		if ct != CredentialsTypeWebAuthn {
+			toPublish.Config = []byte{}
+		}
+		credsToPublish[ct] = toPublish
+	}
+
+	ii := *i
+	ii.Credentials = credsToPublish
+	return &ii
+}
+
 func (i *Identity) WithDeclassifiedCredentialsOIDC(ctx context.Context, c cipher.Provider) (*Identity, error) {
 	credsToPublish := make(map[CredentialsType]Credentials)
 
@@ -417,11 +475,16 @@ func (i *Identity) WithDeclassifiedCredentialsOIDC(ctx context.Context, c cipher
 		toPublish := original
 		toPublish.Config = []byte{}
 
-		for _, token := range []string{"initial_id_token", "initial_access_token", "initial_refresh_token"} {
+		for _, token := range []string{"initial_id_token", "initial_access_token", "initial_refresh_token", "id_token", "access_token", "refresh_token"} {
 			var i int
 			var err error
 			gjson.GetBytes(original.Config, "providers").ForEach(func(_, v gjson.Result) bool {
 				key := fmt.Sprintf("%d.%s", i, token)
+				if !v.Get(token).Exists() {
+					// The latest tokens are missing for credentials created before they were tracked.
+					i++
+					return true
+				}
 				ciphertext := v.Get(token).String()
 
 				var plaintext []byte
@@ -445,6 +508,13 @@ func (i *Identity) WithDeclassifiedCredentialsOIDC(ctx context.Context, c cipher
 					return false
 				}
 
+				if expiresAt := v.Get("token_expires_at"); expiresAt.Exists() {
+					toPublish.Config, err = sjson.SetBytes(toPublish.Config, fmt.Sprintf("providers.%d.token_expires_at", i), expiresAt.String())
+					if err != nil {
+						return false
+					}
+				}
+
 				i++
 				return true
 			})
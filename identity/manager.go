@@ -6,9 +6,14 @@ package identity
 import (
 	"context"
 	"reflect"
+	"time"
+
+	"github.com/tidwall/gjson"
 
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/stringslice"
 
 	"github.com/ory/kratos/driver/config"
 
@@ -232,3 +237,51 @@ func (m *Manager) CountActiveMultiFactorCredentials(ctx context.Context, i *Iden
 	}
 	return count, nil
 }
+
+// MFAEnrollmentPending returns true if the MFA enrollment policy applies to the identity and the identity
+// has set up fewer second factors than required. The identity must include its credentials.
+func (m *Manager) MFAEnrollmentPending(ctx context.Context, i *Identity) (bool, error) {
+	policy := m.r.Config().SelfServiceSettingsMFAEnrollment(ctx)
+	if !policy.Required &&
+		!stringslice.Has(policy.IdentitySchemas, i.SchemaID) &&
+		!(len(policy.MetadataAdminFlag) > 0 && gjson.GetBytes(i.MetadataAdmin, policy.MetadataAdminFlag).Bool()) {
+		return false, nil
+	}
+
+	count, err := m.CountActiveMultiFactorCredentials(ctx, i)
+	if err != nil {
+		return false, err
+	}
+
+	return count < policy.MinFactors, nil
+}
+
+// SetMFAEnrollmentDeadline starts the grace period of the MFA enrollment policy if the identity has to set up
+// more second factors, and clears the deadline once it has. It returns true if the deadline was changed, in
+// which case the identity needs to be persisted. The identity must include its credentials.
+func (m *Manager) SetMFAEnrollmentDeadline(ctx context.Context, i *Identity) (changed bool, err error) {
+	ctx, span := m.r.Tracer(ctx).Tracer().Start(ctx, "identity.Manager.SetMFAEnrollmentDeadline")
+	defer otelx.End(span, &err)
+
+	policy := m.r.Config().SelfServiceSettingsMFAEnrollment(ctx)
+	if !policy.Enabled() && i.MFAEnrollmentDeadline == nil {
+		return false, nil
+	}
+
+	pending, err := m.MFAEnrollmentPending(ctx, i)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case pending && i.MFAEnrollmentDeadline == nil:
+		deadline := sqlxx.NullTime(time.Now().UTC().Add(policy.GracePeriod).Round(time.Second))
+		i.MFAEnrollmentDeadline = &deadline
+		return true, nil
+	case !pending && i.MFAEnrollmentDeadline != nil:
+		i.MFAEnrollmentDeadline = nil
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -203,6 +203,94 @@ func TestManager(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
+	t.Run("method=SetMFAEnrollmentDeadline", func(t *testing.T) {
+		webAuthn := identity.Credentials{
+			Type:        identity.CredentialsTypeWebAuthn,
+			Identifiers: []string{"foo"},
+			Config:      []byte(`{"credentials":[{"is_passwordless":false}]}`),
+		}
+
+		t.Run("case=should not set a deadline without policy", func(t *testing.T) {
+			id := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			changed, err := reg.IdentityManager().SetMFAEnrollmentDeadline(ctx, id)
+			require.NoError(t, err)
+			assert.False(t, changed)
+			assert.Nil(t, id.MFAEnrollmentDeadline)
+		})
+
+		t.Run("case=should set and clear the deadline", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeySelfServiceSettingsMFAEnrollmentRequired, true)
+			conf.MustSet(ctx, config.ViperKeySelfServiceSettingsMFAEnrollmentGracePeriod, "1h")
+			t.Cleanup(func() {
+				conf.MustSet(ctx, config.ViperKeySelfServiceSettingsMFAEnrollmentRequired, false)
+			})
+
+			id := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			changed, err := reg.IdentityManager().SetMFAEnrollmentDeadline(ctx, id)
+			require.NoError(t, err)
+			assert.True(t, changed)
+			require.NotNil(t, id.MFAEnrollmentDeadline)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), time.Time(*id.MFAEnrollmentDeadline), time.Minute)
+			assert.False(t, id.MFAEnrollmentOverdue())
+
+			deadline := *id.MFAEnrollmentDeadline
+			changed, err = reg.IdentityManager().SetMFAEnrollmentDeadline(ctx, id)
+			require.NoError(t, err)
+			assert.False(t, changed, "an existing deadline must not be extended")
+			assert.Equal(t, deadline, *id.MFAEnrollmentDeadline)
+
+			id.Credentials[identity.CredentialsTypeWebAuthn] = webAuthn
+			changed, err = reg.IdentityManager().SetMFAEnrollmentDeadline(ctx, id)
+			require.NoError(t, err)
+			assert.True(t, changed)
+			assert.Nil(t, id.MFAEnrollmentDeadline)
+		})
+
+		t.Run("case=should respect the minimum number of factors", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeySelfServiceSettingsMFAEnrollmentRequired, true)
+			conf.MustSet(ctx, config.ViperKeySelfServiceSettingsMFAEnrollmentMinFactors, 2)
+			t.Cleanup(func() {
+				conf.MustSet(ctx, config.ViperKeySelfServiceSettingsMFAEnrollmentRequired, false)
+				conf.MustSet(ctx, config.ViperKeySelfServiceSettingsMFAEnrollmentMinFactors, 1)
+			})
+
+			id := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			id.Credentials[identity.CredentialsTypeWebAuthn] = webAuthn
+			pending, err := reg.IdentityManager().MFAEnrollmentPending(ctx, id)
+			require.NoError(t, err)
+			assert.True(t, pending)
+		})
+
+		t.Run("case=should apply policy to flagged identities only", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeySelfServiceSettingsMFAEnrollmentMetadataFlag, "require_mfa")
+			t.Cleanup(func() {
+				conf.MustSet(ctx, config.ViperKeySelfServiceSettingsMFAEnrollmentMetadataFlag, "")
+			})
+
+			id := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			pending, err := reg.IdentityManager().MFAEnrollmentPending(ctx, id)
+			require.NoError(t, err)
+			assert.False(t, pending)
+
+			id.MetadataAdmin = []byte(`{"require_mfa":true}`)
+			pending, err = reg.IdentityManager().MFAEnrollmentPending(ctx, id)
+			require.NoError(t, err)
+			assert.True(t, pending)
+		})
+
+		t.Run("case=should clear the deadline once the policy is disabled", func(t *testing.T) {
+			id := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			deadline := sqlxx.NullTime(time.Now().Add(-time.Hour))
+			id.MFAEnrollmentDeadline = &deadline
+			assert.True(t, id.MFAEnrollmentOverdue())
+
+			changed, err := reg.IdentityManager().SetMFAEnrollmentDeadline(ctx, id)
+			require.NoError(t, err)
+			assert.True(t, changed)
+			assert.Nil(t, id.MFAEnrollmentDeadline)
+		})
+	})
+
 	t.Run("method=UpdateTraits", func(t *testing.T) {
 		t.Run("case=should update protected traits with option", func(t *testing.T) {
 			original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
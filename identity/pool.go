@@ -51,6 +51,13 @@ type (
 		// FindByCredentialsIdentifier returns an identity by querying for it's credential identifiers.
 		FindByCredentialsIdentifier(ctx context.Context, ct CredentialsType, match string) (*Identity, *Credentials, error)
 
+		// FindByWebAuthnUserHandle returns the identity whose WebAuthn credentials use the given user handle.
+		FindByWebAuthnUserHandle(ctx context.Context, userHandle []byte) (*Identity, error)
+
+		// CompareAndSwapCredentialsConfig replaces the config of the identity's credentials of the given type
+		// if it still equals expected. It returns sqlcon.ErrNoRows if the config was changed in the meantime.
+		CompareAndSwapCredentialsConfig(ctx context.Context, identityID uuid.UUID, ct CredentialsType, expected, updated sqlxx.JSONRawMessage) error
+
 		// DeleteIdentity removes an identity by its id. Will return an error
 		// if identity exists, backend connectivity is broken, or trait validation fails.
 		DeleteIdentity(context.Context, uuid.UUID) error
@@ -774,6 +774,80 @@ func TestPool(ctx context.Context, conf *config.Config, p persistence.Persister,
 			})
 		})
 
+		t.Run("case=find identity by its webauthn user handle", func(t *testing.T) {
+			userHandle := x.NewUUID()
+			expected := passwordIdentity("", x.NewUUID().String())
+			expected.Traits = identity.Traits(`{}`)
+			expected.SetCredentials(identity.CredentialsTypeWebAuthn, identity.Credentials{
+				Type:        identity.CredentialsTypeWebAuthn,
+				Identifiers: []string{x.NewUUID().String()},
+				Config:      sqlxx.JSONRawMessage(`{"credentials":[],"user_handle":"` + base64.StdEncoding.EncodeToString(userHandle[:]) + `"}`),
+			})
+
+			require.NoError(t, p.CreateIdentity(ctx, expected))
+			createdIDs = append(createdIDs, expected.ID)
+
+			actual, err := p.FindByWebAuthnUserHandle(ctx, userHandle[:])
+			require.NoError(t, err)
+			assert.Equal(t, expected.ID, actual.ID)
+			assert.Empty(t, actual.Credentials)
+
+			t.Run("not if the user handle is unknown", func(t *testing.T) {
+				other := x.NewUUID()
+				_, err := p.FindByWebAuthnUserHandle(ctx, other[:])
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+
+			t.Run("not if the user handle is empty", func(t *testing.T) {
+				_, err := p.FindByWebAuthnUserHandle(ctx, nil)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+
+			t.Run("not if on another network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				_, err := p.FindByWebAuthnUserHandle(ctx, userHandle[:])
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+		})
+
+		t.Run("case=compare and swap the credentials config", func(t *testing.T) {
+			expected := passwordIdentity("", x.NewUUID().String())
+			expected.Traits = identity.Traits(`{}`)
+			expected.SetCredentials(identity.CredentialsTypeTOTP, identity.Credentials{
+				Type:        identity.CredentialsTypeTOTP,
+				Identifiers: []string{x.NewUUID().String()},
+				Config:      sqlxx.JSONRawMessage(`{"totp_url":"otpauth://totp/foo","last_used_step":1}`),
+			})
+
+			require.NoError(t, p.CreateIdentity(ctx, expected))
+			createdIDs = append(createdIDs, expected.ID)
+
+			_, c, err := p.FindByCredentialsIdentifier(ctx, identity.CredentialsTypeTOTP, expected.Credentials[identity.CredentialsTypeTOTP].Identifiers[0])
+			require.NoError(t, err)
+
+			updated := sqlxx.JSONRawMessage(`{"totp_url":"otpauth://totp/foo","last_used_step":2}`)
+			require.NoError(t, p.CompareAndSwapCredentialsConfig(ctx, expected.ID, identity.CredentialsTypeTOTP, c.Config, updated))
+
+			actual, err := p.GetIdentityConfidential(ctx, expected.ID)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(updated), string(actual.Credentials[identity.CredentialsTypeTOTP].Config))
+
+			t.Run("not if the config was changed in the meantime", func(t *testing.T) {
+				err := p.CompareAndSwapCredentialsConfig(ctx, expected.ID, identity.CredentialsTypeTOTP, c.Config, sqlxx.JSONRawMessage(`{"totp_url":"otpauth://totp/foo","last_used_step":3}`))
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				actual, err := p.GetIdentityConfidential(ctx, expected.ID)
+				require.NoError(t, err)
+				assert.JSONEq(t, string(updated), string(actual.Credentials[identity.CredentialsTypeTOTP].Config))
+			})
+
+			t.Run("not if on another network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				err := p.CompareAndSwapCredentialsConfig(ctx, expected.ID, identity.CredentialsTypeTOTP, updated, c.Config)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+		})
+
 		t.Run("suite=verifiable-address", func(t *testing.T) {
 			createIdentityWithAddresses := func(t *testing.T, email string) identity.VerifiableAddress {
 				var i identity.Identity
@@ -17,6 +17,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 )
 
@@ -630,6 +631,27 @@ type FrontendApi interface {
 	 */
 	PerformNativeLogoutExecute(r FrontendApiApiPerformNativeLogoutRequest) (*http.Response, error)
 
+	/*
+			 * PerformOidcBackChannelLogout Perform OpenID Connect Back-Channel Logout
+			 * This endpoint is called by the upstream OpenID Connect provider when the user logged out there. It verifies the
+		logout token and revokes all sessions which were authenticated with the provider for the token's session ID or,
+		if the token has no session ID, its subject.
+
+		Logout tokens must contain a unique ID (`jti`) and the time they were issued at (`iat`). Tokens issued more than
+		ten minutes ago and tokens whose ID was already used are rejected.
+
+		See https://openid.net/specs/openid-connect-backchannel-1_0.html
+			 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			 * @param provider The ID of the OpenID Connect provider which sends the logout token.
+			 * @return FrontendApiApiPerformOidcBackChannelLogoutRequest
+	*/
+	PerformOidcBackChannelLogout(ctx context.Context, provider string) FrontendApiApiPerformOidcBackChannelLogoutRequest
+
+	/*
+	 * PerformOidcBackChannelLogoutExecute executes the request
+	 */
+	PerformOidcBackChannelLogoutExecute(r FrontendApiApiPerformOidcBackChannelLogoutRequest) (*http.Response, error)
+
 	/*
 			 * ToSession Check Who the Current HTTP Session Belongs To
 			 * Uses the HTTP Headers in the GET request to determine (e.g. by using checking the cookies) who is authenticated.
@@ -681,6 +703,12 @@ type FrontendApi interface {
 
 		`session_inactive`: No active session was found in the request (e.g. no Ory Session Cookie / Ory Session Token).
 		`session_aal2_required`: An active session was found but it does not fulfil the Authenticator Assurance Level, implying that the session must (e.g.) authenticate the second factor.
+		`session_refresh_required`: An active session was found but it does not fulfil the `max_age` or `required_amr` requirements, implying that the session must re-authenticate.
+
+		Use the `max_age` and `required_amr` query parameters to require step-up authentication for sensitive operations,
+		for example `?max_age=300&required_amr=webauthn` to require that the identity signed in using WebAuthn within the
+		last five minutes. If the requirements are not met, the `redirect_browser_to` field of the error points to a login
+		flow which re-authenticates the identity with the required method.
 			 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 			 * @return FrontendApiApiToSessionRequest
 	*/
@@ -927,6 +955,7 @@ type FrontendApiApiCreateBrowserLoginFlowRequest struct {
 	ApiService     FrontendApi
 	refresh        *bool
 	aal            *string
+	requiredMethod *string
 	returnTo       *string
 	cookie         *string
 	loginChallenge *string
@@ -940,6 +969,10 @@ func (r FrontendApiApiCreateBrowserLoginFlowRequest) Aal(aal string) FrontendApi
 	r.aal = &aal
 	return r
 }
+func (r FrontendApiApiCreateBrowserLoginFlowRequest) RequiredMethod(requiredMethod string) FrontendApiApiCreateBrowserLoginFlowRequest {
+	r.requiredMethod = &requiredMethod
+	return r
+}
 func (r FrontendApiApiCreateBrowserLoginFlowRequest) ReturnTo(returnTo string) FrontendApiApiCreateBrowserLoginFlowRequest {
 	r.returnTo = &returnTo
 	return r
@@ -1024,6 +1057,9 @@ func (a *FrontendApiService) CreateBrowserLoginFlowExecute(r FrontendApiApiCreat
 	if r.aal != nil {
 		localVarQueryParams.Add("aal", parameterToString(*r.aal, ""))
 	}
+	if r.requiredMethod != nil {
+		localVarQueryParams.Add("required_method", parameterToString(*r.requiredMethod, ""))
+	}
 	if r.returnTo != nil {
 		localVarQueryParams.Add("return_to", parameterToString(*r.returnTo, ""))
 	}
@@ -1844,11 +1880,12 @@ func (a *FrontendApiService) CreateBrowserVerificationFlowExecute(r FrontendApiA
 }
 
 type FrontendApiApiCreateNativeLoginFlowRequest struct {
-	ctx           context.Context
-	ApiService    FrontendApi
-	refresh       *bool
-	aal           *string
-	xSessionToken *string
+	ctx            context.Context
+	ApiService     FrontendApi
+	refresh        *bool
+	aal            *string
+	requiredMethod *string
+	xSessionToken  *string
 }
 
 func (r FrontendApiApiCreateNativeLoginFlowRequest) Refresh(refresh bool) FrontendApiApiCreateNativeLoginFlowRequest {
@@ -1859,6 +1896,10 @@ func (r FrontendApiApiCreateNativeLoginFlowRequest) Aal(aal string) FrontendApiA
 	r.aal = &aal
 	return r
 }
+func (r FrontendApiApiCreateNativeLoginFlowRequest) RequiredMethod(requiredMethod string) FrontendApiApiCreateNativeLoginFlowRequest {
+	r.requiredMethod = &requiredMethod
+	return r
+}
 func (r FrontendApiApiCreateNativeLoginFlowRequest) XSessionToken(xSessionToken string) FrontendApiApiCreateNativeLoginFlowRequest {
 	r.xSessionToken = &xSessionToken
 	return r
@@ -1931,6 +1972,9 @@ func (a *FrontendApiService) CreateNativeLoginFlowExecute(r FrontendApiApiCreate
 	if r.aal != nil {
 		localVarQueryParams.Add("aal", parameterToString(*r.aal, ""))
 	}
+	if r.requiredMethod != nil {
+		localVarQueryParams.Add("required_method", parameterToString(*r.requiredMethod, ""))
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -4254,11 +4298,152 @@ func (a *FrontendApiService) PerformNativeLogoutExecute(r FrontendApiApiPerformN
 	return localVarHTTPResponse, nil
 }
 
+type FrontendApiApiPerformOidcBackChannelLogoutRequest struct {
+	ctx         context.Context
+	ApiService  FrontendApi
+	provider    string
+	logoutToken *string
+}
+
+func (r FrontendApiApiPerformOidcBackChannelLogoutRequest) LogoutToken(logoutToken string) FrontendApiApiPerformOidcBackChannelLogoutRequest {
+	r.logoutToken = &logoutToken
+	return r
+}
+
+func (r FrontendApiApiPerformOidcBackChannelLogoutRequest) Execute() (*http.Response, error) {
+	return r.ApiService.PerformOidcBackChannelLogoutExecute(r)
+}
+
+/*
+  - PerformOidcBackChannelLogout Perform OpenID Connect Back-Channel Logout
+  - This endpoint is called by the upstream OpenID Connect provider when the user logged out there. It verifies the
+
+logout token and revokes all sessions which were authenticated with the provider for the token's session ID or,
+if the token has no session ID, its subject.
+
+Logout tokens must contain a unique ID (`jti`) and the time they were issued at (`iat`). Tokens issued more than
+ten minutes ago and tokens whose ID was already used are rejected.
+
+See https://openid.net/specs/openid-connect-backchannel-1_0.html
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param provider The ID of the OpenID Connect provider which sends the logout token.
+  - @return FrontendApiApiPerformOidcBackChannelLogoutRequest
+*/
+func (a *FrontendApiService) PerformOidcBackChannelLogout(ctx context.Context, provider string) FrontendApiApiPerformOidcBackChannelLogoutRequest {
+	return FrontendApiApiPerformOidcBackChannelLogoutRequest{
+		ApiService: a,
+		ctx:        ctx,
+		provider:   provider,
+	}
+}
+
+/*
+ * Execute executes the request
+ */
+func (a *FrontendApiService) PerformOidcBackChannelLogoutExecute(r FrontendApiApiPerformOidcBackChannelLogoutRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "FrontendApiService.PerformOidcBackChannelLogout")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/methods/oidc/backchannel-logout/{provider}"
+	localVarPath = strings.Replace(localVarPath, "{"+"provider"+"}", url.PathEscape(parameterToString(r.provider, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.logoutToken == nil {
+		return nil, reportError("logoutToken is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/x-www-form-urlencoded"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	localVarFormParams.Add("logout_token", parameterToString(*r.logoutToken, ""))
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
 type FrontendApiApiToSessionRequest struct {
 	ctx           context.Context
 	ApiService    FrontendApi
 	xSessionToken *string
 	cookie        *string
+	maxAge        *int64
+	requiredAmr   *[]string
 }
 
 func (r FrontendApiApiToSessionRequest) XSessionToken(xSessionToken string) FrontendApiApiToSessionRequest {
@@ -4269,6 +4454,14 @@ func (r FrontendApiApiToSessionRequest) Cookie(cookie string) FrontendApiApiToSe
 	r.cookie = &cookie
 	return r
 }
+func (r FrontendApiApiToSessionRequest) MaxAge(maxAge int64) FrontendApiApiToSessionRequest {
+	r.maxAge = &maxAge
+	return r
+}
+func (r FrontendApiApiToSessionRequest) RequiredAmr(requiredAmr []string) FrontendApiApiToSessionRequest {
+	r.requiredAmr = &requiredAmr
+	return r
+}
 
 func (r FrontendApiApiToSessionRequest) Execute() (*Session, *http.Response, error) {
 	return r.ApiService.ToSessionExecute(r)
@@ -4326,6 +4519,12 @@ As explained above, this request may fail due to several reasons. The `error.id`
 
 `session_inactive`: No active session was found in the request (e.g. no Ory Session Cookie / Ory Session Token).
 `session_aal2_required`: An active session was found but it does not fulfil the Authenticator Assurance Level, implying that the session must (e.g.) authenticate the second factor.
+`session_refresh_required`: An active session was found but it does not fulfil the `max_age` or `required_amr` requirements, implying that the session must re-authenticate.
+
+Use the `max_age` and `required_amr` query parameters to require step-up authentication for sensitive operations,
+for example `?max_age=300&required_amr=webauthn` to require that the identity signed in using WebAuthn within the
+last five minutes. If the requirements are not met, the `redirect_browser_to` field of the error points to a login
+flow which re-authenticates the identity with the required method.
   - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
   - @return FrontendApiApiToSessionRequest
 */
@@ -4360,6 +4559,20 @@ func (a *FrontendApiService) ToSessionExecute(r FrontendApiApiToSessionRequest)
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.maxAge != nil {
+		localVarQueryParams.Add("max_age", parameterToString(*r.maxAge, ""))
+	}
+	if r.requiredAmr != nil {
+		t := *r.requiredAmr
+		if reflect.TypeOf(t).Kind() == reflect.Slice {
+			s := reflect.ValueOf(t)
+			for i := 0; i < s.Len(); i++ {
+				localVarQueryParams.Add("required_amr", parameterToString(s.Index(i), "multi"))
+			}
+		} else {
+			localVarQueryParams.Add("required_amr", parameterToString(t, "multi"))
+		}
+	}
 
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
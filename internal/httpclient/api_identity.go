@@ -44,6 +44,37 @@ type IdentityApi interface {
 	 */
 	CreateIdentityExecute(r IdentityApiApiCreateIdentityRequest) (*Identity, *http.Response, error)
 
+	/*
+			 * CreateIdentityCredentialItem Add an item to an identity's credential
+			 * Links a social sign in provider (oidc) to an identity without user interaction, for example when importing
+		identities whose subject at the provider is already known. The identity can then sign in with the provider.
+			 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			 * @param id ID is the identity's ID.
+			 * @param type_ Type is the credential's Type. Currently, only oidc is supported.
+			 * @return IdentityApiApiCreateIdentityCredentialItemRequest
+	*/
+	CreateIdentityCredentialItem(ctx context.Context, id string, type_ string) IdentityApiApiCreateIdentityCredentialItemRequest
+
+	/*
+	 * CreateIdentityCredentialItemExecute executes the request
+	 * @return IdentityCredentialItem
+	 */
+	CreateIdentityCredentialItemExecute(r IdentityApiApiCreateIdentityCredentialItemRequest) (*IdentityCredentialItem, *http.Response, error)
+
+	/*
+	 * CreateOidcProvider Create an OpenID Connect Provider
+	 * Adds a social sign in provider without changing the configuration. The provider can be used right away. The configuration is validated and, for providers using OpenID Connect Discovery, the discovery document of the issuer is fetched. The client secret and private keys are stored encrypted.
+	 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	 * @return IdentityApiApiCreateOidcProviderRequest
+	 */
+	CreateOidcProvider(ctx context.Context) IdentityApiApiCreateOidcProviderRequest
+
+	/*
+	 * CreateOidcProviderExecute executes the request
+	 * @return OidcProvider
+	 */
+	CreateOidcProviderExecute(r IdentityApiApiCreateOidcProviderRequest) (*OidcProvider, *http.Response, error)
+
 	/*
 			 * CreateRecoveryCodeForIdentity Create a Recovery Code
 			 * This endpoint creates a recovery code which should be given to the user in order for them to recover
@@ -90,13 +121,29 @@ type IdentityApi interface {
 	 */
 	DeleteIdentityExecute(r IdentityApiApiDeleteIdentityRequest) (*http.Response, error)
 
+	/*
+	 * DeleteIdentityCredentialItem Delete an item of an identity's credential
+	 * Deletes a single security key (webauthn) or unlinks a single social sign in provider (oidc) of an identity. The last first factor credential of an identity can not be deleted.
+	 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	 * @param id ID is the identity's ID.
+	 * @param type_ Type is the credential's Type. One of webauthn, oidc
+	 * @param item Item is the ID of the credential item.
+	 * @return IdentityApiApiDeleteIdentityCredentialItemRequest
+	 */
+	DeleteIdentityCredentialItem(ctx context.Context, id string, type_ string, item string) IdentityApiApiDeleteIdentityCredentialItemRequest
+
+	/*
+	 * DeleteIdentityCredentialItemExecute executes the request
+	 */
+	DeleteIdentityCredentialItemExecute(r IdentityApiApiDeleteIdentityCredentialItemRequest) (*http.Response, error)
+
 	/*
 			 * DeleteIdentityCredentials Delete a credential for a specific identity
 			 * Delete an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) credential by its type
 		You can only delete second factor (aal2) credentials.
 			 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 			 * @param id ID is the identity's ID.
-			 * @param type_ Type is the credential's Type. One of totp, webauthn, lookup
+			 * @param type_ Type is the credential's Type. One of totp, webauthn, lookup, hotp
 			 * @return IdentityApiApiDeleteIdentityCredentialsRequest
 	*/
 	DeleteIdentityCredentials(ctx context.Context, id string, type_ string) IdentityApiApiDeleteIdentityCredentialsRequest
@@ -121,6 +168,20 @@ type IdentityApi interface {
 	 */
 	DeleteIdentitySessionsExecute(r IdentityApiApiDeleteIdentitySessionsRequest) (*http.Response, error)
 
+	/*
+	 * DeleteOidcProvider Delete an OpenID Connect Provider
+	 * Removes a social sign in provider which was added using the admin API. Providers which identities have still linked can not be deleted. Remove the OpenID Connect credential items of these identities first.
+	 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	 * @param id ID is the provider's ID.
+	 * @return IdentityApiApiDeleteOidcProviderRequest
+	 */
+	DeleteOidcProvider(ctx context.Context, id string) IdentityApiApiDeleteOidcProviderRequest
+
+	/*
+	 * DeleteOidcProviderExecute executes the request
+	 */
+	DeleteOidcProviderExecute(r IdentityApiApiDeleteOidcProviderRequest) (*http.Response, error)
+
 	/*
 	 * DisableSession Deactivate a Session
 	 * Calling this endpoint deactivates the specified session. Session data is not deleted.
@@ -184,6 +245,38 @@ type IdentityApi interface {
 	 */
 	GetIdentitySchemaExecute(r IdentityApiApiGetIdentitySchemaRequest) (map[string]interface{}, *http.Response, error)
 
+	/*
+	 * GetIdentityUpstreamTokens Get a valid upstream access token of an identity
+	 * Returns a currently valid access token issued to the identity by a linked social sign in provider. This allows calling the provider's APIs on behalf of the identity. If the stored access token expired, it is refreshed using the refresh token, which requires the provider to have issued one (for example by requesting the `offline_access` scope).
+	 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	 * @param id ID is the identity's ID.
+	 * @param type_ Type is the credential's Type. Currently, only oidc is supported.
+	 * @param provider Provider is the ID of the linked OpenID Connect provider.
+	 * @return IdentityApiApiGetIdentityUpstreamTokensRequest
+	 */
+	GetIdentityUpstreamTokens(ctx context.Context, id string, type_ string, provider string) IdentityApiApiGetIdentityUpstreamTokensRequest
+
+	/*
+	 * GetIdentityUpstreamTokensExecute executes the request
+	 * @return IdentityUpstreamTokens
+	 */
+	GetIdentityUpstreamTokensExecute(r IdentityApiApiGetIdentityUpstreamTokensRequest) (*IdentityUpstreamTokens, *http.Response, error)
+
+	/*
+	 * GetOidcProvider Get an OpenID Connect Provider
+	 * Returns a social sign in provider which was added using the admin API. Client secrets and private keys are never returned.
+	 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	 * @param id ID is the provider's ID.
+	 * @return IdentityApiApiGetOidcProviderRequest
+	 */
+	GetOidcProvider(ctx context.Context, id string) IdentityApiApiGetOidcProviderRequest
+
+	/*
+	 * GetOidcProviderExecute executes the request
+	 * @return OidcProvider
+	 */
+	GetOidcProviderExecute(r IdentityApiApiGetOidcProviderRequest) (*OidcProvider, *http.Response, error)
+
 	/*
 			 * GetSession Get Session
 			 * This endpoint is useful for:
@@ -215,6 +308,22 @@ type IdentityApi interface {
 	 */
 	ListIdentitiesExecute(r IdentityApiApiListIdentitiesRequest) ([]Identity, *http.Response, error)
 
+	/*
+	 * ListIdentityCredentialItems List the items of an identity's credential
+	 * Lists the security keys (webauthn) or linked social sign in providers (oidc) of an identity.
+	 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	 * @param id ID is the identity's ID.
+	 * @param type_ Type is the credential's Type. One of webauthn, oidc
+	 * @return IdentityApiApiListIdentityCredentialItemsRequest
+	 */
+	ListIdentityCredentialItems(ctx context.Context, id string, type_ string) IdentityApiApiListIdentityCredentialItemsRequest
+
+	/*
+	 * ListIdentityCredentialItemsExecute executes the request
+	 * @return []IdentityCredentialItem
+	 */
+	ListIdentityCredentialItemsExecute(r IdentityApiApiListIdentityCredentialItemsRequest) ([]IdentityCredentialItem, *http.Response, error)
+
 	/*
 	 * ListIdentitySchemas Get all Identity Schemas
 	 * Returns a list of all identity schemas currently in use.
@@ -244,6 +353,20 @@ type IdentityApi interface {
 	 */
 	ListIdentitySessionsExecute(r IdentityApiApiListIdentitySessionsRequest) ([]Session, *http.Response, error)
 
+	/*
+	 * ListOidcProviders List OpenID Connect Providers
+	 * Lists the social sign in providers which were added using the admin API. Providers defined in the configuration are not included. Client secrets and private keys are never returned.
+	 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	 * @return IdentityApiApiListOidcProvidersRequest
+	 */
+	ListOidcProviders(ctx context.Context) IdentityApiApiListOidcProvidersRequest
+
+	/*
+	 * ListOidcProvidersExecute executes the request
+	 * @return []OidcProvider
+	 */
+	ListOidcProvidersExecute(r IdentityApiApiListOidcProvidersRequest) ([]OidcProvider, *http.Response, error)
+
 	/*
 	 * ListSessions List All Sessions
 	 * Listing all sessions that exist.
@@ -274,6 +397,39 @@ type IdentityApi interface {
 	 */
 	PatchIdentityExecute(r IdentityApiApiPatchIdentityRequest) (*Identity, *http.Response, error)
 
+	/*
+		 * RegenerateIdentityCredentials Regenerate a credential for a specific identity
+		 * Regenerate an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) credential by its type.
+			Currently, only lookup secrets (recovery codes) can be regenerated. The previous lookup secrets are invalidated
+			and the new ones are returned once in the response.
+		 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+		 * @param id ID is the identity's ID.
+		 * @param type_ Type is the credential's Type. Currently, only lookup_secret is supported.
+		 * @return IdentityApiApiRegenerateIdentityCredentialsRequest
+	*/
+	RegenerateIdentityCredentials(ctx context.Context, id string, type_ string) IdentityApiApiRegenerateIdentityCredentialsRequest
+
+	/*
+	 * RegenerateIdentityCredentialsExecute executes the request
+	 * @return IdentityRegeneratedCredentials
+	 */
+	RegenerateIdentityCredentialsExecute(r IdentityApiApiRegenerateIdentityCredentialsRequest) (*IdentityRegeneratedCredentials, *http.Response, error)
+
+	/*
+			 * RevokeSessions Revoke Sessions Matching a Filter
+			 * Calling this endpoint deactivates all sessions matching the given filter, for example all sessions
+		created from a compromised IP range. Session data is not deleted. At least one filter besides active must be provided.
+			 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+			 * @return IdentityApiApiRevokeSessionsRequest
+	*/
+	RevokeSessions(ctx context.Context) IdentityApiApiRevokeSessionsRequest
+
+	/*
+	 * RevokeSessionsExecute executes the request
+	 * @return RevokedSessions
+	 */
+	RevokeSessionsExecute(r IdentityApiApiRevokeSessionsRequest) (*RevokedSessions, *http.Response, error)
+
 	/*
 			 * UpdateIdentity Update an Identity
 			 * This endpoint updates an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model). The full identity
@@ -289,6 +445,38 @@ type IdentityApi interface {
 	 * @return Identity
 	 */
 	UpdateIdentityExecute(r IdentityApiApiUpdateIdentityRequest) (*Identity, *http.Response, error)
+
+	/*
+	 * UpdateIdentityCredentialItem Update an item of an identity's credential
+	 * Renames a single security key (webauthn) of an identity or clears its clone warning. Security keys flagged as cloned can be deleted using deleteIdentityCredentialItem.
+	 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	 * @param id ID is the identity's ID.
+	 * @param type_ Type is the credential's Type. Currently, only webauthn is supported.
+	 * @param item Item is the ID of the credential item.
+	 * @return IdentityApiApiUpdateIdentityCredentialItemRequest
+	 */
+	UpdateIdentityCredentialItem(ctx context.Context, id string, type_ string, item string) IdentityApiApiUpdateIdentityCredentialItemRequest
+
+	/*
+	 * UpdateIdentityCredentialItemExecute executes the request
+	 * @return IdentityCredentialItem
+	 */
+	UpdateIdentityCredentialItemExecute(r IdentityApiApiUpdateIdentityCredentialItemRequest) (*IdentityCredentialItem, *http.Response, error)
+
+	/*
+	 * UpdateOidcProvider Update an OpenID Connect Provider
+	 * Replaces the configuration of a social sign in provider which was added using the admin API. The change takes effect right away. If the client secret or private key is omitted, the stored one is kept. The ID of the provider can not be changed.
+	 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	 * @param id ID is the provider's ID.
+	 * @return IdentityApiApiUpdateOidcProviderRequest
+	 */
+	UpdateOidcProvider(ctx context.Context, id string) IdentityApiApiUpdateOidcProviderRequest
+
+	/*
+	 * UpdateOidcProviderExecute executes the request
+	 * @return OidcProvider
+	 */
+	UpdateOidcProviderExecute(r IdentityApiApiUpdateOidcProviderRequest) (*OidcProvider, *http.Response, error)
 }
 
 // IdentityApiService IdentityApi service
@@ -447,60 +635,71 @@ func (a *IdentityApiService) CreateIdentityExecute(r IdentityApiApiCreateIdentit
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiCreateRecoveryCodeForIdentityRequest struct {
-	ctx                               context.Context
-	ApiService                        IdentityApi
-	createRecoveryCodeForIdentityBody *CreateRecoveryCodeForIdentityBody
+type IdentityApiApiCreateIdentityCredentialItemRequest struct {
+	ctx                              context.Context
+	ApiService                       IdentityApi
+	id                               string
+	type_                            string
+	createIdentityCredentialItemBody *CreateIdentityCredentialItemBody
 }
 
-func (r IdentityApiApiCreateRecoveryCodeForIdentityRequest) CreateRecoveryCodeForIdentityBody(createRecoveryCodeForIdentityBody CreateRecoveryCodeForIdentityBody) IdentityApiApiCreateRecoveryCodeForIdentityRequest {
-	r.createRecoveryCodeForIdentityBody = &createRecoveryCodeForIdentityBody
+func (r IdentityApiApiCreateIdentityCredentialItemRequest) CreateIdentityCredentialItemBody(createIdentityCredentialItemBody CreateIdentityCredentialItemBody) IdentityApiApiCreateIdentityCredentialItemRequest {
+	r.createIdentityCredentialItemBody = &createIdentityCredentialItemBody
 	return r
 }
 
-func (r IdentityApiApiCreateRecoveryCodeForIdentityRequest) Execute() (*RecoveryCodeForIdentity, *http.Response, error) {
-	return r.ApiService.CreateRecoveryCodeForIdentityExecute(r)
+func (r IdentityApiApiCreateIdentityCredentialItemRequest) Execute() (*IdentityCredentialItem, *http.Response, error) {
+	return r.ApiService.CreateIdentityCredentialItemExecute(r)
 }
 
 /*
-  - CreateRecoveryCodeForIdentity Create a Recovery Code
-  - This endpoint creates a recovery code which should be given to the user in order for them to recover
+  - CreateIdentityCredentialItem Add an item to an identity's credential
+  - Links a social sign in provider (oidc) to an identity without user interaction, for example when importing
 
-(or activate) their account.
+identities whose subject at the provider is already known. The identity can then sign in with the provider.
   - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-  - @return IdentityApiApiCreateRecoveryCodeForIdentityRequest
+  - @param id ID is the identity's ID.
+  - @param type_ Type is the credential's Type. Currently, only oidc is supported.
+  - @return IdentityApiApiCreateIdentityCredentialItemRequest
 */
-func (a *IdentityApiService) CreateRecoveryCodeForIdentity(ctx context.Context) IdentityApiApiCreateRecoveryCodeForIdentityRequest {
-	return IdentityApiApiCreateRecoveryCodeForIdentityRequest{
+func (a *IdentityApiService) CreateIdentityCredentialItem(ctx context.Context, id string, type_ string) IdentityApiApiCreateIdentityCredentialItemRequest {
+	return IdentityApiApiCreateIdentityCredentialItemRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
+		type_:      type_,
 	}
 }
 
 /*
  * Execute executes the request
- * @return RecoveryCodeForIdentity
+ * @return IdentityCredentialItem
  */
-func (a *IdentityApiService) CreateRecoveryCodeForIdentityExecute(r IdentityApiApiCreateRecoveryCodeForIdentityRequest) (*RecoveryCodeForIdentity, *http.Response, error) {
+func (a *IdentityApiService) CreateIdentityCredentialItemExecute(r IdentityApiApiCreateIdentityCredentialItemRequest) (*IdentityCredentialItem, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *RecoveryCodeForIdentity
+		localVarReturnValue  *IdentityCredentialItem
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.CreateRecoveryCodeForIdentity")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.CreateIdentityCredentialItem")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/recovery/code"
+	localVarPath := localBasePath + "/admin/identities/{id}/credentials/{type}/items"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"type"+"}", url.PathEscape(parameterToString(r.type_, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.createIdentityCredentialItemBody == nil {
+		return localVarReturnValue, nil, reportError("createIdentityCredentialItemBody is required and must be specified")
+	}
 
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{"application/json"}
@@ -520,7 +719,7 @@ func (a *IdentityApiService) CreateRecoveryCodeForIdentityExecute(r IdentityApiA
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	// body params
-	localVarPostBody = r.createRecoveryCodeForIdentityBody
+	localVarPostBody = r.createIdentityCredentialItemBody
 	if r.ctx != nil {
 		// API Key Authentication
 		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
@@ -577,6 +776,16 @@ func (a *IdentityApiService) CreateRecoveryCodeForIdentityExecute(r IdentityApiA
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
@@ -599,31 +808,29 @@ func (a *IdentityApiService) CreateRecoveryCodeForIdentityExecute(r IdentityApiA
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiCreateRecoveryLinkForIdentityRequest struct {
-	ctx                               context.Context
-	ApiService                        IdentityApi
-	createRecoveryLinkForIdentityBody *CreateRecoveryLinkForIdentityBody
+type IdentityApiApiCreateOidcProviderRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	body       *map[string]interface{}
 }
 
-func (r IdentityApiApiCreateRecoveryLinkForIdentityRequest) CreateRecoveryLinkForIdentityBody(createRecoveryLinkForIdentityBody CreateRecoveryLinkForIdentityBody) IdentityApiApiCreateRecoveryLinkForIdentityRequest {
-	r.createRecoveryLinkForIdentityBody = &createRecoveryLinkForIdentityBody
+func (r IdentityApiApiCreateOidcProviderRequest) Body(body map[string]interface{}) IdentityApiApiCreateOidcProviderRequest {
+	r.body = &body
 	return r
 }
 
-func (r IdentityApiApiCreateRecoveryLinkForIdentityRequest) Execute() (*RecoveryLinkForIdentity, *http.Response, error) {
-	return r.ApiService.CreateRecoveryLinkForIdentityExecute(r)
+func (r IdentityApiApiCreateOidcProviderRequest) Execute() (*OidcProvider, *http.Response, error) {
+	return r.ApiService.CreateOidcProviderExecute(r)
 }
 
 /*
-  - CreateRecoveryLinkForIdentity Create a Recovery Link
-  - This endpoint creates a recovery link which should be given to the user in order for them to recover
-
-(or activate) their account.
-  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-  - @return IdentityApiApiCreateRecoveryLinkForIdentityRequest
-*/
-func (a *IdentityApiService) CreateRecoveryLinkForIdentity(ctx context.Context) IdentityApiApiCreateRecoveryLinkForIdentityRequest {
-	return IdentityApiApiCreateRecoveryLinkForIdentityRequest{
+ * CreateOidcProvider Create an OpenID Connect Provider
+ * Adds a social sign in provider without changing the configuration. The provider can be used right away. The configuration is validated and, for providers using OpenID Connect Discovery, the discovery document of the issuer is fetched. The client secret and private keys are stored encrypted.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return IdentityApiApiCreateOidcProviderRequest
+ */
+func (a *IdentityApiService) CreateOidcProvider(ctx context.Context) IdentityApiApiCreateOidcProviderRequest {
+	return IdentityApiApiCreateOidcProviderRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -631,28 +838,31 @@ func (a *IdentityApiService) CreateRecoveryLinkForIdentity(ctx context.Context)
 
 /*
  * Execute executes the request
- * @return RecoveryLinkForIdentity
+ * @return OidcProvider
  */
-func (a *IdentityApiService) CreateRecoveryLinkForIdentityExecute(r IdentityApiApiCreateRecoveryLinkForIdentityRequest) (*RecoveryLinkForIdentity, *http.Response, error) {
+func (a *IdentityApiService) CreateOidcProviderExecute(r IdentityApiApiCreateOidcProviderRequest) (*OidcProvider, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *RecoveryLinkForIdentity
+		localVarReturnValue  *OidcProvider
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.CreateRecoveryLinkForIdentity")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.CreateOidcProvider")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/recovery/link"
+	localVarPath := localBasePath + "/admin/oidc/providers"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.body == nil {
+		return localVarReturnValue, nil, reportError("body is required and must be specified")
+	}
 
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{"application/json"}
@@ -672,7 +882,7 @@ func (a *IdentityApiService) CreateRecoveryLinkForIdentityExecute(r IdentityApiA
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	// body params
-	localVarPostBody = r.createRecoveryLinkForIdentityBody
+	localVarPostBody = r.body
 	if r.ctx != nil {
 		// API Key Authentication
 		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
@@ -719,7 +929,7 @@ func (a *IdentityApiService) CreateRecoveryLinkForIdentityExecute(r IdentityApiA
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 404 {
+		if localVarHTTPResponse.StatusCode == 409 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -751,60 +961,63 @@ func (a *IdentityApiService) CreateRecoveryLinkForIdentityExecute(r IdentityApiA
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiDeleteIdentityRequest struct {
-	ctx        context.Context
-	ApiService IdentityApi
-	id         string
+type IdentityApiApiCreateRecoveryCodeForIdentityRequest struct {
+	ctx                               context.Context
+	ApiService                        IdentityApi
+	createRecoveryCodeForIdentityBody *CreateRecoveryCodeForIdentityBody
 }
 
-func (r IdentityApiApiDeleteIdentityRequest) Execute() (*http.Response, error) {
-	return r.ApiService.DeleteIdentityExecute(r)
+func (r IdentityApiApiCreateRecoveryCodeForIdentityRequest) CreateRecoveryCodeForIdentityBody(createRecoveryCodeForIdentityBody CreateRecoveryCodeForIdentityBody) IdentityApiApiCreateRecoveryCodeForIdentityRequest {
+	r.createRecoveryCodeForIdentityBody = &createRecoveryCodeForIdentityBody
+	return r
+}
+
+func (r IdentityApiApiCreateRecoveryCodeForIdentityRequest) Execute() (*RecoveryCodeForIdentity, *http.Response, error) {
+	return r.ApiService.CreateRecoveryCodeForIdentityExecute(r)
 }
 
 /*
-  - DeleteIdentity Delete an Identity
-  - Calling this endpoint irrecoverably and permanently deletes the [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) given its ID. This action can not be undone.
+  - CreateRecoveryCodeForIdentity Create a Recovery Code
+  - This endpoint creates a recovery code which should be given to the user in order for them to recover
 
-This endpoint returns 204 when the identity was deleted or when the identity was not found, in which case it is
-assumed that is has been deleted already.
+(or activate) their account.
   - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-  - @param id ID is the identity's ID.
-  - @return IdentityApiApiDeleteIdentityRequest
+  - @return IdentityApiApiCreateRecoveryCodeForIdentityRequest
 */
-func (a *IdentityApiService) DeleteIdentity(ctx context.Context, id string) IdentityApiApiDeleteIdentityRequest {
-	return IdentityApiApiDeleteIdentityRequest{
+func (a *IdentityApiService) CreateRecoveryCodeForIdentity(ctx context.Context) IdentityApiApiCreateRecoveryCodeForIdentityRequest {
+	return IdentityApiApiCreateRecoveryCodeForIdentityRequest{
 		ApiService: a,
 		ctx:        ctx,
-		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
+ * @return RecoveryCodeForIdentity
  */
-func (a *IdentityApiService) DeleteIdentityExecute(r IdentityApiApiDeleteIdentityRequest) (*http.Response, error) {
+func (a *IdentityApiService) CreateRecoveryCodeForIdentityExecute(r IdentityApiApiCreateRecoveryCodeForIdentityRequest) (*RecoveryCodeForIdentity, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodDelete
+		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
+		localVarReturnValue  *RecoveryCodeForIdentity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.DeleteIdentity")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.CreateRecoveryCodeForIdentity")
 	if err != nil {
-		return nil, &GenericOpenAPIError{error: err.Error()}
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/identities/{id}"
-	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath := localBasePath + "/admin/recovery/code"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -820,6 +1033,8 @@ func (a *IdentityApiService) DeleteIdentityExecute(r IdentityApiApiDeleteIdentit
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	// body params
+	localVarPostBody = r.createRecoveryCodeForIdentityBody
 	if r.ctx != nil {
 		// API Key Authentication
 		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
@@ -836,19 +1051,19 @@ func (a *IdentityApiService) DeleteIdentityExecute(r IdentityApiApiDeleteIdentit
 	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
-		return nil, err
+		return localVarReturnValue, nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarHTTPResponse, err
+		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
 	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarHTTPResponse, err
+		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -856,88 +1071,105 @@ func (a *IdentityApiService) DeleteIdentityExecute(r IdentityApiApiDeleteIdentit
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarHTTPResponse, newErr
+				return localVarReturnValue, localVarHTTPResponse, newErr
 			}
 			newErr.model = v
-			return localVarHTTPResponse, newErr
+			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
 			newErr.error = err.Error()
-			return localVarHTTPResponse, newErr
+			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
 		newErr.model = v
-		return localVarHTTPResponse, newErr
+		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	return localVarHTTPResponse, nil
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiDeleteIdentityCredentialsRequest struct {
-	ctx        context.Context
-	ApiService IdentityApi
-	id         string
-	type_      string
+type IdentityApiApiCreateRecoveryLinkForIdentityRequest struct {
+	ctx                               context.Context
+	ApiService                        IdentityApi
+	createRecoveryLinkForIdentityBody *CreateRecoveryLinkForIdentityBody
 }
 
-func (r IdentityApiApiDeleteIdentityCredentialsRequest) Execute() (*Identity, *http.Response, error) {
-	return r.ApiService.DeleteIdentityCredentialsExecute(r)
+func (r IdentityApiApiCreateRecoveryLinkForIdentityRequest) CreateRecoveryLinkForIdentityBody(createRecoveryLinkForIdentityBody CreateRecoveryLinkForIdentityBody) IdentityApiApiCreateRecoveryLinkForIdentityRequest {
+	r.createRecoveryLinkForIdentityBody = &createRecoveryLinkForIdentityBody
+	return r
+}
+
+func (r IdentityApiApiCreateRecoveryLinkForIdentityRequest) Execute() (*RecoveryLinkForIdentity, *http.Response, error) {
+	return r.ApiService.CreateRecoveryLinkForIdentityExecute(r)
 }
 
 /*
-  - DeleteIdentityCredentials Delete a credential for a specific identity
-  - Delete an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) credential by its type
+  - CreateRecoveryLinkForIdentity Create a Recovery Link
+  - This endpoint creates a recovery link which should be given to the user in order for them to recover
 
-You can only delete second factor (aal2) credentials.
+(or activate) their account.
   - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-  - @param id ID is the identity's ID.
-  - @param type_ Type is the credential's Type. One of totp, webauthn, lookup
-  - @return IdentityApiApiDeleteIdentityCredentialsRequest
+  - @return IdentityApiApiCreateRecoveryLinkForIdentityRequest
 */
-func (a *IdentityApiService) DeleteIdentityCredentials(ctx context.Context, id string, type_ string) IdentityApiApiDeleteIdentityCredentialsRequest {
-	return IdentityApiApiDeleteIdentityCredentialsRequest{
+func (a *IdentityApiService) CreateRecoveryLinkForIdentity(ctx context.Context) IdentityApiApiCreateRecoveryLinkForIdentityRequest {
+	return IdentityApiApiCreateRecoveryLinkForIdentityRequest{
 		ApiService: a,
 		ctx:        ctx,
-		id:         id,
-		type_:      type_,
 	}
 }
 
 /*
  * Execute executes the request
- * @return Identity
+ * @return RecoveryLinkForIdentity
  */
-func (a *IdentityApiService) DeleteIdentityCredentialsExecute(r IdentityApiApiDeleteIdentityCredentialsRequest) (*Identity, *http.Response, error) {
+func (a *IdentityApiService) CreateRecoveryLinkForIdentityExecute(r IdentityApiApiCreateRecoveryLinkForIdentityRequest) (*RecoveryLinkForIdentity, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodDelete
+		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *Identity
+		localVarReturnValue  *RecoveryLinkForIdentity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.DeleteIdentityCredentials")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.CreateRecoveryLinkForIdentity")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/identities/{id}/credentials/{type}"
-	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
-	localVarPath = strings.Replace(localVarPath, "{"+"type"+"}", url.PathEscape(parameterToString(r.type_, "")), -1)
+	localVarPath := localBasePath + "/admin/recovery/link"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -953,6 +1185,8 @@ func (a *IdentityApiService) DeleteIdentityCredentialsExecute(r IdentityApiApiDe
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	// body params
+	localVarPostBody = r.createRecoveryLinkForIdentityBody
 	if r.ctx != nil {
 		// API Key Authentication
 		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
@@ -989,6 +1223,16 @@ func (a *IdentityApiService) DeleteIdentityCredentialsExecute(r IdentityApiApiDe
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1021,25 +1265,28 @@ func (a *IdentityApiService) DeleteIdentityCredentialsExecute(r IdentityApiApiDe
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiDeleteIdentitySessionsRequest struct {
+type IdentityApiApiDeleteIdentityRequest struct {
 	ctx        context.Context
 	ApiService IdentityApi
 	id         string
 }
 
-func (r IdentityApiApiDeleteIdentitySessionsRequest) Execute() (*http.Response, error) {
-	return r.ApiService.DeleteIdentitySessionsExecute(r)
+func (r IdentityApiApiDeleteIdentityRequest) Execute() (*http.Response, error) {
+	return r.ApiService.DeleteIdentityExecute(r)
 }
 
 /*
- * DeleteIdentitySessions Delete & Invalidate an Identity's Sessions
- * Calling this endpoint irrecoverably and permanently deletes and invalidates all sessions that belong to the given Identity.
- * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @param id ID is the identity's ID.
- * @return IdentityApiApiDeleteIdentitySessionsRequest
- */
-func (a *IdentityApiService) DeleteIdentitySessions(ctx context.Context, id string) IdentityApiApiDeleteIdentitySessionsRequest {
-	return IdentityApiApiDeleteIdentitySessionsRequest{
+  - DeleteIdentity Delete an Identity
+  - Calling this endpoint irrecoverably and permanently deletes the [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) given its ID. This action can not be undone.
+
+This endpoint returns 204 when the identity was deleted or when the identity was not found, in which case it is
+assumed that is has been deleted already.
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param id ID is the identity's ID.
+  - @return IdentityApiApiDeleteIdentityRequest
+*/
+func (a *IdentityApiService) DeleteIdentity(ctx context.Context, id string) IdentityApiApiDeleteIdentityRequest {
+	return IdentityApiApiDeleteIdentityRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
@@ -1049,7 +1296,7 @@ func (a *IdentityApiService) DeleteIdentitySessions(ctx context.Context, id stri
 /*
  * Execute executes the request
  */
-func (a *IdentityApiService) DeleteIdentitySessionsExecute(r IdentityApiApiDeleteIdentitySessionsRequest) (*http.Response, error) {
+func (a *IdentityApiService) DeleteIdentityExecute(r IdentityApiApiDeleteIdentityRequest) (*http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodDelete
 		localVarPostBody     interface{}
@@ -1058,12 +1305,12 @@ func (a *IdentityApiService) DeleteIdentitySessionsExecute(r IdentityApiApiDelet
 		localVarFileBytes    []byte
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.DeleteIdentitySessions")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.DeleteIdentity")
 	if err != nil {
 		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/identities/{id}/sessions"
+	localVarPath := localBasePath + "/admin/identities/{id}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
@@ -1123,26 +1370,6 @@ func (a *IdentityApiService) DeleteIdentitySessionsExecute(r IdentityApiApiDelet
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarHTTPResponse, newErr
-		}
-		if localVarHTTPResponse.StatusCode == 401 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1166,35 +1393,47 @@ func (a *IdentityApiService) DeleteIdentitySessionsExecute(r IdentityApiApiDelet
 	return localVarHTTPResponse, nil
 }
 
-type IdentityApiApiDisableSessionRequest struct {
-	ctx        context.Context
-	ApiService IdentityApi
-	id         string
+type IdentityApiApiDeleteIdentityCredentialItemRequest struct {
+	ctx            context.Context
+	ApiService     IdentityApi
+	id             string
+	type_          string
+	item           string
+	revokeSessions *bool
 }
 
-func (r IdentityApiApiDisableSessionRequest) Execute() (*http.Response, error) {
-	return r.ApiService.DisableSessionExecute(r)
+func (r IdentityApiApiDeleteIdentityCredentialItemRequest) RevokeSessions(revokeSessions bool) IdentityApiApiDeleteIdentityCredentialItemRequest {
+	r.revokeSessions = &revokeSessions
+	return r
+}
+
+func (r IdentityApiApiDeleteIdentityCredentialItemRequest) Execute() (*http.Response, error) {
+	return r.ApiService.DeleteIdentityCredentialItemExecute(r)
 }
 
 /*
- * DisableSession Deactivate a Session
- * Calling this endpoint deactivates the specified session. Session data is not deleted.
+ * DeleteIdentityCredentialItem Delete an item of an identity's credential
+ * Deletes a single security key (webauthn) or unlinks a single social sign in provider (oidc) of an identity. The last first factor credential of an identity can not be deleted.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @param id ID is the session's ID.
- * @return IdentityApiApiDisableSessionRequest
+ * @param id ID is the identity's ID.
+ * @param type_ Type is the credential's Type. One of webauthn, oidc
+ * @param item Item is the ID of the credential item.
+ * @return IdentityApiApiDeleteIdentityCredentialItemRequest
  */
-func (a *IdentityApiService) DisableSession(ctx context.Context, id string) IdentityApiApiDisableSessionRequest {
-	return IdentityApiApiDisableSessionRequest{
+func (a *IdentityApiService) DeleteIdentityCredentialItem(ctx context.Context, id string, type_ string, item string) IdentityApiApiDeleteIdentityCredentialItemRequest {
+	return IdentityApiApiDeleteIdentityCredentialItemRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
+		type_:      type_,
+		item:       item,
 	}
 }
 
 /*
  * Execute executes the request
  */
-func (a *IdentityApiService) DisableSessionExecute(r IdentityApiApiDisableSessionRequest) (*http.Response, error) {
+func (a *IdentityApiService) DeleteIdentityCredentialItemExecute(r IdentityApiApiDeleteIdentityCredentialItemRequest) (*http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodDelete
 		localVarPostBody     interface{}
@@ -1203,18 +1442,23 @@ func (a *IdentityApiService) DisableSessionExecute(r IdentityApiApiDisableSessio
 		localVarFileBytes    []byte
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.DisableSession")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.DeleteIdentityCredentialItem")
 	if err != nil {
 		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/sessions/{id}"
+	localVarPath := localBasePath + "/admin/identities/{id}/credentials/{type}/items/{item}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"type"+"}", url.PathEscape(parameterToString(r.type_, "")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"item"+"}", url.PathEscape(parameterToString(r.item, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
+	if r.revokeSessions != nil {
+		localVarQueryParams.Add("revoke_sessions", parameterToString(*r.revokeSessions, ""))
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1278,7 +1522,7 @@ func (a *IdentityApiService) DisableSessionExecute(r IdentityApiApiDisableSessio
 			newErr.model = v
 			return localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 401 {
+		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -1301,56 +1545,58 @@ func (a *IdentityApiService) DisableSessionExecute(r IdentityApiApiDisableSessio
 	return localVarHTTPResponse, nil
 }
 
-type IdentityApiApiExtendSessionRequest struct {
+type IdentityApiApiDeleteIdentityCredentialsRequest struct {
 	ctx        context.Context
 	ApiService IdentityApi
 	id         string
+	type_      string
 }
 
-func (r IdentityApiApiExtendSessionRequest) Execute() (*Session, *http.Response, error) {
-	return r.ApiService.ExtendSessionExecute(r)
+func (r IdentityApiApiDeleteIdentityCredentialsRequest) Execute() (*Identity, *http.Response, error) {
+	return r.ApiService.DeleteIdentityCredentialsExecute(r)
 }
 
 /*
-  - ExtendSession Extend a Session
-  - Calling this endpoint extends the given session ID. If `session.earliest_possible_extend` is set it
-
-will only extend the session after the specified time has passed.
+  - DeleteIdentityCredentials Delete a credential for a specific identity
+  - Delete an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) credential by its type
 
-Retrieve the session ID from the `/sessions/whoami` endpoint / `toSession` SDK method.
+You can only delete second factor (aal2) credentials.
   - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-  - @param id ID is the session's ID.
-  - @return IdentityApiApiExtendSessionRequest
+  - @param id ID is the identity's ID.
+  - @param type_ Type is the credential's Type. One of totp, webauthn, lookup, hotp
+  - @return IdentityApiApiDeleteIdentityCredentialsRequest
 */
-func (a *IdentityApiService) ExtendSession(ctx context.Context, id string) IdentityApiApiExtendSessionRequest {
-	return IdentityApiApiExtendSessionRequest{
+func (a *IdentityApiService) DeleteIdentityCredentials(ctx context.Context, id string, type_ string) IdentityApiApiDeleteIdentityCredentialsRequest {
+	return IdentityApiApiDeleteIdentityCredentialsRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
+		type_:      type_,
 	}
 }
 
 /*
  * Execute executes the request
- * @return Session
+ * @return Identity
  */
-func (a *IdentityApiService) ExtendSessionExecute(r IdentityApiApiExtendSessionRequest) (*Session, *http.Response, error) {
+func (a *IdentityApiService) DeleteIdentityCredentialsExecute(r IdentityApiApiDeleteIdentityCredentialsRequest) (*Identity, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodPatch
+		localVarHTTPMethod   = http.MethodDelete
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *Session
+		localVarReturnValue  *Identity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ExtendSession")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.DeleteIdentityCredentials")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/sessions/{id}/extend"
+	localVarPath := localBasePath + "/admin/identities/{id}/credentials/{type}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"type"+"}", url.PathEscape(parameterToString(r.type_, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -1409,16 +1655,6 @@ func (a *IdentityApiService) ExtendSessionExecute(r IdentityApiApiExtendSessionR
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1451,33 +1687,25 @@ func (a *IdentityApiService) ExtendSessionExecute(r IdentityApiApiExtendSessionR
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiGetIdentityRequest struct {
-	ctx               context.Context
-	ApiService        IdentityApi
-	id                string
-	includeCredential *[]string
-}
-
-func (r IdentityApiApiGetIdentityRequest) IncludeCredential(includeCredential []string) IdentityApiApiGetIdentityRequest {
-	r.includeCredential = &includeCredential
-	return r
+type IdentityApiApiDeleteIdentitySessionsRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
 }
 
-func (r IdentityApiApiGetIdentityRequest) Execute() (*Identity, *http.Response, error) {
-	return r.ApiService.GetIdentityExecute(r)
+func (r IdentityApiApiDeleteIdentitySessionsRequest) Execute() (*http.Response, error) {
+	return r.ApiService.DeleteIdentitySessionsExecute(r)
 }
 
 /*
-  - GetIdentity Get an Identity
-  - Return an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) by its ID. You can optionally
-
-include credentials (e.g. social sign in connections) in the response by using the `include_credential` query parameter.
-  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-  - @param id ID must be set to the ID of identity you want to get
-  - @return IdentityApiApiGetIdentityRequest
-*/
-func (a *IdentityApiService) GetIdentity(ctx context.Context, id string) IdentityApiApiGetIdentityRequest {
-	return IdentityApiApiGetIdentityRequest{
+ * DeleteIdentitySessions Delete & Invalidate an Identity's Sessions
+ * Calling this endpoint irrecoverably and permanently deletes and invalidates all sessions that belong to the given Identity.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID is the identity's ID.
+ * @return IdentityApiApiDeleteIdentitySessionsRequest
+ */
+func (a *IdentityApiService) DeleteIdentitySessions(ctx context.Context, id string) IdentityApiApiDeleteIdentitySessionsRequest {
+	return IdentityApiApiDeleteIdentitySessionsRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
@@ -1486,41 +1714,28 @@ func (a *IdentityApiService) GetIdentity(ctx context.Context, id string) Identit
 
 /*
  * Execute executes the request
- * @return Identity
  */
-func (a *IdentityApiService) GetIdentityExecute(r IdentityApiApiGetIdentityRequest) (*Identity, *http.Response, error) {
+func (a *IdentityApiService) DeleteIdentitySessionsExecute(r IdentityApiApiDeleteIdentitySessionsRequest) (*http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodDelete
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *Identity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.GetIdentity")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.DeleteIdentitySessions")
 	if err != nil {
-		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/identities/{id}"
+	localVarPath := localBasePath + "/admin/identities/{id}/sessions"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
-	if r.includeCredential != nil {
-		t := *r.includeCredential
-		if reflect.TypeOf(t).Kind() == reflect.Slice {
-			s := reflect.ValueOf(t)
-			for i := 0; i < s.Len(); i++ {
-				localVarQueryParams.Add("include_credential", parameterToString(s.Index(i), "multi"))
-			}
-		} else {
-			localVarQueryParams.Add("include_credential", parameterToString(t, "multi"))
-		}
-	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1554,19 +1769,19 @@ func (a *IdentityApiService) GetIdentityExecute(r IdentityApiApiGetIdentityReque
 	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
-		return localVarReturnValue, nil, err
+		return nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -1574,21 +1789,1606 @@ func (a *IdentityApiService) GetIdentityExecute(r IdentityApiApiGetIdentityReque
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
 			newErr.error = err.Error()
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type IdentityApiApiDeleteOidcProviderRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
+}
+
+func (r IdentityApiApiDeleteOidcProviderRequest) Execute() (*http.Response, error) {
+	return r.ApiService.DeleteOidcProviderExecute(r)
+}
+
+/*
+ * DeleteOidcProvider Delete an OpenID Connect Provider
+ * Removes a social sign in provider which was added using the admin API. Providers which identities have still linked can not be deleted. Remove the OpenID Connect credential items of these identities first.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID is the provider's ID.
+ * @return IdentityApiApiDeleteOidcProviderRequest
+ */
+func (a *IdentityApiService) DeleteOidcProvider(ctx context.Context, id string) IdentityApiApiDeleteOidcProviderRequest {
+	return IdentityApiApiDeleteOidcProviderRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+/*
+ * Execute executes the request
+ */
+func (a *IdentityApiService) DeleteOidcProviderExecute(r IdentityApiApiDeleteOidcProviderRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodDelete
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.DeleteOidcProvider")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/oidc/providers/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type IdentityApiApiDisableSessionRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
+}
+
+func (r IdentityApiApiDisableSessionRequest) Execute() (*http.Response, error) {
+	return r.ApiService.DisableSessionExecute(r)
+}
+
+/*
+ * DisableSession Deactivate a Session
+ * Calling this endpoint deactivates the specified session. Session data is not deleted.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID is the session's ID.
+ * @return IdentityApiApiDisableSessionRequest
+ */
+func (a *IdentityApiService) DisableSession(ctx context.Context, id string) IdentityApiApiDisableSessionRequest {
+	return IdentityApiApiDisableSessionRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+/*
+ * Execute executes the request
+ */
+func (a *IdentityApiService) DisableSessionExecute(r IdentityApiApiDisableSessionRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodDelete
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.DisableSession")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/sessions/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type IdentityApiApiExtendSessionRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
+}
+
+func (r IdentityApiApiExtendSessionRequest) Execute() (*Session, *http.Response, error) {
+	return r.ApiService.ExtendSessionExecute(r)
+}
+
+/*
+  - ExtendSession Extend a Session
+  - Calling this endpoint extends the given session ID. If `session.earliest_possible_extend` is set it
+
+will only extend the session after the specified time has passed.
+
+Retrieve the session ID from the `/sessions/whoami` endpoint / `toSession` SDK method.
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param id ID is the session's ID.
+  - @return IdentityApiApiExtendSessionRequest
+*/
+func (a *IdentityApiService) ExtendSession(ctx context.Context, id string) IdentityApiApiExtendSessionRequest {
+	return IdentityApiApiExtendSessionRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return Session
+ */
+func (a *IdentityApiService) ExtendSessionExecute(r IdentityApiApiExtendSessionRequest) (*Session, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPatch
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *Session
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ExtendSession")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/sessions/{id}/extend"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type IdentityApiApiGetIdentityRequest struct {
+	ctx               context.Context
+	ApiService        IdentityApi
+	id                string
+	includeCredential *[]string
+}
+
+func (r IdentityApiApiGetIdentityRequest) IncludeCredential(includeCredential []string) IdentityApiApiGetIdentityRequest {
+	r.includeCredential = &includeCredential
+	return r
+}
+
+func (r IdentityApiApiGetIdentityRequest) Execute() (*Identity, *http.Response, error) {
+	return r.ApiService.GetIdentityExecute(r)
+}
+
+/*
+  - GetIdentity Get an Identity
+  - Return an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) by its ID. You can optionally
+
+include credentials (e.g. social sign in connections) in the response by using the `include_credential` query parameter.
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param id ID must be set to the ID of identity you want to get
+  - @return IdentityApiApiGetIdentityRequest
+*/
+func (a *IdentityApiService) GetIdentity(ctx context.Context, id string) IdentityApiApiGetIdentityRequest {
+	return IdentityApiApiGetIdentityRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return Identity
+ */
+func (a *IdentityApiService) GetIdentityExecute(r IdentityApiApiGetIdentityRequest) (*Identity, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *Identity
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.GetIdentity")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/identities/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.includeCredential != nil {
+		t := *r.includeCredential
+		if reflect.TypeOf(t).Kind() == reflect.Slice {
+			s := reflect.ValueOf(t)
+			for i := 0; i < s.Len(); i++ {
+				localVarQueryParams.Add("include_credential", parameterToString(s.Index(i), "multi"))
+			}
+		} else {
+			localVarQueryParams.Add("include_credential", parameterToString(t, "multi"))
+		}
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type IdentityApiApiGetIdentitySchemaRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
+}
+
+func (r IdentityApiApiGetIdentitySchemaRequest) Execute() (map[string]interface{}, *http.Response, error) {
+	return r.ApiService.GetIdentitySchemaExecute(r)
+}
+
+/*
+ * GetIdentitySchema Get Identity JSON Schema
+ * Return a specific identity schema.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID must be set to the ID of schema you want to get
+ * @return IdentityApiApiGetIdentitySchemaRequest
+ */
+func (a *IdentityApiService) GetIdentitySchema(ctx context.Context, id string) IdentityApiApiGetIdentitySchemaRequest {
+	return IdentityApiApiGetIdentitySchemaRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return map[string]interface{}
+ */
+func (a *IdentityApiService) GetIdentitySchemaExecute(r IdentityApiApiGetIdentitySchemaRequest) (map[string]interface{}, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  map[string]interface{}
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.GetIdentitySchema")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/schemas/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type IdentityApiApiGetIdentityUpstreamTokensRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
+	type_      string
+	provider   string
+}
+
+func (r IdentityApiApiGetIdentityUpstreamTokensRequest) Execute() (*IdentityUpstreamTokens, *http.Response, error) {
+	return r.ApiService.GetIdentityUpstreamTokensExecute(r)
+}
+
+/*
+ * GetIdentityUpstreamTokens Get a valid upstream access token of an identity
+ * Returns a currently valid access token issued to the identity by a linked social sign in provider. This allows calling the provider's APIs on behalf of the identity. If the stored access token expired, it is refreshed using the refresh token, which requires the provider to have issued one (for example by requesting the `offline_access` scope).
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID is the identity's ID.
+ * @param type_ Type is the credential's Type. Currently, only oidc is supported.
+ * @param provider Provider is the ID of the linked OpenID Connect provider.
+ * @return IdentityApiApiGetIdentityUpstreamTokensRequest
+ */
+func (a *IdentityApiService) GetIdentityUpstreamTokens(ctx context.Context, id string, type_ string, provider string) IdentityApiApiGetIdentityUpstreamTokensRequest {
+	return IdentityApiApiGetIdentityUpstreamTokensRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+		type_:      type_,
+		provider:   provider,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return IdentityUpstreamTokens
+ */
+func (a *IdentityApiService) GetIdentityUpstreamTokensExecute(r IdentityApiApiGetIdentityUpstreamTokensRequest) (*IdentityUpstreamTokens, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *IdentityUpstreamTokens
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.GetIdentityUpstreamTokens")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/identities/{id}/credentials/{type}/tokens/{provider}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"type"+"}", url.PathEscape(parameterToString(r.type_, "")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"provider"+"}", url.PathEscape(parameterToString(r.provider, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type IdentityApiApiGetOidcProviderRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
+}
+
+func (r IdentityApiApiGetOidcProviderRequest) Execute() (*OidcProvider, *http.Response, error) {
+	return r.ApiService.GetOidcProviderExecute(r)
+}
+
+/*
+ * GetOidcProvider Get an OpenID Connect Provider
+ * Returns a social sign in provider which was added using the admin API. Client secrets and private keys are never returned.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID is the provider's ID.
+ * @return IdentityApiApiGetOidcProviderRequest
+ */
+func (a *IdentityApiService) GetOidcProvider(ctx context.Context, id string) IdentityApiApiGetOidcProviderRequest {
+	return IdentityApiApiGetOidcProviderRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return OidcProvider
+ */
+func (a *IdentityApiService) GetOidcProviderExecute(r IdentityApiApiGetOidcProviderRequest) (*OidcProvider, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *OidcProvider
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.GetOidcProvider")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/oidc/providers/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type IdentityApiApiGetSessionRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
+	expand     *[]string
+}
+
+func (r IdentityApiApiGetSessionRequest) Expand(expand []string) IdentityApiApiGetSessionRequest {
+	r.expand = &expand
+	return r
+}
+
+func (r IdentityApiApiGetSessionRequest) Execute() (*Session, *http.Response, error) {
+	return r.ApiService.GetSessionExecute(r)
+}
+
+/*
+  - GetSession Get Session
+  - This endpoint is useful for:
+
+Getting a session object with all specified expandables that exist in an administrative context.
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param id ID is the session's ID.
+  - @return IdentityApiApiGetSessionRequest
+*/
+func (a *IdentityApiService) GetSession(ctx context.Context, id string) IdentityApiApiGetSessionRequest {
+	return IdentityApiApiGetSessionRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return Session
+ */
+func (a *IdentityApiService) GetSessionExecute(r IdentityApiApiGetSessionRequest) (*Session, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *Session
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.GetSession")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/sessions/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.expand != nil {
+		t := *r.expand
+		if reflect.TypeOf(t).Kind() == reflect.Slice {
+			s := reflect.ValueOf(t)
+			for i := 0; i < s.Len(); i++ {
+				localVarQueryParams.Add("expand", parameterToString(s.Index(i), "multi"))
+			}
+		} else {
+			localVarQueryParams.Add("expand", parameterToString(t, "multi"))
+		}
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type IdentityApiApiListIdentitiesRequest struct {
+	ctx                   context.Context
+	ApiService            IdentityApi
+	perPage               *int64
+	page                  *int64
+	credentialsIdentifier *string
+}
+
+func (r IdentityApiApiListIdentitiesRequest) PerPage(perPage int64) IdentityApiApiListIdentitiesRequest {
+	r.perPage = &perPage
+	return r
+}
+func (r IdentityApiApiListIdentitiesRequest) Page(page int64) IdentityApiApiListIdentitiesRequest {
+	r.page = &page
+	return r
+}
+func (r IdentityApiApiListIdentitiesRequest) CredentialsIdentifier(credentialsIdentifier string) IdentityApiApiListIdentitiesRequest {
+	r.credentialsIdentifier = &credentialsIdentifier
+	return r
+}
+
+func (r IdentityApiApiListIdentitiesRequest) Execute() ([]Identity, *http.Response, error) {
+	return r.ApiService.ListIdentitiesExecute(r)
+}
+
+/*
+ * ListIdentities List Identities
+ * Lists all [identities](https://www.ory.sh/docs/kratos/concepts/identity-user-model) in the system.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return IdentityApiApiListIdentitiesRequest
+ */
+func (a *IdentityApiService) ListIdentities(ctx context.Context) IdentityApiApiListIdentitiesRequest {
+	return IdentityApiApiListIdentitiesRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return []Identity
+ */
+func (a *IdentityApiService) ListIdentitiesExecute(r IdentityApiApiListIdentitiesRequest) ([]Identity, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  []Identity
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ListIdentities")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/identities"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.perPage != nil {
+		localVarQueryParams.Add("per_page", parameterToString(*r.perPage, ""))
+	}
+	if r.page != nil {
+		localVarQueryParams.Add("page", parameterToString(*r.page, ""))
+	}
+	if r.credentialsIdentifier != nil {
+		localVarQueryParams.Add("credentials_identifier", parameterToString(*r.credentialsIdentifier, ""))
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type IdentityApiApiListIdentityCredentialItemsRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
+	type_      string
+}
+
+func (r IdentityApiApiListIdentityCredentialItemsRequest) Execute() ([]IdentityCredentialItem, *http.Response, error) {
+	return r.ApiService.ListIdentityCredentialItemsExecute(r)
+}
+
+/*
+ * ListIdentityCredentialItems List the items of an identity's credential
+ * Lists the security keys (webauthn) or linked social sign in providers (oidc) of an identity.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID is the identity's ID.
+ * @param type_ Type is the credential's Type. One of webauthn, oidc
+ * @return IdentityApiApiListIdentityCredentialItemsRequest
+ */
+func (a *IdentityApiService) ListIdentityCredentialItems(ctx context.Context, id string, type_ string) IdentityApiApiListIdentityCredentialItemsRequest {
+	return IdentityApiApiListIdentityCredentialItemsRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+		type_:      type_,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return []IdentityCredentialItem
+ */
+func (a *IdentityApiService) ListIdentityCredentialItemsExecute(r IdentityApiApiListIdentityCredentialItemsRequest) ([]IdentityCredentialItem, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  []IdentityCredentialItem
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ListIdentityCredentialItems")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/identities/{id}/credentials/{type}/items"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"type"+"}", url.PathEscape(parameterToString(r.type_, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type IdentityApiApiListIdentitySchemasRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	perPage    *int64
+	page       *int64
+}
+
+func (r IdentityApiApiListIdentitySchemasRequest) PerPage(perPage int64) IdentityApiApiListIdentitySchemasRequest {
+	r.perPage = &perPage
+	return r
+}
+func (r IdentityApiApiListIdentitySchemasRequest) Page(page int64) IdentityApiApiListIdentitySchemasRequest {
+	r.page = &page
+	return r
+}
+
+func (r IdentityApiApiListIdentitySchemasRequest) Execute() ([]IdentitySchemaContainer, *http.Response, error) {
+	return r.ApiService.ListIdentitySchemasExecute(r)
+}
+
+/*
+ * ListIdentitySchemas Get all Identity Schemas
+ * Returns a list of all identity schemas currently in use.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return IdentityApiApiListIdentitySchemasRequest
+ */
+func (a *IdentityApiService) ListIdentitySchemas(ctx context.Context) IdentityApiApiListIdentitySchemasRequest {
+	return IdentityApiApiListIdentitySchemasRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return []IdentitySchemaContainer
+ */
+func (a *IdentityApiService) ListIdentitySchemasExecute(r IdentityApiApiListIdentitySchemasRequest) ([]IdentitySchemaContainer, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  []IdentitySchemaContainer
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ListIdentitySchemas")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/schemas"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.perPage != nil {
+		localVarQueryParams.Add("per_page", parameterToString(*r.perPage, ""))
+	}
+	if r.page != nil {
+		localVarQueryParams.Add("page", parameterToString(*r.page, ""))
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
 		newErr.model = v
 		return localVarReturnValue, localVarHTTPResponse, newErr
@@ -1606,25 +3406,41 @@ func (a *IdentityApiService) GetIdentityExecute(r IdentityApiApiGetIdentityReque
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiGetIdentitySchemaRequest struct {
+type IdentityApiApiListIdentitySessionsRequest struct {
 	ctx        context.Context
 	ApiService IdentityApi
 	id         string
+	perPage    *int64
+	page       *int64
+	active     *bool
 }
 
-func (r IdentityApiApiGetIdentitySchemaRequest) Execute() (map[string]interface{}, *http.Response, error) {
-	return r.ApiService.GetIdentitySchemaExecute(r)
+func (r IdentityApiApiListIdentitySessionsRequest) PerPage(perPage int64) IdentityApiApiListIdentitySessionsRequest {
+	r.perPage = &perPage
+	return r
+}
+func (r IdentityApiApiListIdentitySessionsRequest) Page(page int64) IdentityApiApiListIdentitySessionsRequest {
+	r.page = &page
+	return r
+}
+func (r IdentityApiApiListIdentitySessionsRequest) Active(active bool) IdentityApiApiListIdentitySessionsRequest {
+	r.active = &active
+	return r
+}
+
+func (r IdentityApiApiListIdentitySessionsRequest) Execute() ([]Session, *http.Response, error) {
+	return r.ApiService.ListIdentitySessionsExecute(r)
 }
 
 /*
- * GetIdentitySchema Get Identity JSON Schema
- * Return a specific identity schema.
+ * ListIdentitySessions List an Identity's Sessions
+ * This endpoint returns all sessions that belong to the given Identity.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @param id ID must be set to the ID of schema you want to get
- * @return IdentityApiApiGetIdentitySchemaRequest
+ * @param id ID is the identity's ID.
+ * @return IdentityApiApiListIdentitySessionsRequest
  */
-func (a *IdentityApiService) GetIdentitySchema(ctx context.Context, id string) IdentityApiApiGetIdentitySchemaRequest {
-	return IdentityApiApiGetIdentitySchemaRequest{
+func (a *IdentityApiService) ListIdentitySessions(ctx context.Context, id string) IdentityApiApiListIdentitySessionsRequest {
+	return IdentityApiApiListIdentitySessionsRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
@@ -1633,30 +3449,39 @@ func (a *IdentityApiService) GetIdentitySchema(ctx context.Context, id string) I
 
 /*
  * Execute executes the request
- * @return map[string]interface{}
+ * @return []Session
  */
-func (a *IdentityApiService) GetIdentitySchemaExecute(r IdentityApiApiGetIdentitySchemaRequest) (map[string]interface{}, *http.Response, error) {
+func (a *IdentityApiService) ListIdentitySessionsExecute(r IdentityApiApiListIdentitySessionsRequest) ([]Session, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  map[string]interface{}
+		localVarReturnValue  []Session
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.GetIdentitySchema")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ListIdentitySessions")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/schemas/{id}"
+	localVarPath := localBasePath + "/admin/identities/{id}/sessions"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
+	if r.perPage != nil {
+		localVarQueryParams.Add("per_page", parameterToString(*r.perPage, ""))
+	}
+	if r.page != nil {
+		localVarQueryParams.Add("page", parameterToString(*r.page, ""))
+	}
+	if r.active != nil {
+		localVarQueryParams.Add("active", parameterToString(*r.active, ""))
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1674,6 +3499,20 @@ func (a *IdentityApiService) GetIdentitySchemaExecute(r IdentityApiApiGetIdentit
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -1696,6 +3535,16 @@ func (a *IdentityApiService) GetIdentitySchemaExecute(r IdentityApiApiGetIdentit
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v ErrorGeneric
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1727,66 +3576,251 @@ func (a *IdentityApiService) GetIdentitySchemaExecute(r IdentityApiApiGetIdentit
 
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
-
-type IdentityApiApiGetSessionRequest struct {
-	ctx        context.Context
-	ApiService IdentityApi
-	id         string
-	expand     *[]string
+
+type IdentityApiApiListOidcProvidersRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+}
+
+func (r IdentityApiApiListOidcProvidersRequest) Execute() ([]OidcProvider, *http.Response, error) {
+	return r.ApiService.ListOidcProvidersExecute(r)
+}
+
+/*
+ * ListOidcProviders List OpenID Connect Providers
+ * Lists the social sign in providers which were added using the admin API. Providers defined in the configuration are not included. Client secrets and private keys are never returned.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return IdentityApiApiListOidcProvidersRequest
+ */
+func (a *IdentityApiService) ListOidcProviders(ctx context.Context) IdentityApiApiListOidcProvidersRequest {
+	return IdentityApiApiListOidcProvidersRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return []OidcProvider
+ */
+func (a *IdentityApiService) ListOidcProvidersExecute(r IdentityApiApiListOidcProvidersRequest) ([]OidcProvider, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  []OidcProvider
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ListOidcProviders")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/admin/oidc/providers"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		var v ErrorGeneric
+		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+		if err != nil {
+			newErr.error = err.Error()
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		newErr.model = v
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type IdentityApiApiListSessionsRequest struct {
+	ctx                  context.Context
+	ApiService           IdentityApi
+	pageSize             *int64
+	pageToken            *string
+	active               *bool
+	expand               *[]string
+	identityId           *string
+	aal                  *string
+	authenticationMethod *string
+	createdAfter         *string
+	createdBefore        *string
+	authenticatedAfter   *string
+	authenticatedBefore  *string
+	ipAddress            *string
+	userAgent            *string
+}
+
+func (r IdentityApiApiListSessionsRequest) PageSize(pageSize int64) IdentityApiApiListSessionsRequest {
+	r.pageSize = &pageSize
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) PageToken(pageToken string) IdentityApiApiListSessionsRequest {
+	r.pageToken = &pageToken
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) Active(active bool) IdentityApiApiListSessionsRequest {
+	r.active = &active
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) Expand(expand []string) IdentityApiApiListSessionsRequest {
+	r.expand = &expand
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) IdentityId(identityId string) IdentityApiApiListSessionsRequest {
+	r.identityId = &identityId
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) Aal(aal string) IdentityApiApiListSessionsRequest {
+	r.aal = &aal
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) AuthenticationMethod(authenticationMethod string) IdentityApiApiListSessionsRequest {
+	r.authenticationMethod = &authenticationMethod
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) CreatedAfter(createdAfter string) IdentityApiApiListSessionsRequest {
+	r.createdAfter = &createdAfter
+	return r
 }
-
-func (r IdentityApiApiGetSessionRequest) Expand(expand []string) IdentityApiApiGetSessionRequest {
-	r.expand = &expand
+func (r IdentityApiApiListSessionsRequest) CreatedBefore(createdBefore string) IdentityApiApiListSessionsRequest {
+	r.createdBefore = &createdBefore
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) AuthenticatedAfter(authenticatedAfter string) IdentityApiApiListSessionsRequest {
+	r.authenticatedAfter = &authenticatedAfter
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) AuthenticatedBefore(authenticatedBefore string) IdentityApiApiListSessionsRequest {
+	r.authenticatedBefore = &authenticatedBefore
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) IpAddress(ipAddress string) IdentityApiApiListSessionsRequest {
+	r.ipAddress = &ipAddress
+	return r
+}
+func (r IdentityApiApiListSessionsRequest) UserAgent(userAgent string) IdentityApiApiListSessionsRequest {
+	r.userAgent = &userAgent
 	return r
 }
 
-func (r IdentityApiApiGetSessionRequest) Execute() (*Session, *http.Response, error) {
-	return r.ApiService.GetSessionExecute(r)
+func (r IdentityApiApiListSessionsRequest) Execute() ([]Session, *http.Response, error) {
+	return r.ApiService.ListSessionsExecute(r)
 }
 
 /*
-  - GetSession Get Session
-  - This endpoint is useful for:
-
-Getting a session object with all specified expandables that exist in an administrative context.
-  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-  - @param id ID is the session's ID.
-  - @return IdentityApiApiGetSessionRequest
-*/
-func (a *IdentityApiService) GetSession(ctx context.Context, id string) IdentityApiApiGetSessionRequest {
-	return IdentityApiApiGetSessionRequest{
+ * ListSessions List All Sessions
+ * Listing all sessions that exist.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return IdentityApiApiListSessionsRequest
+ */
+func (a *IdentityApiService) ListSessions(ctx context.Context) IdentityApiApiListSessionsRequest {
+	return IdentityApiApiListSessionsRequest{
 		ApiService: a,
 		ctx:        ctx,
-		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return Session
+ * @return []Session
  */
-func (a *IdentityApiService) GetSessionExecute(r IdentityApiApiGetSessionRequest) (*Session, *http.Response, error) {
+func (a *IdentityApiService) ListSessionsExecute(r IdentityApiApiListSessionsRequest) ([]Session, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *Session
+		localVarReturnValue  []Session
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.GetSession")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ListSessions")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/sessions/{id}"
-	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath := localBasePath + "/admin/sessions"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
+	if r.pageSize != nil {
+		localVarQueryParams.Add("page_size", parameterToString(*r.pageSize, ""))
+	}
+	if r.pageToken != nil {
+		localVarQueryParams.Add("page_token", parameterToString(*r.pageToken, ""))
+	}
+	if r.active != nil {
+		localVarQueryParams.Add("active", parameterToString(*r.active, ""))
+	}
 	if r.expand != nil {
 		t := *r.expand
 		if reflect.TypeOf(t).Kind() == reflect.Slice {
@@ -1798,6 +3832,33 @@ func (a *IdentityApiService) GetSessionExecute(r IdentityApiApiGetSessionRequest
 			localVarQueryParams.Add("expand", parameterToString(t, "multi"))
 		}
 	}
+	if r.identityId != nil {
+		localVarQueryParams.Add("identity_id", parameterToString(*r.identityId, ""))
+	}
+	if r.aal != nil {
+		localVarQueryParams.Add("aal", parameterToString(*r.aal, ""))
+	}
+	if r.authenticationMethod != nil {
+		localVarQueryParams.Add("authentication_method", parameterToString(*r.authenticationMethod, ""))
+	}
+	if r.createdAfter != nil {
+		localVarQueryParams.Add("created_after", parameterToString(*r.createdAfter, ""))
+	}
+	if r.createdBefore != nil {
+		localVarQueryParams.Add("created_before", parameterToString(*r.createdBefore, ""))
+	}
+	if r.authenticatedAfter != nil {
+		localVarQueryParams.Add("authenticated_after", parameterToString(*r.authenticatedAfter, ""))
+	}
+	if r.authenticatedBefore != nil {
+		localVarQueryParams.Add("authenticated_before", parameterToString(*r.authenticatedBefore, ""))
+	}
+	if r.ipAddress != nil {
+		localVarQueryParams.Add("ip_address", parameterToString(*r.ipAddress, ""))
+	}
+	if r.userAgent != nil {
+		localVarQueryParams.Add("user_agent", parameterToString(*r.userAgent, ""))
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1883,80 +3944,67 @@ func (a *IdentityApiService) GetSessionExecute(r IdentityApiApiGetSessionRequest
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiListIdentitiesRequest struct {
-	ctx                   context.Context
-	ApiService            IdentityApi
-	perPage               *int64
-	page                  *int64
-	credentialsIdentifier *string
+type IdentityApiApiPatchIdentityRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
+	jsonPatch  *[]JsonPatch
 }
 
-func (r IdentityApiApiListIdentitiesRequest) PerPage(perPage int64) IdentityApiApiListIdentitiesRequest {
-	r.perPage = &perPage
-	return r
-}
-func (r IdentityApiApiListIdentitiesRequest) Page(page int64) IdentityApiApiListIdentitiesRequest {
-	r.page = &page
-	return r
-}
-func (r IdentityApiApiListIdentitiesRequest) CredentialsIdentifier(credentialsIdentifier string) IdentityApiApiListIdentitiesRequest {
-	r.credentialsIdentifier = &credentialsIdentifier
+func (r IdentityApiApiPatchIdentityRequest) JsonPatch(jsonPatch []JsonPatch) IdentityApiApiPatchIdentityRequest {
+	r.jsonPatch = &jsonPatch
 	return r
 }
 
-func (r IdentityApiApiListIdentitiesRequest) Execute() ([]Identity, *http.Response, error) {
-	return r.ApiService.ListIdentitiesExecute(r)
+func (r IdentityApiApiPatchIdentityRequest) Execute() (*Identity, *http.Response, error) {
+	return r.ApiService.PatchIdentityExecute(r)
 }
 
 /*
- * ListIdentities List Identities
- * Lists all [identities](https://www.ory.sh/docs/kratos/concepts/identity-user-model) in the system.
- * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return IdentityApiApiListIdentitiesRequest
- */
-func (a *IdentityApiService) ListIdentities(ctx context.Context) IdentityApiApiListIdentitiesRequest {
-	return IdentityApiApiListIdentitiesRequest{
+  - PatchIdentity Patch an Identity
+  - Partially updates an [identity's](https://www.ory.sh/docs/kratos/concepts/identity-user-model) field using [JSON Patch](https://jsonpatch.com/).
+
+The fields `id`, `stateChangedAt` and `credentials` can not be updated using this method.
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param id ID must be set to the ID of identity you want to update
+  - @return IdentityApiApiPatchIdentityRequest
+*/
+func (a *IdentityApiService) PatchIdentity(ctx context.Context, id string) IdentityApiApiPatchIdentityRequest {
+	return IdentityApiApiPatchIdentityRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return []Identity
+ * @return Identity
  */
-func (a *IdentityApiService) ListIdentitiesExecute(r IdentityApiApiListIdentitiesRequest) ([]Identity, *http.Response, error) {
+func (a *IdentityApiService) PatchIdentityExecute(r IdentityApiApiPatchIdentityRequest) (*Identity, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodPatch
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  []Identity
+		localVarReturnValue  *Identity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ListIdentities")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.PatchIdentity")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/identities"
+	localVarPath := localBasePath + "/admin/identities/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
-	if r.perPage != nil {
-		localVarQueryParams.Add("per_page", parameterToString(*r.perPage, ""))
-	}
-	if r.page != nil {
-		localVarQueryParams.Add("page", parameterToString(*r.page, ""))
-	}
-	if r.credentialsIdentifier != nil {
-		localVarQueryParams.Add("credentials_identifier", parameterToString(*r.credentialsIdentifier, ""))
-	}
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -1972,6 +4020,8 @@ func (a *IdentityApiService) ListIdentitiesExecute(r IdentityApiApiListIdentitie
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	// body params
+	localVarPostBody = r.jsonPatch
 	if r.ctx != nil {
 		// API Key Authentication
 		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
@@ -2008,6 +4058,36 @@ func (a *IdentityApiService) ListIdentitiesExecute(r IdentityApiApiListIdentitie
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
@@ -2030,70 +4110,64 @@ func (a *IdentityApiService) ListIdentitiesExecute(r IdentityApiApiListIdentitie
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiListIdentitySchemasRequest struct {
+type IdentityApiApiRegenerateIdentityCredentialsRequest struct {
 	ctx        context.Context
 	ApiService IdentityApi
-	perPage    *int64
-	page       *int64
-}
-
-func (r IdentityApiApiListIdentitySchemasRequest) PerPage(perPage int64) IdentityApiApiListIdentitySchemasRequest {
-	r.perPage = &perPage
-	return r
-}
-func (r IdentityApiApiListIdentitySchemasRequest) Page(page int64) IdentityApiApiListIdentitySchemasRequest {
-	r.page = &page
-	return r
+	id         string
+	type_      string
 }
 
-func (r IdentityApiApiListIdentitySchemasRequest) Execute() ([]IdentitySchemaContainer, *http.Response, error) {
-	return r.ApiService.ListIdentitySchemasExecute(r)
+func (r IdentityApiApiRegenerateIdentityCredentialsRequest) Execute() (*IdentityRegeneratedCredentials, *http.Response, error) {
+	return r.ApiService.RegenerateIdentityCredentialsExecute(r)
 }
 
 /*
- * ListIdentitySchemas Get all Identity Schemas
- * Returns a list of all identity schemas currently in use.
- * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return IdentityApiApiListIdentitySchemasRequest
- */
-func (a *IdentityApiService) ListIdentitySchemas(ctx context.Context) IdentityApiApiListIdentitySchemasRequest {
-	return IdentityApiApiListIdentitySchemasRequest{
+  - RegenerateIdentityCredentials Regenerate a credential for a specific identity
+  - Regenerate an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model) credential by its type.
+
+Currently, only lookup secrets (recovery codes) can be regenerated. The previous lookup secrets are invalidated
+and the new ones are returned once in the response.
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param id ID is the identity's ID.
+  - @param type_ Type is the credential's Type. Currently, only lookup_secret is supported.
+  - @return IdentityApiApiRegenerateIdentityCredentialsRequest
+*/
+func (a *IdentityApiService) RegenerateIdentityCredentials(ctx context.Context, id string, type_ string) IdentityApiApiRegenerateIdentityCredentialsRequest {
+	return IdentityApiApiRegenerateIdentityCredentialsRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
+		type_:      type_,
 	}
 }
 
 /*
  * Execute executes the request
- * @return []IdentitySchemaContainer
+ * @return IdentityRegeneratedCredentials
  */
-func (a *IdentityApiService) ListIdentitySchemasExecute(r IdentityApiApiListIdentitySchemasRequest) ([]IdentitySchemaContainer, *http.Response, error) {
+func (a *IdentityApiService) RegenerateIdentityCredentialsExecute(r IdentityApiApiRegenerateIdentityCredentialsRequest) (*IdentityRegeneratedCredentials, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  []IdentitySchemaContainer
+		localVarReturnValue  *IdentityRegeneratedCredentials
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ListIdentitySchemas")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.RegenerateIdentityCredentials")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/schemas"
+	localVarPath := localBasePath + "/admin/identities/{id}/credentials/{type}/regenerate"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"type"+"}", url.PathEscape(parameterToString(r.type_, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
-	if r.perPage != nil {
-		localVarQueryParams.Add("per_page", parameterToString(*r.perPage, ""))
-	}
-	if r.page != nil {
-		localVarQueryParams.Add("page", parameterToString(*r.page, ""))
-	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -2111,6 +4185,20 @@ func (a *IdentityApiService) ListIdentitySchemasExecute(r IdentityApiApiListIden
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["oryAccessToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -2133,6 +4221,26 @@ func (a *IdentityApiService) ListIdentitySchemasExecute(r IdentityApiApiListIden
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
@@ -2154,83 +4262,137 @@ func (a *IdentityApiService) ListIdentitySchemasExecute(r IdentityApiApiListIden
 
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
-
-type IdentityApiApiListIdentitySessionsRequest struct {
-	ctx        context.Context
-	ApiService IdentityApi
-	id         string
-	perPage    *int64
-	page       *int64
-	active     *bool
+
+type IdentityApiApiRevokeSessionsRequest struct {
+	ctx                  context.Context
+	ApiService           IdentityApi
+	active               *bool
+	identityId           *string
+	aal                  *string
+	authenticationMethod *string
+	createdAfter         *string
+	createdBefore        *string
+	authenticatedAfter   *string
+	authenticatedBefore  *string
+	ipAddress            *string
+	userAgent            *string
 }
 
-func (r IdentityApiApiListIdentitySessionsRequest) PerPage(perPage int64) IdentityApiApiListIdentitySessionsRequest {
-	r.perPage = &perPage
+func (r IdentityApiApiRevokeSessionsRequest) Active(active bool) IdentityApiApiRevokeSessionsRequest {
+	r.active = &active
 	return r
 }
-func (r IdentityApiApiListIdentitySessionsRequest) Page(page int64) IdentityApiApiListIdentitySessionsRequest {
-	r.page = &page
+func (r IdentityApiApiRevokeSessionsRequest) IdentityId(identityId string) IdentityApiApiRevokeSessionsRequest {
+	r.identityId = &identityId
 	return r
 }
-func (r IdentityApiApiListIdentitySessionsRequest) Active(active bool) IdentityApiApiListIdentitySessionsRequest {
-	r.active = &active
+func (r IdentityApiApiRevokeSessionsRequest) Aal(aal string) IdentityApiApiRevokeSessionsRequest {
+	r.aal = &aal
+	return r
+}
+func (r IdentityApiApiRevokeSessionsRequest) AuthenticationMethod(authenticationMethod string) IdentityApiApiRevokeSessionsRequest {
+	r.authenticationMethod = &authenticationMethod
+	return r
+}
+func (r IdentityApiApiRevokeSessionsRequest) CreatedAfter(createdAfter string) IdentityApiApiRevokeSessionsRequest {
+	r.createdAfter = &createdAfter
+	return r
+}
+func (r IdentityApiApiRevokeSessionsRequest) CreatedBefore(createdBefore string) IdentityApiApiRevokeSessionsRequest {
+	r.createdBefore = &createdBefore
+	return r
+}
+func (r IdentityApiApiRevokeSessionsRequest) AuthenticatedAfter(authenticatedAfter string) IdentityApiApiRevokeSessionsRequest {
+	r.authenticatedAfter = &authenticatedAfter
+	return r
+}
+func (r IdentityApiApiRevokeSessionsRequest) AuthenticatedBefore(authenticatedBefore string) IdentityApiApiRevokeSessionsRequest {
+	r.authenticatedBefore = &authenticatedBefore
+	return r
+}
+func (r IdentityApiApiRevokeSessionsRequest) IpAddress(ipAddress string) IdentityApiApiRevokeSessionsRequest {
+	r.ipAddress = &ipAddress
+	return r
+}
+func (r IdentityApiApiRevokeSessionsRequest) UserAgent(userAgent string) IdentityApiApiRevokeSessionsRequest {
+	r.userAgent = &userAgent
 	return r
 }
 
-func (r IdentityApiApiListIdentitySessionsRequest) Execute() ([]Session, *http.Response, error) {
-	return r.ApiService.ListIdentitySessionsExecute(r)
+func (r IdentityApiApiRevokeSessionsRequest) Execute() (*RevokedSessions, *http.Response, error) {
+	return r.ApiService.RevokeSessionsExecute(r)
 }
 
 /*
- * ListIdentitySessions List an Identity's Sessions
- * This endpoint returns all sessions that belong to the given Identity.
- * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @param id ID is the identity's ID.
- * @return IdentityApiApiListIdentitySessionsRequest
- */
-func (a *IdentityApiService) ListIdentitySessions(ctx context.Context, id string) IdentityApiApiListIdentitySessionsRequest {
-	return IdentityApiApiListIdentitySessionsRequest{
+  - RevokeSessions Revoke Sessions Matching a Filter
+  - Calling this endpoint deactivates all sessions matching the given filter, for example all sessions
+
+created from a compromised IP range. Session data is not deleted. At least one filter besides active must be provided.
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @return IdentityApiApiRevokeSessionsRequest
+*/
+func (a *IdentityApiService) RevokeSessions(ctx context.Context) IdentityApiApiRevokeSessionsRequest {
+	return IdentityApiApiRevokeSessionsRequest{
 		ApiService: a,
 		ctx:        ctx,
-		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return []Session
+ * @return RevokedSessions
  */
-func (a *IdentityApiService) ListIdentitySessionsExecute(r IdentityApiApiListIdentitySessionsRequest) ([]Session, *http.Response, error) {
+func (a *IdentityApiService) RevokeSessionsExecute(r IdentityApiApiRevokeSessionsRequest) (*RevokedSessions, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  []Session
+		localVarReturnValue  *RevokedSessions
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ListIdentitySessions")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.RevokeSessions")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/identities/{id}/sessions"
-	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath := localBasePath + "/admin/sessions/revoke"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
-	if r.perPage != nil {
-		localVarQueryParams.Add("per_page", parameterToString(*r.perPage, ""))
-	}
-	if r.page != nil {
-		localVarQueryParams.Add("page", parameterToString(*r.page, ""))
-	}
 	if r.active != nil {
 		localVarQueryParams.Add("active", parameterToString(*r.active, ""))
 	}
+	if r.identityId != nil {
+		localVarQueryParams.Add("identity_id", parameterToString(*r.identityId, ""))
+	}
+	if r.aal != nil {
+		localVarQueryParams.Add("aal", parameterToString(*r.aal, ""))
+	}
+	if r.authenticationMethod != nil {
+		localVarQueryParams.Add("authentication_method", parameterToString(*r.authenticationMethod, ""))
+	}
+	if r.createdAfter != nil {
+		localVarQueryParams.Add("created_after", parameterToString(*r.createdAfter, ""))
+	}
+	if r.createdBefore != nil {
+		localVarQueryParams.Add("created_before", parameterToString(*r.createdBefore, ""))
+	}
+	if r.authenticatedAfter != nil {
+		localVarQueryParams.Add("authenticated_after", parameterToString(*r.authenticatedAfter, ""))
+	}
+	if r.authenticatedBefore != nil {
+		localVarQueryParams.Add("authenticated_before", parameterToString(*r.authenticatedBefore, ""))
+	}
+	if r.ipAddress != nil {
+		localVarQueryParams.Add("ip_address", parameterToString(*r.ipAddress, ""))
+	}
+	if r.userAgent != nil {
+		localVarQueryParams.Add("user_agent", parameterToString(*r.userAgent, ""))
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -2294,16 +4456,6 @@ func (a *IdentityApiService) ListIdentitySessionsExecute(r IdentityApiApiListIde
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 404 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
@@ -2326,96 +4478,67 @@ func (a *IdentityApiService) ListIdentitySessionsExecute(r IdentityApiApiListIde
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiListSessionsRequest struct {
-	ctx        context.Context
-	ApiService IdentityApi
-	pageSize   *int64
-	pageToken  *string
-	active     *bool
-	expand     *[]string
+type IdentityApiApiUpdateIdentityRequest struct {
+	ctx                context.Context
+	ApiService         IdentityApi
+	id                 string
+	updateIdentityBody *UpdateIdentityBody
 }
 
-func (r IdentityApiApiListSessionsRequest) PageSize(pageSize int64) IdentityApiApiListSessionsRequest {
-	r.pageSize = &pageSize
-	return r
-}
-func (r IdentityApiApiListSessionsRequest) PageToken(pageToken string) IdentityApiApiListSessionsRequest {
-	r.pageToken = &pageToken
-	return r
-}
-func (r IdentityApiApiListSessionsRequest) Active(active bool) IdentityApiApiListSessionsRequest {
-	r.active = &active
-	return r
-}
-func (r IdentityApiApiListSessionsRequest) Expand(expand []string) IdentityApiApiListSessionsRequest {
-	r.expand = &expand
+func (r IdentityApiApiUpdateIdentityRequest) UpdateIdentityBody(updateIdentityBody UpdateIdentityBody) IdentityApiApiUpdateIdentityRequest {
+	r.updateIdentityBody = &updateIdentityBody
 	return r
 }
 
-func (r IdentityApiApiListSessionsRequest) Execute() ([]Session, *http.Response, error) {
-	return r.ApiService.ListSessionsExecute(r)
+func (r IdentityApiApiUpdateIdentityRequest) Execute() (*Identity, *http.Response, error) {
+	return r.ApiService.UpdateIdentityExecute(r)
 }
 
 /*
- * ListSessions List All Sessions
- * Listing all sessions that exist.
- * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return IdentityApiApiListSessionsRequest
- */
-func (a *IdentityApiService) ListSessions(ctx context.Context) IdentityApiApiListSessionsRequest {
-	return IdentityApiApiListSessionsRequest{
+  - UpdateIdentity Update an Identity
+  - This endpoint updates an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model). The full identity
+
+payload (except credentials) is expected. It is possible to update the identity's credentials as well.
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param id ID must be set to the ID of identity you want to update
+  - @return IdentityApiApiUpdateIdentityRequest
+*/
+func (a *IdentityApiService) UpdateIdentity(ctx context.Context, id string) IdentityApiApiUpdateIdentityRequest {
+	return IdentityApiApiUpdateIdentityRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return []Session
+ * @return Identity
  */
-func (a *IdentityApiService) ListSessionsExecute(r IdentityApiApiListSessionsRequest) ([]Session, *http.Response, error) {
+func (a *IdentityApiService) UpdateIdentityExecute(r IdentityApiApiUpdateIdentityRequest) (*Identity, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodPut
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  []Session
+		localVarReturnValue  *Identity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.ListSessions")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.UpdateIdentity")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/sessions"
+	localVarPath := localBasePath + "/admin/identities/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
-	if r.pageSize != nil {
-		localVarQueryParams.Add("page_size", parameterToString(*r.pageSize, ""))
-	}
-	if r.pageToken != nil {
-		localVarQueryParams.Add("page_token", parameterToString(*r.pageToken, ""))
-	}
-	if r.active != nil {
-		localVarQueryParams.Add("active", parameterToString(*r.active, ""))
-	}
-	if r.expand != nil {
-		t := *r.expand
-		if reflect.TypeOf(t).Kind() == reflect.Slice {
-			s := reflect.ValueOf(t)
-			for i := 0; i < s.Len(); i++ {
-				localVarQueryParams.Add("expand", parameterToString(s.Index(i), "multi"))
-			}
-		} else {
-			localVarQueryParams.Add("expand", parameterToString(t, "multi"))
-		}
-	}
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -2431,6 +4554,8 @@ func (a *IdentityApiService) ListSessionsExecute(r IdentityApiApiListSessionsReq
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	// body params
+	localVarPostBody = r.updateIdentityBody
 	if r.ctx != nil {
 		// API Key Authentication
 		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
@@ -2477,6 +4602,26 @@ func (a *IdentityApiService) ListSessionsExecute(r IdentityApiApiListSessionsReq
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v ErrorGeneric
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
@@ -2499,64 +4644,73 @@ func (a *IdentityApiService) ListSessionsExecute(r IdentityApiApiListSessionsReq
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiPatchIdentityRequest struct {
-	ctx        context.Context
-	ApiService IdentityApi
-	id         string
-	jsonPatch  *[]JsonPatch
+type IdentityApiApiUpdateIdentityCredentialItemRequest struct {
+	ctx                              context.Context
+	ApiService                       IdentityApi
+	id                               string
+	type_                            string
+	item                             string
+	updateIdentityCredentialItemBody *UpdateIdentityCredentialItemBody
 }
 
-func (r IdentityApiApiPatchIdentityRequest) JsonPatch(jsonPatch []JsonPatch) IdentityApiApiPatchIdentityRequest {
-	r.jsonPatch = &jsonPatch
+func (r IdentityApiApiUpdateIdentityCredentialItemRequest) UpdateIdentityCredentialItemBody(updateIdentityCredentialItemBody UpdateIdentityCredentialItemBody) IdentityApiApiUpdateIdentityCredentialItemRequest {
+	r.updateIdentityCredentialItemBody = &updateIdentityCredentialItemBody
 	return r
 }
 
-func (r IdentityApiApiPatchIdentityRequest) Execute() (*Identity, *http.Response, error) {
-	return r.ApiService.PatchIdentityExecute(r)
+func (r IdentityApiApiUpdateIdentityCredentialItemRequest) Execute() (*IdentityCredentialItem, *http.Response, error) {
+	return r.ApiService.UpdateIdentityCredentialItemExecute(r)
 }
 
 /*
-  - PatchIdentity Patch an Identity
-  - Partially updates an [identity's](https://www.ory.sh/docs/kratos/concepts/identity-user-model) field using [JSON Patch](https://jsonpatch.com/).
-
-The fields `id`, `stateChangedAt` and `credentials` can not be updated using this method.
-  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-  - @param id ID must be set to the ID of identity you want to update
-  - @return IdentityApiApiPatchIdentityRequest
-*/
-func (a *IdentityApiService) PatchIdentity(ctx context.Context, id string) IdentityApiApiPatchIdentityRequest {
-	return IdentityApiApiPatchIdentityRequest{
+ * UpdateIdentityCredentialItem Update an item of an identity's credential
+ * Renames a single security key (webauthn) of an identity or clears its clone warning. Security keys flagged as cloned can be deleted using deleteIdentityCredentialItem.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID is the identity's ID.
+ * @param type_ Type is the credential's Type. Currently, only webauthn is supported.
+ * @param item Item is the ID of the credential item.
+ * @return IdentityApiApiUpdateIdentityCredentialItemRequest
+ */
+func (a *IdentityApiService) UpdateIdentityCredentialItem(ctx context.Context, id string, type_ string, item string) IdentityApiApiUpdateIdentityCredentialItemRequest {
+	return IdentityApiApiUpdateIdentityCredentialItemRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
+		type_:      type_,
+		item:       item,
 	}
 }
 
 /*
  * Execute executes the request
- * @return Identity
+ * @return IdentityCredentialItem
  */
-func (a *IdentityApiService) PatchIdentityExecute(r IdentityApiApiPatchIdentityRequest) (*Identity, *http.Response, error) {
+func (a *IdentityApiService) UpdateIdentityCredentialItemExecute(r IdentityApiApiUpdateIdentityCredentialItemRequest) (*IdentityCredentialItem, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPatch
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *Identity
+		localVarReturnValue  *IdentityCredentialItem
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.PatchIdentity")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.UpdateIdentityCredentialItem")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/identities/{id}"
+	localVarPath := localBasePath + "/admin/identities/{id}/credentials/{type}/items/{item}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"type"+"}", url.PathEscape(parameterToString(r.type_, "")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"item"+"}", url.PathEscape(parameterToString(r.item, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.updateIdentityCredentialItemBody == nil {
+		return localVarReturnValue, nil, reportError("updateIdentityCredentialItemBody is required and must be specified")
+	}
 
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{"application/json"}
@@ -2576,7 +4730,7 @@ func (a *IdentityApiService) PatchIdentityExecute(r IdentityApiApiPatchIdentityR
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	// body params
-	localVarPostBody = r.jsonPatch
+	localVarPostBody = r.updateIdentityCredentialItemBody
 	if r.ctx != nil {
 		// API Key Authentication
 		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
@@ -2633,16 +4787,6 @@ func (a *IdentityApiService) PatchIdentityExecute(r IdentityApiApiPatchIdentityR
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 409 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
@@ -2665,33 +4809,31 @@ func (a *IdentityApiService) PatchIdentityExecute(r IdentityApiApiPatchIdentityR
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type IdentityApiApiUpdateIdentityRequest struct {
-	ctx                context.Context
-	ApiService         IdentityApi
-	id                 string
-	updateIdentityBody *UpdateIdentityBody
+type IdentityApiApiUpdateOidcProviderRequest struct {
+	ctx        context.Context
+	ApiService IdentityApi
+	id         string
+	body       *map[string]interface{}
 }
 
-func (r IdentityApiApiUpdateIdentityRequest) UpdateIdentityBody(updateIdentityBody UpdateIdentityBody) IdentityApiApiUpdateIdentityRequest {
-	r.updateIdentityBody = &updateIdentityBody
+func (r IdentityApiApiUpdateOidcProviderRequest) Body(body map[string]interface{}) IdentityApiApiUpdateOidcProviderRequest {
+	r.body = &body
 	return r
 }
 
-func (r IdentityApiApiUpdateIdentityRequest) Execute() (*Identity, *http.Response, error) {
-	return r.ApiService.UpdateIdentityExecute(r)
+func (r IdentityApiApiUpdateOidcProviderRequest) Execute() (*OidcProvider, *http.Response, error) {
+	return r.ApiService.UpdateOidcProviderExecute(r)
 }
 
 /*
-  - UpdateIdentity Update an Identity
-  - This endpoint updates an [identity](https://www.ory.sh/docs/kratos/concepts/identity-user-model). The full identity
-
-payload (except credentials) is expected. It is possible to update the identity's credentials as well.
-  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-  - @param id ID must be set to the ID of identity you want to update
-  - @return IdentityApiApiUpdateIdentityRequest
-*/
-func (a *IdentityApiService) UpdateIdentity(ctx context.Context, id string) IdentityApiApiUpdateIdentityRequest {
-	return IdentityApiApiUpdateIdentityRequest{
+ * UpdateOidcProvider Update an OpenID Connect Provider
+ * Replaces the configuration of a social sign in provider which was added using the admin API. The change takes effect right away. If the client secret or private key is omitted, the stored one is kept. The ID of the provider can not be changed.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID is the provider's ID.
+ * @return IdentityApiApiUpdateOidcProviderRequest
+ */
+func (a *IdentityApiService) UpdateOidcProvider(ctx context.Context, id string) IdentityApiApiUpdateOidcProviderRequest {
+	return IdentityApiApiUpdateOidcProviderRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
@@ -2700,29 +4842,32 @@ func (a *IdentityApiService) UpdateIdentity(ctx context.Context, id string) Iden
 
 /*
  * Execute executes the request
- * @return Identity
+ * @return OidcProvider
  */
-func (a *IdentityApiService) UpdateIdentityExecute(r IdentityApiApiUpdateIdentityRequest) (*Identity, *http.Response, error) {
+func (a *IdentityApiService) UpdateOidcProviderExecute(r IdentityApiApiUpdateOidcProviderRequest) (*OidcProvider, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPut
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *Identity
+		localVarReturnValue  *OidcProvider
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.UpdateIdentity")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "IdentityApiService.UpdateOidcProvider")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/admin/identities/{id}"
+	localVarPath := localBasePath + "/admin/oidc/providers/{id}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.body == nil {
+		return localVarReturnValue, nil, reportError("body is required and must be specified")
+	}
 
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{"application/json"}
@@ -2742,7 +4887,7 @@ func (a *IdentityApiService) UpdateIdentityExecute(r IdentityApiApiUpdateIdentit
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	// body params
-	localVarPostBody = r.updateIdentityBody
+	localVarPostBody = r.body
 	if r.ctx != nil {
 		// API Key Authentication
 		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
@@ -2799,16 +4944,6 @@ func (a *IdentityApiService) UpdateIdentityExecute(r IdentityApiApiUpdateIdentit
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 409 {
-			var v ErrorGeneric
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		var v ErrorGeneric
 		err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 		if err != nil {
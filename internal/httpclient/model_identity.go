@@ -24,10 +24,13 @@ type Identity struct {
 	Credentials *map[string]IdentityCredentials `json:"credentials,omitempty"`
 	// ID is the identity's unique identifier.  The Identity ID can not be changed and can not be chosen. This ensures future compatibility and optimization for distributed stores such as CockroachDB.
 	Id string `json:"id"`
+	// LookupSecretsRemaining is the number of lookup secrets (recovery codes) which the identity has not used yet.  It is only set if the identity has set up lookup secrets.
+	LookupSecretsRemaining *int64 `json:"lookup_secrets_remaining,omitempty"`
 	// NullJSONRawMessage represents a json.RawMessage that works well with JSON, SQL, and Swagger and is NULLable-
 	MetadataAdmin interface{} `json:"metadata_admin,omitempty"`
 	// NullJSONRawMessage represents a json.RawMessage that works well with JSON, SQL, and Swagger and is NULLable-
-	MetadataPublic interface{} `json:"metadata_public,omitempty"`
+	MetadataPublic        interface{} `json:"metadata_public,omitempty"`
+	MfaEnrollmentDeadline *time.Time  `json:"mfa_enrollment_deadline,omitempty"`
 	// RecoveryAddresses contains all the addresses that can be used to recover an identity.
 	RecoveryAddresses []RecoveryIdentityAddress `json:"recovery_addresses,omitempty"`
 	// SchemaID is the ID of the JSON Schema to be used for validating the identity's traits.
@@ -153,6 +156,38 @@ func (o *Identity) SetId(v string) {
 	o.Id = v
 }
 
+// GetLookupSecretsRemaining returns the LookupSecretsRemaining field value if set, zero value otherwise.
+func (o *Identity) GetLookupSecretsRemaining() int64 {
+	if o == nil || o.LookupSecretsRemaining == nil {
+		var ret int64
+		return ret
+	}
+	return *o.LookupSecretsRemaining
+}
+
+// GetLookupSecretsRemainingOk returns a tuple with the LookupSecretsRemaining field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Identity) GetLookupSecretsRemainingOk() (*int64, bool) {
+	if o == nil || o.LookupSecretsRemaining == nil {
+		return nil, false
+	}
+	return o.LookupSecretsRemaining, true
+}
+
+// HasLookupSecretsRemaining returns a boolean if a field has been set.
+func (o *Identity) HasLookupSecretsRemaining() bool {
+	if o != nil && o.LookupSecretsRemaining != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetLookupSecretsRemaining gets a reference to the given int64 and assigns it to the LookupSecretsRemaining field.
+func (o *Identity) SetLookupSecretsRemaining(v int64) {
+	o.LookupSecretsRemaining = &v
+}
+
 // GetMetadataAdmin returns the MetadataAdmin field value if set, zero value otherwise (both if not set or set to explicit null).
 func (o *Identity) GetMetadataAdmin() interface{} {
 	if o == nil {
@@ -219,6 +254,38 @@ func (o *Identity) SetMetadataPublic(v interface{}) {
 	o.MetadataPublic = v
 }
 
+// GetMfaEnrollmentDeadline returns the MfaEnrollmentDeadline field value if set, zero value otherwise.
+func (o *Identity) GetMfaEnrollmentDeadline() time.Time {
+	if o == nil || o.MfaEnrollmentDeadline == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.MfaEnrollmentDeadline
+}
+
+// GetMfaEnrollmentDeadlineOk returns a tuple with the MfaEnrollmentDeadline field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Identity) GetMfaEnrollmentDeadlineOk() (*time.Time, bool) {
+	if o == nil || o.MfaEnrollmentDeadline == nil {
+		return nil, false
+	}
+	return o.MfaEnrollmentDeadline, true
+}
+
+// HasMfaEnrollmentDeadline returns a boolean if a field has been set.
+func (o *Identity) HasMfaEnrollmentDeadline() bool {
+	if o != nil && o.MfaEnrollmentDeadline != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMfaEnrollmentDeadline gets a reference to the given time.Time and assigns it to the MfaEnrollmentDeadline field.
+func (o *Identity) SetMfaEnrollmentDeadline(v time.Time) {
+	o.MfaEnrollmentDeadline = &v
+}
+
 // GetRecoveryAddresses returns the RecoveryAddresses field value if set, zero value otherwise.
 func (o *Identity) GetRecoveryAddresses() []RecoveryIdentityAddress {
 	if o == nil || o.RecoveryAddresses == nil {
@@ -464,12 +531,18 @@ func (o Identity) MarshalJSON() ([]byte, error) {
 	if true {
 		toSerialize["id"] = o.Id
 	}
+	if o.LookupSecretsRemaining != nil {
+		toSerialize["lookup_secrets_remaining"] = o.LookupSecretsRemaining
+	}
 	if o.MetadataAdmin != nil {
 		toSerialize["metadata_admin"] = o.MetadataAdmin
 	}
 	if o.MetadataPublic != nil {
 		toSerialize["metadata_public"] = o.MetadataPublic
 	}
+	if o.MfaEnrollmentDeadline != nil {
+		toSerialize["mfa_enrollment_deadline"] = o.MfaEnrollmentDeadline
+	}
 	if o.RecoveryAddresses != nil {
 		toSerialize["recovery_addresses"] = o.RecoveryAddresses
 	}
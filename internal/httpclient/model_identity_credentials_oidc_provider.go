@@ -13,15 +13,21 @@ package client
 
 import (
 	"encoding/json"
+	"time"
 )
 
 // IdentityCredentialsOidcProvider struct for IdentityCredentialsOidcProvider
 type IdentityCredentialsOidcProvider struct {
-	InitialAccessToken  *string `json:"initial_access_token,omitempty"`
-	InitialIdToken      *string `json:"initial_id_token,omitempty"`
-	InitialRefreshToken *string `json:"initial_refresh_token,omitempty"`
-	Provider            *string `json:"provider,omitempty"`
-	Subject             *string `json:"subject,omitempty"`
+	AccessToken *string `json:"access_token,omitempty"`
+	// IDToken, AccessToken and RefreshToken are the latest (encrypted) tokens issued by the provider. Unlike the initial tokens, they are updated on every login and whenever the access token is refreshed.
+	IdToken             *string    `json:"id_token,omitempty"`
+	InitialAccessToken  *string    `json:"initial_access_token,omitempty"`
+	InitialIdToken      *string    `json:"initial_id_token,omitempty"`
+	InitialRefreshToken *string    `json:"initial_refresh_token,omitempty"`
+	Provider            *string    `json:"provider,omitempty"`
+	RefreshToken        *string    `json:"refresh_token,omitempty"`
+	Subject             *string    `json:"subject,omitempty"`
+	TokenExpiresAt      *time.Time `json:"token_expires_at,omitempty"`
 }
 
 // NewIdentityCredentialsOidcProvider instantiates a new IdentityCredentialsOidcProvider object
@@ -41,6 +47,70 @@ func NewIdentityCredentialsOidcProviderWithDefaults() *IdentityCredentialsOidcPr
 	return &this
 }
 
+// GetAccessToken returns the AccessToken field value if set, zero value otherwise.
+func (o *IdentityCredentialsOidcProvider) GetAccessToken() string {
+	if o == nil || o.AccessToken == nil {
+		var ret string
+		return ret
+	}
+	return *o.AccessToken
+}
+
+// GetAccessTokenOk returns a tuple with the AccessToken field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityCredentialsOidcProvider) GetAccessTokenOk() (*string, bool) {
+	if o == nil || o.AccessToken == nil {
+		return nil, false
+	}
+	return o.AccessToken, true
+}
+
+// HasAccessToken returns a boolean if a field has been set.
+func (o *IdentityCredentialsOidcProvider) HasAccessToken() bool {
+	if o != nil && o.AccessToken != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetAccessToken gets a reference to the given string and assigns it to the AccessToken field.
+func (o *IdentityCredentialsOidcProvider) SetAccessToken(v string) {
+	o.AccessToken = &v
+}
+
+// GetIdToken returns the IdToken field value if set, zero value otherwise.
+func (o *IdentityCredentialsOidcProvider) GetIdToken() string {
+	if o == nil || o.IdToken == nil {
+		var ret string
+		return ret
+	}
+	return *o.IdToken
+}
+
+// GetIdTokenOk returns a tuple with the IdToken field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityCredentialsOidcProvider) GetIdTokenOk() (*string, bool) {
+	if o == nil || o.IdToken == nil {
+		return nil, false
+	}
+	return o.IdToken, true
+}
+
+// HasIdToken returns a boolean if a field has been set.
+func (o *IdentityCredentialsOidcProvider) HasIdToken() bool {
+	if o != nil && o.IdToken != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetIdToken gets a reference to the given string and assigns it to the IdToken field.
+func (o *IdentityCredentialsOidcProvider) SetIdToken(v string) {
+	o.IdToken = &v
+}
+
 // GetInitialAccessToken returns the InitialAccessToken field value if set, zero value otherwise.
 func (o *IdentityCredentialsOidcProvider) GetInitialAccessToken() string {
 	if o == nil || o.InitialAccessToken == nil {
@@ -169,6 +239,38 @@ func (o *IdentityCredentialsOidcProvider) SetProvider(v string) {
 	o.Provider = &v
 }
 
+// GetRefreshToken returns the RefreshToken field value if set, zero value otherwise.
+func (o *IdentityCredentialsOidcProvider) GetRefreshToken() string {
+	if o == nil || o.RefreshToken == nil {
+		var ret string
+		return ret
+	}
+	return *o.RefreshToken
+}
+
+// GetRefreshTokenOk returns a tuple with the RefreshToken field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityCredentialsOidcProvider) GetRefreshTokenOk() (*string, bool) {
+	if o == nil || o.RefreshToken == nil {
+		return nil, false
+	}
+	return o.RefreshToken, true
+}
+
+// HasRefreshToken returns a boolean if a field has been set.
+func (o *IdentityCredentialsOidcProvider) HasRefreshToken() bool {
+	if o != nil && o.RefreshToken != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetRefreshToken gets a reference to the given string and assigns it to the RefreshToken field.
+func (o *IdentityCredentialsOidcProvider) SetRefreshToken(v string) {
+	o.RefreshToken = &v
+}
+
 // GetSubject returns the Subject field value if set, zero value otherwise.
 func (o *IdentityCredentialsOidcProvider) GetSubject() string {
 	if o == nil || o.Subject == nil {
@@ -201,8 +303,46 @@ func (o *IdentityCredentialsOidcProvider) SetSubject(v string) {
 	o.Subject = &v
 }
 
+// GetTokenExpiresAt returns the TokenExpiresAt field value if set, zero value otherwise.
+func (o *IdentityCredentialsOidcProvider) GetTokenExpiresAt() time.Time {
+	if o == nil || o.TokenExpiresAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.TokenExpiresAt
+}
+
+// GetTokenExpiresAtOk returns a tuple with the TokenExpiresAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityCredentialsOidcProvider) GetTokenExpiresAtOk() (*time.Time, bool) {
+	if o == nil || o.TokenExpiresAt == nil {
+		return nil, false
+	}
+	return o.TokenExpiresAt, true
+}
+
+// HasTokenExpiresAt returns a boolean if a field has been set.
+func (o *IdentityCredentialsOidcProvider) HasTokenExpiresAt() bool {
+	if o != nil && o.TokenExpiresAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetTokenExpiresAt gets a reference to the given time.Time and assigns it to the TokenExpiresAt field.
+func (o *IdentityCredentialsOidcProvider) SetTokenExpiresAt(v time.Time) {
+	o.TokenExpiresAt = &v
+}
+
 func (o IdentityCredentialsOidcProvider) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
+	if o.AccessToken != nil {
+		toSerialize["access_token"] = o.AccessToken
+	}
+	if o.IdToken != nil {
+		toSerialize["id_token"] = o.IdToken
+	}
 	if o.InitialAccessToken != nil {
 		toSerialize["initial_access_token"] = o.InitialAccessToken
 	}
@@ -215,9 +355,15 @@ func (o IdentityCredentialsOidcProvider) MarshalJSON() ([]byte, error) {
 	if o.Provider != nil {
 		toSerialize["provider"] = o.Provider
 	}
+	if o.RefreshToken != nil {
+		toSerialize["refresh_token"] = o.RefreshToken
+	}
 	if o.Subject != nil {
 		toSerialize["subject"] = o.Subject
 	}
+	if o.TokenExpiresAt != nil {
+		toSerialize["token_expires_at"] = o.TokenExpiresAt
+	}
 	return json.Marshal(toSerialize)
 }
 
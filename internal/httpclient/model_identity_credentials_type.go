@@ -26,6 +26,8 @@ const (
 	IDENTITYCREDENTIALSTYPE_OIDC          IdentityCredentialsType = "oidc"
 	IDENTITYCREDENTIALSTYPE_WEBAUTHN      IdentityCredentialsType = "webauthn"
 	IDENTITYCREDENTIALSTYPE_LOOKUP_SECRET IdentityCredentialsType = "lookup_secret"
+	IDENTITYCREDENTIALSTYPE_OTP           IdentityCredentialsType = "otp"
+	IDENTITYCREDENTIALSTYPE_HOTP          IdentityCredentialsType = "hotp"
 )
 
 func (v *IdentityCredentialsType) UnmarshalJSON(src []byte) error {
@@ -35,7 +37,7 @@ func (v *IdentityCredentialsType) UnmarshalJSON(src []byte) error {
 		return err
 	}
 	enumTypeValue := IdentityCredentialsType(value)
-	for _, existing := range []IdentityCredentialsType{"password", "totp", "oidc", "webauthn", "lookup_secret"} {
+	for _, existing := range []IdentityCredentialsType{"password", "totp", "oidc", "webauthn", "lookup_secret", "otp", "hotp"} {
 		if existing == enumTypeValue {
 			*v = enumTypeValue
 			return nil
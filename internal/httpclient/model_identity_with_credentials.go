@@ -17,6 +17,7 @@ import (
 
 // IdentityWithCredentials Create Identity and Import Credentials
 type IdentityWithCredentials struct {
+	Hotp     *IdentityWithCredentialsHotp     `json:"hotp,omitempty"`
 	Oidc     *IdentityWithCredentialsOidc     `json:"oidc,omitempty"`
 	Password *IdentityWithCredentialsPassword `json:"password,omitempty"`
 }
@@ -38,6 +39,38 @@ func NewIdentityWithCredentialsWithDefaults() *IdentityWithCredentials {
 	return &this
 }
 
+// GetHotp returns the Hotp field value if set, zero value otherwise.
+func (o *IdentityWithCredentials) GetHotp() IdentityWithCredentialsHotp {
+	if o == nil || o.Hotp == nil {
+		var ret IdentityWithCredentialsHotp
+		return ret
+	}
+	return *o.Hotp
+}
+
+// GetHotpOk returns a tuple with the Hotp field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityWithCredentials) GetHotpOk() (*IdentityWithCredentialsHotp, bool) {
+	if o == nil || o.Hotp == nil {
+		return nil, false
+	}
+	return o.Hotp, true
+}
+
+// HasHotp returns a boolean if a field has been set.
+func (o *IdentityWithCredentials) HasHotp() bool {
+	if o != nil && o.Hotp != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetHotp gets a reference to the given IdentityWithCredentialsHotp and assigns it to the Hotp field.
+func (o *IdentityWithCredentials) SetHotp(v IdentityWithCredentialsHotp) {
+	o.Hotp = &v
+}
+
 // GetOidc returns the Oidc field value if set, zero value otherwise.
 func (o *IdentityWithCredentials) GetOidc() IdentityWithCredentialsOidc {
 	if o == nil || o.Oidc == nil {
@@ -104,6 +137,9 @@ func (o *IdentityWithCredentials) SetPassword(v IdentityWithCredentialsPassword)
 
 func (o IdentityWithCredentials) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
+	if o.Hotp != nil {
+		toSerialize["hotp"] = o.Hotp
+	}
 	if o.Oidc != nil {
 		toSerialize["oidc"] = o.Oidc
 	}
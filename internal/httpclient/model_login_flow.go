@@ -32,8 +32,9 @@ type LoginFlow struct {
 	// Refresh stores whether this login flow should enforce re-authentication.
 	Refresh *bool `json:"refresh,omitempty"`
 	// RequestURL is the initial URL that was requested from Ory Kratos. It can be used to forward information contained in the URL's path or query for example.
-	RequestUrl   string                       `json:"request_url"`
-	RequestedAal *AuthenticatorAssuranceLevel `json:"requested_aal,omitempty"`
+	RequestUrl     string                       `json:"request_url"`
+	RequestedAal   *AuthenticatorAssuranceLevel `json:"requested_aal,omitempty"`
+	RequiredMethod *IdentityCredentialsType     `json:"required_method,omitempty"`
 	// ReturnTo contains the requested return_to URL.
 	ReturnTo *string `json:"return_to,omitempty"`
 	// The flow type can either be `api` or `browser`.
@@ -365,6 +366,38 @@ func (o *LoginFlow) SetRequestedAal(v AuthenticatorAssuranceLevel) {
 	o.RequestedAal = &v
 }
 
+// GetRequiredMethod returns the RequiredMethod field value if set, zero value otherwise.
+func (o *LoginFlow) GetRequiredMethod() IdentityCredentialsType {
+	if o == nil || o.RequiredMethod == nil {
+		var ret IdentityCredentialsType
+		return ret
+	}
+	return *o.RequiredMethod
+}
+
+// GetRequiredMethodOk returns a tuple with the RequiredMethod field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoginFlow) GetRequiredMethodOk() (*IdentityCredentialsType, bool) {
+	if o == nil || o.RequiredMethod == nil {
+		return nil, false
+	}
+	return o.RequiredMethod, true
+}
+
+// HasRequiredMethod returns a boolean if a field has been set.
+func (o *LoginFlow) HasRequiredMethod() bool {
+	if o != nil && o.RequiredMethod != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetRequiredMethod gets a reference to the given IdentityCredentialsType and assigns it to the RequiredMethod field.
+func (o *LoginFlow) SetRequiredMethod(v IdentityCredentialsType) {
+	o.RequiredMethod = &v
+}
+
 // GetReturnTo returns the ReturnTo field value if set, zero value otherwise.
 func (o *LoginFlow) GetReturnTo() string {
 	if o == nil || o.ReturnTo == nil {
@@ -509,6 +542,9 @@ func (o LoginFlow) MarshalJSON() ([]byte, error) {
 	if o.RequestedAal != nil {
 		toSerialize["requested_aal"] = o.RequestedAal
 	}
+	if o.RequiredMethod != nil {
+		toSerialize["required_method"] = o.RequiredMethod
+	}
 	if o.ReturnTo != nil {
 		toSerialize["return_to"] = o.ReturnTo
 	}
@@ -22,6 +22,8 @@ type SessionAuthenticationMethod struct {
 	// When the authentication challenge was completed.
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 	Method      *string    `json:"method,omitempty"`
+	// The ID of the upstream identity provider used for the authentication (e.g. when using the `oidc` method).
+	Provider *string `json:"provider,omitempty"`
 }
 
 // NewSessionAuthenticationMethod instantiates a new SessionAuthenticationMethod object
@@ -137,6 +139,38 @@ func (o *SessionAuthenticationMethod) SetMethod(v string) {
 	o.Method = &v
 }
 
+// GetProvider returns the Provider field value if set, zero value otherwise.
+func (o *SessionAuthenticationMethod) GetProvider() string {
+	if o == nil || o.Provider == nil {
+		var ret string
+		return ret
+	}
+	return *o.Provider
+}
+
+// GetProviderOk returns a tuple with the Provider field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SessionAuthenticationMethod) GetProviderOk() (*string, bool) {
+	if o == nil || o.Provider == nil {
+		return nil, false
+	}
+	return o.Provider, true
+}
+
+// HasProvider returns a boolean if a field has been set.
+func (o *SessionAuthenticationMethod) HasProvider() bool {
+	if o != nil && o.Provider != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetProvider gets a reference to the given string and assigns it to the Provider field.
+func (o *SessionAuthenticationMethod) SetProvider(v string) {
+	o.Provider = &v
+}
+
 func (o SessionAuthenticationMethod) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
 	if o.Aal != nil {
@@ -148,6 +182,9 @@ func (o SessionAuthenticationMethod) MarshalJSON() ([]byte, error) {
 	if o.Method != nil {
 		toSerialize["method"] = o.Method
 	}
+	if o.Provider != nil {
+		toSerialize["provider"] = o.Provider
+	}
 	return json.Marshal(toSerialize)
 }
 
@@ -18,11 +18,28 @@ import (
 
 // UpdateLoginFlowBody - struct for UpdateLoginFlowBody
 type UpdateLoginFlowBody struct {
-	UpdateLoginFlowWithLookupSecretMethod *UpdateLoginFlowWithLookupSecretMethod
-	UpdateLoginFlowWithOidcMethod         *UpdateLoginFlowWithOidcMethod
-	UpdateLoginFlowWithPasswordMethod     *UpdateLoginFlowWithPasswordMethod
-	UpdateLoginFlowWithTotpMethod         *UpdateLoginFlowWithTotpMethod
-	UpdateLoginFlowWithWebAuthnMethod     *UpdateLoginFlowWithWebAuthnMethod
+	UpdateLoginFlowWithHotpMethod            *UpdateLoginFlowWithHotpMethod
+	UpdateLoginFlowWithIdentifierFirstMethod *UpdateLoginFlowWithIdentifierFirstMethod
+	UpdateLoginFlowWithLookupSecretMethod    *UpdateLoginFlowWithLookupSecretMethod
+	UpdateLoginFlowWithOidcMethod            *UpdateLoginFlowWithOidcMethod
+	UpdateLoginFlowWithOtpMethod             *UpdateLoginFlowWithOtpMethod
+	UpdateLoginFlowWithPasswordMethod        *UpdateLoginFlowWithPasswordMethod
+	UpdateLoginFlowWithTotpMethod            *UpdateLoginFlowWithTotpMethod
+	UpdateLoginFlowWithWebAuthnMethod        *UpdateLoginFlowWithWebAuthnMethod
+}
+
+// UpdateLoginFlowWithHotpMethodAsUpdateLoginFlowBody is a convenience function that returns UpdateLoginFlowWithHotpMethod wrapped in UpdateLoginFlowBody
+func UpdateLoginFlowWithHotpMethodAsUpdateLoginFlowBody(v *UpdateLoginFlowWithHotpMethod) UpdateLoginFlowBody {
+	return UpdateLoginFlowBody{
+		UpdateLoginFlowWithHotpMethod: v,
+	}
+}
+
+// UpdateLoginFlowWithIdentifierFirstMethodAsUpdateLoginFlowBody is a convenience function that returns UpdateLoginFlowWithIdentifierFirstMethod wrapped in UpdateLoginFlowBody
+func UpdateLoginFlowWithIdentifierFirstMethodAsUpdateLoginFlowBody(v *UpdateLoginFlowWithIdentifierFirstMethod) UpdateLoginFlowBody {
+	return UpdateLoginFlowBody{
+		UpdateLoginFlowWithIdentifierFirstMethod: v,
+	}
 }
 
 // UpdateLoginFlowWithLookupSecretMethodAsUpdateLoginFlowBody is a convenience function that returns UpdateLoginFlowWithLookupSecretMethod wrapped in UpdateLoginFlowBody
@@ -39,6 +56,13 @@ func UpdateLoginFlowWithOidcMethodAsUpdateLoginFlowBody(v *UpdateLoginFlowWithOi
 	}
 }
 
+// UpdateLoginFlowWithOtpMethodAsUpdateLoginFlowBody is a convenience function that returns UpdateLoginFlowWithOtpMethod wrapped in UpdateLoginFlowBody
+func UpdateLoginFlowWithOtpMethodAsUpdateLoginFlowBody(v *UpdateLoginFlowWithOtpMethod) UpdateLoginFlowBody {
+	return UpdateLoginFlowBody{
+		UpdateLoginFlowWithOtpMethod: v,
+	}
+}
+
 // UpdateLoginFlowWithPasswordMethodAsUpdateLoginFlowBody is a convenience function that returns UpdateLoginFlowWithPasswordMethod wrapped in UpdateLoginFlowBody
 func UpdateLoginFlowWithPasswordMethodAsUpdateLoginFlowBody(v *UpdateLoginFlowWithPasswordMethod) UpdateLoginFlowBody {
 	return UpdateLoginFlowBody{
@@ -64,6 +88,32 @@ func UpdateLoginFlowWithWebAuthnMethodAsUpdateLoginFlowBody(v *UpdateLoginFlowWi
 func (dst *UpdateLoginFlowBody) UnmarshalJSON(data []byte) error {
 	var err error
 	match := 0
+	// try to unmarshal data into UpdateLoginFlowWithHotpMethod
+	err = newStrictDecoder(data).Decode(&dst.UpdateLoginFlowWithHotpMethod)
+	if err == nil {
+		jsonUpdateLoginFlowWithHotpMethod, _ := json.Marshal(dst.UpdateLoginFlowWithHotpMethod)
+		if string(jsonUpdateLoginFlowWithHotpMethod) == "{}" { // empty struct
+			dst.UpdateLoginFlowWithHotpMethod = nil
+		} else {
+			match++
+		}
+	} else {
+		dst.UpdateLoginFlowWithHotpMethod = nil
+	}
+
+	// try to unmarshal data into UpdateLoginFlowWithIdentifierFirstMethod
+	err = newStrictDecoder(data).Decode(&dst.UpdateLoginFlowWithIdentifierFirstMethod)
+	if err == nil {
+		jsonUpdateLoginFlowWithIdentifierFirstMethod, _ := json.Marshal(dst.UpdateLoginFlowWithIdentifierFirstMethod)
+		if string(jsonUpdateLoginFlowWithIdentifierFirstMethod) == "{}" { // empty struct
+			dst.UpdateLoginFlowWithIdentifierFirstMethod = nil
+		} else {
+			match++
+		}
+	} else {
+		dst.UpdateLoginFlowWithIdentifierFirstMethod = nil
+	}
+
 	// try to unmarshal data into UpdateLoginFlowWithLookupSecretMethod
 	err = newStrictDecoder(data).Decode(&dst.UpdateLoginFlowWithLookupSecretMethod)
 	if err == nil {
@@ -90,6 +140,19 @@ func (dst *UpdateLoginFlowBody) UnmarshalJSON(data []byte) error {
 		dst.UpdateLoginFlowWithOidcMethod = nil
 	}
 
+	// try to unmarshal data into UpdateLoginFlowWithOtpMethod
+	err = newStrictDecoder(data).Decode(&dst.UpdateLoginFlowWithOtpMethod)
+	if err == nil {
+		jsonUpdateLoginFlowWithOtpMethod, _ := json.Marshal(dst.UpdateLoginFlowWithOtpMethod)
+		if string(jsonUpdateLoginFlowWithOtpMethod) == "{}" { // empty struct
+			dst.UpdateLoginFlowWithOtpMethod = nil
+		} else {
+			match++
+		}
+	} else {
+		dst.UpdateLoginFlowWithOtpMethod = nil
+	}
+
 	// try to unmarshal data into UpdateLoginFlowWithPasswordMethod
 	err = newStrictDecoder(data).Decode(&dst.UpdateLoginFlowWithPasswordMethod)
 	if err == nil {
@@ -131,8 +194,11 @@ func (dst *UpdateLoginFlowBody) UnmarshalJSON(data []byte) error {
 
 	if match > 1 { // more than 1 match
 		// reset to nil
+		dst.UpdateLoginFlowWithHotpMethod = nil
+		dst.UpdateLoginFlowWithIdentifierFirstMethod = nil
 		dst.UpdateLoginFlowWithLookupSecretMethod = nil
 		dst.UpdateLoginFlowWithOidcMethod = nil
+		dst.UpdateLoginFlowWithOtpMethod = nil
 		dst.UpdateLoginFlowWithPasswordMethod = nil
 		dst.UpdateLoginFlowWithTotpMethod = nil
 		dst.UpdateLoginFlowWithWebAuthnMethod = nil
@@ -147,6 +213,14 @@ func (dst *UpdateLoginFlowBody) UnmarshalJSON(data []byte) error {
 
 // Marshal data from the first non-nil pointers in the struct to JSON
 func (src UpdateLoginFlowBody) MarshalJSON() ([]byte, error) {
+	if src.UpdateLoginFlowWithHotpMethod != nil {
+		return json.Marshal(&src.UpdateLoginFlowWithHotpMethod)
+	}
+
+	if src.UpdateLoginFlowWithIdentifierFirstMethod != nil {
+		return json.Marshal(&src.UpdateLoginFlowWithIdentifierFirstMethod)
+	}
+
 	if src.UpdateLoginFlowWithLookupSecretMethod != nil {
 		return json.Marshal(&src.UpdateLoginFlowWithLookupSecretMethod)
 	}
@@ -155,6 +229,10 @@ func (src UpdateLoginFlowBody) MarshalJSON() ([]byte, error) {
 		return json.Marshal(&src.UpdateLoginFlowWithOidcMethod)
 	}
 
+	if src.UpdateLoginFlowWithOtpMethod != nil {
+		return json.Marshal(&src.UpdateLoginFlowWithOtpMethod)
+	}
+
 	if src.UpdateLoginFlowWithPasswordMethod != nil {
 		return json.Marshal(&src.UpdateLoginFlowWithPasswordMethod)
 	}
@@ -175,6 +253,14 @@ func (obj *UpdateLoginFlowBody) GetActualInstance() interface{} {
 	if obj == nil {
 		return nil
 	}
+	if obj.UpdateLoginFlowWithHotpMethod != nil {
+		return obj.UpdateLoginFlowWithHotpMethod
+	}
+
+	if obj.UpdateLoginFlowWithIdentifierFirstMethod != nil {
+		return obj.UpdateLoginFlowWithIdentifierFirstMethod
+	}
+
 	if obj.UpdateLoginFlowWithLookupSecretMethod != nil {
 		return obj.UpdateLoginFlowWithLookupSecretMethod
 	}
@@ -183,6 +269,10 @@ func (obj *UpdateLoginFlowBody) GetActualInstance() interface{} {
 		return obj.UpdateLoginFlowWithOidcMethod
 	}
 
+	if obj.UpdateLoginFlowWithOtpMethod != nil {
+		return obj.UpdateLoginFlowWithOtpMethod
+	}
+
 	if obj.UpdateLoginFlowWithPasswordMethod != nil {
 		return obj.UpdateLoginFlowWithPasswordMethod
 	}
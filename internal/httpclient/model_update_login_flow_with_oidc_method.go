@@ -25,7 +25,7 @@ type UpdateLoginFlowWithOidcMethod struct {
 	Provider string `json:"provider"`
 	// The identity traits. This is a placeholder for the registration flow.
 	Traits map[string]interface{} `json:"traits,omitempty"`
-	// UpstreamParameters are the parameters that are passed to the upstream identity provider.  These parameters are optional and depend on what the upstream identity provider supports. Supported parameters are: `login_hint` (string): The `login_hint` parameter suppresses the account chooser and either pre-fills the email box on the sign-in form, or selects the proper session. `hd` (string): The `hd` parameter limits the login/registration process to a Google Organization, e.g. `mycollege.edu`.
+	// UpstreamParameters are the parameters that are passed to the upstream identity provider.  These parameters are optional and depend on what the upstream identity provider supports. Supported parameters are: `login_hint` (string): The `login_hint` parameter suppresses the account chooser and either pre-fills the email box on the sign-in form, or selects the proper session. `hd` (string): The `hd` parameter limits the login/registration process to a Google Organization, e.g. `mycollege.edu`. `prompt`, `acr_values`, `max_age` and `ui_locales` (string): Only passed on if enabled in the provider's `upstream_parameters` configuration.
 	UpstreamParameters map[string]interface{} `json:"upstream_parameters,omitempty"`
 }
 
@@ -19,8 +19,8 @@ import (
 type UpdateLoginFlowWithWebAuthnMethod struct {
 	// Sending the anti-csrf token is only required for browser login flows.
 	CsrfToken *string `json:"csrf_token,omitempty"`
-	// Identifier is the email or username of the user trying to log in.
-	Identifier string `json:"identifier"`
+	// Identifier is the email or username of the user trying to log in. It can be omitted when signing in with a passkey, because the identity is then resolved from the user handle of the WebAuthn response.
+	Identifier *string `json:"identifier,omitempty"`
 	// Method should be set to \"webAuthn\" when logging in using the WebAuthn strategy.
 	Method string `json:"method"`
 	// Login a WebAuthn Security Key  This must contain the ID of the WebAuthN connection.
@@ -31,9 +31,8 @@ type UpdateLoginFlowWithWebAuthnMethod struct {
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewUpdateLoginFlowWithWebAuthnMethod(identifier string, method string) *UpdateLoginFlowWithWebAuthnMethod {
+func NewUpdateLoginFlowWithWebAuthnMethod(method string) *UpdateLoginFlowWithWebAuthnMethod {
 	this := UpdateLoginFlowWithWebAuthnMethod{}
-	this.Identifier = identifier
 	this.Method = method
 	return &this
 }
@@ -78,28 +77,36 @@ func (o *UpdateLoginFlowWithWebAuthnMethod) SetCsrfToken(v string) {
 	o.CsrfToken = &v
 }
 
-// GetIdentifier returns the Identifier field value
+// GetIdentifier returns the Identifier field value if set, zero value otherwise.
 func (o *UpdateLoginFlowWithWebAuthnMethod) GetIdentifier() string {
-	if o == nil {
+	if o == nil || o.Identifier == nil {
 		var ret string
 		return ret
 	}
-
-	return o.Identifier
+	return *o.Identifier
 }
 
-// GetIdentifierOk returns a tuple with the Identifier field value
+// GetIdentifierOk returns a tuple with the Identifier field value if set, nil otherwise
 // and a boolean to check if the value has been set.
 func (o *UpdateLoginFlowWithWebAuthnMethod) GetIdentifierOk() (*string, bool) {
-	if o == nil {
+	if o == nil || o.Identifier == nil {
 		return nil, false
 	}
-	return &o.Identifier, true
+	return o.Identifier, true
+}
+
+// HasIdentifier returns a boolean if a field has been set.
+func (o *UpdateLoginFlowWithWebAuthnMethod) HasIdentifier() bool {
+	if o != nil && o.Identifier != nil {
+		return true
+	}
+
+	return false
 }
 
-// SetIdentifier sets field value
+// SetIdentifier gets a reference to the given string and assigns it to the Identifier field.
 func (o *UpdateLoginFlowWithWebAuthnMethod) SetIdentifier(v string) {
-	o.Identifier = v
+	o.Identifier = &v
 }
 
 // GetMethod returns the Method field value
@@ -163,7 +170,7 @@ func (o UpdateLoginFlowWithWebAuthnMethod) MarshalJSON() ([]byte, error) {
 	if o.CsrfToken != nil {
 		toSerialize["csrf_token"] = o.CsrfToken
 	}
-	if true {
+	if o.Identifier != nil {
 		toSerialize["identifier"] = o.Identifier
 	}
 	if true {
@@ -18,12 +18,22 @@ import (
 
 // UpdateSettingsFlowBody - Update Settings Flow Request Body
 type UpdateSettingsFlowBody struct {
-	UpdateSettingsFlowWithLookupMethod   *UpdateSettingsFlowWithLookupMethod
-	UpdateSettingsFlowWithOidcMethod     *UpdateSettingsFlowWithOidcMethod
-	UpdateSettingsFlowWithPasswordMethod *UpdateSettingsFlowWithPasswordMethod
-	UpdateSettingsFlowWithProfileMethod  *UpdateSettingsFlowWithProfileMethod
-	UpdateSettingsFlowWithTotpMethod     *UpdateSettingsFlowWithTotpMethod
-	UpdateSettingsFlowWithWebAuthnMethod *UpdateSettingsFlowWithWebAuthnMethod
+	UpdateSettingsFlowWithHotpMethod          *UpdateSettingsFlowWithHotpMethod
+	UpdateSettingsFlowWithLookupMethod        *UpdateSettingsFlowWithLookupMethod
+	UpdateSettingsFlowWithOidcMethod          *UpdateSettingsFlowWithOidcMethod
+	UpdateSettingsFlowWithOtpMethod           *UpdateSettingsFlowWithOtpMethod
+	UpdateSettingsFlowWithPasswordMethod      *UpdateSettingsFlowWithPasswordMethod
+	UpdateSettingsFlowWithProfileMethod       *UpdateSettingsFlowWithProfileMethod
+	UpdateSettingsFlowWithTotpMethod          *UpdateSettingsFlowWithTotpMethod
+	UpdateSettingsFlowWithTrustedDeviceMethod *UpdateSettingsFlowWithTrustedDeviceMethod
+	UpdateSettingsFlowWithWebAuthnMethod      *UpdateSettingsFlowWithWebAuthnMethod
+}
+
+// UpdateSettingsFlowWithHotpMethodAsUpdateSettingsFlowBody is a convenience function that returns UpdateSettingsFlowWithHotpMethod wrapped in UpdateSettingsFlowBody
+func UpdateSettingsFlowWithHotpMethodAsUpdateSettingsFlowBody(v *UpdateSettingsFlowWithHotpMethod) UpdateSettingsFlowBody {
+	return UpdateSettingsFlowBody{
+		UpdateSettingsFlowWithHotpMethod: v,
+	}
 }
 
 // UpdateSettingsFlowWithLookupMethodAsUpdateSettingsFlowBody is a convenience function that returns UpdateSettingsFlowWithLookupMethod wrapped in UpdateSettingsFlowBody
@@ -40,6 +50,13 @@ func UpdateSettingsFlowWithOidcMethodAsUpdateSettingsFlowBody(v *UpdateSettingsF
 	}
 }
 
+// UpdateSettingsFlowWithOtpMethodAsUpdateSettingsFlowBody is a convenience function that returns UpdateSettingsFlowWithOtpMethod wrapped in UpdateSettingsFlowBody
+func UpdateSettingsFlowWithOtpMethodAsUpdateSettingsFlowBody(v *UpdateSettingsFlowWithOtpMethod) UpdateSettingsFlowBody {
+	return UpdateSettingsFlowBody{
+		UpdateSettingsFlowWithOtpMethod: v,
+	}
+}
+
 // UpdateSettingsFlowWithPasswordMethodAsUpdateSettingsFlowBody is a convenience function that returns UpdateSettingsFlowWithPasswordMethod wrapped in UpdateSettingsFlowBody
 func UpdateSettingsFlowWithPasswordMethodAsUpdateSettingsFlowBody(v *UpdateSettingsFlowWithPasswordMethod) UpdateSettingsFlowBody {
 	return UpdateSettingsFlowBody{
@@ -61,6 +78,13 @@ func UpdateSettingsFlowWithTotpMethodAsUpdateSettingsFlowBody(v *UpdateSettingsF
 	}
 }
 
+// UpdateSettingsFlowWithTrustedDeviceMethodAsUpdateSettingsFlowBody is a convenience function that returns UpdateSettingsFlowWithTrustedDeviceMethod wrapped in UpdateSettingsFlowBody
+func UpdateSettingsFlowWithTrustedDeviceMethodAsUpdateSettingsFlowBody(v *UpdateSettingsFlowWithTrustedDeviceMethod) UpdateSettingsFlowBody {
+	return UpdateSettingsFlowBody{
+		UpdateSettingsFlowWithTrustedDeviceMethod: v,
+	}
+}
+
 // UpdateSettingsFlowWithWebAuthnMethodAsUpdateSettingsFlowBody is a convenience function that returns UpdateSettingsFlowWithWebAuthnMethod wrapped in UpdateSettingsFlowBody
 func UpdateSettingsFlowWithWebAuthnMethodAsUpdateSettingsFlowBody(v *UpdateSettingsFlowWithWebAuthnMethod) UpdateSettingsFlowBody {
 	return UpdateSettingsFlowBody{
@@ -72,6 +96,19 @@ func UpdateSettingsFlowWithWebAuthnMethodAsUpdateSettingsFlowBody(v *UpdateSetti
 func (dst *UpdateSettingsFlowBody) UnmarshalJSON(data []byte) error {
 	var err error
 	match := 0
+	// try to unmarshal data into UpdateSettingsFlowWithHotpMethod
+	err = newStrictDecoder(data).Decode(&dst.UpdateSettingsFlowWithHotpMethod)
+	if err == nil {
+		jsonUpdateSettingsFlowWithHotpMethod, _ := json.Marshal(dst.UpdateSettingsFlowWithHotpMethod)
+		if string(jsonUpdateSettingsFlowWithHotpMethod) == "{}" { // empty struct
+			dst.UpdateSettingsFlowWithHotpMethod = nil
+		} else {
+			match++
+		}
+	} else {
+		dst.UpdateSettingsFlowWithHotpMethod = nil
+	}
+
 	// try to unmarshal data into UpdateSettingsFlowWithLookupMethod
 	err = newStrictDecoder(data).Decode(&dst.UpdateSettingsFlowWithLookupMethod)
 	if err == nil {
@@ -98,6 +135,19 @@ func (dst *UpdateSettingsFlowBody) UnmarshalJSON(data []byte) error {
 		dst.UpdateSettingsFlowWithOidcMethod = nil
 	}
 
+	// try to unmarshal data into UpdateSettingsFlowWithOtpMethod
+	err = newStrictDecoder(data).Decode(&dst.UpdateSettingsFlowWithOtpMethod)
+	if err == nil {
+		jsonUpdateSettingsFlowWithOtpMethod, _ := json.Marshal(dst.UpdateSettingsFlowWithOtpMethod)
+		if string(jsonUpdateSettingsFlowWithOtpMethod) == "{}" { // empty struct
+			dst.UpdateSettingsFlowWithOtpMethod = nil
+		} else {
+			match++
+		}
+	} else {
+		dst.UpdateSettingsFlowWithOtpMethod = nil
+	}
+
 	// try to unmarshal data into UpdateSettingsFlowWithPasswordMethod
 	err = newStrictDecoder(data).Decode(&dst.UpdateSettingsFlowWithPasswordMethod)
 	if err == nil {
@@ -137,6 +187,19 @@ func (dst *UpdateSettingsFlowBody) UnmarshalJSON(data []byte) error {
 		dst.UpdateSettingsFlowWithTotpMethod = nil
 	}
 
+	// try to unmarshal data into UpdateSettingsFlowWithTrustedDeviceMethod
+	err = newStrictDecoder(data).Decode(&dst.UpdateSettingsFlowWithTrustedDeviceMethod)
+	if err == nil {
+		jsonUpdateSettingsFlowWithTrustedDeviceMethod, _ := json.Marshal(dst.UpdateSettingsFlowWithTrustedDeviceMethod)
+		if string(jsonUpdateSettingsFlowWithTrustedDeviceMethod) == "{}" { // empty struct
+			dst.UpdateSettingsFlowWithTrustedDeviceMethod = nil
+		} else {
+			match++
+		}
+	} else {
+		dst.UpdateSettingsFlowWithTrustedDeviceMethod = nil
+	}
+
 	// try to unmarshal data into UpdateSettingsFlowWithWebAuthnMethod
 	err = newStrictDecoder(data).Decode(&dst.UpdateSettingsFlowWithWebAuthnMethod)
 	if err == nil {
@@ -152,11 +215,14 @@ func (dst *UpdateSettingsFlowBody) UnmarshalJSON(data []byte) error {
 
 	if match > 1 { // more than 1 match
 		// reset to nil
+		dst.UpdateSettingsFlowWithHotpMethod = nil
 		dst.UpdateSettingsFlowWithLookupMethod = nil
 		dst.UpdateSettingsFlowWithOidcMethod = nil
+		dst.UpdateSettingsFlowWithOtpMethod = nil
 		dst.UpdateSettingsFlowWithPasswordMethod = nil
 		dst.UpdateSettingsFlowWithProfileMethod = nil
 		dst.UpdateSettingsFlowWithTotpMethod = nil
+		dst.UpdateSettingsFlowWithTrustedDeviceMethod = nil
 		dst.UpdateSettingsFlowWithWebAuthnMethod = nil
 
 		return fmt.Errorf("Data matches more than one schema in oneOf(UpdateSettingsFlowBody)")
@@ -169,6 +235,10 @@ func (dst *UpdateSettingsFlowBody) UnmarshalJSON(data []byte) error {
 
 // Marshal data from the first non-nil pointers in the struct to JSON
 func (src UpdateSettingsFlowBody) MarshalJSON() ([]byte, error) {
+	if src.UpdateSettingsFlowWithHotpMethod != nil {
+		return json.Marshal(&src.UpdateSettingsFlowWithHotpMethod)
+	}
+
 	if src.UpdateSettingsFlowWithLookupMethod != nil {
 		return json.Marshal(&src.UpdateSettingsFlowWithLookupMethod)
 	}
@@ -177,6 +247,10 @@ func (src UpdateSettingsFlowBody) MarshalJSON() ([]byte, error) {
 		return json.Marshal(&src.UpdateSettingsFlowWithOidcMethod)
 	}
 
+	if src.UpdateSettingsFlowWithOtpMethod != nil {
+		return json.Marshal(&src.UpdateSettingsFlowWithOtpMethod)
+	}
+
 	if src.UpdateSettingsFlowWithPasswordMethod != nil {
 		return json.Marshal(&src.UpdateSettingsFlowWithPasswordMethod)
 	}
@@ -189,6 +263,10 @@ func (src UpdateSettingsFlowBody) MarshalJSON() ([]byte, error) {
 		return json.Marshal(&src.UpdateSettingsFlowWithTotpMethod)
 	}
 
+	if src.UpdateSettingsFlowWithTrustedDeviceMethod != nil {
+		return json.Marshal(&src.UpdateSettingsFlowWithTrustedDeviceMethod)
+	}
+
 	if src.UpdateSettingsFlowWithWebAuthnMethod != nil {
 		return json.Marshal(&src.UpdateSettingsFlowWithWebAuthnMethod)
 	}
@@ -201,6 +279,10 @@ func (obj *UpdateSettingsFlowBody) GetActualInstance() interface{} {
 	if obj == nil {
 		return nil
 	}
+	if obj.UpdateSettingsFlowWithHotpMethod != nil {
+		return obj.UpdateSettingsFlowWithHotpMethod
+	}
+
 	if obj.UpdateSettingsFlowWithLookupMethod != nil {
 		return obj.UpdateSettingsFlowWithLookupMethod
 	}
@@ -209,6 +291,10 @@ func (obj *UpdateSettingsFlowBody) GetActualInstance() interface{} {
 		return obj.UpdateSettingsFlowWithOidcMethod
 	}
 
+	if obj.UpdateSettingsFlowWithOtpMethod != nil {
+		return obj.UpdateSettingsFlowWithOtpMethod
+	}
+
 	if obj.UpdateSettingsFlowWithPasswordMethod != nil {
 		return obj.UpdateSettingsFlowWithPasswordMethod
 	}
@@ -221,6 +307,10 @@ func (obj *UpdateSettingsFlowBody) GetActualInstance() interface{} {
 		return obj.UpdateSettingsFlowWithTotpMethod
 	}
 
+	if obj.UpdateSettingsFlowWithTrustedDeviceMethod != nil {
+		return obj.UpdateSettingsFlowWithTrustedDeviceMethod
+	}
+
 	if obj.UpdateSettingsFlowWithWebAuthnMethod != nil {
 		return obj.UpdateSettingsFlowWithWebAuthnMethod
 	}
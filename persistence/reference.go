@@ -26,6 +26,8 @@ import (
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/strategy/code"
 	"github.com/ory/kratos/selfservice/strategy/link"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/selfservice/strategy/otp"
 	"github.com/ory/kratos/session"
 )
 
@@ -49,6 +51,8 @@ type Persister interface {
 	link.VerificationTokenPersister
 	code.RecoveryCodePersister
 	code.VerificationCodePersister
+	oidc.ProviderPersister
+	otp.CodeCounterPersister
 
 	CleanupDatabase(context.Context, time.Duration, time.Duration, int) error
 	Close(context.Context) error
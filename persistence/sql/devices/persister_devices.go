@@ -41,5 +41,8 @@ func (p DevicePersister) WithNetworkID(nid uuid.UUID) session.DevicePersister {
 
 func (p *DevicePersister) CreateDevice(ctx context.Context, d *session.Device) error {
 	d.NID = p.NetworkID(ctx)
+	if d.IPAddress != nil {
+		d.IPAddressKey = session.IPAddressKey(*d.IPAddress)
+	}
 	return sqlcon.HandleError(popx.GetConnection(ctx, p.c.WithContext(ctx)).Create(d))
 }
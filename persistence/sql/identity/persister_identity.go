@@ -6,6 +6,7 @@ package identity
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"fmt"
 	"strings"
 	"time"
@@ -181,6 +182,103 @@ func (p *IdentityPersister) FindByCredentialsIdentifier(ctx context.Context, ct
 	return i.CopyWithoutCredentials(), creds, nil
 }
 
+func (p *IdentityPersister) FindByWebAuthnUserHandle(ctx context.Context, userHandle []byte) (_ *identity.Identity, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.FindByWebAuthnUserHandle")
+	defer otelx.End(span, &err)
+
+	if len(userHandle) == 0 {
+		return nil, errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	// The user handle is stored base64 encoded in the JSON config, which each dialect queries differently.
+	conn := p.GetConnection(ctx)
+	encoded := base64.StdEncoding.EncodeToString(userHandle)
+	var clause string
+	switch conn.Dialect.Name() {
+	case "postgres", "cockroach":
+		clause = "ic.config->>'user_handle' = ?"
+	case "mysql":
+		clause = "JSON_UNQUOTE(JSON_EXTRACT(ic.config, '$.user_handle')) = ?"
+	default:
+		// The standard base64 alphabet does not contain any LIKE wildcards. Whitespace is removed because the
+		// config is stored as it was written.
+		clause = "REPLACE(ic.config, ' ', '') LIKE ?"
+		encoded = fmt.Sprintf(`%%"user_handle":%q%%`, encoded)
+	}
+
+	var find struct {
+		IdentityID uuid.UUID `db:"identity_id"`
+	}
+
+	nid := p.NetworkID(ctx)
+	//#nosec G201 -- clause is one of the static expressions above
+	if err := conn.RawQuery(fmt.Sprintf(`
+		SELECT
+			ic.identity_id
+		FROM identity_credentials ic
+				INNER JOIN identity_credential_types ict
+					ON ic.identity_credential_type_id = ict.id
+		WHERE %s
+		AND ic.nid = ?
+		AND ict.name = ?
+		LIMIT 1`, clause),
+		encoded,
+		nid,
+		identity.CredentialsTypeWebAuthn,
+	).First(&find); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	i, err := p.GetIdentityConfidential(ctx, find.IdentityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.CopyWithoutCredentials(), nil
+}
+
+func (p *IdentityPersister) CompareAndSwapCredentialsConfig(ctx context.Context, identityID uuid.UUID, ct identity.CredentialsType, expected, updated sqlxx.JSONRawMessage) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CompareAndSwapCredentialsConfig")
+	defer otelx.End(span, &err)
+
+	t, err := p.findIdentityCredentialsType(ctx, ct)
+	if err != nil {
+		return err
+	}
+
+	// The config is stored as JSON, which each dialect compares differently.
+	conn := p.GetConnection(ctx)
+	var clause string
+	switch conn.Dialect.Name() {
+	case "postgres", "cockroach":
+		clause = "config = CAST(? AS jsonb)"
+	case "mysql":
+		clause = "config = CAST(? AS JSON)"
+	default:
+		clause = "config = ?"
+	}
+
+	//#nosec G201 -- clause is one of the static expressions above
+	count, err := conn.RawQuery(fmt.Sprintf(
+		"UPDATE %s SET config = ?, updated_at = ? WHERE identity_id = ? AND identity_credential_type_id = ? AND nid = ? AND %s",
+		new(identity.Credentials).TableName(ctx),
+		clause,
+	),
+		updated,
+		time.Now().UTC(),
+		identityID,
+		t.ID,
+		p.NetworkID(ctx),
+		expected,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
+
 func (p *IdentityPersister) findIdentityCredentialsType(ctx context.Context, ct identity.CredentialsType) (_ *identity.CredentialsTypeTable, err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.findIdentityCredentialsType")
 	defer otelx.End(span, &err)
@@ -391,6 +489,10 @@ func (p *IdentityPersister) CreateIdentity(ctx context.Context, i *identity.Iden
 		return err
 	}
 
+	if err := i.UpdateLookupSecretsRemaining(); err != nil {
+		return err
+	}
+
 	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
 		if err := tx.Create(i); err != nil {
 			return sqlcon.HandleError(err)
@@ -553,6 +655,10 @@ func (p *IdentityPersister) UpdateIdentity(ctx context.Context, i *identity.Iden
 		return err
 	}
 
+	if err := i.UpdateLookupSecretsRemaining(); err != nil {
+		return err
+	}
+
 	i.NID = p.NetworkID(ctx)
 	return sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
 		if count, err := tx.Where("id = ? AND nid = ?", i.ID, p.NetworkID(ctx)).Count(i); err != nil {
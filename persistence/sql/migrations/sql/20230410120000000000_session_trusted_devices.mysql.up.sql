@@ -0,0 +1,15 @@
+CREATE TABLE `session_trusted_devices`
+(
+  `id`          char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  `identity_id` char(36) NOT NULL,
+  `ip_address`  VARCHAR(50)  DEFAULT '',
+  `user_agent`  VARCHAR(512) DEFAULT '',
+  `nid`         char(36) NOT NULL,
+  `expires_at`  DATETIME NOT NULL,
+  `created_at`  DATETIME NOT NULL,
+  `updated_at`  DATETIME NOT NULL,
+  FOREIGN KEY (`identity_id`) REFERENCES `identities` (`id`) ON DELETE cascade,
+  FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON DELETE cascade
+) ENGINE = InnoDB;
+CREATE INDEX `session_trusted_devices_identity_id_nid_idx` ON `session_trusted_devices` (`identity_id`, `nid`);
@@ -0,0 +1,14 @@
+CREATE TABLE "session_trusted_devices"
+(
+  "id"          UUID PRIMARY KEY NOT NULL,
+  "identity_id" UUID             NOT NULL,
+  "ip_address"  VARCHAR(50)  DEFAULT '',
+  "user_agent"  VARCHAR(512) DEFAULT '',
+  "nid"         UUID             NOT NULL,
+  "expires_at"  timestamp        NOT NULL,
+  "created_at"  timestamp        NOT NULL,
+  "updated_at"  timestamp        NOT NULL,
+  CONSTRAINT "session_trusted_devices_identity_id_fk" FOREIGN KEY ("identity_id") REFERENCES "identities" ("id") ON DELETE cascade,
+  CONSTRAINT "session_trusted_devices_nid_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON DELETE cascade
+);
+CREATE INDEX "session_trusted_devices_identity_id_nid_idx" ON "session_trusted_devices" (identity_id, nid);
@@ -0,0 +1 @@
+DROP TABLE "session_revocation_notifications";
@@ -0,0 +1,16 @@
+CREATE TABLE `session_revocation_notifications`
+(
+  `id`          char(36)    NOT NULL,
+  PRIMARY KEY (`id`),
+  `session_id`  char(36)    NOT NULL,
+  `identity_id` char(36)    NOT NULL,
+  `receiver`    TEXT        NOT NULL,
+  `status`      VARCHAR(16) NOT NULL,
+  `send_count`  INT         NOT NULL DEFAULT 0,
+  `last_error`  TEXT        NOT NULL,
+  `nid`         char(36)    NOT NULL,
+  `created_at`  DATETIME    NOT NULL,
+  `updated_at`  DATETIME    NOT NULL,
+  FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON DELETE cascade
+) ENGINE = InnoDB;
+CREATE INDEX `session_revocation_notifications_nid_status_created_at_idx` ON `session_revocation_notifications` (`nid`, `status`, `created_at`);
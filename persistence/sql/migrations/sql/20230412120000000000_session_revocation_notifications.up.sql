@@ -0,0 +1,15 @@
+CREATE TABLE "session_revocation_notifications"
+(
+  "id"          UUID PRIMARY KEY NOT NULL,
+  "session_id"  UUID             NOT NULL,
+  "identity_id" UUID             NOT NULL,
+  "receiver"    TEXT             NOT NULL,
+  "status"      VARCHAR(16)      NOT NULL,
+  "send_count"  INTEGER          NOT NULL DEFAULT 0,
+  "last_error"  TEXT             NOT NULL DEFAULT '',
+  "nid"         UUID             NOT NULL,
+  "created_at"  timestamp        NOT NULL,
+  "updated_at"  timestamp        NOT NULL,
+  CONSTRAINT "session_revocation_notifications_nid_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON DELETE cascade
+);
+CREATE INDEX "session_revocation_notifications_nid_status_created_at_idx" ON "session_revocation_notifications" (nid, status, created_at);
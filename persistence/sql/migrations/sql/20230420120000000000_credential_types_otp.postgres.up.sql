@@ -0,0 +1 @@
+INSERT INTO identity_credential_types (id, name) SELECT '3a9b9c2e-2f6c-4e63-9d34-8f3f5c0a7b41', 'otp' WHERE NOT EXISTS ( SELECT * FROM identity_credential_types WHERE name = 'otp');
@@ -0,0 +1,2 @@
+ALTER TABLE `identities`
+DROP `mfa_enrollment_deadline`;
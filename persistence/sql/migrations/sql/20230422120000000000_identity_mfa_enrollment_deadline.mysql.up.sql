@@ -0,0 +1,2 @@
+ALTER TABLE `identities`
+ADD `mfa_enrollment_deadline` DATETIME NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE identities
+DROP lookup_secrets_remaining;
@@ -0,0 +1,2 @@
+ALTER TABLE identities
+ADD lookup_secrets_remaining integer NULL;
@@ -0,0 +1 @@
+INSERT INTO identity_credential_types (id, name) SELECT 'c1f8a0d4-6b2e-4f5a-9e7d-2a4b8c6d0e13', 'hotp' WHERE NOT EXISTS ( SELECT * FROM identity_credential_types WHERE name = 'hotp');
@@ -0,0 +1,12 @@
+CREATE TABLE `selfservice_oidc_providers`
+(
+  `id`          char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  `provider_id` VARCHAR(255) NOT NULL,
+  `config`      TEXT NOT NULL,
+  `nid`         char(36) NOT NULL,
+  `created_at`  DATETIME NOT NULL,
+  `updated_at`  DATETIME NOT NULL,
+  FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON DELETE cascade
+) ENGINE = InnoDB;
+CREATE UNIQUE INDEX `selfservice_oidc_providers_nid_provider_id_uq_idx` ON `selfservice_oidc_providers` (`nid`, `provider_id`);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS session_devices_nid_ip_address_key_idx;
+ALTER TABLE session_devices DROP ip_address_key;
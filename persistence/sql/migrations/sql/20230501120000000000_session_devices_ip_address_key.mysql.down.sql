@@ -0,0 +1,2 @@
+DROP INDEX `session_devices_nid_ip_address_key_idx` ON `session_devices`;
+ALTER TABLE `session_devices` DROP `ip_address_key`;
@@ -0,0 +1,2 @@
+ALTER TABLE `session_devices` ADD `ip_address_key` VARCHAR(32) NULL;
+CREATE INDEX `session_devices_nid_ip_address_key_idx` ON `session_devices` (`nid`, `ip_address_key`);
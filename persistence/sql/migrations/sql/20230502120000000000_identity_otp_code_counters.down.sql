@@ -0,0 +1 @@
+DROP TABLE "identity_otp_code_counters";
@@ -0,0 +1,16 @@
+CREATE TABLE `identity_otp_code_counters`
+(
+  `id`          char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  `identity_id` char(36) NOT NULL,
+  `address`     VARCHAR(400) NOT NULL,
+  `code_id`     char(36) NOT NULL,
+  `sent_at`     DATETIME NOT NULL,
+  `attempts`    INT NOT NULL DEFAULT 0,
+  `nid`         char(36) NOT NULL,
+  `created_at`  DATETIME NOT NULL,
+  `updated_at`  DATETIME NOT NULL,
+  FOREIGN KEY (`identity_id`) REFERENCES `identities` (`id`) ON DELETE cascade,
+  FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON DELETE cascade
+) ENGINE = InnoDB;
+CREATE UNIQUE INDEX `identity_otp_code_counters_nid_identity_id_address_uq_idx` ON `identity_otp_code_counters` (`nid`, `identity_id`, `address`);
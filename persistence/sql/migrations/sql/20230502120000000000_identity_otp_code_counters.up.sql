@@ -0,0 +1,15 @@
+CREATE TABLE "identity_otp_code_counters"
+(
+  "id"          UUID PRIMARY KEY NOT NULL,
+  "identity_id" UUID             NOT NULL,
+  "address"     VARCHAR(400)     NOT NULL,
+  "code_id"     UUID             NOT NULL,
+  "sent_at"     timestamp        NOT NULL,
+  "attempts"    INT              NOT NULL DEFAULT 0,
+  "nid"         UUID             NOT NULL,
+  "created_at"  timestamp        NOT NULL,
+  "updated_at"  timestamp        NOT NULL,
+  CONSTRAINT "identity_otp_code_counters_identity_id_fk" FOREIGN KEY ("identity_id") REFERENCES "identities" ("id") ON DELETE cascade,
+  CONSTRAINT "identity_otp_code_counters_nid_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON DELETE cascade
+);
+CREATE UNIQUE INDEX "identity_otp_code_counters_nid_identity_id_address_uq_idx" ON "identity_otp_code_counters" (nid, identity_id, address);
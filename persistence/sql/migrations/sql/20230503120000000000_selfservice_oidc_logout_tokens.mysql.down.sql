@@ -0,0 +1 @@
+DROP TABLE selfservice_oidc_logout_tokens;
@@ -0,0 +1,14 @@
+CREATE TABLE `selfservice_oidc_logout_tokens`
+(
+  `id`          char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  `provider_id` VARCHAR(255) NOT NULL,
+  `jti`         VARCHAR(255) NOT NULL,
+  `expires_at`  DATETIME NOT NULL,
+  `nid`         char(36) NOT NULL,
+  `created_at`  DATETIME NOT NULL,
+  `updated_at`  DATETIME NOT NULL,
+  FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON DELETE cascade
+) ENGINE = InnoDB;
+CREATE UNIQUE INDEX `selfservice_oidc_logout_tokens_nid_provider_id_jti_uq_idx` ON `selfservice_oidc_logout_tokens` (`nid`, `provider_id`, `jti`);
+CREATE INDEX `selfservice_oidc_logout_tokens_nid_expires_at_idx` ON `selfservice_oidc_logout_tokens` (`nid`, `expires_at`);
@@ -0,0 +1,13 @@
+CREATE TABLE "selfservice_oidc_logout_tokens"
+(
+  "id"          UUID PRIMARY KEY NOT NULL,
+  "provider_id" VARCHAR(255)     NOT NULL,
+  "jti"         VARCHAR(255)     NOT NULL,
+  "expires_at"  timestamp        NOT NULL,
+  "nid"         UUID             NOT NULL,
+  "created_at"  timestamp        NOT NULL,
+  "updated_at"  timestamp        NOT NULL,
+  CONSTRAINT "selfservice_oidc_logout_tokens_nid_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON DELETE cascade
+);
+CREATE UNIQUE INDEX "selfservice_oidc_logout_tokens_nid_provider_id_jti_uq_idx" ON "selfservice_oidc_logout_tokens" (nid, provider_id, jti);
+CREATE INDEX "selfservice_oidc_logout_tokens_nid_expires_at_idx" ON "selfservice_oidc_logout_tokens" (nid, expires_at);
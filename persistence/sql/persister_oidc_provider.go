@@ -0,0 +1,139 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/persistence/sql/update"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+)
+
+var _ oidc.ProviderPersister = new(Persister)
+
+func (p *Persister) CreateOIDCProvider(ctx context.Context, provider *oidc.StoredProvider) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateOIDCProvider")
+	defer otelx.End(span, &err)
+
+	provider.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(provider))
+}
+
+func (p *Persister) GetOIDCProvider(ctx context.Context, id string) (_ *oidc.StoredProvider, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetOIDCProvider")
+	defer otelx.End(span, &err)
+
+	var provider oidc.StoredProvider
+	if err := p.GetConnection(ctx).Where("provider_id = ? AND nid = ?", id, p.NetworkID(ctx)).First(&provider); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &provider, nil
+}
+
+func (p *Persister) ListOIDCProviders(ctx context.Context) (_ []oidc.StoredProvider, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListOIDCProviders")
+	defer otelx.End(span, &err)
+
+	providers := make([]oidc.StoredProvider, 0)
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", p.NetworkID(ctx)).
+		Order("provider_id ASC").
+		All(&providers); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return providers, nil
+}
+
+func (p *Persister) UpdateOIDCProvider(ctx context.Context, provider *oidc.StoredProvider) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateOIDCProvider")
+	defer otelx.End(span, &err)
+
+	cp := *provider
+	cp.NID = p.NetworkID(ctx)
+	cp.UpdatedAt = time.Now().UTC()
+	return update.Generic(ctx, p.GetConnection(ctx), p.r.Tracer(ctx).Tracer(), cp)
+}
+
+func (p *Persister) DeleteOIDCProvider(ctx context.Context, id string) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteOIDCProvider")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE provider_id = ? AND nid = ?",
+		new(oidc.StoredProvider).TableName(ctx),
+	),
+		id,
+		p.NetworkID(ctx),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
+
+func (p *Persister) CountOIDCProviderLinks(ctx context.Context, id string) (_ int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CountOIDCProviderLinks")
+	defer otelx.End(span, &err)
+
+	// The identifiers of OpenID Connect credentials are prefixed with the provider ID, which may contain LIKE
+	// wildcards.
+	prefix := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(id) + ":%"
+
+	var result struct {
+		Count int `db:"count"`
+	}
+	if err := p.GetConnection(ctx).RawQuery(`
+		SELECT
+			COUNT(DISTINCT ici.identity_credential_id) AS count
+		FROM identity_credential_identifiers ici
+				INNER JOIN identity_credential_types ict
+					ON ici.identity_credential_type_id = ict.id
+		WHERE ici.identifier LIKE ? ESCAPE '!'
+		AND ici.nid = ?
+		AND ict.name = ?`,
+		prefix,
+		p.NetworkID(ctx),
+		identity.CredentialsTypeOIDC,
+	).First(&result); err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+	return result.Count, nil
+}
+
+func (p *Persister) UseOIDCLogoutToken(ctx context.Context, provider, jti string, expiresAt time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UseOIDCLogoutToken")
+	defer otelx.End(span, &err)
+
+	// Tokens which expired can no longer be replayed and are removed.
+	//#nosec G201 -- TableName is static
+	if err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE expires_at <= ? AND nid = ?",
+		new(oidc.UsedLogoutToken).TableName(ctx),
+	),
+		time.Now().UTC(),
+		p.NetworkID(ctx),
+	).Exec(); err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(&oidc.UsedLogoutToken{
+		ProviderID: provider,
+		JTI:        jti,
+		ExpiresAt:  expiresAt.UTC(),
+		NID:        p.NetworkID(ctx),
+	}))
+}
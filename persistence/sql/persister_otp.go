@@ -0,0 +1,105 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/selfservice/strategy/otp"
+)
+
+var _ otp.CodeCounterPersister = new(Persister)
+
+func (p *Persister) GetOTPCodeCounter(ctx context.Context, identityID uuid.UUID, address string) (_ *otp.CodeCounter, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetOTPCodeCounter")
+	defer otelx.End(span, &err)
+
+	var c otp.CodeCounter
+	if err := p.GetConnection(ctx).Where("identity_id = ? AND address = ? AND nid = ?", identityID, address, p.NetworkID(ctx)).First(&c); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &c, nil
+}
+
+func (p *Persister) ResetOTPCodeCounter(ctx context.Context, c *otp.CodeCounter, notBefore time.Time) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ResetOTPCodeCounter")
+	defer otelx.End(span, &err)
+
+	c.NID = p.NetworkID(ctx)
+	c.Attempts = 0
+
+	// The condition on sent_at makes sure that only one of several concurrent requests sends a code.
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET code_id = ?, sent_at = ?, attempts = 0, updated_at = ? WHERE identity_id = ? AND address = ? AND nid = ? AND sent_at <= ?",
+		c.TableName(ctx),
+	),
+		c.CodeID,
+		c.SentAt,
+		time.Now().UTC(),
+		c.IdentityID,
+		c.Address,
+		c.NID,
+		notBefore,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count > 0 {
+		return nil
+	}
+
+	if _, err := p.GetOTPCodeCounter(ctx, c.IdentityID, c.Address); err == nil {
+		return errors.WithStack(otp.ErrCodeThrottled)
+	} else if !errors.Is(err, sqlcon.ErrNoRows) {
+		return err
+	}
+
+	if err := sqlcon.HandleError(p.GetConnection(ctx).Create(c)); errors.Is(err, sqlcon.ErrUniqueViolation) {
+		// A code was sent to the address by a concurrent request.
+		return errors.WithStack(otp.ErrCodeThrottled)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *Persister) IncrementOTPCodeAttempts(ctx context.Context, identityID uuid.UUID, address string, codeID uuid.UUID) (attempts int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.IncrementOTPCodeAttempts")
+	defer otelx.End(span, &err)
+
+	table := new(otp.CodeCounter).TableName(ctx)
+	nid := p.NetworkID(ctx)
+	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		//#nosec G201 -- TableName is static
+		count, err := tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET attempts = attempts + 1 WHERE identity_id = ? AND address = ? AND code_id = ? AND nid = ?", table),
+			identityID, address, codeID, nid,
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		} else if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows)
+		}
+
+		// Because MySQL does not support "RETURNING" clauses, but we need the updated `attempts` later on.
+		//#nosec G201 -- TableName is static
+		return sqlcon.HandleError(tx.RawQuery(fmt.Sprintf(
+			"SELECT attempts FROM %s WHERE identity_id = ? AND address = ? AND nid = ?", table),
+			identityID, address, nid,
+		).First(&attempts))
+	}); err != nil {
+		return 0, err
+	}
+
+	return attempts, nil
+}
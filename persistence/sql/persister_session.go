@@ -5,7 +5,9 @@ package sql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gobuffalo/pop/v6"
@@ -13,6 +15,8 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/ory/herodot"
+
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/session"
 	"github.com/ory/x/otelx"
@@ -60,7 +64,7 @@ func (p *Persister) GetSession(ctx context.Context, sid uuid.UUID, expandables s
 	return &s, nil
 }
 
-func (p *Persister) ListSessions(ctx context.Context, active *bool, paginatorOpts []keysetpagination.Option, expandables session.Expandables) (_ []session.Session, _ int64, _ *keysetpagination.Paginator, err error) {
+func (p *Persister) ListSessions(ctx context.Context, filter *session.ListSessionsFilter, paginatorOpts []keysetpagination.Option, expandables session.Expandables) (_ []session.Session, _ int64, _ *keysetpagination.Paginator, err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListSessions")
 	defer otelx.End(span, &err)
 
@@ -75,12 +79,12 @@ func (p *Persister) ListSessions(ctx context.Context, active *bool, paginatorOpt
 
 	if err := p.Transaction(ctx, func(ctx context.Context, c *pop.Connection) error {
 		q := c.Where("nid = ?", nid)
-		if active != nil {
-			if *active {
-				q.Where("active = ? AND expires_at >= ?", *active, time.Now().UTC())
-			} else {
-				q.Where("(active = ? OR expires_at < ?)", *active, time.Now().UTC())
-			}
+		clauses, args, err := p.sessionFilterClauses(ctx, c, filter)
+		if err != nil {
+			return err
+		}
+		for i := range clauses {
+			q = q.Where(clauses[i], args[i]...)
 		}
 
 		// Get the total count of matching items
@@ -217,40 +221,44 @@ func (p *Persister) DeleteSession(ctx context.Context, sid uuid.UUID) (err error
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteSession")
 	defer otelx.End(span, &err)
 
-	nid := p.NetworkID(ctx)
-	//#nosec G201 -- TableName is static
-	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE id = ? AND nid = ?", new(session.Session).TableName(ctx)),
-		sid,
-		nid,
-	).ExecWithCount()
-	if err != nil {
-		return sqlcon.HandleError(err)
-	}
-	if count == 0 {
-		return errors.WithStack(sqlcon.ErrNoRows)
-	}
-	return nil
+	return p.withRevocationNotifications(ctx, func(ctx context.Context) error {
+		nid := p.NetworkID(ctx)
+		//#nosec G201 -- TableName is static
+		count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE id = ? AND nid = ?", new(session.Session).TableName(ctx)),
+			sid,
+			nid,
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+		if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows)
+		}
+		return nil
+	}, "id = ?", sid)
 }
 
 func (p *Persister) DeleteSessionsByIdentity(ctx context.Context, identityID uuid.UUID) (err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteSessionsByIdentity")
 	defer otelx.End(span, &err)
 
-	//#nosec G201 -- TableName is static
-	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
-		"DELETE FROM %s WHERE identity_id = ? AND nid = ?",
-		new(session.Session).TableName(ctx),
-	),
-		identityID,
-		p.NetworkID(ctx),
-	).ExecWithCount()
-	if err != nil {
-		return sqlcon.HandleError(err)
-	}
-	if count == 0 {
-		return errors.WithStack(sqlcon.ErrNoRows)
-	}
-	return nil
+	return p.withRevocationNotifications(ctx, func(ctx context.Context) error {
+		//#nosec G201 -- TableName is static
+		count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+			"DELETE FROM %s WHERE identity_id = ? AND nid = ?",
+			new(session.Session).TableName(ctx),
+		),
+			identityID,
+			p.NetworkID(ctx),
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+		if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows)
+		}
+		return nil
+	}, "identity_id = ?", identityID)
 }
 
 func (p *Persister) GetSessionByToken(ctx context.Context, token string, expand session.Expandables, identityExpand identity.Expandables) (res *session.Session, err error) {
@@ -302,42 +310,46 @@ func (p *Persister) DeleteSessionByToken(ctx context.Context, token string) (err
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteSessionByToken")
 	defer otelx.End(span, &err)
 
-	//#nosec G201 -- TableName is static
-	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
-		"DELETE FROM %s WHERE token = ? AND nid = ?",
-		new(session.Session).TableName(ctx),
-	),
-		token,
-		p.NetworkID(ctx),
-	).ExecWithCount()
-	if err != nil {
-		return sqlcon.HandleError(err)
-	}
-	if count == 0 {
-		return errors.WithStack(sqlcon.ErrNoRows)
-	}
-	return nil
+	return p.withRevocationNotifications(ctx, func(ctx context.Context) error {
+		//#nosec G201 -- TableName is static
+		count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+			"DELETE FROM %s WHERE token = ? AND nid = ?",
+			new(session.Session).TableName(ctx),
+		),
+			token,
+			p.NetworkID(ctx),
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+		if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows)
+		}
+		return nil
+	}, "token = ?", token)
 }
 
 func (p *Persister) RevokeSessionByToken(ctx context.Context, token string) (err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeSessionByToken")
 	defer otelx.End(span, &err)
 
-	//#nosec G201 -- TableName is static
-	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
-		"UPDATE %s SET active = false WHERE token = ? AND nid = ?",
-		new(session.Session).TableName(ctx),
-	),
-		token,
-		p.NetworkID(ctx),
-	).ExecWithCount()
-	if err != nil {
-		return sqlcon.HandleError(err)
-	}
-	if count == 0 {
-		return errors.WithStack(sqlcon.ErrNoRows)
-	}
-	return nil
+	return p.withRevocationNotifications(ctx, func(ctx context.Context) error {
+		//#nosec G201 -- TableName is static
+		count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+			"UPDATE %s SET active = false WHERE token = ? AND nid = ?",
+			new(session.Session).TableName(ctx),
+		),
+			token,
+			p.NetworkID(ctx),
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+		if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows)
+		}
+		return nil
+	}, "token = ?", token)
 }
 
 // RevokeSessionById revokes a given session
@@ -345,21 +357,23 @@ func (p *Persister) RevokeSessionById(ctx context.Context, sID uuid.UUID) (err e
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeSessionById")
 	defer otelx.End(span, &err)
 
-	//#nosec G201 -- TableName is static
-	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
-		"UPDATE %s SET active = false WHERE id = ? AND nid = ?",
-		new(session.Session).TableName(ctx),
-	),
-		sID,
-		p.NetworkID(ctx),
-	).ExecWithCount()
-	if err != nil {
-		return sqlcon.HandleError(err)
-	}
-	if count == 0 {
-		return errors.WithStack(sqlcon.ErrNoRows)
-	}
-	return nil
+	return p.withRevocationNotifications(ctx, func(ctx context.Context) error {
+		//#nosec G201 -- TableName is static
+		count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+			"UPDATE %s SET active = false WHERE id = ? AND nid = ?",
+			new(session.Session).TableName(ctx),
+		),
+			sID,
+			p.NetworkID(ctx),
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+		if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows)
+		}
+		return nil
+	}, "id = ?", sID)
 }
 
 // RevokeSession revokes a given session. If the session does not exist or was not modified,
@@ -368,19 +382,21 @@ func (p *Persister) RevokeSession(ctx context.Context, iID, sID uuid.UUID) (err
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeSession")
 	defer otelx.End(span, &err)
 
-	//#nosec G201 -- TableName is static
-	err = p.GetConnection(ctx).RawQuery(fmt.Sprintf(
-		"UPDATE %s SET active = false WHERE id = ? AND identity_id = ? AND nid = ?",
-		new(session.Session).TableName(ctx),
-	),
-		sID,
-		iID,
-		p.NetworkID(ctx),
-	).Exec()
-	if err != nil {
-		return sqlcon.HandleError(err)
-	}
-	return nil
+	return p.withRevocationNotifications(ctx, func(ctx context.Context) error {
+		//#nosec G201 -- TableName is static
+		err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+			"UPDATE %s SET active = false WHERE id = ? AND identity_id = ? AND nid = ?",
+			new(session.Session).TableName(ctx),
+		),
+			sID,
+			iID,
+			p.NetworkID(ctx),
+		).Exec()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+		return nil
+	}, "id = ? AND identity_id = ?", sID, iID)
 }
 
 // RevokeSessionsIdentityExcept marks all except the given session of an identity inactive.
@@ -388,21 +404,196 @@ func (p *Persister) RevokeSessionsIdentityExcept(ctx context.Context, iID, sID u
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeSessionsIdentityExcept")
 	defer otelx.End(span, &err)
 
+	if err := p.withRevocationNotifications(ctx, func(ctx context.Context) error {
+		//#nosec G201 -- TableName is static
+		count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+			"UPDATE %s SET active = false WHERE identity_id = ? AND id != ? AND nid = ?",
+			new(session.Session).TableName(ctx),
+		),
+			iID,
+			sID,
+			p.NetworkID(ctx),
+		).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+		res = count
+		return nil
+	}, "identity_id = ? AND id != ?", iID, sID); err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// HasDevice returns true if any session of the identity was used from the given device.
+func (p *Persister) HasDevice(ctx context.Context, iID uuid.UUID, d *session.Device) (_ bool, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.HasDevice")
+	defer otelx.End(span, &err)
+
+	nid := p.NetworkID(ctx)
 	//#nosec G201 -- TableName is static
-	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
-		"UPDATE %s SET active = false WHERE identity_id = ? AND id != ? AND nid = ?",
-		new(session.Session).TableName(ctx),
-	),
-		iID,
-		sID,
-		p.NetworkID(ctx),
-	).ExecWithCount()
+	q := p.GetConnection(ctx).Where(fmt.Sprintf("nid = ? AND session_id IN (SELECT id FROM %s WHERE identity_id = ? AND nid = ?)", new(session.Session).TableName(ctx)), nid, iID, nid)
+
+	if d.IPAddress == nil {
+		q = q.Where("ip_address IS NULL")
+	} else {
+		q = q.Where("ip_address = ?", *d.IPAddress)
+	}
+
+	if d.UserAgent == nil {
+		q = q.Where("user_agent IS NULL")
+	} else {
+		q = q.Where("user_agent = ?", stringsx.TruncateByteLen(*d.UserAgent, SessionDeviceUserAgentMaxLength))
+	}
+
+	exists, err := q.Exists(new(session.Device))
+	if err != nil {
+		return false, sqlcon.HandleError(err)
+	}
+	return exists, nil
+}
+
+// RevokeSessions marks all sessions matching the filter inactive.
+func (p *Persister) RevokeSessions(ctx context.Context, filter *session.ListSessionsFilter) (res int, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.RevokeSessions")
+	defer otelx.End(span, &err)
+
+	if filter == nil || filter.IsEmpty() {
+		return 0, errors.WithStack(herodot.ErrBadRequest.WithReason("Refusing to revoke sessions without a filter besides active."))
+	}
+
+	c := p.GetConnection(ctx)
+	clauses, args, err := p.sessionFilterClauses(ctx, c, filter)
 	if err != nil {
-		return 0, sqlcon.HandleError(err)
+		return 0, err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET active = false WHERE nid = ?", new(session.Session).TableName(ctx))
+	values := []interface{}{p.NetworkID(ctx)}
+	var conditionArgs []interface{}
+	for i := range clauses {
+		query += " AND " + clauses[i]
+		conditionArgs = append(conditionArgs, args[i]...)
+	}
+	values = append(values, conditionArgs...)
+
+	if err := p.withRevocationNotifications(ctx, func(ctx context.Context) error {
+		//#nosec G201 -- TableName is static and clauses only contain placeholders
+		count, err := p.GetConnection(ctx).RawQuery(query, values...).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+		res = count
+		return nil
+	}, strings.Join(clauses, " AND "), conditionArgs...); err != nil {
+		return 0, err
 	}
-	return count, nil
+	return res, nil
 }
 
+// sessionFilterClauses translates the filter into SQL conditions on the sessions table. Each clause
+// comes with its own list of arguments.
+func (p *Persister) sessionFilterClauses(ctx context.Context, c *pop.Connection, f *session.ListSessionsFilter) (clauses []string, args [][]interface{}, err error) {
+	if f == nil {
+		return nil, nil, nil
+	}
+
+	add := func(clause string, values ...interface{}) {
+		clauses = append(clauses, clause)
+		args = append(args, values)
+	}
+
+	sessions := new(session.Session).TableName(ctx)
+	devices := new(session.Device).TableName(ctx)
+
+	if f.Active != nil {
+		if *f.Active {
+			add(sessions+".active = ? AND "+sessions+".expires_at >= ?", true, time.Now().UTC())
+		} else {
+			add("("+sessions+".active = ? OR "+sessions+".expires_at < ?)", false, time.Now().UTC())
+		}
+	}
+	if f.IdentityID != uuid.Nil {
+		add(sessions+".identity_id = ?", f.IdentityID)
+	}
+	if f.AAL != "" {
+		add(sessions+".aal = ?", f.AAL)
+	}
+	if f.AuthenticationMethod != "" {
+		// The authentication methods are stored as JSON, which each dialect queries differently.
+		switch c.Dialect.Name() {
+		case "postgres", "cockroach":
+			add(sessions+".authentication_methods @> ?::jsonb", fmt.Sprintf(`[{"method":%q}]`, f.AuthenticationMethod))
+		case "mysql":
+			add("JSON_CONTAINS("+sessions+".authentication_methods, ?)", fmt.Sprintf(`[{"method":%q}]`, f.AuthenticationMethod))
+		default:
+			add(sessions+".authentication_methods LIKE ?", fmt.Sprintf(`%%"method":%q%%`, f.AuthenticationMethod))
+		}
+	}
+	if f.UpstreamProvider != "" || f.UpstreamSubject != "" || f.UpstreamSessionID != "" {
+		upstream := map[string]string{}
+		for key, value := range map[string]string{
+			"provider":         f.UpstreamProvider,
+			"upstream_subject": f.UpstreamSubject,
+			"upstream_sid":     f.UpstreamSessionID,
+		} {
+			if value != "" {
+				upstream[key] = value
+			}
+		}
+
+		contains, err := json.Marshal([]map[string]string{upstream})
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+
+		switch c.Dialect.Name() {
+		case "postgres", "cockroach":
+			add(sessions+".authentication_methods @> ?::jsonb", string(contains))
+		case "mysql":
+			add("JSON_CONTAINS("+sessions+".authentication_methods, ?)", string(contains))
+		default:
+			// Without JSON support, each value must appear somewhere in the authentication methods.
+			for key, value := range upstream {
+				encoded, err := json.Marshal(value)
+				if err != nil {
+					return nil, nil, errors.WithStack(err)
+				}
+				add(sessions+".authentication_methods LIKE ? ESCAPE '!'", "%"+likeEscaper.Replace(fmt.Sprintf(`"%s":%s`, key, encoded))+"%")
+			}
+		}
+	}
+	if !f.CreatedAfter.IsZero() {
+		add(sessions+".created_at >= ?", f.CreatedAfter.UTC())
+	}
+	if !f.CreatedBefore.IsZero() {
+		add(sessions+".created_at < ?", f.CreatedBefore.UTC())
+	}
+	if !f.AuthenticatedAfter.IsZero() {
+		add(sessions+".authenticated_at >= ?", f.AuthenticatedAfter.UTC())
+	}
+	if !f.AuthenticatedBefore.IsZero() {
+		add(sessions+".authenticated_at < ?", f.AuthenticatedBefore.UTC())
+	}
+	if f.IPNetwork != nil {
+		first, last := session.IPNetworkKeyRange(f.IPNetwork)
+		add(fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.session_id = %[2]s.id AND %[1]s.nid = %[2]s.nid AND %[1]s.ip_address_key BETWEEN ? AND ?)",
+			devices, sessions,
+		), first, last)
+	}
+	if f.UserAgent != "" {
+		add(fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.session_id = %[2]s.id AND %[1]s.nid = %[2]s.nid AND LOWER(%[1]s.user_agent) LIKE ? ESCAPE '!')",
+			devices, sessions,
+		), "%"+likeEscaper.Replace(strings.ToLower(f.UserAgent))+"%")
+	}
+
+	return clauses, args, nil
+}
+
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
 func (p *Persister) DeleteExpiredSessions(ctx context.Context, expiresAt time.Time, limit int) (err error) {
 	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteExpiredSessions")
 	defer otelx.End(span, &err)
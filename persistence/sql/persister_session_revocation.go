@@ -0,0 +1,108 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/persistence/sql/update"
+	"github.com/ory/kratos/session"
+)
+
+// withRevocationNotifications runs revoke and, if revocation notification receivers are configured, queues a
+// notification for every receiver and every active session matching the condition. Both happen in the same
+// transaction so that no revocation goes unnoticed.
+func (p *Persister) withRevocationNotifications(ctx context.Context, revoke func(ctx context.Context) error, condition string, args ...interface{}) error {
+	receivers := p.r.Config().SessionRevocationNotificationReceivers(ctx)
+	if len(receivers) == 0 {
+		return revoke(ctx)
+	}
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		nid := p.NetworkID(ctx)
+
+		var sessions []session.Session
+		if err := tx.Select("id", "identity_id").
+			Where("nid = ? AND active = ? AND expires_at > ?", nid, true, time.Now().UTC()).
+			Where(condition, args...).
+			All(&sessions); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		if err := revoke(ctx); err != nil {
+			return err
+		}
+
+		for _, s := range sessions {
+			for _, receiver := range receivers {
+				if err := tx.Create(&session.RevocationNotification{
+					SessionID:  s.ID,
+					IdentityID: s.IdentityID,
+					Receiver:   receiver.String(),
+					Status:     session.RevocationNotificationStatusQueued,
+					NID:        nid,
+				}); err != nil {
+					return sqlcon.HandleError(err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (p *Persister) NextRevocationNotifications(ctx context.Context, limit uint8) (notifications []session.RevocationNotification, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.NextRevocationNotifications")
+	defer otelx.End(span, &err)
+
+	if err := p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		var n []session.RevocationNotification
+		if err := tx.
+			Where("nid = ? AND (status = ? OR (status = ? AND updated_at < ?))",
+				p.NetworkID(ctx),
+				session.RevocationNotificationStatusQueued,
+				session.RevocationNotificationStatusProcessing,
+				time.Now().UTC().Add(-session.RevocationNotificationProcessingTimeout),
+			).
+			Order("created_at ASC").
+			Limit(int(limit)).
+			All(&n); err != nil {
+			return err
+		}
+
+		for i := range n {
+			n[i].Status = session.RevocationNotificationStatusProcessing
+			n[i].UpdatedAt = time.Now().UTC()
+			if err := update.Generic(ctx, tx, p.r.Tracer(ctx).Tracer(), &n[i], "status", "updated_at"); err != nil {
+				return err
+			}
+		}
+
+		notifications = n
+		return nil
+	}); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return notifications, nil
+}
+
+func (p *Persister) UpdateRevocationNotification(ctx context.Context, n *session.RevocationNotification) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.UpdateRevocationNotification")
+	defer otelx.End(span, &err)
+
+	if n.NID != p.NetworkID(ctx) {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	n.UpdatedAt = time.Now().UTC()
+	return update.Generic(ctx, p.GetConnection(ctx), p.r.Tracer(ctx).Tracer(), n, "status", "send_count", "last_error", "updated_at")
+}
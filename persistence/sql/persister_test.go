@@ -43,6 +43,8 @@ import (
 	verification "github.com/ory/kratos/selfservice/flow/verification/test"
 	code "github.com/ory/kratos/selfservice/strategy/code/test"
 	link "github.com/ory/kratos/selfservice/strategy/link/test"
+	oidc "github.com/ory/kratos/selfservice/strategy/oidc/test"
+	otp "github.com/ory/kratos/selfservice/strategy/otp/test"
 	session "github.com/ory/kratos/session/test"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/sqlcon"
@@ -262,6 +264,14 @@ func TestPersister(t *testing.T) {
 				pop.SetLogger(pl(t))
 				code.TestPersister(ctx, conf, p)(t)
 			})
+			t.Run("contract=oidc.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				oidc.TestPersister(ctx, conf, p)(t)
+			})
+			t.Run("contract=otp.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				otp.TestPersister(ctx, conf, p)(t)
+			})
 			t.Run("contract=continuity.TestPersister", func(t *testing.T) {
 				pop.SetLogger(pl(t))
 				continuity.TestPersister(ctx, p)(t)
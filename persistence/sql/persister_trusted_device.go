@@ -0,0 +1,92 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/session"
+)
+
+var _ session.TrustedDevicePersister = new(Persister)
+
+func (p *Persister) CreateTrustedDevice(ctx context.Context, d *session.TrustedDevice) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.CreateTrustedDevice")
+	defer otelx.End(span, &err)
+
+	d.NID = p.NetworkID(ctx)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(d))
+}
+
+func (p *Persister) GetTrustedDevice(ctx context.Context, identityID, id uuid.UUID) (_ *session.TrustedDevice, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.GetTrustedDevice")
+	defer otelx.End(span, &err)
+
+	var d session.TrustedDevice
+	if err := p.GetConnection(ctx).Where("id = ? AND identity_id = ? AND nid = ?", id, identityID, p.NetworkID(ctx)).First(&d); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return &d, nil
+}
+
+func (p *Persister) ListTrustedDevices(ctx context.Context, identityID uuid.UUID) (_ []session.TrustedDevice, err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.ListTrustedDevices")
+	defer otelx.End(span, &err)
+
+	devices := make([]session.TrustedDevice, 0)
+	if err := p.GetConnection(ctx).
+		Where("identity_id = ? AND nid = ? AND expires_at > ?", identityID, p.NetworkID(ctx), time.Now().UTC()).
+		Order("created_at DESC").
+		All(&devices); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return devices, nil
+}
+
+func (p *Persister) DeleteTrustedDevice(ctx context.Context, identityID, id uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteTrustedDevice")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE id = ? AND identity_id = ? AND nid = ?",
+		new(session.TrustedDevice).TableName(ctx),
+	),
+		id,
+		identityID,
+		p.NetworkID(ctx),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
+
+func (p *Persister) DeleteTrustedDevicesByIdentity(ctx context.Context, identityID uuid.UUID) (err error) {
+	ctx, span := p.r.Tracer(ctx).Tracer().Start(ctx, "persistence.sql.DeleteTrustedDevicesByIdentity")
+	defer otelx.End(span, &err)
+
+	//#nosec G201 -- TableName is static
+	if err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE identity_id = ? AND nid = ?",
+		new(session.TrustedDevice).TableName(ctx),
+	),
+		identityID,
+		p.NetworkID(ctx),
+	).Exec(); err != nil {
+		return sqlcon.HandleError(err)
+	}
+	return nil
+}
@@ -42,6 +42,17 @@ func NewTOTPVerifierWrongError(instancePtr string) error {
 	})
 }
 
+func NewTOTPCodeAlreadyUsedError() error {
+	t := text.NewErrorValidationTOTPCodeAlreadyUsed()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
 func NewWebAuthnVerifierWrongError(instancePtr string) error {
 	t := text.NewErrorValidationTOTPVerifierWrong()
 	return errors.WithStack(&ValidationError{
@@ -210,6 +221,37 @@ func NewNoTOTPDeviceRegistered() error {
 	})
 }
 
+func NewNoHOTPDeviceRegistered() error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `you have no hardware token set up`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationNoHOTPDevice()),
+	})
+}
+
+func NewHOTPResyncRequiredError() error {
+	t := text.NewErrorValidationHOTPResyncRequired()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: "#/hotp_code",
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewAccountNotFoundError() error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     "this account does not exist or has no login method configured",
+			InstancePtr: "#/identifier",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationAccountNotFound()),
+	})
+}
+
 func NewNoLookupDefined() error {
 	return errors.WithStack(&ValidationError{
 		ValidationError: &jsonschema.ValidationError{
@@ -220,6 +262,66 @@ func NewNoLookupDefined() error {
 	})
 }
 
+func NewWebAuthnAuthenticatorNotAllowedError(reason string, args ...interface{}) error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     fmt.Sprintf(reason, args...),
+			InstancePtr: "#/webauthn_register",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationWebAuthnAuthenticatorNotAllowed()),
+	})
+}
+
+func NewOIDCRequirementsNotMetError(provider, reason string) error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     fmt.Sprintf("the claims do not meet the requirements of the provider %s: %s", provider, reason),
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationOIDCRequirementsNotMet(provider, reason)),
+	})
+}
+
+func NewWebAuthnCloneDetectedError() error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `the signature counter of the security key indicates that it was cloned`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationWebAuthnCloneDetected()),
+	})
+}
+
+func NewNoOTPAddressError() error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `you have no address set up to receive sign in codes`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationNoOTPAddress()),
+	})
+}
+
+func NewOTPCodeInvalidError() error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `the sign in code is invalid or has expired`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationOTPCodeInvalid()),
+	})
+}
+
+func NewOTPResendThrottledError(seconds int) error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `a sign in code was sent recently`,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationOTPResendThrottled(seconds)),
+	})
+}
+
 func NewNoWebAuthnRegistered() error {
 	return errors.WithStack(&ValidationError{
 		ValidationError: &jsonschema.ValidationError{
@@ -4,15 +4,103 @@
 package login
 
 import (
+	_ "embed"
+	"net/http"
+
 	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
 
+	"github.com/ory/herodot"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/decoderx"
 )
 
+//go:embed .schema/trusted_device.schema.json
+var trustedDeviceSchema []byte
+
+const internalContextKeyRememberDevice = "trusted_device_remember"
+
 func CheckAAL(f *Flow, expected identity.AuthenticatorAssuranceLevel) error {
 	if f.RequestedAAL != expected {
 		return errors.WithStack(flow.ErrStrategyNotResponsible)
 	}
 	return nil
 }
+
+// NewRememberDeviceNode returns the checkbox which asks to trust the browser after completing the second factor.
+func NewRememberDeviceNode() *node.Node {
+	return node.NewInputField(node.TrustedDeviceRemember, false, node.DefaultGroup, node.InputAttributeTypeCheckbox).
+		WithMetaLabel(text.NewInfoNodeLabelRememberDevice())
+}
+
+func trustedDevicesEnabled(r *http.Request, d executorDependencies) bool {
+	return d.Config().SelfServiceStrategy(r.Context(), identity.CredentialsTypeTrustedDevice.String()).Enabled
+}
+
+// rememberDeviceRequested stores in the flow's internal context if the browser should be trusted after completing
+// the second factor. The request body is left intact for the login strategies.
+func rememberDeviceRequested(r *http.Request, f *Flow) (err error) {
+	var p struct {
+		Remember bool `json:"trusted_device_remember" form:"trusted_device_remember"`
+	}
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(trustedDeviceSchema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := decoderx.NewHTTP().Decode(r, &p, compiler,
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderSetValidatePayloads(false),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	f.EnsureInternalContext()
+	f.InternalContext, err = sjson.SetBytes(f.InternalContext, internalContextKeyRememberDevice, p.Remember)
+	return errors.WithStack(err)
+}
+
+// trustDevice completes the second factor of the session if the identity has set up a second factor
+// and the browser is trusted by the identity.
+func (e *HookExecutor) trustDevice(r *http.Request, i *identity.Identity, s *session.Session) error {
+	if s.AuthenticatorAssuranceLevel >= identity.AuthenticatorAssuranceLevel2 || !trustedDevicesEnabled(r, e.d) {
+		return nil
+	}
+
+	if len(i.Credentials) == 0 {
+		if err := e.d.PrivilegedIdentityPool().HydrateIdentityAssociations(r.Context(), i, identity.ExpandCredentials); err != nil {
+			return err
+		}
+	}
+
+	if count, err := e.d.IdentityManager().CountActiveMultiFactorCredentials(r.Context(), i); err != nil {
+		return err
+	} else if count == 0 {
+		return nil
+	}
+
+	if _, err := e.d.SessionManager().FetchTrustedDevice(r.Context(), r, i.ID); errors.Is(err, herodot.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	s.CompletedLoginFor(identity.CredentialsTypeTrustedDevice, identity.AuthenticatorAssuranceLevel2)
+	return nil
+}
+
+// shouldRememberDevice returns true if the identity completed the second factor in this flow and asked to trust
+// the browser.
+func (e *HookExecutor) shouldRememberDevice(r *http.Request, f *Flow, s *session.Session) bool {
+	return f.Type == flow.TypeBrowser &&
+		f.RequestedAAL == identity.AuthenticatorAssuranceLevel2 &&
+		s.AuthenticatorAssuranceLevel >= identity.AuthenticatorAssuranceLevel2 &&
+		trustedDevicesEnabled(r, e.d) &&
+		gjson.GetBytes(f.InternalContext, internalContextKeyRememberDevice).Bool()
+}
@@ -97,6 +97,25 @@ func (s *ErrorHandler) WriteFlowError(w http.ResponseWriter, r *http.Request, f
 		return
 	}
 
+	if errors.Is(err, flow.ErrStrategyAsksToReturnToUI) {
+		if err := sortNodes(r.Context(), f.UI.Nodes); err != nil {
+			s.forward(w, r, f, err)
+			return
+		}
+
+		if err := s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
+			s.forward(w, r, f, err)
+			return
+		}
+
+		if f.Type == flow.TypeBrowser && !x.IsJSONRequest(r) {
+			http.Redirect(w, r, f.AppendTo(s.d.Config().SelfServiceFlowLoginUI(r.Context())).String(), http.StatusSeeOther)
+		} else {
+			s.d.Writer().Write(w, r, f)
+		}
+		return
+	}
+
 	f.UI.ResetMessages()
 	if err := f.UI.ParseError(group, err); err != nil {
 		s.forward(w, r, f, err)
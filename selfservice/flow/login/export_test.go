@@ -12,3 +12,7 @@ import (
 func RequiresAAL2ForTest(e HookExecutor, r *http.Request, s *session.Session) (bool, error) {
 	return e.requiresAAL2(r, s, nil) // *login.Flow is nil to avoid an import cycle
 }
+
+func RememberDeviceForTest(f *Flow) {
+	f.InternalContext = []byte(`{"` + internalContextKeyRememberDevice + `":true}`)
+}
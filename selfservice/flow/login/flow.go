@@ -119,6 +119,12 @@ type Flow struct {
 	//
 	// This value can be one of "aal1", "aal2", "aal3".
 	RequestedAAL identity.AuthenticatorAssuranceLevel `json:"requested_aal" faker:"len=4" db:"requested_aal"`
+
+	// RequiredMethod contains the requested `required_method` parameter.
+	//
+	// If set, the flow can only be completed using this login method, for example when step-up authentication
+	// was requested using the `required_amr` parameter of the `/sessions/whoami` endpoint.
+	RequiredMethod identity.CredentialsType `json:"required_method,omitempty" faker:"-" db:"-"`
 }
 
 func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Request, flowType flow.Type) (*Flow, error) {
@@ -158,6 +164,7 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 		RequestedAAL: identity.AuthenticatorAssuranceLevel(strings.ToLower(stringsx.Coalesce(
 			r.URL.Query().Get("aal"),
 			string(identity.AuthenticatorAssuranceLevel1)))),
+		RequiredMethod:  identity.CredentialsType(r.URL.Query().Get("required_method")),
 		InternalContext: []byte("{}"),
 	}, nil
 }
@@ -210,6 +217,7 @@ func (f *Flow) EnsureInternalContext() {
 func (f Flow) MarshalJSON() ([]byte, error) {
 	type local Flow
 	f.SetReturnTo()
+	f.SetRequiredMethod()
 	return json.Marshal(local(f))
 }
 
@@ -223,13 +231,25 @@ func (f *Flow) SetReturnTo() {
 	}
 }
 
+func (f *Flow) SetRequiredMethod() {
+	// Required method is already set, do not overwrite it.
+	if len(f.RequiredMethod) > 0 {
+		return
+	}
+	if u, err := url.Parse(f.RequestURL); err == nil {
+		f.RequiredMethod = identity.CredentialsType(u.Query().Get("required_method"))
+	}
+}
+
 func (f *Flow) AfterFind(*pop.Connection) error {
 	f.SetReturnTo()
+	f.SetRequiredMethod()
 	return nil
 }
 
 func (f *Flow) AfterSave(*pop.Connection) error {
 	f.SetReturnTo()
+	f.SetRequiredMethod()
 	return nil
 }
 
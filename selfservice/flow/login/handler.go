@@ -133,6 +133,22 @@ func (h *Handler) NewLoginFlow(w http.ResponseWriter, r *http.Request, ft flow.T
 		return nil, nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to parse AuthenticationMethod Assurance Level (AAL): %s", cs.ToUnknownCaseErr()))
 	}
 
+	strategies := h.d.LoginStrategies(r.Context())
+	if f.RequiredMethod != "" {
+		required, err := strategies.Strategy(f.RequiredMethod)
+		if err != nil {
+			return nil, nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The required login method %s is not enabled.", f.RequiredMethod))
+		}
+		strategies = Strategies{required}
+
+		// Requiring a method always re-authenticates the session, and a second factor can only be completed
+		// at the AAL it provides.
+		f.Refresh = true
+		if aal := required.CompletedAuthenticationMethod(r.Context()).AAL; aal > f.RequestedAAL {
+			f.RequestedAAL = aal
+		}
+	}
+
 	// We assume an error means the user has no session
 	sess, err := h.d.SessionManager().FetchFromRequest(r.Context(), r)
 	if e := new(session.ErrNoActiveSessionFound); errors.As(err, &e) {
@@ -183,13 +199,30 @@ preLoginHook:
 		f.UI.Messages.Add(text.NewInfoLoginMFA())
 	}
 
+	// The identifier-first style only applies to the first factor. Refreshes already know the identity and
+	// render only its methods.
+	_, err = strategies.Strategy(identity.CredentialsTypeIdentifierFirst)
+	identifierFirst := err == nil && !f.Refresh && f.RequestedAAL == identity.AuthenticatorAssuranceLevel1
+
 	var s Strategy
-	for _, s = range h.d.LoginStrategies(r.Context()) {
+	for _, s = range strategies {
+		if ifs, ok := s.(IdentifierFirstStrategy); ok && identifierFirst {
+			if err := ifs.PopulateLoginMethodIdentifierFirstIdentification(r, f); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
 		if err := s.PopulateLoginMethod(r, f.RequestedAAL, f); err != nil {
 			return nil, nil, err
 		}
 	}
 
+	if f.Type == flow.TypeBrowser && f.RequestedAAL == identity.AuthenticatorAssuranceLevel2 &&
+		conf.SelfServiceStrategy(r.Context(), identity.CredentialsTypeTrustedDevice.String()).Enabled {
+		f.UI.Nodes.Append(NewRememberDeviceNode())
+	}
+
 	if err := sortNodes(r.Context(), f.UI.Nodes); err != nil {
 		return nil, nil, err
 	}
@@ -246,6 +279,15 @@ type createNativeLoginFlow struct {
 	// in: query
 	RequestAAL identity.AuthenticatorAssuranceLevel `json:"aal"`
 
+	// Require a Specific Login Method
+	//
+	// If set, the login flow can only be completed using this method, for example `webauthn`. This
+	// implies `refresh=true` and is used for step-up authentication, see the `required_amr` parameter
+	// of the `/sessions/whoami` endpoint.
+	//
+	// in: query
+	RequiredMethod string `json:"required_method"`
+
 	// The Session Token of the Identity performing the settings flow.
 	//
 	// in: header
@@ -322,6 +364,15 @@ type createBrowserLoginFlow struct {
 	// in: query
 	RequestAAL identity.AuthenticatorAssuranceLevel `json:"aal"`
 
+	// Require a Specific Login Method
+	//
+	// If set, the login flow can only be completed using this method, for example `webauthn`. This
+	// implies `refresh=true` and is used for step-up authentication, see the `required_amr` parameter
+	// of the `/sessions/whoami` endpoint.
+	//
+	// in: query
+	RequiredMethod string `json:"required_method"`
+
 	// The URL to return the browser to after the flow was completed.
 	//
 	// in: query
@@ -714,9 +765,20 @@ continueLogin:
 		return
 	}
 
+	if f.Type == flow.TypeBrowser && f.RequestedAAL == identity.AuthenticatorAssuranceLevel2 {
+		if err := rememberDeviceRequested(r, f); err != nil {
+			h.d.LoginFlowErrorHandler().WriteFlowError(w, r, f, node.DefaultGroup, err)
+			return
+		}
+	}
+
 	var i *identity.Identity
 	var group node.UiNodeGroup
 	for _, ss := range h.d.AllLoginStrategies() {
+		if f.RequiredMethod != "" && ss.ID() != f.RequiredMethod {
+			continue
+		}
+
 		interim, err := ss.Login(w, r, f, sess.IdentityID)
 		group = ss.NodeGroup()
 		if errors.Is(err, flow.ErrStrategyNotResponsible) {
@@ -461,6 +461,40 @@ func TestFlowLifecycle(t *testing.T) {
 				assertion(body, true, true)
 				assert.Equal(t, gjson.GetBytes(body, "ui.messages.0.text").String(), text.NewInfoLoginReAuth().Text)
 			})
+
+			t.Run("case=required method implies refresh and limits the methods", func(t *testing.T) {
+				conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".totp.enabled", true)
+				t.Cleanup(func() {
+					conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".totp.enabled", false)
+				})
+
+				res, body := initAuthenticatedFlow(t, url.Values{"required_method": {"password"}}, true)
+				assert.Contains(t, res.Request.URL.String(), login.RouteInitAPIFlow)
+				assertion(body, true, true)
+				assert.Equal(t, "password", gjson.GetBytes(body, "required_method").String(), "%s", body)
+				assert.Equal(t, "aal1", gjson.GetBytes(body, "requested_aal").String(), "%s", body)
+				for _, group := range gjson.GetBytes(body, "ui.nodes.#.group").Array() {
+					assert.Contains(t, []string{"default", "password"}, group.String(), "%s", body)
+				}
+			})
+
+			t.Run("case=required second factor method requests aal2", func(t *testing.T) {
+				conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".totp.enabled", true)
+				t.Cleanup(func() {
+					conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".totp.enabled", false)
+				})
+
+				_, body := initAuthenticatedFlow(t, url.Values{"required_method": {"totp"}}, true)
+				assertion(body, true, true)
+				assert.Equal(t, "totp", gjson.GetBytes(body, "required_method").String(), "%s", body)
+				assert.Equal(t, "aal2", gjson.GetBytes(body, "requested_aal").String(), "%s", body)
+			})
+
+			t.Run("case=required method must be enabled", func(t *testing.T) {
+				res, body := initAuthenticatedFlow(t, url.Values{"required_method": {"totp"}}, true)
+				assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+				assert.Equal(t, "The required login method totp is not enabled.", gjson.GetBytes(body, "error.reason").String(), "%s", body)
+			})
 		})
 
 		t.Run("flow=browser", func(t *testing.T) {
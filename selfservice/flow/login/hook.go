@@ -45,6 +45,8 @@ type (
 	executorDependencies interface {
 		config.Provider
 		hydra.HydraProvider
+		identity.ManagementProvider
+		identity.PrivilegedPoolProvider
 		session.ManagementProvider
 		session.PersistenceProvider
 		x.CSRFTokenGeneratorProvider
@@ -122,6 +124,14 @@ func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, g n
 		return err
 	}
 
+	if err := e.trustDevice(r, i, s); err != nil {
+		return err
+	}
+
+	if err := e.updateMFAEnrollmentDeadline(r, i); err != nil {
+		return err
+	}
+
 	// Verify the redirect URL before we do any other processing.
 	c := e.d.Config()
 	returnTo, err := x.SecureRedirectTo(r, c.SelfServiceBrowserDefaultReturnTo(r.Context()),
@@ -202,6 +212,12 @@ func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, g n
 		return errors.WithStack(err)
 	}
 
+	if e.shouldRememberDevice(r, a, s) {
+		if err := e.d.SessionManager().IssueTrustedDeviceCookie(r.Context(), w, r, s); err != nil {
+			return err
+		}
+	}
+
 	e.d.Audit().
 		WithRequest(r).
 		WithField("identity_id", i.ID).
@@ -246,6 +262,9 @@ func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, g n
 			return err
 		}
 		finalReturnTo = rt
+	} else if i.MFAEnrollmentOverdue() {
+		// The identity has to set up its second factors before it can continue.
+		finalReturnTo = e.mfaEnrollmentURL(r, finalReturnTo)
 	}
 
 	x.ContentNegotiationRedirection(w, r, s, e.d.Writer(), finalReturnTo)
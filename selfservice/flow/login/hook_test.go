@@ -5,6 +5,7 @@ package login_test
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/url"
 	"testing"
@@ -60,6 +61,11 @@ func TestLoginExecutor(t *testing.T) {
 					a.RequestURL = x.RequestURL(r).String()
 					sess := session.NewInactiveSession()
 					sess.CompletedLoginFor(identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+					if r.URL.Query().Get("remember_device") == "true" {
+						a.RequestedAAL = identity.AuthenticatorAssuranceLevel2
+						login.RememberDeviceForTest(a)
+						sess.CompletedLoginFor(identity.CredentialsTypeTOTP, identity.AuthenticatorAssuranceLevel2)
+					}
 					if useIdentity == nil {
 						useIdentity = testhelpers.SelfServiceHookCreateFakeIdentity(t, reg)
 					}
@@ -227,6 +233,82 @@ func TestLoginExecutor(t *testing.T) {
 						assert.Empty(t, gjson.Get(body, "session_token").String())
 					})
 				})
+
+				t.Run("case=trusted devices", func(t *testing.T) {
+					conf.MustSet(ctx, config.ViperKeySessionWhoAmIAAL, "highest_available")
+					conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".trusted_device.enabled", true)
+					_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+					t.Cleanup(func() {
+						conf.MustSet(ctx, config.ViperKeySessionWhoAmIAAL, "aal1")
+						conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".trusted_device.enabled", false)
+					})
+					t.Cleanup(testhelpers.SelfServiceHookConfigReset(t, conf))
+
+					useIdentity := &identity.Identity{Credentials: map[identity.CredentialsType]identity.Credentials{
+						identity.CredentialsTypePassword: {Type: identity.CredentialsTypePassword, Config: []byte(`{"hashed_password": "$argon2id$v=19$m=32,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw"}`), Identifiers: []string{testhelpers.RandomEmail()}},
+						identity.CredentialsTypeWebAuthn: {Type: identity.CredentialsTypeWebAuthn, Config: []byte(`{"credentials":[{"is_passwordless":false}]}`), Identifiers: []string{testhelpers.RandomEmail()}},
+					}}
+					require.NoError(t, reg.Persister().CreateIdentity(context.Background(), useIdentity))
+
+					doRequest := func(t *testing.T, ts *httptest.Server, query string, cookies ...*http.Cookie) (*http.Response, string) {
+						req, err := http.NewRequest("GET", ts.URL+"/login/post"+query, nil)
+						require.NoError(t, err)
+						req.Header.Set("Accept", "application/json")
+						for _, c := range cookies {
+							req.AddCookie(c)
+						}
+						res, err := ts.Client().Do(req)
+						require.NoError(t, err)
+						defer res.Body.Close()
+						body, err := io.ReadAll(res.Body)
+						require.NoError(t, err)
+						return res, string(body)
+					}
+
+					findCookie := func(res *http.Response) *http.Cookie {
+						for _, c := range res.Cookies() {
+							if c.Name == session.TrustedDeviceCookieName {
+								return c
+							}
+						}
+						return nil
+					}
+
+					ts := newServer(t, flow.TypeBrowser, useIdentity)
+
+					t.Run("case=does not remember the device unless asked to", func(t *testing.T) {
+						res, body := doRequest(t, ts, "")
+						assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+						assert.Nil(t, findCookie(res))
+						assert.Empty(t, gjson.Get(body, "session.identity").String(), body)
+					})
+
+					t.Run("case=remembers the device and skips the second factor next time", func(t *testing.T) {
+						res, body := doRequest(t, ts, "?remember_device=true")
+						assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+						trusted := findCookie(res)
+						require.NotNil(t, trusted)
+
+						res, body = doRequest(t, ts, "", trusted)
+						assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+						assert.Nil(t, findCookie(res))
+						assert.EqualValues(t, "aal2", gjson.Get(body, "session.authenticator_assurance_level").String(), body)
+						assert.EqualValues(t, identity.CredentialsTypeTrustedDevice, gjson.Get(body, "session.authentication_methods.1.method").String(), body)
+						assert.NotEmpty(t, gjson.Get(body, "session.identity").String(), body)
+					})
+
+					t.Run("case=ignores the trusted device if disabled", func(t *testing.T) {
+						res, body := doRequest(t, ts, "?remember_device=true")
+						assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+						trusted := findCookie(res)
+						require.NotNil(t, trusted)
+
+						conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".trusted_device.enabled", false)
+						res, body = doRequest(t, ts, "", trusted)
+						assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+						assert.EqualValues(t, "aal1", gjson.Get(body, "session.authenticator_assurance_level").String(), body)
+					})
+				})
 			})
 
 			t.Run("type=api", func(t *testing.T) {
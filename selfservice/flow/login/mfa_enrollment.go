@@ -0,0 +1,47 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package login
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/x/urlx"
+)
+
+// updateMFAEnrollmentDeadline starts the grace period of the MFA enrollment policy for the identity which signed in,
+// or clears it if the identity has set up enough second factors in the meantime.
+func (e *HookExecutor) updateMFAEnrollmentDeadline(r *http.Request, i *identity.Identity) error {
+	if !e.d.Config().SelfServiceSettingsMFAEnrollment(r.Context()).Enabled() && i.MFAEnrollmentDeadline == nil {
+		return nil
+	}
+
+	confidential, err := e.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), i.ID)
+	if err != nil {
+		return err
+	}
+
+	if changed, err := e.d.IdentityManager().SetMFAEnrollmentDeadline(r.Context(), confidential); err != nil {
+		return err
+	} else if !changed {
+		return nil
+	}
+
+	if err := e.d.PrivilegedIdentityPool().UpdateIdentity(r.Context(), confidential); err != nil {
+		return err
+	}
+
+	i.MFAEnrollmentDeadline = confidential.MFAEnrollmentDeadline
+	return nil
+}
+
+// mfaEnrollmentURL returns the URL which initializes a settings flow in which the identity sets up its second
+// factors before continuing to returnTo.
+func (e *HookExecutor) mfaEnrollmentURL(r *http.Request, returnTo string) string {
+	return urlx.CopyWithQuery(
+		urlx.AppendPaths(e.d.Config().SelfPublicURL(r.Context()), "/self-service/settings/browser"),
+		url.Values{"return_to": {returnTo}},
+	).String()
+}
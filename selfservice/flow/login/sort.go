@@ -14,10 +14,13 @@ func sortNodes(ctx context.Context, n node.Nodes) error {
 		node.SortByGroups([]node.UiNodeGroup{
 			node.OpenIDConnectGroup,
 			node.DefaultGroup,
+			node.IdentifierFirstGroup,
 			node.WebAuthnGroup,
 			node.PasswordGroup,
 			node.TOTPGroup,
 			node.LookupGroup,
+			node.OTPGroup,
+			node.HOTPGroup,
 		}),
 		node.SortUseOrder([]string{
 			"csrf_token",
@@ -27,6 +27,19 @@ type Strategy interface {
 	CompletedAuthenticationMethod(ctx context.Context) session.AuthenticationMethod
 }
 
+// IdentifierFirstStrategy is implemented by strategies which support the identifier-first login style. In
+// this style, the first step only asks for the identifier, and the second step only shows the login methods
+// available for that identifier.
+type IdentifierFirstStrategy interface {
+	// PopulateLoginMethodIdentifierFirstIdentification adds the nodes which are shown before the identity is known.
+	PopulateLoginMethodIdentifierFirstIdentification(r *http.Request, f *Flow) error
+
+	// PopulateLoginMethodIdentifierFirstCredentials adds the nodes which are shown once the identifier was
+	// submitted. The identity is nil if it is unknown or must not be revealed, in which case only nodes which
+	// do not depend on the identity may be added.
+	PopulateLoginMethodIdentifierFirstCredentials(r *http.Request, f *Flow, identifier string, i *identity.Identity) error
+}
+
 type Strategies []Strategy
 
 func (s Strategies) Strategy(id identity.CredentialsType) (Strategy, error) {
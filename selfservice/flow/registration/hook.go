@@ -88,13 +88,16 @@ type (
 	HookExecutorProvider interface {
 		RegistrationExecutor() *HookExecutor
 	}
+
+	// SessionOption modifies the session which is issued after the registration.
+	SessionOption func(s *session.Session)
 )
 
 func NewHookExecutor(d executorDependencies) *HookExecutor {
 	return &HookExecutor{d: d}
 }
 
-func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity) error {
+func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity, opts ...SessionOption) error {
 	e.d.Logger().
 		WithRequest(r).
 		WithField("identity_id", i.ID).
@@ -176,6 +179,9 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 	if err != nil {
 		return err
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
 
 	e.d.Logger().
 		WithRequest(r).
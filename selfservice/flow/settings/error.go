@@ -60,12 +60,22 @@ type FlowNeedsReAuth struct {
 	//
 	// required: true
 	RedirectBrowserTo string `json:"redirect_browser_to"`
+
+	// RequiredMethod is the method the user must re-authenticate with, if any. It is passed to the login flow as
+	// the `required_method` parameter.
+	RequiredMethod identity.CredentialsType `json:"-"`
 }
 
 func (e *FlowNeedsReAuth) EnhanceJSONError() interface{} {
 	return e
 }
 
+// WithRequiredMethod requires the user to re-authenticate with the given method.
+func (e *FlowNeedsReAuth) WithRequiredMethod(method identity.CredentialsType) *FlowNeedsReAuth {
+	e.RequiredMethod = method
+	return e
+}
+
 func NewFlowNeedsReAuth() *FlowNeedsReAuth {
 	return &FlowNeedsReAuth{
 		DefaultError: herodot.ErrForbidden.WithID(text.ErrIDNeedsPrivilegedSession).
@@ -83,8 +93,11 @@ func (s *ErrorHandler) reauthenticate(
 	err *FlowNeedsReAuth,
 ) {
 	returnTo := urlx.CopyWithQuery(urlx.AppendPaths(s.d.Config().SelfPublicURL(r.Context()), r.URL.Path), r.URL.Query())
-	redirectTo := urlx.AppendPaths(urlx.CopyWithQuery(s.d.Config().SelfPublicURL(r.Context()),
-		url.Values{"refresh": {"true"}, "return_to": {returnTo.String()}}),
+	query := url.Values{"refresh": {"true"}, "return_to": {returnTo.String()}}
+	if err.RequiredMethod != "" {
+		query.Set("required_method", string(err.RequiredMethod))
+	}
+	redirectTo := urlx.AppendPaths(urlx.CopyWithQuery(s.d.Config().SelfPublicURL(r.Context()), query),
 		login.RouteInitBrowserFlow).String()
 	err.RedirectBrowserTo = redirectTo
 	if f.Type == flow.TypeAPI || x.IsJSONRequest(r) {
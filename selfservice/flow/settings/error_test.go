@@ -345,6 +345,24 @@ func TestHandleError(t *testing.T) {
 			require.Contains(t, res.Request.URL.String(), conf.GetProvider(ctx).String(config.ViperKeySelfServiceLoginUI))
 		})
 
+		t.Run("case=session old error with required method", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeyURLsAllowedReturnToDomains, []string{urlx.AppendPaths(conf.SelfPublicURL(ctx), "/error").String()})
+			t.Cleanup(reset)
+
+			settingsFlow = &settings.Flow{Type: flow.TypeBrowser}
+			flowError = settings.NewFlowNeedsReAuth().WithRequiredMethod(identity.CredentialsTypePassword)
+			flowMethod = settings.StrategyProfile
+
+			res, err := ts.Client().Get(ts.URL + "/error")
+			require.NoError(t, err)
+			defer res.Body.Close()
+			require.Contains(t, res.Request.URL.String(), conf.GetProvider(ctx).String(config.ViperKeySelfServiceLoginUI))
+
+			body := x.MustReadAll(res.Body)
+			assert.Equal(t, string(identity.CredentialsTypePassword), gjson.GetBytes(body, "required_method").String(), "%s", body)
+			assert.Equal(t, urlx.AppendPaths(conf.SelfPublicURL(ctx), "/error").String(), gjson.GetBytes(body, "return_to").String(), "%s", body)
+		})
+
 		t.Run("case=validation error", func(t *testing.T) {
 			t.Cleanup(reset)
 
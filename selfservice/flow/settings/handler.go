@@ -131,11 +131,17 @@ func (h *Handler) NewFlow(w http.ResponseWriter, r *http.Request, i *identity.Id
 			return nil, err
 		}
 
+		if !allowedDuringMFAEnrollment(i, strategy.SettingsStrategyID()) {
+			continue
+		}
+
 		if err := strategy.PopulateSettingsMethod(r, i, f); err != nil {
 			return nil, err
 		}
 	}
 
+	addMFAEnrollmentMessage(i, f)
+
 	ds, err := h.d.Config().DefaultIdentityTraitsSchemaURL(r.Context())
 	if err != nil {
 		return nil, err
@@ -577,6 +583,10 @@ func (h *Handler) updateSettingsFlow(w http.ResponseWriter, r *http.Request, ps
 	var s string
 	var updateContext *UpdateContext
 	for _, strat := range h.d.AllSettingsStrategies() {
+		if !allowedDuringMFAEnrollment(ss.Identity, strat.SettingsStrategyID()) {
+			continue
+		}
+
 		uc, err := strat.Settings(w, r, f, ss)
 		if errors.Is(err, flow.ErrStrategyNotResponsible) {
 			continue
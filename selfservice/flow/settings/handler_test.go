@@ -31,6 +31,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 
+	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 
 	"github.com/ory/kratos/driver/config"
@@ -136,6 +137,25 @@ func TestHandler(t *testing.T) {
 				assertion(t, body, true)
 			})
 
+			t.Run("description=only offers second factors once the MFA enrollment deadline passed", func(t *testing.T) {
+				pending := sqlxx.NullTime(time.Now().Add(time.Hour))
+				user := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, &identity.Identity{
+					State: identity.StateActive, Traits: identity.Traits(`{}`), MFAEnrollmentDeadline: &pending})
+				res, body := initFlow(t, user, true)
+				require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+				assert.EqualValues(t, text.InfoSelfServiceSettingsMFAEnrollmentPending, gjson.GetBytes(body, "ui.messages.0.id").Int(), "%s", body)
+				assert.True(t, gjson.GetBytes(body, `ui.nodes.#(group=="password")`).Exists(), "%s", body)
+
+				overdue := sqlxx.NullTime(time.Now().Add(-time.Hour))
+				user = testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, &identity.Identity{
+					State: identity.StateActive, Traits: identity.Traits(`{}`), MFAEnrollmentDeadline: &overdue})
+				res, body = initFlow(t, user, true)
+				require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+				assert.EqualValues(t, text.InfoSelfServiceSettingsMFAEnrollmentOverdue, gjson.GetBytes(body, "ui.messages.0.id").Int(), "%s", body)
+				assert.False(t, gjson.GetBytes(body, `ui.nodes.#(group=="password")`).Exists(), "%s", body)
+				assert.False(t, gjson.GetBytes(body, `ui.nodes.#(group=="profile")`).Exists(), "%s", body)
+			})
+
 			t.Run("description=can not init if identity has aal2 but session has aal1", func(t *testing.T) {
 				conf.MustSet(ctx, config.ViperKeySelfServiceSettingsRequiredAAL, config.HighestAvailableAAL)
 				res, body := initFlow(t, aal2Identity, true)
@@ -211,6 +211,10 @@ func (e *HookExecutor) PostSettingsHook(w http.ResponseWriter, r *http.Request,
 		options = append(options, identity.ManagerAllowWriteProtectedTraits)
 	}
 
+	if _, err := e.d.IdentityManager().SetMFAEnrollmentDeadline(r.Context(), i); err != nil {
+		return err
+	}
+
 	if err := e.d.IdentityManager().Update(r.Context(), i, options...); err != nil {
 		if errors.Is(err, identity.ErrProtectedFieldModified) {
 			e.d.Logger().WithError(err).Debug("Modifying protected field requires re-authentication.")
@@ -0,0 +1,42 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package settings
+
+import (
+	"time"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/text"
+	"github.com/ory/x/stringslice"
+)
+
+// mfaEnrollmentStrategies are the strategies which remain available once the MFA enrollment deadline has passed.
+var mfaEnrollmentStrategies = []string{
+	identity.CredentialsTypeTOTP.String(),
+	identity.CredentialsTypeHOTP.String(),
+	identity.CredentialsTypeWebAuthn.String(),
+	identity.CredentialsTypeLookup.String(),
+	identity.CredentialsTypeOTP.String(),
+}
+
+// allowedDuringMFAEnrollment returns false if the identity missed the MFA enrollment deadline and the strategy
+// can not be used to set up a second factor.
+func allowedDuringMFAEnrollment(i *identity.Identity, strategy string) bool {
+	return !i.MFAEnrollmentOverdue() || stringslice.Has(mfaEnrollmentStrategies, strategy)
+}
+
+// addMFAEnrollmentMessage tells the identity to set up its second factors if the MFA enrollment policy requires it.
+func addMFAEnrollmentMessage(i *identity.Identity, f *Flow) {
+	if i.MFAEnrollmentDeadline == nil {
+		return
+	}
+
+	deadline := time.Time(*i.MFAEnrollmentDeadline)
+	if i.MFAEnrollmentOverdue() {
+		f.UI.Messages.Add(text.NewInfoSelfServiceSettingsMFAEnrollmentOverdue(deadline))
+		return
+	}
+
+	f.UI.Messages.Add(text.NewInfoSelfServiceSettingsMFAEnrollmentPending(deadline))
+}
@@ -18,8 +18,11 @@ func sortNodes(ctx context.Context, n node.Nodes, schemaRef string) error {
 			node.PasswordGroup,
 			node.OpenIDConnectGroup,
 			node.LookupGroup,
+			node.OTPGroup,
+			node.HOTPGroup,
 			node.WebAuthnGroup,
 			node.TOTPGroup,
+			node.TrustedDeviceGroup,
 		}),
 		node.SortUseOrderAppend([]string{
 			// Lookup
@@ -4,8 +4,9 @@
 package hook
 
 const (
-	KeySessionIssuer    = "session"
-	KeySessionDestroyer = "revoke_active_sessions"
-	KeyWebHook          = "web_hook"
-	KeyAddressVerifier  = "require_verified_address"
+	KeySessionIssuer     = "session"
+	KeySessionDestroyer  = "revoke_active_sessions"
+	KeyWebHook           = "web_hook"
+	KeyAddressVerifier   = "require_verified_address"
+	KeyNewDeviceNotifier = "notify_new_device"
 )
@@ -0,0 +1,131 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/otelx"
+	"github.com/ory/x/pointerx"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+)
+
+var _ login.PostHookExecutor = new(NewDeviceNotifier)
+
+type (
+	newDeviceNotifierDependencies interface {
+		config.Provider
+		courier.Provider
+		template.Dependencies
+		session.PersistenceProvider
+		x.LoggingProvider
+	}
+	// NewDeviceNotifier notifies the identity about logins from devices it has not used before.
+	NewDeviceNotifier struct {
+		r newDeviceNotifierDependencies
+	}
+)
+
+func NewNewDeviceNotifier(r newDeviceNotifierDependencies) *NewDeviceNotifier {
+	return &NewDeviceNotifier{r: r}
+}
+
+func (e *NewDeviceNotifier) ExecuteLoginPostHook(_ http.ResponseWriter, r *http.Request, _ node.UiNodeGroup, _ *login.Flow, s *session.Session) error {
+	return otelx.WithSpan(r.Context(), "selfservice.hook.NewDeviceNotifier.ExecuteLoginPostHook", func(ctx context.Context) error {
+		if s.Identity == nil || len(s.Devices) == 0 {
+			return nil
+		}
+		device := s.Devices[len(s.Devices)-1]
+
+		// Every device is new on the first login, so we do not notify about it.
+		if _, total, err := e.r.SessionPersister().ListSessionsByIdentity(ctx, s.IdentityID, nil, 1, 1, uuid.Nil, session.ExpandNothing); err != nil {
+			return err
+		} else if total == 0 {
+			return nil
+		}
+
+		if known, err := e.r.SessionPersister().HasDevice(ctx, s.IdentityID, &device); err != nil {
+			return err
+		} else if known {
+			return nil
+		}
+
+		// The session is only stored after all login hooks ran, so we assign the ID here to be able to revoke it later.
+		if s.ID == uuid.Nil {
+			s.ID = x.NewUUID()
+		}
+
+		link, err := session.NewUnrecognizedLoginURL(ctx, e.r.Config(), s)
+		if err != nil {
+			return err
+		}
+
+		model, err := x.StructToMap(s.Identity)
+		if err != nil {
+			return err
+		}
+
+		c, err := e.r.Courier(ctx)
+		if err != nil {
+			return err
+		}
+
+		ipAddress, userAgent, location := pointerx.StringR(device.IPAddress), pointerx.StringR(device.UserAgent), pointerx.StringR(device.Location)
+		for _, address := range s.Identity.VerifiableAddresses {
+			// Only notify addresses which are known to belong to the identity.
+			if !address.Verified {
+				continue
+			}
+
+			switch address.Via {
+			case identity.VerifiableAddressTypeEmail:
+				if _, err := c.QueueEmail(ctx, email.NewLoginNewDevice(e.r, &email.LoginNewDeviceModel{
+					To:                   address.Value,
+					IPAddress:            ipAddress,
+					UserAgent:            userAgent,
+					Location:             location,
+					UnrecognizedLoginURL: link.String(),
+					Identity:             model,
+				})); err != nil {
+					return err
+				}
+			case identity.VerifiableAddressTypePhone:
+				if !e.r.Config().CourierSMSEnabled(ctx) {
+					continue
+				}
+
+				if _, err := c.QueueSMS(ctx, sms.NewLoginNewDevice(e.r, &sms.LoginNewDeviceModel{
+					To:                   address.Value,
+					IPAddress:            ipAddress,
+					UserAgent:            userAgent,
+					Location:             location,
+					UnrecognizedLoginURL: link.String(),
+					Identity:             model,
+				})); err != nil {
+					return err
+				}
+			}
+		}
+
+		e.r.Audit().
+			WithRequest(r).
+			WithField("identity_id", s.IdentityID).
+			WithField("session_id", s.ID).
+			Info("Notified identity about a login from a new device.")
+		return nil
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hook_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/pointerx"
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+)
+
+func TestNewDeviceNotifier(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/verify.schema.json")
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, "https://www.ory.sh/")
+	conf.MustSet(ctx, config.ViperKeyCourierSMTPURL, "smtp://foo@bar@dev.null/")
+
+	h := hook.NewNewDeviceNotifier(reg)
+	r := &http.Request{URL: urlx.ParseOrPanic("https://www.ory.sh/")}
+
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.Traits = identity.Traits(`{"emails":["verified@ory.sh","unverified@ory.sh"]}`)
+	require.NoError(t, reg.IdentityManager().Create(ctx, i))
+
+	address, err := reg.IdentityPool().FindVerifiableAddressByValue(ctx, identity.VerifiableAddressTypeEmail, "verified@ory.sh")
+	require.NoError(t, err)
+	verifiedAt := sqlxx.NullTime(time.Now())
+	address.Status = identity.VerifiableAddressStatusCompleted
+	address.Verified = true
+	address.VerifiedAt = &verifiedAt
+	require.NoError(t, reg.PrivilegedIdentityPool().UpdateVerifiableAddress(ctx, address))
+
+	i, err = reg.IdentityPool().GetIdentity(ctx, i.ID, identity.ExpandDefault)
+	require.NoError(t, err)
+
+	login := func(t *testing.T, ip string) *session.Session {
+		s, err := session.NewActiveSession(r, i, conf, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		s.Devices = []session.Device{{IPAddress: pointerx.String(ip), UserAgent: pointerx.String("Mozilla/5.0")}}
+		require.NoError(t, h.ExecuteLoginPostHook(httptest.NewRecorder(), r, node.DefaultGroup, nil, s))
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+		return s
+	}
+
+	expectNoMessages := func(t *testing.T) {
+		_, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.ErrorIs(t, err, courier.ErrQueueEmpty)
+	}
+
+	t.Run("case=does not notify on first login", func(t *testing.T) {
+		login(t, "10.0.0.1")
+		expectNoMessages(t)
+	})
+
+	t.Run("case=does not notify on known device", func(t *testing.T) {
+		login(t, "10.0.0.1")
+		expectNoMessages(t)
+	})
+
+	t.Run("case=notifies verified addresses on new device", func(t *testing.T) {
+		s := login(t, "10.0.0.2")
+
+		messages, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "verified@ory.sh", messages[0].Recipient)
+		assert.Equal(t, courier.TypeLoginNewDevice, messages[0].TemplateType)
+		assert.Contains(t, messages[0].Body, "10.0.0.2")
+		assert.Contains(t, messages[0].Body, "https://www.ory.sh"+session.RouteUnrecognizedLogin+"?token=")
+
+		actual, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		assert.Equal(t, s.ID, actual.ID)
+	})
+
+	t.Run("case=does not notify once the device is known", func(t *testing.T) {
+		login(t, "10.0.0.2")
+		expectNoMessages(t)
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hotp
+
+import (
+	"strings"
+
+	"github.com/pquerna/otp"
+	stdhotp "github.com/pquerna/otp/hotp"
+
+	"github.com/ory/kratos/identity"
+)
+
+func validateOpts(o *identity.CredentialsHOTPConfig) stdhotp.ValidateOpts {
+	opts := stdhotp.ValidateOpts{Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1}
+	if o.Digits > 0 {
+		opts.Digits = otp.Digits(o.Digits)
+	}
+
+	switch strings.ToUpper(o.Algorithm) {
+	case otp.AlgorithmSHA256.String():
+		opts.Algorithm = otp.AlgorithmSHA256
+	case otp.AlgorithmSHA512.String():
+		opts.Algorithm = otp.AlgorithmSHA512
+	}
+
+	return opts
+}
+
+// findCounter returns the first counter value in [from, to] for which the passcode was generated.
+func findCounter(passcode string, o *identity.CredentialsHOTPConfig, from, to uint64) (counter uint64, ok bool) {
+	opts := validateOpts(o)
+	for i := from; i <= to; i++ {
+		if valid, _ := stdhotp.ValidateCustom(passcode, i, o.Secret, opts); valid {
+			return i, true
+		}
+
+		// Prevent an endless loop if the window reaches the end of the counter space.
+		if i == to {
+			break
+		}
+	}
+
+	return 0, false
+}
+
+// Validate checks the passcode against the credentials and returns the counter value for which it was generated.
+//
+// Passcodes are accepted for the expected counter value and up to `lookAhead` counter values after it.
+func Validate(passcode string, o *identity.CredentialsHOTPConfig, lookAhead uint64) (counter uint64, ok bool) {
+	return findCounter(passcode, o, o.Counter, o.Counter+lookAhead)
+}
+
+// FindResync searches the counter values after the look-ahead window and up to `window` counter values after the
+// expected one for the passcode. If found, the hardware token can be resynchronised once the passcode of the next
+// counter value is provided as well.
+func FindResync(passcode string, o *identity.CredentialsHOTPConfig, lookAhead, window uint64) (counter uint64, ok bool) {
+	if window <= lookAhead {
+		return 0, false
+	}
+
+	return findCounter(passcode, o, o.Counter+lookAhead+1, o.Counter+window)
+}
+
+// ValidateNext checks whether the passcode was generated for the counter value following `counter`.
+func ValidateNext(passcode string, o *identity.CredentialsHOTPConfig, counter uint64) bool {
+	_, ok := findCounter(passcode, o, counter+1, counter+1)
+	return ok
+}
@@ -0,0 +1,108 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hotp_test
+
+import (
+	"testing"
+
+	"github.com/pquerna/otp"
+	stdhotp "github.com/pquerna/otp/hotp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/strategy/hotp"
+)
+
+const testSecret = "JBSWY3DPEHPK3PXP"
+
+func generateCode(t *testing.T, o *identity.CredentialsHOTPConfig, counter uint64) string {
+	opts := stdhotp.ValidateOpts{Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1}
+	if o.Digits > 0 {
+		opts.Digits = otp.Digits(o.Digits)
+	}
+	switch o.Algorithm {
+	case "SHA256":
+		opts.Algorithm = otp.AlgorithmSHA256
+	case "SHA512":
+		opts.Algorithm = otp.AlgorithmSHA512
+	}
+
+	code, err := stdhotp.GenerateCodeCustom(o.Secret, counter, opts)
+	require.NoError(t, err)
+	return code
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("case=accepts the expected counter value", func(t *testing.T) {
+		o := &identity.CredentialsHOTPConfig{Secret: testSecret, Counter: 5}
+
+		counter, ok := hotp.Validate(generateCode(t, o, 5), o, 10)
+		require.True(t, ok)
+		assert.EqualValues(t, 5, counter)
+	})
+
+	t.Run("case=accepts counter values within the look-ahead window", func(t *testing.T) {
+		o := &identity.CredentialsHOTPConfig{Secret: testSecret, Counter: 5}
+
+		counter, ok := hotp.Validate(generateCode(t, o, 15), o, 10)
+		require.True(t, ok)
+		assert.EqualValues(t, 15, counter)
+
+		_, ok = hotp.Validate(generateCode(t, o, 16), o, 10)
+		assert.False(t, ok)
+	})
+
+	t.Run("case=rejects earlier counter values", func(t *testing.T) {
+		o := &identity.CredentialsHOTPConfig{Secret: testSecret, Counter: 5}
+
+		_, ok := hotp.Validate(generateCode(t, o, 4), o, 10)
+		assert.False(t, ok)
+	})
+
+	t.Run("case=respects algorithm and digits", func(t *testing.T) {
+		o := &identity.CredentialsHOTPConfig{Secret: testSecret, Algorithm: "SHA512", Digits: 8}
+		code := generateCode(t, o, 0)
+		require.Len(t, code, 8)
+
+		_, ok := hotp.Validate(code, o, 0)
+		assert.True(t, ok)
+
+		_, ok = hotp.Validate(code, &identity.CredentialsHOTPConfig{Secret: testSecret, Digits: 8}, 0)
+		assert.False(t, ok)
+	})
+}
+
+func TestFindResync(t *testing.T) {
+	o := &identity.CredentialsHOTPConfig{Secret: testSecret, Counter: 5}
+
+	t.Run("case=finds counter values after the look-ahead window", func(t *testing.T) {
+		counter, ok := hotp.FindResync(generateCode(t, o, 50), o, 10, 100)
+		require.True(t, ok)
+		assert.EqualValues(t, 50, counter)
+	})
+
+	t.Run("case=ignores counter values within the look-ahead window", func(t *testing.T) {
+		_, ok := hotp.FindResync(generateCode(t, o, 10), o, 10, 100)
+		assert.False(t, ok)
+	})
+
+	t.Run("case=ignores counter values after the resynchronisation window", func(t *testing.T) {
+		_, ok := hotp.FindResync(generateCode(t, o, 106), o, 10, 100)
+		assert.False(t, ok)
+	})
+
+	t.Run("case=is disabled if the window does not exceed the look-ahead window", func(t *testing.T) {
+		_, ok := hotp.FindResync(generateCode(t, o, 10), o, 10, 10)
+		assert.False(t, ok)
+	})
+}
+
+func TestValidateNext(t *testing.T) {
+	o := &identity.CredentialsHOTPConfig{Secret: testSecret}
+
+	assert.True(t, hotp.ValidateNext(generateCode(t, o, 51), o, 50))
+	assert.False(t, hotp.ValidateNext(generateCode(t, o, 50), o, 50))
+	assert.False(t, hotp.ValidateNext(generateCode(t, o, 52), o, 50))
+}
@@ -0,0 +1,167 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hotp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+)
+
+// internalContextKeyResync stores the counter value of a code which was found in the resynchronisation window. The
+// hardware token is resynchronised once the code of the next counter value is submitted.
+const internalContextKeyResync = "resync"
+
+func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
+}
+
+func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.AuthenticatorAssuranceLevel, sr *login.Flow) error {
+	// This strategy can only solve AAL2
+	if requestedAAL != identity.AuthenticatorAssuranceLevel2 {
+		return nil
+	}
+
+	// We have done proper validation before so this should never error
+	sess, err := s.d.SessionManager().FetchFromRequest(r.Context(), r)
+	if err != nil {
+		return err
+	}
+
+	id, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), sess.IdentityID)
+	if err != nil {
+		return err
+	}
+
+	_, ok := id.GetCredentials(s.ID())
+	if !ok {
+		// Identity has no hardware token
+		return nil
+	}
+
+	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	sr.UI.SetNode(NewHOTPCodeNode())
+	sr.UI.GetNodes().Append(node.NewInputField("method", s.ID(), node.HOTPGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoLoginHOTP()))
+
+	return nil
+}
+
+func (s *Strategy) handleLoginError(r *http.Request, f *login.Flow, err error) error {
+	if f != nil {
+		f.UI.Nodes.ResetNodes(node.HOTPCode)
+		if f.Type == flow.TypeBrowser {
+			f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		}
+	}
+
+	return err
+}
+
+// Update Login Flow with HOTP Method
+//
+// swagger:model updateLoginFlowWithHotpMethod
+type updateLoginFlowWithHotpMethod struct {
+	// Method should be set to "hotp" when logging in using the HOTP strategy.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `json:"csrf_token"`
+
+	// The code shown by the hardware token.
+	//
+	// required: true
+	HOTPCode string `json:"hotp_code"`
+}
+
+func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow, identityID uuid.UUID) (i *identity.Identity, err error) {
+	if err := login.CheckAAL(f, identity.AuthenticatorAssuranceLevel2); err != nil {
+		return nil, err
+	}
+
+	if err := flow.MethodEnabledAndAllowedFromRequest(r, s.ID().String(), s.d); err != nil {
+		return nil, err
+	}
+
+	var p updateLoginFlowWithHotpMethod
+	if err := s.hd.Decode(r, &p,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.MustHTTPRawJSONSchemaCompiler(loginSchema),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, f.Type, s.d.Config().DisableAPIFlowEnforcement(r.Context()), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), identityID.String())
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewNoHOTPDeviceRegistered()))
+	}
+
+	var o identity.CredentialsHOTPConfig
+	if err := json.Unmarshal(c.Config, &o); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The HOTP credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	f.EnsureInternalContext()
+	resync := gjson.GetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), internalContextKeyResync))
+	f.InternalContext, err = sjson.DeleteBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), internalContextKeyResync))
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(err))
+	}
+
+	var next uint64
+	if resync.Exists() && resync.Uint() >= o.Counter && ValidateNext(p.HOTPCode, &o, resync.Uint()) {
+		// The code follows the one found in the resynchronisation window.
+		next = resync.Uint() + 2
+	} else if counter, ok := Validate(p.HOTPCode, &o, s.d.Config().HOTPLookAhead(r.Context())); ok {
+		next = counter + 1
+	} else if counter, ok := FindResync(p.HOTPCode, &o, s.d.Config().HOTPLookAhead(r.Context()), s.d.Config().HOTPResyncWindow(r.Context())); ok {
+		f.InternalContext, err = sjson.SetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), internalContextKeyResync), counter)
+		if err != nil {
+			return nil, s.handleLoginError(r, f, errors.WithStack(err))
+		}
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewHOTPResyncRequiredError()))
+	} else {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewTOTPVerifierWrongError("#/")))
+	}
+
+	// Codes of this and all earlier counter values must not be usable again.
+	o.Counter = next
+	encoded, err := json.Marshal(&o)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to encode updated HOTP credentials.").WithDebug(err.Error())))
+	}
+
+	// The counter is only advanced if no concurrent request used a code in the meantime.
+	if err := s.d.PrivilegedIdentityPool().CompareAndSwapCredentialsConfig(r.Context(), i.ID, s.ID(), c.Config, encoded); errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewTOTPCodeAlreadyUsedError()))
+	} else if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to update identity.").WithDebug(err.Error())))
+	}
+
+	f.Active = s.ID()
+	if err = s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Could not update flow").WithDebug(err.Error())))
+	}
+
+	return i, nil
+}
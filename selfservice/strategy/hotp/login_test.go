@@ -0,0 +1,299 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hotp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	kratos "github.com/ory/kratos/internal/httpclient"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/assertx"
+	"github.com/ory/x/ioutilx"
+)
+
+func TestCompleteLogin(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword)+".enabled", false)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeHOTP)+".enabled", true)
+
+	router := x.NewRouterPublic()
+	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
+
+	errTS := testhelpers.NewErrorTestServer(t, reg)
+	uiTS := testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	redirTS := testhelpers.NewRedirSessionEchoTS(t, reg)
+
+	// Overwrite these two to make it more explicit when tests fail
+	conf.MustSet(ctx, config.ViperKeySelfServiceErrorUI, errTS.URL+"/error-ts")
+	conf.MustSet(ctx, config.ViperKeySelfServiceLoginUI, uiTS.URL+"/login-ts")
+
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeySecretsDefault, []string{"not-a-secure-session-key"})
+
+	type flowType string
+	const (
+		flowTypeAPI     flowType = "api"
+		flowTypeBrowser flowType = "browser"
+		flowTypeSPA     flowType = "spa"
+	)
+
+	newClient := func(t *testing.T, ft flowType, id *identity.Identity) *http.Client {
+		if ft == flowTypeAPI {
+			return testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+		}
+		return testhelpers.NewHTTPClientWithIdentitySessionCookie(t, reg, id)
+	}
+
+	initFlow := func(t *testing.T, ft flowType, client *http.Client) *kratos.LoginFlow {
+		if ft == flowTypeAPI {
+			return testhelpers.InitializeLoginFlowViaAPI(t, client, publicTS, false, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel2))
+		}
+		return testhelpers.InitializeLoginFlowViaBrowser(t, client, publicTS, false, ft == flowTypeSPA, false, false, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel2))
+	}
+
+	submit := func(t *testing.T, ft flowType, client *http.Client, f *kratos.LoginFlow, code string) (string, *http.Response) {
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set("method", identity.CredentialsTypeHOTP.String())
+		values.Set(node.HOTPCode, code)
+		if ft == flowTypeAPI {
+			return testhelpers.LoginMakeRequest(t, true, false, f, client, testhelpers.EncodeFormAsJSON(t, true, values))
+		}
+		return testhelpers.LoginMakeRequest(t, false, ft == flowTypeSPA, f, client, values.Encode())
+	}
+
+	checkURL := func(t *testing.T, ft flowType, res *http.Response) {
+		if ft == flowTypeBrowser {
+			assert.Contains(t, res.Request.URL.String(), uiTS.URL+"/login-ts")
+		} else {
+			assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+		}
+	}
+
+	checkSignedIn := func(t *testing.T, ft flowType, body string, res *http.Response) {
+		prefix := "session."
+		if ft == flowTypeBrowser {
+			assert.Contains(t, res.Request.URL.String(), redirTS.URL+"/return-ts")
+			prefix = ""
+		} else {
+			assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+		}
+
+		assert.True(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, gjson.Get(body, prefix+"authenticator_assurance_level").String(), "%s", body)
+		require.Len(t, gjson.Get(body, prefix+"authentication_methods").Array(), 2, "%s", body)
+		assert.EqualValues(t, identity.CredentialsTypePassword, gjson.Get(body, prefix+"authentication_methods.0.method").String(), "%s", body)
+		assert.EqualValues(t, identity.CredentialsTypeHOTP, gjson.Get(body, prefix+"authentication_methods.1.method").String(), "%s", body)
+	}
+
+	checkInvalid := func(t *testing.T, ft flowType, body string, res *http.Response, message *text.Message) {
+		checkURL(t, ft, res)
+		assert.NotEmpty(t, gjson.Get(body, "id").String(), "%s", body)
+		assert.Contains(t, body, message.Text)
+	}
+
+	counter := func(t *testing.T, id *identity.Identity) uint64 {
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id.ID)
+		require.NoError(t, err)
+		c, ok := i.GetCredentials(identity.CredentialsTypeHOTP)
+		require.True(t, ok)
+
+		var o identity.CredentialsHOTPConfig
+		require.NoError(t, json.Unmarshal(c.Config, &o))
+		return o.Counter
+	}
+
+	t.Run("case=code input is shown if the identity has a hardware token", func(t *testing.T) {
+		id := createIdentity(t, reg, &identity.CredentialsHOTPConfig{Secret: testSecret})
+
+		f := initFlow(t, flowTypeAPI, newClient(t, flowTypeAPI, id))
+		nodes, err := json.Marshal(f.Ui.Nodes)
+		require.NoError(t, err)
+
+		assert.True(t, gjson.GetBytes(nodes, "#(attributes.name==hotp_code)").Exists(), "%s", nodes)
+		assert.Equal(t, "hotp", gjson.GetBytes(nodes, "#(attributes.name==method).attributes.value").String(), "%s", nodes)
+	})
+
+	t.Run("case=code input is not shown if the identity has no hardware token", func(t *testing.T) {
+		id := createIdentity(t, reg, nil)
+
+		f := initFlow(t, flowTypeAPI, newClient(t, flowTypeAPI, id))
+		assertx.EqualAsJSON(t, nil, f.Ui.Nodes)
+	})
+
+	t.Run("case=should pass with a valid code", func(t *testing.T) {
+		for _, ft := range []flowType{flowTypeAPI, flowTypeBrowser, flowTypeSPA} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				o := &identity.CredentialsHOTPConfig{Secret: testSecret, Counter: 3}
+				id := createIdentity(t, reg, o)
+				client := newClient(t, ft, id)
+
+				body, res := submit(t, ft, client, initFlow(t, ft, client), generateCode(t, o, 3))
+				checkSignedIn(t, ft, body, res)
+				assert.EqualValues(t, 4, counter(t, id))
+			})
+		}
+	})
+
+	t.Run("case=should reject a code which was already used", func(t *testing.T) {
+		o := &identity.CredentialsHOTPConfig{Secret: testSecret}
+		id := createIdentity(t, reg, o)
+		client := newClient(t, flowTypeAPI, id)
+		code := generateCode(t, o, 0)
+
+		body, res := submit(t, flowTypeAPI, client, initFlow(t, flowTypeAPI, client), code)
+		checkSignedIn(t, flowTypeAPI, body, res)
+
+		// The session is at AAL2 now, so a new one is needed to start another flow.
+		client = newClient(t, flowTypeAPI, id)
+		body, res = submit(t, flowTypeAPI, client, initFlow(t, flowTypeAPI, client), code)
+		checkInvalid(t, flowTypeAPI, body, res, text.NewErrorValidationTOTPVerifierWrong())
+		assert.EqualValues(t, 1, counter(t, id))
+	})
+
+	t.Run("case=should accept a code only once when submitted concurrently", func(t *testing.T) {
+		o := &identity.CredentialsHOTPConfig{Secret: testSecret}
+		id := createIdentity(t, reg, o)
+		code := generateCode(t, o, 0)
+
+		const concurrency = 5
+		clients := make([]*http.Client, concurrency)
+		requests := make([]*http.Request, concurrency)
+		for k := range requests {
+			clients[k] = newClient(t, flowTypeAPI, id)
+			f := initFlow(t, flowTypeAPI, clients[k])
+			values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+			values.Set("method", identity.CredentialsTypeHOTP.String())
+			values.Set(node.HOTPCode, code)
+			requests[k] = testhelpers.NewRequest(t, true, "POST", f.Ui.Action, bytes.NewBufferString(testhelpers.EncodeFormAsJSON(t, true, values)))
+		}
+
+		var wg sync.WaitGroup
+		bodies := make([]string, concurrency)
+		for k := range requests {
+			wg.Add(1)
+			go func(k int) {
+				defer wg.Done()
+				res, err := clients[k].Do(requests[k])
+				if err != nil {
+					return
+				}
+				defer res.Body.Close()
+				bodies[k] = string(ioutilx.MustReadAll(res.Body))
+			}(k)
+		}
+		wg.Wait()
+
+		var accepted int
+		for _, body := range bodies {
+			if gjson.Get(body, "session.active").Bool() {
+				accepted++
+			}
+		}
+		assert.Equal(t, 1, accepted, "%v", bodies)
+		assert.EqualValues(t, 1, counter(t, id))
+	})
+
+	t.Run("case=should pass with a code within the look-ahead window", func(t *testing.T) {
+		o := &identity.CredentialsHOTPConfig{Secret: testSecret, Algorithm: "SHA256", Digits: 8}
+		id := createIdentity(t, reg, o)
+		client := newClient(t, flowTypeAPI, id)
+
+		body, res := submit(t, flowTypeAPI, client, initFlow(t, flowTypeAPI, client), generateCode(t, o, 7))
+		checkSignedIn(t, flowTypeAPI, body, res)
+		assert.EqualValues(t, 8, counter(t, id))
+	})
+
+	t.Run("case=should resynchronise with two consecutive codes", func(t *testing.T) {
+		for _, ft := range []flowType{flowTypeAPI, flowTypeBrowser, flowTypeSPA} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				o := &identity.CredentialsHOTPConfig{Secret: testSecret}
+				id := createIdentity(t, reg, o)
+				client := newClient(t, ft, id)
+				f := initFlow(t, ft, client)
+
+				body, res := submit(t, ft, client, f, generateCode(t, o, 40))
+				checkInvalid(t, ft, body, res, text.NewErrorValidationHOTPResyncRequired())
+				assert.EqualValues(t, 0, counter(t, id))
+
+				body, res = submit(t, ft, client, f, generateCode(t, o, 41))
+				checkSignedIn(t, ft, body, res)
+				assert.EqualValues(t, 42, counter(t, id))
+			})
+		}
+	})
+
+	t.Run("case=should not resynchronise with non-consecutive codes", func(t *testing.T) {
+		o := &identity.CredentialsHOTPConfig{Secret: testSecret}
+		id := createIdentity(t, reg, o)
+		client := newClient(t, flowTypeAPI, id)
+		f := initFlow(t, flowTypeAPI, client)
+
+		body, res := submit(t, flowTypeAPI, client, f, generateCode(t, o, 40))
+		checkInvalid(t, flowTypeAPI, body, res, text.NewErrorValidationHOTPResyncRequired())
+
+		body, res = submit(t, flowTypeAPI, client, f, generateCode(t, o, 60))
+		checkInvalid(t, flowTypeAPI, body, res, text.NewErrorValidationHOTPResyncRequired())
+		assert.EqualValues(t, 0, counter(t, id))
+	})
+
+	t.Run("case=should fail with an invalid code", func(t *testing.T) {
+		for _, ft := range []flowType{flowTypeAPI, flowTypeBrowser, flowTypeSPA} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				o := &identity.CredentialsHOTPConfig{Secret: testSecret}
+				id := createIdentity(t, reg, o)
+				client := newClient(t, ft, id)
+
+				body, res := submit(t, ft, client, initFlow(t, ft, client), generateCode(t, o, 500))
+				checkInvalid(t, ft, body, res, text.NewErrorValidationTOTPVerifierWrong())
+				assert.True(t, gjson.Get(body, "ui.nodes.#(attributes.name==hotp_code)").Exists(), "%s", body)
+			})
+		}
+	})
+
+	t.Run("case=should fail if the code is missing", func(t *testing.T) {
+		id := createIdentity(t, reg, &identity.CredentialsHOTPConfig{Secret: testSecret})
+		client := newClient(t, flowTypeAPI, id)
+
+		body, res := submit(t, flowTypeAPI, client, initFlow(t, flowTypeAPI, client), "")
+		checkURL(t, flowTypeAPI, res)
+		assert.Equal(t, "Property hotp_code is missing.", gjson.Get(body, "ui.nodes.#(attributes.name==hotp_code).messages.0.text").String(), "%s", body)
+	})
+
+	t.Run("case=should fail if CSRF token is invalid", func(t *testing.T) {
+		o := &identity.CredentialsHOTPConfig{Secret: testSecret}
+		id := createIdentity(t, reg, o)
+
+		t.Run("type=browser", func(t *testing.T) {
+			client := newClient(t, flowTypeBrowser, id)
+			f := initFlow(t, flowTypeBrowser, client)
+			values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+			values.Del("csrf_token")
+			values.Set(node.HOTPCode, generateCode(t, o, 0))
+			body, res := testhelpers.LoginMakeRequest(t, false, false, f, client, values.Encode())
+
+			assert.Contains(t, res.Request.URL.String(), errTS.URL)
+			assert.Equal(t, x.ErrInvalidCSRFToken.Reason(), gjson.Get(body, "reason").String(), body)
+		})
+
+		assert.EqualValues(t, 0, counter(t, id))
+	})
+
+}
@@ -0,0 +1,23 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hotp
+
+import (
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+)
+
+func NewHOTPCodeNode() *node.Node {
+	return node.NewInputField(node.HOTPCode, "", node.HOTPGroup,
+		node.InputAttributeTypeText,
+		node.WithRequiredInputAttribute).
+		WithMetaLabel(text.NewInfoLoginHOTPLabel())
+}
+
+func NewUnlinkHOTPNode() *node.Node {
+	return node.NewInputField(node.HOTPUnlink, "true", node.HOTPGroup,
+		node.InputAttributeTypeSubmit,
+		node.WithRequiredInputAttribute).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsUpdateUnlinkHOTP())
+}
@@ -0,0 +1,182 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hotp
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+)
+
+func (s *Strategy) RegisterSettingsRoutes(_ *x.RouterPublic) {
+}
+
+func (s *Strategy) SettingsStrategyID() string {
+	return identity.CredentialsTypeHOTP.String()
+}
+
+// Update Settings Flow with HOTP Method
+//
+// swagger:model updateSettingsFlowWithHotpMethod
+type updateSettingsFlowWithHotpMethod struct {
+	// UnlinkHOTP if true will remove the hardware token, effectively
+	// removing the credential. New hardware tokens are provisioned
+	// by an administrator.
+	UnlinkHOTP bool `json:"hotp_unlink"`
+
+	// CSRFToken is the anti-CSRF token
+	CSRFToken string `json:"csrf_token"`
+
+	// Method
+	//
+	// Should be set to "hotp" when trying to remove a hardware token.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Flow is flow ID.
+	//
+	// swagger:ignore
+	Flow string `json:"flow"`
+}
+
+func (p *updateSettingsFlowWithHotpMethod) GetFlowID() uuid.UUID {
+	return x.ParseUUID(p.Flow)
+}
+
+func (p *updateSettingsFlowWithHotpMethod) SetFlowID(rid uuid.UUID) {
+	p.Flow = rid.String()
+}
+
+func (s *Strategy) Settings(w http.ResponseWriter, r *http.Request, f *settings.Flow, ss *session.Session) (*settings.UpdateContext, error) {
+	var p updateSettingsFlowWithHotpMethod
+	ctxUpdate, err := settings.PrepareUpdate(s.d, w, r, f, ss, settings.ContinuityKey(s.SettingsStrategyID()), &p)
+	if errors.Is(err, settings.ErrContinuePreviousAction) {
+		return ctxUpdate, s.continueSettingsFlow(w, r, ctxUpdate, &p)
+	} else if err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if err := s.decodeSettingsFlow(r, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if p.UnlinkHOTP {
+		// This is a submit so we need to manually set the type to HOTP
+		p.Method = s.SettingsStrategyID()
+		if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+			return nil, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+		}
+	} else if err := flow.MethodEnabledAndAllowedFromRequest(r, s.SettingsStrategyID(), s.d); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	// This does not come from the payload!
+	p.Flow = ctxUpdate.Flow.ID.String()
+	if err := s.continueSettingsFlow(w, r, ctxUpdate, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	return ctxUpdate, nil
+}
+
+func (s *Strategy) decodeSettingsFlow(r *http.Request, dest interface{}) error {
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(settingsSchema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return decoderx.NewHTTP().Decode(r, dest, compiler,
+		decoderx.HTTPDecoderAllowedMethods("POST", "GET"),
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	)
+}
+
+func (s *Strategy) continueSettingsFlow(
+	w http.ResponseWriter, r *http.Request,
+	ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithHotpMethod,
+) error {
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+		return err
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, ctxUpdate.Flow.Type, s.d.Config().DisableAPIFlowEnforcement(r.Context()), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return err
+	}
+
+	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(r.Context())).Before(time.Now()) {
+		return errors.WithStack(settings.NewFlowNeedsReAuth())
+	}
+
+	if !p.UnlinkHOTP {
+		ctxUpdate.UpdateIdentity(ctxUpdate.Session.Identity)
+		return nil
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), ctxUpdate.Session.Identity.ID)
+	if err != nil {
+		return err
+	}
+
+	i.DeleteCredentialsType(identity.CredentialsTypeHOTP)
+	ctxUpdate.UpdateIdentity(i)
+	return nil
+}
+
+func (s *Strategy) identityHasHOTP(ctx context.Context, id uuid.UUID) (bool, error) {
+	confidential, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := s.CountActiveMultiFactorCredentials(confidential.Credentials)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (s *Strategy) PopulateSettingsMethod(r *http.Request, id *identity.Identity, f *settings.Flow) error {
+	hasHOTP, err := s.identityHasHOTP(r.Context(), id.ID)
+	if err != nil {
+		return err
+	}
+
+	// Hardware tokens are provisioned by an administrator, so there is nothing to show without one.
+	if !hasHOTP {
+		return nil
+	}
+
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.Nodes.Upsert(NewUnlinkHOTPNode())
+	return nil
+}
+
+func (s *Strategy) handleSettingsError(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithHotpMethod, err error) error {
+	// Do not pause flow if the flow type is an API flow as we can't save cookies in those flows.
+	if e := new(settings.FlowNeedsReAuth); errors.As(err, &e) && ctxUpdate.Flow != nil && ctxUpdate.Flow.Type == flow.TypeBrowser {
+		if err := s.d.ContinuityManager().Pause(r.Context(), w, r, settings.ContinuityKey(s.SettingsStrategyID()), settings.ContinuityOptions(p, ctxUpdate.GetSessionIdentity())...); err != nil {
+			return err
+		}
+	}
+
+	if ctxUpdate.Flow != nil {
+		ctxUpdate.Flow.UI.ResetMessages()
+		ctxUpdate.Flow.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+
+	return err
+}
@@ -0,0 +1,158 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hotp_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/assertx"
+)
+
+func TestCompleteSettings(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword)+".enabled", false)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".profile.enabled", false)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeHOTP)+".enabled", true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceSettingsRequiredAAL, "aal1")
+
+	router := x.NewRouterPublic()
+	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
+
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	uiTS := testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewRedirSessionEchoTS(t, reg)
+	loginTS := testhelpers.NewLoginUIFlowEchoServer(t, reg)
+
+	conf.MustSet(ctx, config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeySecretsDefault, []string{"not-a-secure-session-key"})
+
+	doAPIFlow := func(t *testing.T, v func(url.Values), id *identity.Identity) (string, *http.Response) {
+		apiClient := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		v(values)
+		payload := testhelpers.EncodeFormAsJSON(t, true, values)
+		return testhelpers.SettingsMakeRequest(t, true, false, f, apiClient, payload)
+	}
+
+	doBrowserFlow := func(t *testing.T, spa bool, v func(url.Values), id *identity.Identity) (string, *http.Response) {
+		browserClient := testhelpers.NewHTTPClientWithIdentitySessionCookie(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaBrowser(t, browserClient, spa, publicTS)
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		v(values)
+		return testhelpers.SettingsMakeRequest(t, false, spa, f, browserClient, testhelpers.EncodeFormAsJSON(t, spa, values))
+	}
+
+	hasHOTP := func(t *testing.T, id *identity.Identity) bool {
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id.ID)
+		require.NoError(t, err)
+		_, ok := i.GetCredentials(identity.CredentialsTypeHOTP)
+		return ok
+	}
+
+	unlink := func(v url.Values) {
+		v.Set(node.HOTPUnlink, "true")
+	}
+
+	t.Run("case=unlink button is shown if the identity has a hardware token", func(t *testing.T) {
+		id := createIdentity(t, reg, &identity.CredentialsHOTPConfig{Secret: testSecret})
+		apiClient := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
+
+		var found bool
+		for _, n := range f.Ui.Nodes {
+			if n.Group == node.HOTPGroup.String() && n.Attributes.UiNodeInputAttributes.Name == node.HOTPUnlink {
+				found = true
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("case=unlink button is not shown if the identity has no hardware token", func(t *testing.T) {
+		id := createIdentity(t, reg, nil)
+		apiClient := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
+
+		for _, n := range f.Ui.Nodes {
+			assert.NotEqual(t, node.HOTPGroup.String(), n.Group)
+		}
+	})
+
+	t.Run("case=unlinks the hardware token", func(t *testing.T) {
+		for _, tc := range []struct {
+			d  string
+			do func(t *testing.T, v func(url.Values), id *identity.Identity) (string, *http.Response)
+		}{
+			{d: "api", do: doAPIFlow},
+			{d: "spa", do: func(t *testing.T, v func(url.Values), id *identity.Identity) (string, *http.Response) {
+				return doBrowserFlow(t, true, v, id)
+			}},
+			{d: "browser", do: func(t *testing.T, v func(url.Values), id *identity.Identity) (string, *http.Response) {
+				return doBrowserFlow(t, false, v, id)
+			}},
+		} {
+			t.Run("type="+tc.d, func(t *testing.T) {
+				id := createIdentity(t, reg, &identity.CredentialsHOTPConfig{Secret: testSecret})
+
+				actual, res := tc.do(t, unlink, id)
+				assert.Equal(t, http.StatusOK, res.StatusCode, actual)
+				assert.EqualValues(t, settings.StateSuccess, gjson.Get(actual, "state").String(), actual)
+				assert.False(t, gjson.Get(actual, `ui.nodes.#(attributes.name=="hotp_unlink")`).Exists(), actual)
+				assert.False(t, hasHOTP(t, id))
+				if tc.d == "browser" {
+					assert.Contains(t, res.Request.URL.String(), uiTS.URL)
+				}
+			})
+		}
+	})
+
+	t.Run("case=can not unlink without privileged session", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1ns")
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+		})
+
+		id := createIdentity(t, reg, &identity.CredentialsHOTPConfig{Secret: testSecret})
+
+		t.Run("type=api", func(t *testing.T) {
+			actual, res := doAPIFlow(t, unlink, id)
+			assert.Equal(t, http.StatusForbidden, res.StatusCode)
+			assert.Contains(t, gjson.Get(actual, "redirect_browser_to").String(), publicTS.URL+"/self-service/login/browser?refresh=true&return_to=")
+			assert.True(t, hasHOTP(t, id))
+		})
+
+		t.Run("type=spa", func(t *testing.T) {
+			actual, res := doBrowserFlow(t, true, unlink, id)
+			assert.Equal(t, http.StatusForbidden, res.StatusCode)
+			assert.Contains(t, gjson.Get(actual, "redirect_browser_to").String(), publicTS.URL+"/self-service/login/browser?refresh=true&return_to=")
+			assert.True(t, hasHOTP(t, id))
+		})
+
+		t.Run("type=browser", func(t *testing.T) {
+			actual, res := doBrowserFlow(t, false, unlink, id)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Contains(t, res.Request.URL.String(), loginTS.URL+"/login-ts")
+			assertx.EqualAsJSON(t, text.NewInfoLoginReAuth().Text, gjson.Get(actual, "ui.messages.0.text").String(), actual)
+			assert.True(t, hasHOTP(t, id))
+		})
+	})
+}
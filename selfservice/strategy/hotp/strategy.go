@@ -0,0 +1,105 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hotp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+)
+
+var _ login.Strategy = new(Strategy)
+var _ settings.Strategy = new(Strategy)
+var _ identity.ActiveCredentialsCounter = new(Strategy)
+
+type strategyDependencies interface {
+	x.LoggingProvider
+	x.WriterProvider
+	x.CSRFTokenGeneratorProvider
+	x.CSRFProvider
+
+	config.Provider
+
+	continuity.ManagementProvider
+
+	errorx.ManagementProvider
+
+	login.HooksProvider
+	login.ErrorHandlerProvider
+	login.HookExecutorProvider
+	login.FlowPersistenceProvider
+	login.HandlerProvider
+
+	settings.FlowPersistenceProvider
+	settings.HookExecutorProvider
+	settings.HooksProvider
+	settings.ErrorHandlerProvider
+
+	identity.PrivilegedPoolProvider
+	identity.ValidationProvider
+
+	session.HandlerProvider
+	session.ManagementProvider
+}
+
+// Strategy accepts codes of counter-based (HOTP) hardware tokens as a second factor. The tokens
+// are provisioned by importing credentials through the admin API.
+type Strategy struct {
+	d  strategyDependencies
+	hd *decoderx.HTTP
+}
+
+func NewStrategy(d strategyDependencies) *Strategy {
+	return &Strategy{
+		d:  d,
+		hd: decoderx.NewHTTP(),
+	}
+}
+
+func (s *Strategy) CountActiveFirstFactorCredentials(cc map[identity.CredentialsType]identity.Credentials) (count int, err error) {
+	return 0, nil
+}
+
+func (s *Strategy) CountActiveMultiFactorCredentials(cc map[identity.CredentialsType]identity.Credentials) (count int, err error) {
+	for _, c := range cc {
+		if c.Type == s.ID() && len(c.Config) > 0 {
+			var conf identity.CredentialsHOTPConfig
+			if err = json.Unmarshal(c.Config, &conf); err != nil {
+				return 0, errors.WithStack(err)
+			}
+
+			if len(c.Identifiers) > 0 && len(c.Identifiers[0]) > 0 && len(conf.Secret) > 0 {
+				count++
+			}
+		}
+	}
+	return
+}
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeHOTP
+}
+
+func (s *Strategy) NodeGroup() node.UiNodeGroup {
+	return node.HOTPGroup
+}
+
+func (s *Strategy) CompletedAuthenticationMethod(ctx context.Context) session.AuthenticationMethod {
+	return session.AuthenticationMethod{
+		Method: s.ID(),
+		AAL:    identity.AuthenticatorAssuranceLevel2,
+	}
+}
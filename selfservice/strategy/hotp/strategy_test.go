@@ -0,0 +1,119 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package hotp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/selfservice/strategy/hotp"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/sqlxx"
+)
+
+// createIdentity creates an identity with a password and, if conf is not nil, a hardware token.
+func createIdentity(t *testing.T, reg driver.Registry, conf *identity.CredentialsHOTPConfig) *identity.Identity {
+	email := x.NewUUID().String() + "@ory.sh"
+	password := x.NewUUID().String()
+	p, err := reg.Hasher(context.Background()).Generate(context.Background(), []byte(password))
+	require.NoError(t, err)
+
+	i := &identity.Identity{
+		Traits: identity.Traits(fmt.Sprintf(`{"email":"%s"}`, email)),
+	}
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+
+	i.Credentials = map[identity.CredentialsType]identity.Credentials{
+		identity.CredentialsTypePassword: {
+			Type:        identity.CredentialsTypePassword,
+			Identifiers: []string{email},
+			Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(p) + `"}`),
+		},
+	}
+
+	if conf != nil {
+		c, err := json.Marshal(conf)
+		require.NoError(t, err)
+		i.Credentials[identity.CredentialsTypeHOTP] = identity.Credentials{
+			Type:        identity.CredentialsTypeHOTP,
+			Identifiers: []string{i.ID.String()},
+			Config:      c,
+		}
+	}
+
+	require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(context.Background(), i))
+	return i
+}
+
+func TestCountActiveCredentials(t *testing.T) {
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	strategy := hotp.NewStrategy(reg)
+
+	t.Run("first factor", func(t *testing.T) {
+		actual, err := strategy.CountActiveFirstFactorCredentials(nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, actual)
+	})
+
+	t.Run("multi factor", func(t *testing.T) {
+		for k, tc := range []struct {
+			in       identity.CredentialsCollection
+			expected int
+		}{
+			{
+				in: identity.CredentialsCollection{{
+					Type:   strategy.ID(),
+					Config: []byte{},
+				}},
+				expected: 0,
+			},
+			{
+				in: identity.CredentialsCollection{{
+					Type:        strategy.ID(),
+					Identifiers: []string{"foo"},
+					Config:      []byte(`{"secret": ""}`),
+				}},
+				expected: 0,
+			},
+			{
+				in: identity.CredentialsCollection{{
+					Type:   strategy.ID(),
+					Config: []byte(`{"secret": "` + testSecret + `"}`),
+				}},
+				expected: 0,
+			},
+			{
+				in: identity.CredentialsCollection{{
+					Type:        strategy.ID(),
+					Identifiers: []string{"foo"},
+					Config:      []byte(`{"secret": "` + testSecret + `", "counter": 3}`),
+				}},
+				expected: 1,
+			},
+			{
+				in:       identity.CredentialsCollection{{}, {}},
+				expected: 0,
+			},
+		} {
+			t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+				cc := map[identity.CredentialsType]identity.Credentials{}
+				for _, c := range tc.in {
+					cc[c.Type] = c
+				}
+
+				actual, err := strategy.CountActiveMultiFactorCredentials(cc)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			})
+		}
+	})
+}
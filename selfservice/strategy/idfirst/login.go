@@ -0,0 +1,154 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package idfirst
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+)
+
+func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
+}
+
+// PopulateLoginMethod does nothing because the identifier step is only shown through
+// PopulateLoginMethodIdentifierFirstIdentification.
+func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.AuthenticatorAssuranceLevel, sr *login.Flow) error {
+	return nil
+}
+
+func (s *Strategy) PopulateLoginMethodIdentifierFirstIdentification(r *http.Request, sr *login.Flow) error {
+	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	sr.UI.SetNode(node.NewInputField("identifier", "", node.DefaultGroup, node.InputAttributeTypeText, node.WithRequiredInputAttribute).WithMetaLabel(text.NewInfoNodeLabelID()))
+	sr.UI.GetNodes().Append(node.NewInputField("method", s.ID(), node.IdentifierFirstGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelContinue()))
+	return nil
+}
+
+func (s *Strategy) PopulateLoginMethodIdentifierFirstCredentials(r *http.Request, sr *login.Flow, identifier string, i *identity.Identity) error {
+	return nil
+}
+
+func (s *Strategy) handleLoginError(r *http.Request, f *login.Flow, payload *updateLoginFlowWithIdentifierFirstMethod, err error) error {
+	if f != nil {
+		f.UI.Nodes.SetValueAttribute("identifier", payload.Identifier)
+		if f.Type == flow.TypeBrowser {
+			f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		}
+	}
+
+	return err
+}
+
+// Update Login Flow with Identifier First Method
+//
+// swagger:model updateLoginFlowWithIdentifierFirstMethod
+type updateLoginFlowWithIdentifierFirstMethod struct {
+	// Method should be set to "identifier_first" when submitting the identifier of the identifier-first login.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `json:"csrf_token"`
+
+	// The identifier of the account, for example the email address.
+	//
+	// required: true
+	Identifier string `json:"identifier"`
+}
+
+// Login never completes the login flow. It renders the login methods available for the submitted identifier
+// and asks the user interface to show the flow again. The flow is stored by the login error handler.
+func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow, identityID uuid.UUID) (i *identity.Identity, err error) {
+	if err := login.CheckAAL(f, identity.AuthenticatorAssuranceLevel1); err != nil {
+		return nil, err
+	}
+
+	if err := flow.MethodEnabledAndAllowedFromRequest(r, s.ID().String(), s.d); err != nil {
+		return nil, err
+	}
+
+	var p updateLoginFlowWithIdentifierFirstMethod
+	if err := s.hd.Decode(r, &p,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.MustHTTPRawJSONSchemaCompiler(loginSchema),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return nil, s.handleLoginError(r, f, &p, err)
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, f.Type, s.d.Config().DisableAPIFlowEnforcement(r.Context()), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return nil, s.handleLoginError(r, f, &p, err)
+	}
+
+	conf, err := s.Config(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	if !conf.EnumerationProtection {
+		i, err = s.findIdentity(r.Context(), p.Identifier)
+		if errors.Is(err, sqlcon.ErrNoRows) {
+			return nil, s.handleLoginError(r, f, &p, errors.WithStack(schema.NewAccountNotFoundError()))
+		} else if err != nil {
+			return nil, s.handleLoginError(r, f, &p, err)
+		}
+	}
+
+	previous := f.UI.Nodes
+	f.UI.ResetMessages()
+	f.UI.Nodes = node.Nodes{}
+	if f.Type == flow.TypeBrowser {
+		f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+	f.UI.SetNode(node.NewInputField("identifier", p.Identifier, node.DefaultGroup, node.InputAttributeTypeHidden))
+	prepared := len(f.UI.Nodes)
+
+	for _, ls := range s.d.LoginStrategies(r.Context()) {
+		if ifs, ok := ls.(login.IdentifierFirstStrategy); ok {
+			if err := ifs.PopulateLoginMethodIdentifierFirstCredentials(r, f, p.Identifier, i); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(f.UI.Nodes) == prepared {
+		// Without enumeration protection the account exists but has no login method which can be shown.
+		f.UI.Nodes = previous
+		return nil, s.handleLoginError(r, f, &p, errors.WithStack(schema.NewAccountNotFoundError()))
+	}
+
+	return nil, errors.WithStack(flow.ErrStrategyAsksToReturnToUI)
+}
+
+// findIdentity looks up the identity using the identifiers of its credentials or, for identities which only
+// sign in with social sign in providers, its email address.
+func (s *Strategy) findIdentity(ctx context.Context, identifier string) (*identity.Identity, error) {
+	for _, ct := range []identity.CredentialsType{identity.CredentialsTypePassword, identity.CredentialsTypeWebAuthn} {
+		i, _, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, ct, identifier)
+		if err == nil {
+			return s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+		} else if !errors.Is(err, sqlcon.ErrNoRows) {
+			return nil, err
+		}
+	}
+
+	address, err := s.d.PrivilegedIdentityPool().FindVerifiableAddressByValue(ctx, identity.VerifiableAddressTypeEmail, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, address.IdentityID)
+}
@@ -0,0 +1,282 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package idfirst_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	kratos "github.com/ory/kratos/internal/httpclient"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/sqlxx"
+)
+
+func createIdentity(t *testing.T, reg driver.Registry, withPassword bool, providers ...string) (*identity.Identity, string, string) {
+	ctx := context.Background()
+	email := x.NewUUID().String() + "@ory.sh"
+	password := x.NewUUID().String()
+
+	i := identity.NewIdentity("")
+	i.Traits = identity.Traits(fmt.Sprintf(`{"email":"%s"}`, email))
+	i.VerifiableAddresses = []identity.VerifiableAddress{*identity.NewVerifiableEmailAddress(email, i.ID)}
+
+	if withPassword {
+		p, err := reg.Hasher(ctx).Generate(ctx, []byte(password))
+		require.NoError(t, err)
+		i.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{
+			Type:        identity.CredentialsTypePassword,
+			Identifiers: []string{email},
+			Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(p) + `"}`),
+		})
+	}
+
+	if len(providers) > 0 {
+		var links identity.CredentialsOIDC
+		var identifiers []string
+		for _, provider := range providers {
+			links.Providers = append(links.Providers, identity.CredentialsOIDCProvider{Provider: provider, Subject: email})
+			identifiers = append(identifiers, identity.OIDCUniqueID(provider, email))
+		}
+		c, err := identity.NewCredentialsOIDC(nil, providers[0], email)
+		require.NoError(t, err)
+		c.Identifiers = identifiers
+		i.SetCredentials(identity.CredentialsTypeOIDC, *c)
+	}
+
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+	return i, email, password
+}
+
+func TestCompleteLogin(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword)+".enabled", true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeIdentifierFirst)+".enabled", true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".enabled", true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".config", &oidc.ConfigurationCollection{Providers: []oidc.Configuration{
+		{ID: "github", Provider: "github", ClientID: "client", ClientSecret: "secret", Mapper: "file://./stub/oidc.jsonnet"},
+		{ID: "corp", Provider: "generic", ClientID: "client", ClientSecret: "secret", IssuerURL: "https://corp.example.org", Mapper: "file://./stub/oidc.jsonnet", Domains: []string{"Corp.Example.org"}},
+	}})
+
+	router := x.NewRouterPublic()
+	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
+
+	errTS := testhelpers.NewErrorTestServer(t, reg)
+	uiTS := testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	redirTS := testhelpers.NewRedirSessionEchoTS(t, reg)
+
+	// Overwrite these two to make it more explicit when tests fail
+	conf.MustSet(ctx, config.ViperKeySelfServiceErrorUI, errTS.URL+"/error-ts")
+	conf.MustSet(ctx, config.ViperKeySelfServiceLoginUI, uiTS.URL+"/login-ts")
+
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeySecretsDefault, []string{"not-a-secure-session-key"})
+
+	setEnumerationProtection := func(t *testing.T, enabled bool) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeIdentifierFirst)+".config.enumeration_protection", enabled)
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeIdentifierFirst)+".config.enumeration_protection", false)
+		})
+	}
+
+	type flowType string
+	const (
+		flowTypeAPI     flowType = "api"
+		flowTypeBrowser flowType = "browser"
+		flowTypeSPA     flowType = "spa"
+	)
+
+	newClient := func(ft flowType) *http.Client {
+		if ft == flowTypeAPI {
+			return &http.Client{}
+		}
+		return testhelpers.NewClientWithCookies(t)
+	}
+
+	initFlow := func(t *testing.T, ft flowType, client *http.Client) *kratos.LoginFlow {
+		if ft == flowTypeAPI {
+			return testhelpers.InitializeLoginFlowViaAPI(t, client, publicTS, false)
+		}
+		return testhelpers.InitializeLoginFlowViaBrowser(t, client, publicTS, false, ft == flowTypeSPA, false, false)
+	}
+
+	submit := func(t *testing.T, ft flowType, client *http.Client, f *kratos.LoginFlow, v func(url.Values)) (string, *http.Response) {
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		// Only the submit button which was clicked is sent by the browser.
+		values.Del("provider")
+		v(values)
+		if ft == flowTypeAPI {
+			return testhelpers.LoginMakeRequest(t, true, false, f, client, testhelpers.EncodeFormAsJSON(t, true, values))
+		}
+		return testhelpers.LoginMakeRequest(t, false, ft == flowTypeSPA, f, client, values.Encode())
+	}
+
+	submitIdentifier := func(t *testing.T, ft flowType, client *http.Client, identifier string) (*kratos.LoginFlow, string, *http.Response) {
+		f := initFlow(t, ft, client)
+		body, res := submit(t, ft, client, f, func(v url.Values) {
+			v.Set("method", identity.CredentialsTypeIdentifierFirst.String())
+			v.Set("identifier", identifier)
+		})
+
+		if ft == flowTypeBrowser {
+			assert.Contains(t, res.Request.URL.String(), uiTS.URL+"/login-ts", "%s", body)
+		} else {
+			assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow, "%s", body)
+		}
+
+		updated, _, err := testhelpers.NewSDKCustomClient(publicTS, client).FrontendApi.GetLoginFlow(ctx).Id(f.Id).Execute()
+		require.NoError(t, err)
+		return updated, body, res
+	}
+
+	hasNode := func(body, name, value string) bool {
+		return gjson.Get(body, fmt.Sprintf(`ui.nodes.#(attributes.name==%q)#|#(attributes.value==%q)`, name, value)).Exists()
+	}
+
+	hasPassword := func(body string) bool {
+		return gjson.Get(body, `ui.nodes.#(attributes.name=="password")`).Exists()
+	}
+
+	t.Run("case=first step only asks for the identifier", func(t *testing.T) {
+		for _, ft := range []flowType{flowTypeAPI, flowTypeSPA, flowTypeBrowser} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				f := initFlow(t, ft, newClient(ft))
+				body := x.MustEncodeJSON(t, f)
+
+				assert.True(t, hasNode(body, "identifier", ""), "%s", body)
+				assert.True(t, hasNode(body, "method", "identifier_first"), "%s", body)
+				assert.False(t, hasPassword(body), "%s", body)
+				assert.False(t, hasNode(body, "provider", "corp"), "%s", body)
+				assert.Equal(t, ft != flowTypeAPI, hasNode(body, "provider", "github"), "%s", body)
+			})
+		}
+	})
+
+	t.Run("case=second step shows the password and logs in", func(t *testing.T) {
+		for _, ft := range []flowType{flowTypeAPI, flowTypeSPA, flowTypeBrowser} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				client := newClient(ft)
+				_, email, password := createIdentity(t, reg, true)
+
+				f, body, res := submitIdentifier(t, ft, client, email)
+				assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+				assert.True(t, hasNode(body, "identifier", email), "%s", body)
+				assert.True(t, hasPassword(body), "%s", body)
+				assert.False(t, hasNode(body, "method", "identifier_first"), "%s", body)
+				assert.False(t, hasNode(body, "provider", "github"), "%s", body)
+
+				body, res = submit(t, ft, client, f, func(v url.Values) {
+					v.Set("method", identity.CredentialsTypePassword.String())
+					v.Set("password", password)
+				})
+
+				prefix := "session."
+				if ft == flowTypeBrowser {
+					assert.Contains(t, res.Request.URL.String(), redirTS.URL+"/return-ts")
+					prefix = ""
+				}
+				assert.True(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+				assert.Equal(t, email, gjson.Get(body, prefix+"identity.traits.email").String(), "%s", body)
+			})
+		}
+	})
+
+	t.Run("case=second step only shows linked providers", func(t *testing.T) {
+		for _, ft := range []flowType{flowTypeSPA, flowTypeBrowser} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				_, email, _ := createIdentity(t, reg, false, "github")
+
+				_, body, _ := submitIdentifier(t, ft, newClient(ft), email)
+				assert.True(t, hasNode(body, "provider", "github"), "%s", body)
+				assert.False(t, hasNode(body, "provider", "corp"), "%s", body)
+				assert.False(t, hasPassword(body), "%s", body)
+			})
+		}
+	})
+
+	t.Run("case=unknown accounts are reported without enumeration protection", func(t *testing.T) {
+		for _, ft := range []flowType{flowTypeAPI, flowTypeSPA, flowTypeBrowser} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				identifier := x.NewUUID().String() + "@ory.sh"
+
+				_, body, res := submitIdentifier(t, ft, newClient(ft), identifier)
+				if ft != flowTypeBrowser {
+					assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+				}
+				assert.EqualValues(t, text.ErrorValidationAccountNotFound, gjson.Get(body, `ui.nodes.#(attributes.name=="identifier").messages.0.id`).Int(), "%s", body)
+				assert.True(t, hasNode(body, "identifier", identifier), "%s", body)
+				assert.True(t, hasNode(body, "method", "identifier_first"), "%s", body)
+				assert.False(t, hasPassword(body), "%s", body)
+			})
+		}
+	})
+
+	t.Run("case=unknown accounts are not revealed with enumeration protection", func(t *testing.T) {
+		setEnumerationProtection(t, true)
+
+		for _, ft := range []flowType{flowTypeAPI, flowTypeSPA, flowTypeBrowser} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				_, email, _ := createIdentity(t, reg, false, "github")
+				for _, identifier := range []string{email, x.NewUUID().String() + "@ory.sh"} {
+					_, body, res := submitIdentifier(t, ft, newClient(ft), identifier)
+					assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+					assert.Empty(t, gjson.Get(body, "ui.messages").Array(), "%s", body)
+					assert.True(t, hasPassword(body), "%s", body)
+					assert.False(t, hasNode(body, "provider", "github"), "%s", body)
+				}
+			})
+		}
+	})
+
+	t.Run("case=email domains are routed to their provider", func(t *testing.T) {
+		setEnumerationProtection(t, true)
+
+		for _, ft := range []flowType{flowTypeSPA, flowTypeBrowser} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				_, body, _ := submitIdentifier(t, ft, newClient(ft), "someone@corp.example.org")
+				assert.True(t, hasNode(body, "provider", "corp"), "%s", body)
+				assert.False(t, hasNode(body, "provider", "github"), "%s", body)
+			})
+		}
+	})
+
+	t.Run("case=refreshing shows the methods of the session's identity", func(t *testing.T) {
+		id, email, _ := createIdentity(t, reg, true)
+		client := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+
+		f := testhelpers.InitializeLoginFlowViaAPI(t, client, publicTS, true)
+		body := x.MustEncodeJSON(t, f)
+		assert.True(t, hasNode(body, "identifier", email), "%s", body)
+		assert.True(t, hasPassword(body), "%s", body)
+		assert.False(t, hasNode(body, "method", "identifier_first"), "%s", body)
+	})
+
+	t.Run("case=all methods are shown if identifier first is disabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeIdentifierFirst)+".enabled", false)
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeIdentifierFirst)+".enabled", true)
+		})
+
+		f := initFlow(t, flowTypeSPA, newClient(flowTypeSPA))
+		body := x.MustEncodeJSON(t, f)
+		assert.True(t, hasPassword(body), "%s", body)
+		assert.True(t, hasNode(body, "provider", "corp"), "%s", body)
+		assert.False(t, hasNode(body, "method", "identifier_first"), "%s", body)
+	})
+}
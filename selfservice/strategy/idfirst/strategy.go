@@ -0,0 +1,82 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package idfirst
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+)
+
+var _ login.Strategy = new(Strategy)
+var _ login.IdentifierFirstStrategy = new(Strategy)
+
+type strategyDependencies interface {
+	x.LoggingProvider
+	x.CSRFTokenGeneratorProvider
+	x.CSRFProvider
+
+	config.Provider
+
+	login.StrategyProvider
+
+	identity.PrivilegedPoolProvider
+}
+
+// Strategy splits the first factor of the login flow into two steps. The first step only asks for the
+// identifier. The second step only shows the login methods which are available for that identifier.
+type Strategy struct {
+	d  strategyDependencies
+	hd *decoderx.HTTP
+}
+
+// Configuration is the configuration of the identifier-first login style.
+type Configuration struct {
+	// EnumerationProtection hides whether an account exists. If enabled, the second step only shows login
+	// methods which do not depend on the account, such as the password field and providers matching the
+	// domain of the identifier.
+	EnumerationProtection bool `json:"enumeration_protection"`
+}
+
+func NewStrategy(d strategyDependencies) *Strategy {
+	return &Strategy{
+		d:  d,
+		hd: decoderx.NewHTTP(),
+	}
+}
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeIdentifierFirst
+}
+
+func (s *Strategy) NodeGroup() node.UiNodeGroup {
+	return node.IdentifierFirstGroup
+}
+
+// CompletedAuthenticationMethod is never used because this strategy does not complete the login flow.
+func (s *Strategy) CompletedAuthenticationMethod(ctx context.Context) session.AuthenticationMethod {
+	return session.AuthenticationMethod{
+		Method: s.ID(),
+		AAL:    identity.AuthenticatorAssuranceLevel1,
+	}
+}
+
+func (s *Strategy) Config(ctx context.Context) (*Configuration, error) {
+	var c Configuration
+	conf := s.d.Config().SelfServiceStrategy(ctx, string(s.ID())).Config
+	if err := json.Unmarshal(conf, &c); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the identifier-first configuration: %s", err))
+	}
+	return &c, nil
+}
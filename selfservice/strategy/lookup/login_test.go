@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 
+	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
@@ -262,6 +263,46 @@ func TestCompleteLogin(t *testing.T) {
 		})
 	})
 
+	t.Run("case=should notify once lookup secrets run low", func(t *testing.T) {
+		// The identity has eight unused lookup secrets.
+		conf.MustSet(ctx, config.ViperKeyLookupSecretLowRemainingThreshold, 8)
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeyLookupSecretLowRemainingThreshold, 0)
+		})
+
+		id, _ := createIdentity(t, reg)
+		email := gjson.GetBytes(id.Traits, "subject").String()
+		id.VerifiableAddresses = []identity.VerifiableAddress{{
+			Value:      email,
+			Via:        identity.VerifiableAddressTypeEmail,
+			Verified:   true,
+			Status:     identity.VerifiableAddressStatusCompleted,
+			IdentityID: id.ID,
+		}}
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, id))
+
+		apiClient := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+		body, _ := doAPIFlowWithClient(t, func(v url.Values) {
+			v.Set(node.LookupCodeEnter, "key-0")
+		}, id, apiClient, false)
+		assert.EqualValues(t, 7, gjson.Get(body, "session.identity.lookup_secrets_remaining").Int(), "%s", body)
+
+		messages, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, email, messages[0].Recipient)
+		assert.Equal(t, courier.TypeLookupSecretLow, messages[0].TemplateType)
+		assert.Contains(t, messages[0].Body, "7 unused")
+
+		body, _ = doAPIFlowWithClient(t, func(v url.Values) {
+			v.Set(node.LookupCodeEnter, "key-2")
+		}, id, apiClient, true)
+		assert.EqualValues(t, 6, gjson.Get(body, "session.identity.lookup_secrets_remaining").Int(), "%s", body)
+
+		_, err = reg.CourierPersister().NextMessages(ctx, 10)
+		require.ErrorIs(t, err, courier.ErrQueueEmpty)
+	})
+
 	t.Run("case=should fail because lookup can not handle AAL1", func(t *testing.T) {
 		apiClient := testhelpers.NewDebugClient(t)
 		f := testhelpers.InitializeLoginFlowViaAPI(t, apiClient, publicTS, false)
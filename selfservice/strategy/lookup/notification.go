@@ -0,0 +1,58 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lookup
+
+import (
+	"context"
+
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+)
+
+// notifyLowRemaining informs the verified email addresses of the identity that it is running out of lookup
+// secrets. The notification is only sent once, when the number of unused lookup secrets drops below the
+// configured threshold. The lookup secret was already used at this point, which is why errors are only logged.
+func (s *Strategy) notifyLowRemaining(ctx context.Context, i *identity.Identity, remaining int) {
+	threshold := s.d.Config().LookupSecretLowRemainingThreshold(ctx)
+	if threshold <= 0 || remaining != threshold-1 {
+		return
+	}
+
+	if err := s.queueLowRemaining(ctx, i, remaining); err != nil {
+		s.d.Logger().
+			WithError(err).
+			WithField("identity_id", i.ID).
+			Error("Unable to notify the identity about running low on lookup secrets.")
+	}
+}
+
+func (s *Strategy) queueLowRemaining(ctx context.Context, i *identity.Identity, remaining int) error {
+
+	model, err := x.StructToMap(i)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.d.Courier(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, address := range i.VerifiableAddresses {
+		if !address.Verified || address.Via != identity.VerifiableAddressTypeEmail {
+			continue
+		}
+
+		if _, err := c.QueueEmail(ctx, email.NewLookupSecretLow(s.d, &email.LookupSecretLowModel{
+			To:        address.Value,
+			Remaining: remaining,
+			Identity:  model,
+		})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -12,8 +12,6 @@ import (
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 
-	"github.com/ory/x/randx"
-
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/ui/node"
 
@@ -41,7 +39,7 @@ const (
 	InternalContextKeyRegenerated = "regenerated"
 )
 
-const numCodes = 12
+const numCodes = identity.LookupSecretsCount
 
 var allSettingsNodes = []string{
 	node.LookupRegenerate,
@@ -247,20 +245,17 @@ func (s *Strategy) continueSettingsFlowReveal(w http.ResponseWriter, r *http.Req
 }
 
 func (s *Strategy) continueSettingsFlowRegenerate(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithLookupMethod) error {
-	codes := make([]identity.RecoveryCode, numCodes)
-	for k := range codes {
-		codes[k] = identity.RecoveryCode{Code: randx.MustString(8, randx.AlphaLowerNum)}
-	}
+	codes := identity.NewCredentialsLookupConfig()
 
 	for _, n := range allSettingsNodes {
 		ctxUpdate.Flow.UI.Nodes.Remove(n)
 	}
 
-	ctxUpdate.Flow.UI.Nodes.Upsert((&identity.CredentialsLookupConfig{RecoveryCodes: codes}).ToNode())
+	ctxUpdate.Flow.UI.Nodes.Upsert(codes.ToNode())
 	ctxUpdate.Flow.UI.Nodes.Upsert(NewConfirmLookupNode())
 
 	var err error
-	ctxUpdate.Flow.InternalContext, err = sjson.SetBytes(ctxUpdate.Flow.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyRegenerated), codes)
+	ctxUpdate.Flow.InternalContext, err = sjson.SetBytes(ctxUpdate.Flow.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeyRegenerated), codes.RecoveryCodes)
 	if err != nil {
 		return err
 	}
@@ -10,6 +10,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/identity"
@@ -37,6 +39,9 @@ type registrationStrategyDependencies interface {
 
 	continuity.ManagementProvider
 
+	courier.Provider
+	template.Dependencies
+
 	errorx.ManagementProvider
 	hash.HashProvider
 
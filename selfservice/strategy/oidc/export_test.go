@@ -0,0 +1,43 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+)
+
+type testIder uuid.UUID
+
+func (i testIder) GetID() uuid.UUID {
+	return uuid.UUID(i)
+}
+
+func AuthCodeURLOptionsForTest(ctx context.Context, provider Provider, flowID uuid.UUID) (options []oauth2.AuthCodeOption, pkceVerifier, nonce string) {
+	var c authCodeContainer
+	options = authCodeURLOptions(ctx, provider, testIder(flowID), &c)
+	return options, c.PKCEVerifier, c.Nonce
+}
+
+func (s *Strategy) CheckRequirementsForTest(ctx context.Context, provider Provider, claims *Claims) error {
+	return s.checkRequirements(ctx, provider, claims)
+}
+
+func (s *Strategy) CheckPrivilegedSessionForTest(ctx context.Context, sess *session.Session) error {
+	return s.checkPrivilegedSession(ctx, sess)
+}
+
+func (s *Strategy) CheckLinkableClaimsForTest(ctx context.Context, i *identity.Identity, claims *Claims) error {
+	return s.checkLinkableClaims(ctx, i, claims)
+}
+
+func (s *Strategy) NotifyConnectionChangedForTest(ctx context.Context, i *identity.Identity, provider string, linked bool) {
+	s.notifyConnectionChanged(ctx, i, provider, linked)
+}
@@ -6,6 +6,7 @@ package oidc
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
 
 	"github.com/imdario/mergo"
 
@@ -41,3 +42,70 @@ func merge(containerTraits json.RawMessage, openIDProviderValues json.RawMessage
 
 	return result.Bytes(), nil
 }
+
+// mergeOnLogin merges the values coming from the OpenID Provider (openIDProviderValues) into the identity's existing
+// traits or metadata according to the policy. Nested objects are merged key by key. It also reports whether the
+// document changed.
+func mergeOnLogin(existing json.RawMessage, openIDProviderValues json.RawMessage, policy IdentitySyncPolicy) (json.RawMessage, bool, error) {
+	if policy != IdentitySyncFillEmpty && policy != IdentitySyncOverwrite {
+		return existing, false, nil
+	}
+
+	var pt map[string]interface{}
+	if err := json.NewDecoder(bytes.NewBuffer(openIDProviderValues)).Decode(&pt); err != nil {
+		return nil, false, err
+	}
+
+	et := make(map[string]interface{})
+	if len(existing) > 0 && string(existing) != "null" {
+		if err := json.NewDecoder(bytes.NewBuffer(existing)).Decode(&et); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if !mergeValues(et, pt, policy) {
+		return existing, false, nil
+	}
+
+	result, err := json.Marshal(et)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return result, true, nil
+}
+
+func mergeValues(dst, src map[string]interface{}, policy IdentitySyncPolicy) (changed bool) {
+	for k, v := range src {
+		if sv, ok := v.(map[string]interface{}); ok {
+			if dv, ok := dst[k].(map[string]interface{}); ok {
+				changed = mergeValues(dv, sv, policy) || changed
+				continue
+			}
+		}
+
+		if policy == IdentitySyncFillEmpty && !isEmptyValue(dst[k]) {
+			continue
+		}
+
+		if !reflect.DeepEqual(dst[k], v) {
+			dst[k] = v
+			changed = true
+		}
+	}
+	return changed
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch vv := v.(type) {
+	case nil:
+		return true
+	case string:
+		return vv == ""
+	case []interface{}:
+		return len(vv) == 0
+	case map[string]interface{}:
+		return len(vv) == 0
+	}
+	return false
+}
@@ -47,3 +47,61 @@ func TestMerge(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeOnLogin(t *testing.T) {
+	for k, tc := range []struct {
+		existing json.RawMessage
+		op       json.RawMessage
+		policy   IdentitySyncPolicy
+		expect   json.RawMessage
+		changed  bool
+	}{
+		{
+			existing: json.RawMessage(`{"email":"foo@ory.sh","name":""}`),
+			op:       json.RawMessage(`{"email":"bar@ory.sh","name":"Bar"}`),
+			policy:   IdentitySyncNever,
+			expect:   json.RawMessage(`{"email":"foo@ory.sh","name":""}`),
+		},
+		{
+			existing: json.RawMessage(`{"email":"foo@ory.sh","name":""}`),
+			op:       json.RawMessage(`{"email":"bar@ory.sh","name":"Bar","website":"https://www.ory.sh"}`),
+			policy:   IdentitySyncFillEmpty,
+			expect:   json.RawMessage(`{"email":"foo@ory.sh","name":"Bar","website":"https://www.ory.sh"}`),
+			changed:  true,
+		},
+		{
+			existing: json.RawMessage(`{"email":"foo@ory.sh","name":{"first":"Foo"}}`),
+			op:       json.RawMessage(`{"email":"bar@ory.sh","name":{"first":"Bar","last":"Baz"}}`),
+			policy:   IdentitySyncFillEmpty,
+			expect:   json.RawMessage(`{"email":"foo@ory.sh","name":{"first":"Foo","last":"Baz"}}`),
+			changed:  true,
+		},
+		{
+			existing: json.RawMessage(`{"email":"foo@ory.sh","name":{"first":"Foo"},"custom":true}`),
+			op:       json.RawMessage(`{"email":"bar@ory.sh","name":{"first":"Bar","last":"Baz"}}`),
+			policy:   IdentitySyncOverwrite,
+			expect:   json.RawMessage(`{"email":"bar@ory.sh","name":{"first":"Bar","last":"Baz"},"custom":true}`),
+			changed:  true,
+		},
+		{
+			existing: json.RawMessage(`{"email":"foo@ory.sh","groups":["a","b"]}`),
+			op:       json.RawMessage(`{"email":"foo@ory.sh","groups":["a","b"]}`),
+			policy:   IdentitySyncOverwrite,
+			expect:   json.RawMessage(`{"email":"foo@ory.sh","groups":["a","b"]}`),
+		},
+		{
+			existing: nil,
+			op:       json.RawMessage(`{"plan":"pro"}`),
+			policy:   IdentitySyncFillEmpty,
+			expect:   json.RawMessage(`{"plan":"pro"}`),
+			changed:  true,
+		},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			got, changed, err := mergeOnLogin(tc.existing, tc.op, tc.policy)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(tc.expect), string(got))
+			assert.Equal(t, tc.changed, changed)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+)
+
+// notifyConnectionChanged informs the verified email addresses of the identity that a provider was linked or
+// unlinked. The settings were already updated at this point, which is why errors are only logged.
+func (s *Strategy) notifyConnectionChanged(ctx context.Context, i *identity.Identity, provider string, linked bool) {
+	if err := s.queueConnectionChanged(ctx, i, provider, linked); err != nil {
+		s.d.Logger().
+			WithError(err).
+			WithField("identity_id", i.ID).
+			WithField("provider", provider).
+			Error("Unable to notify the identity about the changed OpenID Connect connection.")
+	}
+}
+
+func (s *Strategy) queueConnectionChanged(ctx context.Context, i *identity.Identity, provider string, linked bool) error {
+	model, err := x.StructToMap(i)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.d.Courier(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, address := range i.VerifiableAddresses {
+		if !address.Verified || address.Via != identity.VerifiableAddressTypeEmail {
+			continue
+		}
+
+		if _, err := c.QueueEmail(ctx, email.NewOIDCConnectionChanged(s.d, &email.OIDCConnectionChangedModel{
+			To:       address.Value,
+			Provider: provider,
+			Linked:   linked,
+			Identity: model,
+		})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
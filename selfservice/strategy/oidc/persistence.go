@@ -0,0 +1,107 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/sqlxx"
+)
+
+// Stored OpenID Connect Provider
+//
+// A social sign in provider which was added using the admin API instead of the configuration.
+//
+// swagger:model oidcProvider
+type StoredProvider struct {
+	ID uuid.UUID `json:"-" faker:"-" db:"id"`
+
+	// The ID of the provider as used in the flows and callback URLs.
+	//
+	// required: true
+	ProviderID string `json:"id" db:"provider_id"`
+
+	// The provider's configuration in the format of `selfservice.methods.oidc.config.providers`. The client
+	// secret and private keys are stored encrypted and are never returned.
+	//
+	// required: true
+	Config sqlxx.JSONRawMessage `json:"config" faker:"-" db:"config"`
+
+	// CreatedAt is the time when the provider was added.
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+	// UpdatedAt is the time when the provider was last changed.
+	//
+	// required: true
+	UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+
+	NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+}
+
+func (p StoredProvider) TableName(ctx context.Context) string {
+	return "selfservice_oidc_providers"
+}
+
+func (p StoredProvider) GetID() uuid.UUID {
+	return p.ID
+}
+
+func (p StoredProvider) GetNID() uuid.UUID {
+	return p.NID
+}
+
+// UsedLogoutToken records the `jti` of a back-channel logout token to reject replays of the token.
+type UsedLogoutToken struct {
+	ID         uuid.UUID `db:"id"`
+	ProviderID string    `db:"provider_id"`
+	JTI        string    `db:"jti"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	NID        uuid.UUID `db:"nid"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+func (t UsedLogoutToken) TableName(ctx context.Context) string {
+	return "selfservice_oidc_logout_tokens"
+}
+
+type (
+	ProviderPersister interface {
+		// CreateOIDCProvider stores a new provider. It fails with sqlcon.ErrUniqueViolation if a provider with the
+		// same ID exists.
+		CreateOIDCProvider(ctx context.Context, p *StoredProvider) error
+
+		// GetOIDCProvider returns the stored provider with the given ID.
+		GetOIDCProvider(ctx context.Context, id string) (*StoredProvider, error)
+
+		// ListOIDCProviders returns all stored providers ordered by their ID.
+		ListOIDCProviders(ctx context.Context) ([]StoredProvider, error)
+
+		// UpdateOIDCProvider replaces the configuration of a stored provider.
+		UpdateOIDCProvider(ctx context.Context, p *StoredProvider) error
+
+		// DeleteOIDCProvider removes the stored provider with the given ID.
+		DeleteOIDCProvider(ctx context.Context, id string) error
+
+		// CountOIDCProviderLinks returns the number of identities which linked the provider with the given ID.
+		CountOIDCProviderLinks(ctx context.Context, id string) (int, error)
+
+		// UseOIDCLogoutToken records that the logout token with the given `jti` was used. It fails with
+		// sqlcon.ErrUniqueViolation if the provider already sent a token with the same `jti`. The record is kept
+		// until expiresAt.
+		UseOIDCLogoutToken(ctx context.Context, provider, jti string, expiresAt time.Time) error
+
+		// NetworkID returns the network the providers are stored in.
+		NetworkID(ctx context.Context) uuid.UUID
+	}
+
+	ProviderPersistenceProvider interface {
+		OIDCProviderPersister() ProviderPersister
+	}
+)
@@ -0,0 +1,62 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"golang.org/x/oauth2"
+
+	"github.com/ory/x/randx"
+)
+
+// pkceSupporter is implemented by providers which can tell whether the authorization server supports PKCE.
+type pkceSupporter interface {
+	supportsPKCE(ctx context.Context) bool
+}
+
+func usePKCE(ctx context.Context, provider Provider) bool {
+	switch provider.Config().PKCE {
+	case "force":
+		return true
+	case "never":
+		return false
+	}
+
+	ps, ok := provider.(pkceSupporter)
+	return ok && ps.supportsPKCE(ctx)
+}
+
+// authCodeURLOptions returns the options for the provider's authorization URL. If PKCE is used or the provider
+// validates nonces, the code verifier and nonce are stored in the container so that they can be checked in the
+// callback.
+func authCodeURLOptions(ctx context.Context, provider Provider, req ider, container *authCodeContainer) []oauth2.AuthCodeOption {
+	options := provider.AuthCodeURLOptions(req)
+
+	if usePKCE(ctx, provider) {
+		container.PKCEVerifier = randx.MustString(64, randx.AlphaNum)
+		challenge := sha256.Sum256([]byte(container.PKCEVerifier))
+		options = append(options,
+			oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+			oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+		)
+	}
+
+	if _, ok := provider.(NonceValidator); ok {
+		container.Nonce = randx.MustString(32, randx.AlphaNum)
+		options = append(options, oauth2.SetAuthURLParam("nonce", container.Nonce))
+	}
+
+	return options
+}
+
+// exchangeOptions returns the options for exchanging the authorization code.
+func (c *authCodeContainer) exchangeOptions() []oauth2.AuthCodeOption {
+	if c.PKCEVerifier == "" {
+		return nil
+	}
+	return []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("code_verifier", c.PKCEVerifier)}
+}
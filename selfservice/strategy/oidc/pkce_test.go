@@ -0,0 +1,126 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/x"
+)
+
+func TestAuthCodeURLOptions(t *testing.T) {
+	ctx := context.Background()
+	_, reg := internal.NewFastRegistryWithMocks(t)
+
+	newIssuer := func(t *testing.T, methods []string) string {
+		var ts *httptest.Server
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                           ts.URL,
+				"authorization_endpoint":           ts.URL + "/auth",
+				"token_endpoint":                   ts.URL + "/token",
+				"jwks_uri":                         ts.URL + "/jwks",
+				"code_challenge_methods_supported": methods,
+			})
+		}))
+		t.Cleanup(ts.Close)
+		return ts.URL
+	}
+
+	authCodeURL := func(t *testing.T, p oidc.Provider) (*url.URL, string, string) {
+		options, verifier, nonce := oidc.AuthCodeURLOptionsForTest(ctx, p, x.NewUUID())
+		c, err := p.OAuth2(ctx)
+		require.NoError(t, err)
+		u, err := url.Parse(c.AuthCodeURL("state", options...))
+		require.NoError(t, err)
+		return u, verifier, nonce
+	}
+
+	genericOAuth2 := func(pkce string) oidc.Provider {
+		return oidc.NewProviderGenericOAuth2(&oidc.Configuration{
+			ID:          "oauth2",
+			Provider:    "generic_oauth2",
+			AuthURL:     "https://example.org/auth",
+			TokenURL:    "https://example.org/token",
+			UserinfoURL: "https://example.org/user",
+			PKCE:        pkce,
+		}, reg)
+	}
+
+	t.Run("case=uses PKCE with S256 if forced", func(t *testing.T) {
+		u, verifier, nonce := authCodeURL(t, genericOAuth2("force"))
+		require.NotEmpty(t, verifier)
+		challenge := sha256.Sum256([]byte(verifier))
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(challenge[:]), u.Query().Get("code_challenge"))
+		assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+
+		assert.Empty(t, nonce, "OAuth 2.0 providers do not issue ID tokens")
+		assert.False(t, u.Query().Has("nonce"))
+	})
+
+	t.Run("case=does not use PKCE if disabled or not supported", func(t *testing.T) {
+		for _, pkce := range []string{"never", "auto", ""} {
+			u, verifier, _ := authCodeURL(t, genericOAuth2(pkce))
+			assert.Empty(t, verifier)
+			assert.False(t, u.Query().Has("code_challenge"))
+		}
+	})
+
+	t.Run("case=detects PKCE support and sends a nonce for OpenID Connect providers", func(t *testing.T) {
+		for _, tc := range []struct {
+			methods []string
+			pkce    string
+			expect  bool
+		}{
+			{methods: []string{"plain", "S256"}, expect: true},
+			{methods: []string{"plain"}, expect: false},
+			{methods: nil, expect: false},
+			{methods: nil, pkce: "force", expect: true},
+			{methods: []string{"S256"}, pkce: "never", expect: false},
+		} {
+			u, verifier, nonce := authCodeURL(t, oidc.NewProviderGenericOIDC(&oidc.Configuration{
+				ID:        "generic",
+				Provider:  "generic",
+				ClientID:  "client",
+				IssuerURL: newIssuer(t, tc.methods),
+				PKCE:      tc.pkce,
+			}, reg))
+			assert.Equal(t, tc.expect, verifier != "", "%+v", tc)
+			assert.Equal(t, tc.expect, u.Query().Has("code_challenge"), "%+v", tc)
+
+			require.NotEmpty(t, nonce)
+			assert.Equal(t, nonce, u.Query().Get("nonce"))
+		}
+	})
+}
+
+func TestProviderGenericOIDC_ValidateNonce(t *testing.T) {
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	p := oidc.NewProviderGenericOIDC(&oidc.Configuration{ID: "generic", Provider: "generic"}, reg)
+
+	token := func(t *testing.T, claims jwt.MapClaims) *oauth2.Token {
+		idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		return new(oauth2.Token).WithExtra(map[string]interface{}{"id_token": idToken})
+	}
+
+	assert.NoError(t, p.ValidateNonce(token(t, jwt.MapClaims{"sub": "foo", "nonce": "expected"}), "expected"))
+	assert.Error(t, p.ValidateNonce(token(t, jwt.MapClaims{"sub": "foo", "nonce": "other"}), "expected"))
+	assert.Error(t, p.ValidateNonce(token(t, jwt.MapClaims{"sub": "foo"}), "expected"))
+	assert.NoError(t, p.ValidateNonce(new(oauth2.Token), "expected"), "nothing to validate without an ID token")
+}
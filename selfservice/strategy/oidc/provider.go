@@ -6,6 +6,8 @@ package oidc
 import (
 	"context"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -66,6 +68,18 @@ func (c *Claims) Validate() error {
 	return nil
 }
 
+// NonceValidator is implemented by providers which verify an ID token and can therefore check that it was issued
+// for the nonce sent in the authorization request.
+type NonceValidator interface {
+	ValidateNonce(exchange *oauth2.Token, nonce string) error
+}
+
+// LogoutTokenVerifier is implemented by providers which can verify the logout tokens sent to the OpenID Connect
+// Back-Channel Logout endpoint.
+type LogoutTokenVerifier interface {
+	VerifyLogoutToken(ctx context.Context, raw string) (*LogoutToken, error)
+}
+
 // UpstreamParameters returns a list of oauth2.AuthCodeOption based on the upstream parameters.
 //
 // Only allowed parameters are returned and the rest is ignored.
@@ -75,22 +89,52 @@ func (c *Claims) Validate() error {
 // Allowed parameters are:
 // - `login_hint` (string): The `login_hint` parameter suppresses the account chooser and either pre-fills the email box on the sign-in form, or selects the proper session.
 // - `hd` (string): The `hd` parameter limits the login/registration process to a Google Organization, e.g. `mycollege.edu`.
+//
+// The following parameters are only allowed if they are listed in the provider's `upstream_parameters` configuration:
+// - `prompt` (string): A space-separated list of `none`, `login`, `consent` and `select_account`.
+// - `acr_values` (string): The requested Authentication Context Class Reference values.
+// - `max_age` (string): The allowable elapsed time in seconds since the user last authenticated with the provider.
+// - `ui_locales` (string): The user's preferred languages for the provider's user interface.
 func UpstreamParameters(provider Provider, upstreamParameters map[string]string) []oauth2.AuthCodeOption {
 	// validation of upstream parameters are already handled in the `oidc/.schema/link.schema.json` and `oidc/.schema/settings.schema.json` file.
 	// `upstreamParameters` will always only contain allowed parameters based on the configuration.
 
 	// we double check the parameters here to prevent any potential security issues.
-	allowedParameters := map[string]struct{}{
-		"login_hint": {},
-		"hd":         {},
+	allowedParameters := map[string]func(string) bool{
+		"login_hint": nil,
+		"hd":         nil,
+	}
+	for _, up := range provider.Config().UpstreamParameters {
+		if validate, ok := configurableUpstreamParameters[up]; ok {
+			allowedParameters[up] = validate
+		}
 	}
 
 	var params []oauth2.AuthCodeOption
 	for up, v := range upstreamParameters {
-		if _, ok := allowedParameters[up]; ok {
+		if validate, ok := allowedParameters[up]; ok && (validate == nil || validate(v)) {
 			params = append(params, oauth2.SetAuthURLParam(up, v))
 		}
 	}
 
 	return params
 }
+
+// configurableUpstreamParameters are the upstream parameters which need to be enabled per provider, together with
+// their validation.
+var configurableUpstreamParameters = map[string]func(string) bool{
+	"prompt": func(v string) bool {
+		for _, p := range strings.Fields(v) {
+			if p != "none" && p != "login" && p != "consent" && p != "select_account" {
+				return false
+			}
+		}
+		return len(v) > 0
+	},
+	"acr_values": nil,
+	"max_age": func(v string) bool {
+		_, err := strconv.ParseUint(v, 10, 32)
+		return err == nil
+	},
+	"ui_locales": nil,
+}
@@ -0,0 +1,102 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/ory/x/httpx"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+)
+
+// ProviderAmazon implements Login with Amazon.
+type ProviderAmazon struct {
+	config *Configuration
+	reg    dependencies
+}
+
+type AmazonProfileResponse struct {
+	UserID     string `json:"user_id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	PostalCode string `json:"postal_code"`
+}
+
+func NewProviderAmazon(
+	config *Configuration,
+	reg dependencies,
+) *ProviderAmazon {
+	return &ProviderAmazon{
+		config: config,
+		reg:    reg,
+	}
+}
+
+func (p *ProviderAmazon) Config() *Configuration {
+	return p.config
+}
+
+func (p *ProviderAmazon) oauth2(ctx context.Context) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://www.amazon.com/ap/oa",
+			TokenURL:  "https://api.amazon.com/auth/o2/token",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		RedirectURL: p.config.Redir(p.reg.Config().OIDCRedirectURIBase(ctx)),
+		Scopes:      p.config.Scope,
+	}
+}
+
+func (p *ProviderAmazon) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	return p.oauth2(ctx), nil
+}
+
+func (p *ProviderAmazon) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{}
+}
+
+func (p *ProviderAmazon) Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (*Claims, error) {
+	o := p.oauth2(ctx)
+	client := p.reg.HTTPClient(ctx, httpx.ResilientClientWithClient(o.Client(ctx, exchange)))
+
+	req, err := retryablehttp.NewRequest("GET", "https://api.amazon.com/user/profile", nil)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	req.Header.Add("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if err := logUpstreamError(p.reg.Logger(), res); err != nil {
+		return nil, err
+	}
+
+	var profile AmazonProfileResponse
+	if err := json.NewDecoder(res.Body).Decode(&profile); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	// Amazon does not tell whether the email address was verified, which is why it is not marked as such.
+	return &Claims{
+		Issuer:  "https://api.amazon.com/auth/o2/token",
+		Subject: profile.UserID,
+		Name:    profile.Name,
+		Email:   profile.Email,
+	}, nil
+}
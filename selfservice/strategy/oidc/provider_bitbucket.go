@@ -0,0 +1,157 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/stringslice"
+	"github.com/ory/x/stringsx"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/x"
+)
+
+// ProviderBitbucket implements the OAuth 2.0 flow of Bitbucket Cloud.
+type ProviderBitbucket struct {
+	config *Configuration
+	reg    dependencies
+}
+
+type BitbucketUserResponse struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+	Links       struct {
+		Avatar struct {
+			Href string `json:"href"`
+		} `json:"avatar"`
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+type BitbucketEmailsResponse struct {
+	Values []struct {
+		Email       string `json:"email"`
+		IsPrimary   bool   `json:"is_primary"`
+		IsConfirmed bool   `json:"is_confirmed"`
+	} `json:"values"`
+}
+
+func NewProviderBitbucket(
+	config *Configuration,
+	reg dependencies,
+) *ProviderBitbucket {
+	return &ProviderBitbucket{
+		config: config,
+		reg:    reg,
+	}
+}
+
+func (p *ProviderBitbucket) Config() *Configuration {
+	return p.config
+}
+
+func (p *ProviderBitbucket) oauth2(ctx context.Context) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://bitbucket.org/site/oauth2/authorize",
+			TokenURL:  "https://bitbucket.org/site/oauth2/access_token",
+			AuthStyle: oauth2.AuthStyleInHeader,
+		},
+		RedirectURL: p.config.Redir(p.reg.Config().OIDCRedirectURIBase(ctx)),
+		Scopes:      p.config.Scope,
+	}
+}
+
+func (p *ProviderBitbucket) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	return p.oauth2(ctx), nil
+}
+
+func (p *ProviderBitbucket) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{}
+}
+
+func (p *ProviderBitbucket) Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (*Claims, error) {
+	o := p.oauth2(ctx)
+	client := p.reg.HTTPClient(ctx, httpx.ResilientClientWithClient(o.Client(ctx, exchange)))
+
+	var user BitbucketUserResponse
+	if err := p.get(client, "https://api.bitbucket.org/2.0/user", &user); err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{
+		// The UUID is wrapped in curly braces, e.g. {c7a04a9b-4ed2-4a4b-8c0b-0c8b4b8e3a2f}.
+		Subject:           user.UUID,
+		Issuer:            "https://bitbucket.org/site/oauth2/access_token",
+		Name:              user.DisplayName,
+		Nickname:          user.Nickname,
+		PreferredUsername: user.Nickname,
+		Picture:           user.Links.Avatar.Href,
+		Profile:           user.Links.HTML.Href,
+		RawClaims: map[string]interface{}{
+			"account_id": user.AccountID,
+		},
+	}
+
+	// Bitbucket does not include the email address in the user response. It is only available from `/user/emails`
+	// if the "email" scope was granted. The granted scopes are space separated in the `scopes` field of the token
+	// response.
+	grantedScopes := stringsx.Splitx(fmt.Sprintf("%s", exchange.Extra("scopes")), " ")
+	if stringslice.Has(grantedScopes, "email") {
+		var emails BitbucketEmailsResponse
+		if err := p.get(client, "https://api.bitbucket.org/2.0/user/emails", &emails); err != nil {
+			return nil, err
+		}
+
+		for _, e := range emails.Values {
+			if e.IsPrimary {
+				claims.Email = e.Email
+				claims.EmailVerified = x.ConvertibleBoolean(e.IsConfirmed)
+				break
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+func (p *ProviderBitbucket) get(client *retryablehttp.Client, u string, v interface{}) error {
+	req, err := retryablehttp.NewRequest("GET", u, nil)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	req.Header.Add("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if err := logUpstreamError(p.reg.Logger(), res); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	return nil
+}
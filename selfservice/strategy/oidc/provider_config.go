@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
 
 	"github.com/ory/x/urlx"
 )
@@ -38,6 +39,14 @@ type Configuration struct {
 	// - dingtalk
 	// - linkedin
 	// - patreon
+	// - generic_oauth2
+	// - x
+	// - amazon
+	// - twitch
+	// - salesforce
+	// - bitbucket
+	// - line
+	// - kakao
 	Provider string `json:"provider"`
 
 	// Label represents an optional label which can be used in the UI generation.
@@ -63,6 +72,30 @@ type Configuration struct {
 	// `provider` is set to `generic`.
 	TokenURL string `json:"token_url"`
 
+	// UserinfoURL is the URL returning the user's profile, typically something like: https://example.org/api/me
+	// Required when `provider` is set to `generic_oauth2`.
+	UserinfoURL string `json:"userinfo_url"`
+
+	// UserinfoMethod is the HTTP method used to call the UserinfoURL. Can be either `GET` (the default) or `POST`.
+	UserinfoMethod string `json:"userinfo_method"`
+
+	// AuthStyle controls how the client credentials are sent to the TokenURL when `provider` is set to
+	// `generic_oauth2`. Can be `auto` (the default), `header` for HTTP Basic Authorization or `params` for the
+	// request body.
+	AuthStyle string `json:"auth_style"`
+
+	// ClaimsMapper is a JSONNet code snippet which maps the userinfo response to the OpenID Connect claims when
+	// `provider` is set to `generic_oauth2`. The response is available as `std.extVar('userinfo')`.
+	//
+	// It must be a URL (file://, http(s)://, base64://). Inline JSONNet code snippets are not supported.
+	ClaimsMapper string `json:"claims_mapper_url"`
+
+	// ClaimsPaths maps OpenID Connect claims (e.g. `sub` or `email`) to GJSON paths in the userinfo response when
+	// `provider` is set to `generic_oauth2` and no ClaimsMapper is set. If neither is set, the userinfo response
+	// must contain the claims. The paths use the GJSON syntax (https://github.com/tidwall/gjson/blob/master/SYNTAX.md),
+	// not JSONPath.
+	ClaimsPaths map[string]string `json:"claims_paths"`
+
 	// Tenant is the Azure AD Tenant to use for authentication, and must be set when `provider` is set to `microsoft`.
 	// Can be either `common`, `organizations`, `consumers` for a multitenant application or a specific tenant like
 	// `8eaef023-2b34-4da1-9baa-8bc8c9d6a490` or `contoso.onmicrosoft.com`.
@@ -103,6 +136,60 @@ type Configuration struct {
 	//
 	// More information: https://openid.net/specs/openid-connect-core-1_0.html#ClaimsParameter
 	RequestedClaims json.RawMessage `json:"requested_claims"`
+
+	// Domains routes identifier-first logins to this provider if the identifier is an email address of one of
+	// these domains. Providers with domains are not shown before the identifier was submitted.
+	Domains []string `json:"domains"`
+
+	// PKCE controls whether Proof Key for Code Exchange (S256) is used. Can be `auto` (the default) to use it if the
+	// provider announces support for it in its OpenID Connect Discovery document, `force` or `never`.
+	PKCE string `json:"pkce"`
+
+	// UpstreamParameters lists the additional upstream parameters (`prompt`, `acr_values`, `max_age` and
+	// `ui_locales`) which may be passed to this provider in the `upstream_parameters` of the login, registration and
+	// settings flows. `login_hint` and `hd` are always allowed.
+	UpstreamParameters []string `json:"upstream_parameters"`
+
+	// RequiredDomains restricts the provider to users whose hosted domain (`hd`) or verified email address belongs
+	// to one of these domains.
+	RequiredDomains []string `json:"required_domains"`
+
+	// RequiredGroups restricts the provider to users who are a member of one of these groups. Memberships are taken
+	// from the `team` claim (Slack), the `organizations` raw claim (GitHub, requires the `read:org` scope, compared
+	// case-insensitively) and the `groups` raw claim.
+	RequiredGroups []string `json:"required_groups"`
+
+	// ClaimsRequirement is a JSONNet code snippet which receives the claims as `std.extVar('claims')` and must
+	// evaluate to `true` for the user to be allowed to use the provider.
+	//
+	// It must be a URL (file://, http(s)://, base64://). Inline JSONNet code snippets are not supported.
+	ClaimsRequirement string `json:"claims_requirement_url"`
+
+	// SyncIdentityOnLogin controls whether the Jsonnet mapper runs again on every login with this provider and how
+	// its output is merged into the identity's traits and metadata. Defaults to `never`.
+	SyncIdentityOnLogin IdentitySyncPolicy `json:"sync_identity_on_login"`
+}
+
+// IdentitySyncPolicy decides how the Jsonnet mapper output is merged into an existing identity on login.
+type IdentitySyncPolicy string
+
+const (
+	// IdentitySyncNever only maps the identity at registration.
+	IdentitySyncNever IdentitySyncPolicy = "never"
+	// IdentitySyncFillEmpty only sets fields which are missing or empty.
+	IdentitySyncFillEmpty IdentitySyncPolicy = "fill_empty"
+	// IdentitySyncOverwrite replaces all fields returned by the mapper.
+	IdentitySyncOverwrite IdentitySyncPolicy = "overwrite"
+)
+
+// HasDomain returns true if identifier-first logins with an email address of the domain are routed to this provider.
+func (p Configuration) HasDomain(domain string) bool {
+	for _, d := range p.Domains {
+		if strings.EqualFold(d, domain) {
+			return true
+		}
+	}
+	return false
 }
 
 func (p Configuration) Redir(public *url.URL) string {
@@ -112,8 +199,20 @@ func (p Configuration) Redir(public *url.URL) string {
 }
 
 type ConfigurationCollection struct {
-	BaseRedirectURI string          `json:"base_redirect_uri"`
-	Providers       []Configuration `json:"providers"`
+	BaseRedirectURI string               `json:"base_redirect_uri"`
+	Providers       []Configuration      `json:"providers"`
+	Linking         LinkingConfiguration `json:"linking"`
+}
+
+// LinkingConfiguration is the policy for linking and unlinking providers in the settings flow.
+type LinkingConfiguration struct {
+	// RequiredAuthenticationMethods requires that the session was (re-)authenticated with one of these methods
+	// within the privileged session max age before a provider can be linked or unlinked. Otherwise, the user is
+	// asked to re-authenticate with the first of these methods which is enabled. If empty, any method is accepted.
+	RequiredAuthenticationMethods []identity.CredentialsType `json:"required_authentication_methods"`
+
+	// RequireVerifiedEmail blocks linking a provider which returns an email address it has not verified.
+	RequireVerifiedEmail bool `json:"require_verified_email"`
 }
 
 func (c ConfigurationCollection) Provider(id string, reg dependencies) (Provider, error) {
@@ -167,6 +266,22 @@ func (c ConfigurationCollection) Provider(id string, reg dependencies) (Provider
 				return NewProviderLinkedIn(&p, reg), nil
 			case addProviderName("patreon"):
 				return NewProviderPatreon(&p, reg), nil
+			case addProviderName("generic_oauth2"):
+				return NewProviderGenericOAuth2(&p, reg), nil
+			case addProviderName("x"):
+				return NewProviderX(&p, reg), nil
+			case addProviderName("amazon"):
+				return NewProviderAmazon(&p, reg), nil
+			case addProviderName("twitch"):
+				return NewProviderTwitch(&p, reg), nil
+			case addProviderName("salesforce"):
+				return NewProviderSalesforce(&p, reg), nil
+			case addProviderName("bitbucket"):
+				return NewProviderBitbucket(&p, reg), nil
+			case addProviderName("line"):
+				return NewProviderLINE(&p, reg), nil
+			case addProviderName("kakao"):
+				return NewProviderKakao(&p, reg), nil
 			}
 			return nil, errors.Errorf("provider type %s is not supported, supported are: %v", p.Provider, providerNames)
 		}
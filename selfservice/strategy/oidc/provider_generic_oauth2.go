@@ -0,0 +1,189 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/httpx"
+)
+
+// ProviderGenericOAuth2 is a provider for OAuth 2.0 servers which do not support OpenID Connect. The user's
+// profile is loaded from the configured userinfo URL and mapped to the OpenID Connect claims.
+type ProviderGenericOAuth2 struct {
+	config *Configuration
+	reg    dependencies
+}
+
+func NewProviderGenericOAuth2(
+	config *Configuration,
+	reg dependencies,
+) *ProviderGenericOAuth2 {
+	return &ProviderGenericOAuth2{
+		config: config,
+		reg:    reg,
+	}
+}
+
+func (g *ProviderGenericOAuth2) Config() *Configuration {
+	return g.config
+}
+
+func (g *ProviderGenericOAuth2) authStyle() oauth2.AuthStyle {
+	switch g.config.AuthStyle {
+	case "header":
+		return oauth2.AuthStyleInHeader
+	case "params":
+		return oauth2.AuthStyleInParams
+	}
+	return oauth2.AuthStyleAutoDetect
+}
+
+func (g *ProviderGenericOAuth2) oauth2(ctx context.Context) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     g.config.ClientID,
+		ClientSecret: g.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   g.config.AuthURL,
+			TokenURL:  g.config.TokenURL,
+			AuthStyle: g.authStyle(),
+		},
+		RedirectURL: g.config.Redir(g.reg.Config().OIDCRedirectURIBase(ctx)),
+		Scopes:      g.config.Scope,
+	}
+}
+
+func (g *ProviderGenericOAuth2) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	if g.config.AuthURL == "" || g.config.TokenURL == "" || g.config.UserinfoURL == "" {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf(`The OAuth 2.0 provider "%s" requires auth_url, token_url and userinfo_url to be set.`, g.config.ID))
+	}
+	return g.oauth2(ctx), nil
+}
+
+func (g *ProviderGenericOAuth2) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{}
+}
+
+func (g *ProviderGenericOAuth2) Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (*Claims, error) {
+	o, err := g.OAuth2(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	method := http.MethodGet
+	if strings.EqualFold(g.config.UserinfoMethod, http.MethodPost) {
+		method = http.MethodPost
+	}
+
+	req, err := retryablehttp.NewRequest(method, g.config.UserinfoURL, nil)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := g.reg.HTTPClient(ctx, httpx.ResilientClientWithClient(o.Client(ctx, exchange)))
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if err := logUpstreamError(g.reg.Logger(), res); err != nil {
+		return nil, err
+	}
+
+	userinfo, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	if !gjson.ValidBytes(userinfo) || !gjson.ParseBytes(userinfo).IsObject() {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The OAuth 2.0 provider did not return a JSON object from the userinfo URL."))
+	}
+
+	mapped, err := g.mapClaims(ctx, userinfo)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := json.NewDecoder(bytes.NewReader(mapped)).Decode(&claims); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the mapped claims: %s", err))
+	}
+
+	if err := json.Unmarshal(userinfo, &claims.RawClaims); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	if claims.Issuer == "" {
+		claims.Issuer = g.config.IssuerURL
+	}
+	if claims.Issuer == "" {
+		claims.Issuer = g.config.UserinfoURL
+	}
+
+	return &claims, nil
+}
+
+// mapClaims maps the userinfo response to the OpenID Connect claims. The Jsonnet claims mapper takes precedence
+// over the claims paths. If neither is configured, the response is expected to contain the claims already.
+func (g *ProviderGenericOAuth2) mapClaims(ctx context.Context, userinfo []byte) ([]byte, error) {
+	if g.config.ClaimsMapper != "" {
+		jn, err := fetcher.NewFetcher(fetcher.WithClient(g.reg.HTTPClient(ctx))).Fetch(g.config.ClaimsMapper)
+		if err != nil {
+			return nil, err
+		}
+
+		vm, err := g.reg.JsonnetVM(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		vm.ExtCode("userinfo", string(userinfo))
+		evaluated, err := vm.EvaluateAnonymousSnippet(g.config.ClaimsMapper, jn.String())
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to evaluate the claims mapper: %s", err))
+		}
+
+		return []byte(evaluated), nil
+	}
+
+	if len(g.config.ClaimsPaths) > 0 {
+		mapped := make(map[string]interface{}, len(g.config.ClaimsPaths))
+		for claim, path := range g.config.ClaimsPaths {
+			result := gjson.GetBytes(userinfo, path)
+			if !result.Exists() {
+				continue
+			}
+
+			if claim == "sub" {
+				// Many providers use numeric user IDs, but the subject must be a string.
+				mapped[claim] = result.String()
+			} else {
+				mapped[claim] = result.Value()
+			}
+		}
+
+		out, err := json.Marshal(mapped)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return out, nil
+	}
+
+	return userinfo, nil
+}
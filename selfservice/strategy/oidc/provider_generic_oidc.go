@@ -5,8 +5,10 @@ package oidc
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/url"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 
@@ -17,6 +19,8 @@ import (
 )
 
 var _ Provider = new(ProviderGenericOIDC)
+var _ NonceValidator = new(ProviderGenericOIDC)
+var _ LogoutTokenVerifier = new(ProviderGenericOIDC)
 
 type ProviderGenericOIDC struct {
 	p      *gooidc.Provider
@@ -120,3 +124,66 @@ func (g *ProviderGenericOIDC) Claims(ctx context.Context, exchange *oauth2.Token
 
 	return g.verifyAndDecodeClaimsWithProvider(ctx, p, raw)
 }
+
+// ValidateNonce checks that the ID token was issued for the nonce sent in the authorization request. The ID token's
+// signature is verified in Claims, which must be called first. Nothing is checked if the provider did not issue an
+// ID token.
+func (g *ProviderGenericOIDC) ValidateNonce(exchange *oauth2.Token, nonce string) error {
+	raw, ok := exchange.Extra("id_token").(string)
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+
+	var claims struct {
+		jwt.RegisteredClaims
+		Nonce string `json:"nonce"`
+	}
+	if _, _, err := new(jwt.Parser).ParseUnverified(raw, &claims); err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
+	}
+
+	if claims.Nonce == "" || subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("The nonce of the ID token does not match the nonce sent to the OpenID Connect provider."))
+	}
+
+	return nil
+}
+
+// VerifyLogoutToken verifies the signature, issuer and audience of an OpenID Connect Back-Channel Logout token and
+// returns the subject and session it refers to.
+func (g *ProviderGenericOIDC) VerifyLogoutToken(ctx context.Context, raw string) (*LogoutToken, error) {
+	p, err := g.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.verifyLogoutTokenWithProvider(ctx, p, raw)
+}
+
+func (g *ProviderGenericOIDC) verifyLogoutTokenWithProvider(ctx context.Context, provider *gooidc.Provider, raw string) (*LogoutToken, error) {
+	// Logout tokens are not required to expire, which is why the expiry is checked in parseLogoutToken instead.
+	token, err := provider.Verifier(&gooidc.Config{ClientID: g.config.ClientID, SkipExpiryCheck: true}).Verify(ctx, raw)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
+	}
+
+	return parseLogoutToken(token)
+}
+
+// supportsPKCE returns true if the provider announces support for PKCE with S256 in its OpenID Connect Discovery
+// document.
+func (g *ProviderGenericOIDC) supportsPKCE(ctx context.Context) bool {
+	p, err := g.provider(ctx)
+	if err != nil {
+		return false
+	}
+
+	var discovery struct {
+		CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+	}
+	if err := p.Claims(&discovery); err != nil {
+		return false
+	}
+
+	return stringslice.Has(discovery.CodeChallengeMethodsSupported, "S256")
+}
@@ -103,5 +103,27 @@ func (g *ProviderGitHub) Claims(ctx context.Context, exchange *oauth2.Token, que
 		}
 	}
 
+	// The organizations are only listed if scope "read:org" is set, so that they can be required.
+	if stringslice.Has(grantedScopes, "read:org") {
+		var organizations []string
+		opts := &ghapi.ListOptions{PerPage: 100}
+		for {
+			orgs, res, err := gh.Organizations.List(ctx, "", opts)
+			if err != nil {
+				return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+			}
+
+			for _, o := range orgs {
+				organizations = append(organizations, o.GetLogin())
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+		claims.RawClaims = map[string]interface{}{"organizations": organizations}
+	}
+
 	return claims, nil
 }
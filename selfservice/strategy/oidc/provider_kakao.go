@@ -0,0 +1,141 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/ory/x/httpx"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/x"
+)
+
+// ProviderKakao implements Kakao Login.
+type ProviderKakao struct {
+	config *Configuration
+	reg    dependencies
+}
+
+type KakaoUserResponse struct {
+	ID           int64 `json:"id"`
+	KakaoAccount struct {
+		Profile struct {
+			Nickname        string `json:"nickname"`
+			ProfileImageURL string `json:"profile_image_url"`
+		} `json:"profile"`
+		Name            string `json:"name"`
+		Email           string `json:"email"`
+		IsEmailValid    bool   `json:"is_email_valid"`
+		IsEmailVerified bool   `json:"is_email_verified"`
+		PhoneNumber     string `json:"phone_number"`
+		// Birthyear is formatted as YYYY and Birthday as MMDD.
+		Birthyear string `json:"birthyear"`
+		Birthday  string `json:"birthday"`
+		Gender    string `json:"gender"`
+	} `json:"kakao_account"`
+}
+
+func NewProviderKakao(
+	config *Configuration,
+	reg dependencies,
+) *ProviderKakao {
+	return &ProviderKakao{
+		config: config,
+		reg:    reg,
+	}
+}
+
+func (p *ProviderKakao) Config() *Configuration {
+	return p.config
+}
+
+func (p *ProviderKakao) oauth2(ctx context.Context) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://kauth.kakao.com/oauth/authorize",
+			TokenURL:  "https://kauth.kakao.com/oauth/token",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		RedirectURL: p.config.Redir(p.reg.Config().OIDCRedirectURIBase(ctx)),
+		Scopes:      p.config.Scope,
+	}
+}
+
+func (p *ProviderKakao) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	return p.oauth2(ctx), nil
+}
+
+func (p *ProviderKakao) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	if isForced(r) {
+		return []oauth2.AuthCodeOption{
+			oauth2.SetAuthURLParam("prompt", "login"),
+		}
+	}
+	return []oauth2.AuthCodeOption{}
+}
+
+func (p *ProviderKakao) Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (*Claims, error) {
+	o := p.oauth2(ctx)
+	client := p.reg.HTTPClient(ctx, httpx.ResilientClientWithClient(o.Client(ctx, exchange)))
+
+	req, err := retryablehttp.NewRequest("GET", "https://kapi.kakao.com/v2/user/me", nil)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	req.Header.Add("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if err := logUpstreamError(p.reg.Logger(), res); err != nil {
+		return nil, err
+	}
+
+	var user KakaoUserResponse
+	if err := json.NewDecoder(res.Body).Decode(&user); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	account := user.KakaoAccount
+	claims := &Claims{
+		Issuer:      "https://kauth.kakao.com",
+		Subject:     strconv.FormatInt(user.ID, 10),
+		Name:        account.Name,
+		Nickname:    account.Profile.Nickname,
+		Picture:     account.Profile.ProfileImageURL,
+		PhoneNumber: account.PhoneNumber,
+		Gender:      account.Gender,
+	}
+	if claims.Name == "" {
+		claims.Name = account.Profile.Nickname
+	}
+
+	// Kakao keeps returning email addresses which are no longer valid (e.g. because the domain expired), so those
+	// are ignored.
+	if account.IsEmailValid {
+		claims.Email = account.Email
+		claims.EmailVerified = x.ConvertibleBoolean(account.IsEmailVerified)
+	}
+
+	if len(account.Birthyear) == 4 && len(account.Birthday) == 4 {
+		claims.Birthdate = account.Birthyear + "-" + account.Birthday[:2] + "-" + account.Birthday[2:]
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,166 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/ory/x/httpx"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+)
+
+// ProviderLINE implements LINE Login v2.1.
+type ProviderLINE struct {
+	config *Configuration
+	reg    dependencies
+}
+
+type LINEProfileResponse struct {
+	UserID        string `json:"userId"`
+	DisplayName   string `json:"displayName"`
+	PictureURL    string `json:"pictureUrl"`
+	StatusMessage string `json:"statusMessage"`
+}
+
+type LINEVerifyResponse struct {
+	Issuer  string `json:"iss"`
+	Subject string `json:"sub"`
+	Name    string `json:"name"`
+	Picture string `json:"picture"`
+	Email   string `json:"email"`
+}
+
+func NewProviderLINE(
+	config *Configuration,
+	reg dependencies,
+) *ProviderLINE {
+	return &ProviderLINE{
+		config: config,
+		reg:    reg,
+	}
+}
+
+func (p *ProviderLINE) Config() *Configuration {
+	return p.config
+}
+
+func (p *ProviderLINE) oauth2(ctx context.Context) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://access.line.me/oauth2/v2.1/authorize",
+			TokenURL:  "https://api.line.me/oauth2/v2.1/token",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		RedirectURL: p.config.Redir(p.reg.Config().OIDCRedirectURIBase(ctx)),
+		Scopes:      p.config.Scope,
+	}
+}
+
+func (p *ProviderLINE) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	return p.oauth2(ctx), nil
+}
+
+func (p *ProviderLINE) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	if isForced(r) {
+		return []oauth2.AuthCodeOption{
+			oauth2.SetAuthURLParam("prompt", "consent"),
+		}
+	}
+	return []oauth2.AuthCodeOption{}
+}
+
+// supportsPKCE returns true because LINE Login v2.1 supports PKCE with S256.
+func (p *ProviderLINE) supportsPKCE(ctx context.Context) bool {
+	return true
+}
+
+func (p *ProviderLINE) Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (*Claims, error) {
+	o := p.oauth2(ctx)
+	client := p.reg.HTTPClient(ctx, httpx.ResilientClientWithClient(o.Client(ctx, exchange)))
+
+	req, err := retryablehttp.NewRequest("GET", "https://api.line.me/v2/profile", nil)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	req.Header.Add("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if err := logUpstreamError(p.reg.Logger(), res); err != nil {
+		return nil, err
+	}
+
+	var profile LINEProfileResponse
+	if err := json.NewDecoder(res.Body).Decode(&profile); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	claims := &Claims{
+		Issuer:  "https://access.line.me",
+		Subject: profile.UserID,
+		Name:    profile.DisplayName,
+		Picture: profile.PictureURL,
+	}
+
+	// The email address is only part of the ID token, which is signed with the channel secret (HS256) unless the
+	// channel uses ES256. Instead of verifying it locally, it is sent to LINE's verify endpoint.
+	if idToken, ok := exchange.Extra("id_token").(string); ok && idToken != "" {
+		verified, err := p.verifyIDToken(ctx, idToken)
+		if err != nil {
+			return nil, err
+		}
+
+		if verified.Subject != profile.UserID {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The subject of the LINE ID token does not match the profile."))
+		}
+
+		// LINE does not tell whether the email address was verified, which is why it is not marked as such.
+		claims.Email = verified.Email
+	}
+
+	return claims, nil
+}
+
+func (p *ProviderLINE) verifyIDToken(ctx context.Context, idToken string) (*LINEVerifyResponse, error) {
+	client := p.reg.HTTPClient(ctx)
+
+	body := url.Values{"id_token": {idToken}, "client_id": {p.config.ClientID}}
+	req, err := retryablehttp.NewRequest("POST", "https://api.line.me/oauth2/v2.1/verify", strings.NewReader(body.Encode()))
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if err := logUpstreamError(p.reg.Logger(), res); err != nil {
+		return nil, err
+	}
+
+	var verified LINEVerifyResponse
+	if err := json.NewDecoder(res.Body).Decode(&verified); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	return &verified, nil
+}
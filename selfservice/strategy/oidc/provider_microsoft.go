@@ -59,10 +59,36 @@ func (m *ProviderMicrosoft) Claims(ctx context.Context, exchange *oauth2.Token,
 		return nil, errors.WithStack(ErrIDTokenMissing)
 	}
 
+	p, err := m.tenantProvider(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := m.verifyAndDecodeClaimsWithProvider(ctx, p, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.updateSubject(ctx, claims, exchange)
+}
+
+// VerifyLogoutToken verifies the logout token with the keys of the tenant which issued it, see Claims.
+func (m *ProviderMicrosoft) VerifyLogoutToken(ctx context.Context, raw string) (*LogoutToken, error) {
+	p, err := m.tenantProvider(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.verifyLogoutTokenWithProvider(ctx, p, raw)
+}
+
+// tenantProvider returns the OpenID Connect provider of the tenant which issued the token. Multi-tenant applications
+// receive tokens from many issuers, so the issuer is derived from the (unverified) `tid` claim.
+func (m *ProviderMicrosoft) tenantProvider(ctx context.Context, raw string) (*gooidc.Provider, error) {
 	parser := new(jwt.Parser)
 	unverifiedClaims := microsoftUnverifiedClaims{}
 	if _, _, err := parser.ParseUnverified(raw, &unverifiedClaims); err != nil {
-		return nil, err
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
 	}
 
 	if _, err := uuid.FromString(unverifiedClaims.TenantID); err != nil {
@@ -74,13 +100,7 @@ func (m *ProviderMicrosoft) Claims(ctx context.Context, exchange *oauth2.Token,
 	if err != nil {
 		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initialize OpenID Connect Provider: %s", err))
 	}
-
-	claims, err := m.verifyAndDecodeClaimsWithProvider(ctx, p, raw)
-	if err != nil {
-		return nil, err
-	}
-
-	return m.updateSubject(ctx, claims, exchange)
+	return p, nil
 }
 
 func (m *ProviderMicrosoft) updateSubject(ctx context.Context, claims *Claims, exchange *oauth2.Token) (*Claims, error) {
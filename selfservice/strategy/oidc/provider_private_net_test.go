@@ -38,6 +38,12 @@ func TestProviderPrivateIP(t *testing.T) {
 	gitlab := func(c *oidc.Configuration) oidc.Provider {
 		return oidc.NewProviderGitLab(c, reg)
 	}
+	genericOAuth2 := func(c *oidc.Configuration) oidc.Provider {
+		return oidc.NewProviderGenericOAuth2(c, reg)
+	}
+	salesforce := func(c *oidc.Configuration) oidc.Provider {
+		return oidc.NewProviderSalesforce(c, reg)
+	}
 
 	// We do not test the Auth URL as the Auth URL is not vulnerable to SSRF attacks.
 	// The AuthURL is only given to the user's browser, thus it is not possible to cause SSRF.
@@ -70,6 +76,9 @@ func TestProviderPrivateIP(t *testing.T) {
 		{p: gitlab, c: &oidc.Configuration{IssuerURL: "http://127.0.0.2/"}, e: "127.0.0.2 is not a public IP address"},
 		// The TokenURL is fixed in GitLab to {issuer_url}/token. Since the issuer is called first, any local token fails also.
 
+		// If the userinfo URL is local, we fail
+		{p: genericOAuth2, c: &oidc.Configuration{AuthURL: "https://example.org/auth", TokenURL: "https://example.org/token", UserinfoURL: "http://127.0.0.2/"}, e: "127.0.0.2 is not a public IP address"},
+
 		// Google uses a fixed token URL and does not use the issuer.
 		// Microsoft uses a fixed token URL and does not use the issuer.
 		// Slack uses a fixed token URL and does not use the issuer.
@@ -77,6 +86,10 @@ func TestProviderPrivateIP(t *testing.T) {
 		// VK uses a fixed token URL and does not use the issuer.
 		// Yandex uses a fixed token URL and does not use the issuer.
 		// NetID uses a fixed token URL and does not use the issuer.
+		// X, Amazon, Twitch, Bitbucket, LINE and Kakao use fixed token URLs and do not use the issuer.
+
+		{p: salesforce, c: &oidc.Configuration{IssuerURL: "http://127.0.0.2/"}, e: "127.0.0.2 is not a public IP address"},
+		// The TokenURL is fixed in Salesforce to {issuer_url}/services/oauth2/token.
 	} {
 		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
 			p := tc.p(tc.c)
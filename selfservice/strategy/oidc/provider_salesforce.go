@@ -0,0 +1,184 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/ory/x/httpx"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/x"
+)
+
+const defaultSalesforceEndpoint = "https://login.salesforce.com"
+
+// ProviderSalesforce implements the OpenID Connect flow of Salesforce. The `issuer_url` can be set to a sandbox
+// (`https://test.salesforce.com`) or a My Domain URL and defaults to `https://login.salesforce.com`.
+type ProviderSalesforce struct {
+	config *Configuration
+	reg    dependencies
+}
+
+type SalesforceUserinfoResponse struct {
+	// Subject is the identity URL of the user, e.g. https://login.salesforce.com/id/<organization_id>/<user_id>.
+	Subject             string               `json:"sub"`
+	UserID              string               `json:"user_id"`
+	OrganizationID      string               `json:"organization_id"`
+	Name                string               `json:"name"`
+	GivenName           string               `json:"given_name"`
+	FamilyName          string               `json:"family_name"`
+	PreferredUsername   string               `json:"preferred_username"`
+	Nickname            string               `json:"nickname"`
+	Profile             string               `json:"profile"`
+	Picture             string               `json:"picture"`
+	Email               string               `json:"email"`
+	EmailVerified       x.ConvertibleBoolean `json:"email_verified"`
+	Zoneinfo            string               `json:"zoneinfo"`
+	Locale              string               `json:"locale"`
+	PhoneNumber         string               `json:"phone_number"`
+	PhoneNumberVerified x.ConvertibleBoolean `json:"phone_number_verified"`
+	// UpdatedAt is an RFC 3339 timestamp instead of the number of seconds mandated by OpenID Connect.
+	UpdatedAt string `json:"updated_at"`
+}
+
+func NewProviderSalesforce(
+	config *Configuration,
+	reg dependencies,
+) *ProviderSalesforce {
+	return &ProviderSalesforce{
+		config: config,
+		reg:    reg,
+	}
+}
+
+func (p *ProviderSalesforce) Config() *Configuration {
+	return p.config
+}
+
+func (p *ProviderSalesforce) endpoint() (*url.URL, error) {
+	var e = defaultSalesforceEndpoint
+	if len(p.config.IssuerURL) > 0 {
+		e = p.config.IssuerURL
+	}
+	return url.Parse(e)
+}
+
+func (p *ProviderSalesforce) oauth2(ctx context.Context) (*oauth2.Config, error) {
+	endpoint, err := p.endpoint()
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	authUrl := *endpoint
+	tokenUrl := *endpoint
+
+	authUrl.Path = path.Join(authUrl.Path, "/services/oauth2/authorize")
+	tokenUrl.Path = path.Join(tokenUrl.Path, "/services/oauth2/token")
+
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  authUrl.String(),
+			TokenURL: tokenUrl.String(),
+		},
+		Scopes:      p.config.Scope,
+		RedirectURL: p.config.Redir(p.reg.Config().OIDCRedirectURIBase(ctx)),
+	}, nil
+}
+
+func (p *ProviderSalesforce) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	return p.oauth2(ctx)
+}
+
+func (p *ProviderSalesforce) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	if isForced(r) {
+		return []oauth2.AuthCodeOption{
+			oauth2.SetAuthURLParam("prompt", "login"),
+		}
+	}
+	return []oauth2.AuthCodeOption{}
+}
+
+// supportsPKCE returns true because Salesforce supports PKCE for all connected apps.
+func (p *ProviderSalesforce) supportsPKCE(ctx context.Context) bool {
+	return true
+}
+
+func (p *ProviderSalesforce) Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (*Claims, error) {
+	o, err := p.OAuth2(ctx)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	client := p.reg.HTTPClient(ctx, httpx.ResilientClientDisallowInternalIPs(), httpx.ResilientClientWithClient(o.Client(ctx, exchange)))
+
+	u, err := p.endpoint()
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	u.Path = path.Join(u.Path, "/services/oauth2/userinfo")
+	req, err := retryablehttp.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	req.Header.Add("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if err := logUpstreamError(p.reg.Logger(), res); err != nil {
+		return nil, err
+	}
+
+	var user SalesforceUserinfoResponse
+	if err := json.NewDecoder(res.Body).Decode(&user); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	// The user ID is used as the subject because the identity URL in `sub` depends on the login host (e.g. My
+	// Domain vs. login.salesforce.com) while the user ID is stable.
+	claims := &Claims{
+		Issuer:              u.String(),
+		Subject:             user.UserID,
+		Name:                user.Name,
+		GivenName:           user.GivenName,
+		FamilyName:          user.FamilyName,
+		Nickname:            user.Nickname,
+		PreferredUsername:   user.PreferredUsername,
+		Profile:             user.Profile,
+		Picture:             user.Picture,
+		Email:               user.Email,
+		EmailVerified:       user.EmailVerified,
+		Zoneinfo:            user.Zoneinfo,
+		Locale:              user.Locale,
+		PhoneNumber:         user.PhoneNumber,
+		PhoneNumberVerified: bool(user.PhoneNumberVerified),
+		RawClaims: map[string]interface{}{
+			"organization_id": user.OrganizationID,
+		},
+	}
+	if claims.Subject == "" {
+		claims.Subject = user.Subject
+	}
+	if updatedAt, err := time.Parse(time.RFC3339, user.UpdatedAt); err == nil {
+		claims.UpdatedAt = updatedAt.Unix()
+	}
+
+	return claims, nil
+}
@@ -4,9 +4,12 @@
 package oidc
 
 import (
+	"net/url"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
 )
 
 func TestClaimsValidate(t *testing.T) {
@@ -17,3 +20,44 @@ func TestClaimsValidate(t *testing.T) {
 	require.Error(t, (&Claims{Subject: "not-empty"}).Validate())
 	require.NoError(t, (&Claims{Issuer: "not-empty", Subject: "not-empty"}).Validate())
 }
+
+func TestUpstreamParameters(t *testing.T) {
+	values := map[string]string{
+		"login_hint": "foo@ory.sh",
+		"hd":         "ory.sh",
+		"prompt":     "login consent",
+		"acr_values": "urn:mace:incommon:iap:silver",
+		"max_age":    "3600",
+		"ui_locales": "de-DE en",
+		"lol":        "invalid",
+	}
+
+	params := func(p Provider, values map[string]string) url.Values {
+		c := &oauth2.Config{Endpoint: oauth2.Endpoint{AuthURL: "https://example.org/auth"}}
+		u, err := url.Parse(c.AuthCodeURL("state", UpstreamParameters(p, values)...))
+		require.NoError(t, err)
+		return u.Query()
+	}
+
+	t.Run("case=only allows login_hint and hd by default", func(t *testing.T) {
+		q := params(NewProviderGenericOAuth2(&Configuration{}, nil), values)
+		assert.Equal(t, "foo@ory.sh", q.Get("login_hint"))
+		assert.Equal(t, "ory.sh", q.Get("hd"))
+		for _, k := range []string{"prompt", "acr_values", "max_age", "ui_locales", "lol"} {
+			assert.False(t, q.Has(k), k)
+		}
+	})
+
+	t.Run("case=allows parameters enabled for the provider", func(t *testing.T) {
+		p := NewProviderGenericOAuth2(&Configuration{UpstreamParameters: []string{"prompt", "acr_values", "max_age", "ui_locales", "lol"}}, nil)
+		q := params(p, values)
+		for _, k := range []string{"login_hint", "hd", "prompt", "acr_values", "max_age", "ui_locales"} {
+			assert.Equal(t, values[k], q.Get(k), k)
+		}
+		assert.False(t, q.Has("lol"))
+
+		q = params(p, map[string]string{"prompt": "login foo", "max_age": "-1"})
+		assert.False(t, q.Has("prompt"))
+		assert.False(t, q.Has("max_age"))
+	})
+}
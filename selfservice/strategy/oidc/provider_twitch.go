@@ -0,0 +1,134 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/ory/x/httpx"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/x"
+)
+
+// twitchDefaultClaims are requested if no claims are configured, because Twitch only includes the
+// claims which are explicitly requested in the ID token and the userinfo response.
+const twitchDefaultClaims = `{"id_token":{"email":null,"email_verified":null,"preferred_username":null,"picture":null},"userinfo":{"email":null,"email_verified":null,"preferred_username":null,"picture":null}}`
+
+// ProviderTwitch implements the OpenID Connect flow of Twitch.
+type ProviderTwitch struct {
+	config *Configuration
+	reg    dependencies
+}
+
+type TwitchUserinfoResponse struct {
+	Issuer            string               `json:"iss"`
+	Subject           string               `json:"sub"`
+	PreferredUsername string               `json:"preferred_username"`
+	Email             string               `json:"email"`
+	EmailVerified     x.ConvertibleBoolean `json:"email_verified"`
+	Picture           string               `json:"picture"`
+	// UpdatedAt is an RFC 3339 timestamp instead of the number of seconds mandated by OpenID Connect.
+	UpdatedAt string `json:"updated_at"`
+}
+
+func NewProviderTwitch(
+	config *Configuration,
+	reg dependencies,
+) *ProviderTwitch {
+	return &ProviderTwitch{
+		config: config,
+		reg:    reg,
+	}
+}
+
+func (p *ProviderTwitch) Config() *Configuration {
+	return p.config
+}
+
+func (p *ProviderTwitch) oauth2(ctx context.Context) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://id.twitch.tv/oauth2/authorize",
+			TokenURL:  "https://id.twitch.tv/oauth2/token",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		RedirectURL: p.config.Redir(p.reg.Config().OIDCRedirectURIBase(ctx)),
+		Scopes:      p.config.Scope,
+	}
+}
+
+func (p *ProviderTwitch) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	return p.oauth2(ctx), nil
+}
+
+func (p *ProviderTwitch) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	var options []oauth2.AuthCodeOption
+
+	if isForced(r) {
+		options = append(options, oauth2.SetAuthURLParam("force_verify", "true"))
+	}
+	if len(p.config.RequestedClaims) != 0 {
+		options = append(options, oauth2.SetAuthURLParam("claims", string(p.config.RequestedClaims)))
+	} else {
+		options = append(options, oauth2.SetAuthURLParam("claims", twitchDefaultClaims))
+	}
+
+	return options
+}
+
+func (p *ProviderTwitch) Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (*Claims, error) {
+	o := p.oauth2(ctx)
+	client := p.reg.HTTPClient(ctx, httpx.ResilientClientWithClient(o.Client(ctx, exchange)))
+
+	req, err := retryablehttp.NewRequest("GET", "https://id.twitch.tv/oauth2/userinfo", nil)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	req.Header.Add("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if err := logUpstreamError(p.reg.Logger(), res); err != nil {
+		return nil, err
+	}
+
+	var user TwitchUserinfoResponse
+	if err := json.NewDecoder(res.Body).Decode(&user); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	claims := &Claims{
+		Issuer:            user.Issuer,
+		Subject:           user.Subject,
+		Nickname:          user.PreferredUsername,
+		PreferredUsername: user.PreferredUsername,
+		Email:             user.Email,
+		EmailVerified:     user.EmailVerified,
+		Picture:           user.Picture,
+	}
+	if claims.Issuer == "" {
+		claims.Issuer = "https://id.twitch.tv/oauth2"
+	}
+	if updatedAt, err := time.Parse(time.RFC3339, user.UpdatedAt); err == nil {
+		claims.UpdatedAt = updatedAt.Unix()
+	}
+
+	return claims, nil
+}
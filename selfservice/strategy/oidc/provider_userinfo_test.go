@@ -5,6 +5,7 @@ package oidc_test
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/url"
@@ -18,6 +19,7 @@ import (
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/x"
 	"github.com/ory/x/otelx"
 
 	"github.com/stretchr/testify/assert"
@@ -309,6 +311,333 @@ func TestProviderClaimsRespectsErrorCodes(t *testing.T) {
 				Email:   "john.doe@example.com",
 			},
 		},
+		{
+			name:             "generic_oauth2 with claims paths",
+			userInfoEndpoint: "https://api.example.org/user",
+			provider: oidc.NewProviderGenericOAuth2(&oidc.Configuration{
+				ID:          "example",
+				Provider:    "generic_oauth2",
+				AuthURL:     "https://example.org/oauth2/auth",
+				TokenURL:    "https://example.org/oauth2/token",
+				UserinfoURL: "https://api.example.org/user",
+				ClaimsPaths: map[string]string{"sub": "data.id", "email": "data.attributes.email", "name": "data.attributes.full_name"},
+			}, reg),
+			userInfoHandler: func(req *http.Request) (*http.Response, error) {
+				if head := req.Header.Get("Authorization"); len(head) == 0 {
+					resp, err := httpmock.NewJsonResponse(401, map[string]interface{}{"error": ""})
+					return resp, err
+				}
+
+				resp, err := httpmock.NewJsonResponse(200, json.RawMessage(`{"data":{"id":123456789012345,"attributes":{"email":"john.doe@example.com","full_name":"John Doe"}}}`))
+				return resp, err
+			},
+			expectedClaims: &oidc.Claims{
+				Issuer:  "https://api.example.org/user",
+				Subject: "123456789012345",
+				Name:    "John Doe",
+				Email:   "john.doe@example.com",
+				RawClaims: map[string]interface{}{"data": map[string]interface{}{"id": 1.23456789012345e+14, "attributes": map[string]interface{}{
+					"email": "john.doe@example.com", "full_name": "John Doe",
+				}}},
+			},
+		},
+		{
+			name:             "generic_oauth2 with claims mapper",
+			userInfoEndpoint: "https://api.example.org/user",
+			provider: oidc.NewProviderGenericOAuth2(&oidc.Configuration{
+				ID:           "example",
+				Provider:     "generic_oauth2",
+				IssuerURL:    "https://example.org",
+				AuthURL:      "https://example.org/oauth2/auth",
+				TokenURL:     "https://example.org/oauth2/token",
+				UserinfoURL:  "https://api.example.org/user",
+				ClaimsMapper: "base64://" + base64.StdEncoding.EncodeToString([]byte(`local u = std.extVar('userinfo'); { sub: std.toString(u.user_id), email: u.mail, email_verified: true }`)),
+			}, reg),
+			userInfoHandler: func(req *http.Request) (*http.Response, error) {
+				if head := req.Header.Get("Authorization"); len(head) == 0 {
+					resp, err := httpmock.NewJsonResponse(401, map[string]interface{}{"error": ""})
+					return resp, err
+				}
+
+				resp, err := httpmock.NewJsonResponse(200, map[string]interface{}{"user_id": "abcd", "mail": "john.doe@example.com"})
+				return resp, err
+			},
+			expectedClaims: &oidc.Claims{
+				Issuer:        "https://example.org",
+				Subject:       "abcd",
+				Email:         "john.doe@example.com",
+				EmailVerified: true,
+				RawClaims:     map[string]interface{}{"user_id": "abcd", "mail": "john.doe@example.com"},
+			},
+		},
+		{
+			name:             "x",
+			userInfoEndpoint: "https://api.twitter.com/2/users/me?user.fields=profile_image_url",
+			provider: oidc.NewProviderX(&oidc.Configuration{
+				ID:       "x",
+				Provider: "x",
+			}, reg),
+			userInfoHandler: func(req *http.Request) (*http.Response, error) {
+				if head := req.Header.Get("Authorization"); len(head) == 0 {
+					resp, err := httpmock.NewJsonResponse(401, map[string]interface{}{"error": ""})
+					return resp, err
+				}
+
+				resp, err := httpmock.NewJsonResponse(200, map[string]interface{}{
+					"data": map[string]interface{}{
+						"id":                "123456789012345",
+						"name":              "John Doe",
+						"username":          "johndoe",
+						"profile_image_url": "https://pbs.twimg.com/profile_images/1/abc_normal.jpg",
+					},
+				})
+				return resp, err
+			},
+			expectedClaims: &oidc.Claims{
+				Issuer:            "https://api.twitter.com/2/oauth2/token",
+				Subject:           "123456789012345",
+				Name:              "John Doe",
+				Nickname:          "johndoe",
+				PreferredUsername: "johndoe",
+				Profile:           "https://x.com/johndoe",
+				Picture:           "https://pbs.twimg.com/profile_images/1/abc.jpg",
+			},
+		},
+		{
+			name:             "amazon",
+			userInfoEndpoint: "https://api.amazon.com/user/profile",
+			provider: oidc.NewProviderAmazon(&oidc.Configuration{
+				ID:       "amazon",
+				Provider: "amazon",
+			}, reg),
+			userInfoHandler: func(req *http.Request) (*http.Response, error) {
+				if head := req.Header.Get("Authorization"); len(head) == 0 {
+					resp, err := httpmock.NewJsonResponse(401, map[string]interface{}{"error": ""})
+					return resp, err
+				}
+
+				resp, err := httpmock.NewJsonResponse(200, map[string]interface{}{
+					"user_id": "amzn1.account.123456789012345",
+					"name":    "John Doe",
+					"email":   "john.doe@example.com",
+				})
+				return resp, err
+			},
+			expectedClaims: &oidc.Claims{
+				Issuer:  "https://api.amazon.com/auth/o2/token",
+				Subject: "amzn1.account.123456789012345",
+				Name:    "John Doe",
+				Email:   "john.doe@example.com",
+			},
+		},
+		{
+			name:             "twitch",
+			userInfoEndpoint: "https://id.twitch.tv/oauth2/userinfo",
+			provider: oidc.NewProviderTwitch(&oidc.Configuration{
+				ID:       "twitch",
+				Provider: "twitch",
+			}, reg),
+			userInfoHandler: func(req *http.Request) (*http.Response, error) {
+				if head := req.Header.Get("Authorization"); len(head) == 0 {
+					resp, err := httpmock.NewJsonResponse(401, map[string]interface{}{"error": ""})
+					return resp, err
+				}
+
+				resp, err := httpmock.NewJsonResponse(200, map[string]interface{}{
+					"aud":                "foo",
+					"iss":                "https://id.twitch.tv/oauth2",
+					"sub":                "123456789012345",
+					"preferred_username": "johndoe",
+					"email":              "john.doe@example.com",
+					"email_verified":     true,
+					"updated_at":         "2020-01-01T00:00:00Z",
+				})
+				return resp, err
+			},
+			expectedClaims: &oidc.Claims{
+				Issuer:            "https://id.twitch.tv/oauth2",
+				Subject:           "123456789012345",
+				Nickname:          "johndoe",
+				PreferredUsername: "johndoe",
+				Email:             "john.doe@example.com",
+				EmailVerified:     true,
+				UpdatedAt:         1577836800,
+			},
+		},
+		{
+			name:             "salesforce",
+			userInfoEndpoint: "https://example.my.salesforce.com/services/oauth2/userinfo",
+			provider: oidc.NewProviderSalesforce(&oidc.Configuration{
+				IssuerURL: "https://example.my.salesforce.com",
+				ID:        "salesforce",
+				Provider:  "salesforce",
+			}, reg),
+			userInfoHandler: func(req *http.Request) (*http.Response, error) {
+				if head := req.Header.Get("Authorization"); len(head) == 0 {
+					resp, err := httpmock.NewJsonResponse(401, map[string]interface{}{"error": ""})
+					return resp, err
+				}
+
+				resp, err := httpmock.NewJsonResponse(200, map[string]interface{}{
+					"sub":                "https://login.salesforce.com/id/00D000000000001/005000000000001",
+					"user_id":            "005000000000001",
+					"organization_id":    "00D000000000001",
+					"preferred_username": "john.doe@example.com",
+					"name":               "John Doe",
+					"given_name":         "John",
+					"family_name":        "Doe",
+					"email":              "john.doe@example.com",
+					"email_verified":     true,
+					"updated_at":         "2020-01-01T00:00:00Z",
+				})
+				return resp, err
+			},
+			expectedClaims: &oidc.Claims{
+				Issuer:            "https://example.my.salesforce.com/services/oauth2/userinfo",
+				Subject:           "005000000000001",
+				Name:              "John Doe",
+				GivenName:         "John",
+				FamilyName:        "Doe",
+				PreferredUsername: "john.doe@example.com",
+				Email:             "john.doe@example.com",
+				EmailVerified:     true,
+				UpdatedAt:         1577836800,
+				RawClaims:         map[string]interface{}{"organization_id": "00D000000000001"},
+			},
+		},
+		{
+			name:             "bitbucket",
+			userInfoEndpoint: "https://api.bitbucket.org/2.0/user",
+			provider: oidc.NewProviderBitbucket(&oidc.Configuration{
+				ID:       "bitbucket",
+				Provider: "bitbucket",
+			}, reg),
+			useToken: token.WithExtra(map[string]interface{}{"scopes": "account email"}),
+			hook: func(t *testing.T) {
+				httpmock.RegisterResponder("GET", "https://api.bitbucket.org/2.0/user/emails",
+					func(req *http.Request) (*http.Response, error) {
+						return httpmock.NewJsonResponse(200, map[string]interface{}{
+							"values": []map[string]interface{}{
+								{"email": "john.doe@example.org", "is_primary": false, "is_confirmed": true},
+								{"email": "john.doe@example.com", "is_primary": true, "is_confirmed": true},
+							},
+						})
+					},
+				)
+			},
+			userInfoHandler: func(req *http.Request) (*http.Response, error) {
+				if head := req.Header.Get("Authorization"); len(head) == 0 {
+					resp, err := httpmock.NewJsonResponse(401, map[string]interface{}{"error": ""})
+					return resp, err
+				}
+
+				resp, err := httpmock.NewJsonResponse(200, map[string]interface{}{
+					"uuid":         "{c7a04a9b-4ed2-4a4b-8c0b-0c8b4b8e3a2f}",
+					"account_id":   "123456:c7a04a9b",
+					"display_name": "John Doe",
+					"nickname":     "johndoe",
+					"links": map[string]interface{}{
+						"avatar": map[string]interface{}{"href": "https://bitbucket.org/account/johndoe/avatar/"},
+						"html":   map[string]interface{}{"href": "https://bitbucket.org/johndoe/"},
+					},
+				})
+				return resp, err
+			},
+			expectedClaims: &oidc.Claims{
+				Issuer:            "https://bitbucket.org/site/oauth2/access_token",
+				Subject:           "{c7a04a9b-4ed2-4a4b-8c0b-0c8b4b8e3a2f}",
+				Name:              "John Doe",
+				Nickname:          "johndoe",
+				PreferredUsername: "johndoe",
+				Picture:           "https://bitbucket.org/account/johndoe/avatar/",
+				Profile:           "https://bitbucket.org/johndoe/",
+				Email:             "john.doe@example.com",
+				EmailVerified:     true,
+				RawClaims:         map[string]interface{}{"account_id": "123456:c7a04a9b"},
+			},
+		},
+		{
+			name:             "line",
+			userInfoEndpoint: "https://api.line.me/v2/profile",
+			provider: oidc.NewProviderLINE(&oidc.Configuration{
+				ID:       "line",
+				Provider: "line",
+				ClientID: "foo",
+			}, reg),
+			useToken: token.WithExtra(map[string]interface{}{"id_token": "id-token"}),
+			hook: func(t *testing.T) {
+				httpmock.RegisterResponder("POST", "https://api.line.me/oauth2/v2.1/verify",
+					func(req *http.Request) (*http.Response, error) {
+						if req.FormValue("id_token") != "id-token" || req.FormValue("client_id") != "foo" {
+							return httpmock.NewJsonResponse(400, map[string]interface{}{"error": "invalid_request"})
+						}
+						return httpmock.NewJsonResponse(200, map[string]interface{}{
+							"iss":   "https://access.line.me",
+							"sub":   "U1234567890abcdef",
+							"aud":   "foo",
+							"email": "john.doe@example.com",
+						})
+					},
+				)
+			},
+			userInfoHandler: func(req *http.Request) (*http.Response, error) {
+				if head := req.Header.Get("Authorization"); len(head) == 0 {
+					resp, err := httpmock.NewJsonResponse(401, map[string]interface{}{"error": ""})
+					return resp, err
+				}
+
+				resp, err := httpmock.NewJsonResponse(200, map[string]interface{}{
+					"userId":      "U1234567890abcdef",
+					"displayName": "John Doe",
+					"pictureUrl":  "https://profile.line-scdn.net/abcdef",
+				})
+				return resp, err
+			},
+			expectedClaims: &oidc.Claims{
+				Issuer:  "https://access.line.me",
+				Subject: "U1234567890abcdef",
+				Name:    "John Doe",
+				Picture: "https://profile.line-scdn.net/abcdef",
+				Email:   "john.doe@example.com",
+			},
+		},
+		{
+			name:             "kakao",
+			userInfoEndpoint: "https://kapi.kakao.com/v2/user/me",
+			provider: oidc.NewProviderKakao(&oidc.Configuration{
+				ID:       "kakao",
+				Provider: "kakao",
+			}, reg),
+			userInfoHandler: func(req *http.Request) (*http.Response, error) {
+				if head := req.Header.Get("Authorization"); len(head) == 0 {
+					resp, err := httpmock.NewJsonResponse(401, map[string]interface{}{"error": ""})
+					return resp, err
+				}
+
+				resp, err := httpmock.NewJsonResponse(200, json.RawMessage(`{
+  "id": 123456789012345,
+  "kakao_account": {
+    "profile": {"nickname": "johndoe", "profile_image_url": "https://k.kakaocdn.net/img.jpg"},
+    "email": "john.doe@example.com",
+    "is_email_valid": true,
+    "is_email_verified": true,
+    "birthyear": "1990",
+    "birthday": "0101"
+  }
+}`))
+				return resp, err
+			},
+			expectedClaims: &oidc.Claims{
+				Issuer:        "https://kauth.kakao.com",
+				Subject:       "123456789012345",
+				Name:          "johndoe",
+				Nickname:      "johndoe",
+				Picture:       "https://k.kakaocdn.net/img.jpg",
+				Email:         "john.doe@example.com",
+				EmailVerified: true,
+				Birthdate:     "1990-01-01",
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			token := token
@@ -356,3 +685,69 @@ func TestProviderClaimsRespectsErrorCodes(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderTokenExchange(t *testing.T) {
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	ctx := context.Background()
+
+	conf := func(provider string) *oidc.Configuration {
+		return &oidc.Configuration{ID: provider, Provider: provider, ClientID: "client", ClientSecret: "secret"}
+	}
+
+	for _, tc := range []struct {
+		provider      oidc.Provider
+		tokenEndpoint string
+		// basicAuth is true if the client credentials must be sent in the Authorization header instead of the body.
+		basicAuth bool
+		pkce      bool
+	}{
+		{provider: oidc.NewProviderX(conf("x"), reg), tokenEndpoint: "https://api.twitter.com/2/oauth2/token", basicAuth: true, pkce: true},
+		{provider: oidc.NewProviderAmazon(conf("amazon"), reg), tokenEndpoint: "https://api.amazon.com/auth/o2/token"},
+		{provider: oidc.NewProviderTwitch(conf("twitch"), reg), tokenEndpoint: "https://id.twitch.tv/oauth2/token"},
+		{provider: oidc.NewProviderSalesforce(conf("salesforce"), reg), tokenEndpoint: "https://login.salesforce.com/services/oauth2/token", basicAuth: true, pkce: true},
+		{provider: oidc.NewProviderBitbucket(conf("bitbucket"), reg), tokenEndpoint: "https://bitbucket.org/site/oauth2/access_token", basicAuth: true},
+		{provider: oidc.NewProviderLINE(conf("line"), reg), tokenEndpoint: "https://api.line.me/oauth2/v2.1/token", pkce: true},
+		{provider: oidc.NewProviderKakao(conf("kakao"), reg), tokenEndpoint: "https://kauth.kakao.com/oauth/token"},
+	} {
+		t.Run("provider="+tc.provider.Config().Provider, func(t *testing.T) {
+			httpmock.Activate()
+			t.Cleanup(httpmock.DeactivateAndReset)
+
+			options, verifier, _ := oidc.AuthCodeURLOptionsForTest(ctx, tc.provider, x.NewUUID())
+			assert.Equal(t, tc.pkce, verifier != "")
+
+			httpmock.RegisterResponder("POST", tc.tokenEndpoint, func(req *http.Request) (*http.Response, error) {
+				require.NoError(t, req.ParseForm())
+				if user, pass, ok := req.BasicAuth(); ok {
+					assert.True(t, tc.basicAuth)
+					assert.Equal(t, "client", user)
+					assert.Equal(t, "secret", pass)
+				} else {
+					assert.False(t, tc.basicAuth)
+					assert.Equal(t, "client", req.PostForm.Get("client_id"))
+					assert.Equal(t, "secret", req.PostForm.Get("client_secret"))
+				}
+				assert.Equal(t, "code", req.PostForm.Get("code"))
+				assert.Equal(t, verifier, req.PostForm.Get("code_verifier"))
+
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"access_token": "access-token",
+					"token_type":   "bearer",
+					"expires_in":   3600,
+				})
+			})
+
+			c, err := tc.provider.OAuth2(ctx)
+			require.NoError(t, err)
+			assert.NotEmpty(t, c.AuthCodeURL("state", options...))
+
+			var exchangeOptions []oauth2.AuthCodeOption
+			if verifier != "" {
+				exchangeOptions = append(exchangeOptions, oauth2.SetAuthURLParam("code_verifier", verifier))
+			}
+			token, err := c.Exchange(ctx, "code", exchangeOptions...)
+			require.NoError(t, err)
+			assert.Equal(t, "access-token", token.AccessToken)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/ory/x/httpx"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+)
+
+// ProviderX implements the OAuth 2.0 flow of X (formerly Twitter). X does not offer OpenID Connect and requires PKCE
+// for the authorization code flow.
+type ProviderX struct {
+	config *Configuration
+	reg    dependencies
+}
+
+type XUserResponse struct {
+	Data struct {
+		ID              string `json:"id"`
+		Name            string `json:"name"`
+		Username        string `json:"username"`
+		ProfileImageURL string `json:"profile_image_url"`
+	} `json:"data"`
+	Errors []struct {
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+}
+
+func NewProviderX(
+	config *Configuration,
+	reg dependencies,
+) *ProviderX {
+	return &ProviderX{
+		config: config,
+		reg:    reg,
+	}
+}
+
+func (p *ProviderX) Config() *Configuration {
+	return p.config
+}
+
+func (p *ProviderX) oauth2(ctx context.Context) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://twitter.com/i/oauth2/authorize",
+			TokenURL:  "https://api.twitter.com/2/oauth2/token",
+			AuthStyle: oauth2.AuthStyleInHeader,
+		},
+		RedirectURL: p.config.Redir(p.reg.Config().OIDCRedirectURIBase(ctx)),
+		Scopes:      p.config.Scope,
+	}
+}
+
+func (p *ProviderX) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	return p.oauth2(ctx), nil
+}
+
+func (p *ProviderX) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{}
+}
+
+// supportsPKCE returns true because X rejects authorization requests without a code challenge.
+func (p *ProviderX) supportsPKCE(ctx context.Context) bool {
+	return true
+}
+
+func (p *ProviderX) Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (*Claims, error) {
+	o := p.oauth2(ctx)
+	client := p.reg.HTTPClient(ctx, httpx.ResilientClientWithClient(o.Client(ctx, exchange)))
+
+	req, err := retryablehttp.NewRequest("GET", "https://api.twitter.com/2/users/me?user.fields=profile_image_url", nil)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	req.Header.Add("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if err := logUpstreamError(p.reg.Logger(), res); err != nil {
+		return nil, err
+	}
+
+	var user XUserResponse
+	if err := json.NewDecoder(res.Body).Decode(&user); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	// X reports some errors with a 200 status code and an "errors" array instead of the user.
+	if user.Data.ID == "" {
+		if len(user.Errors) > 0 {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("X returned an error: %s: %s", user.Errors[0].Title, user.Errors[0].Detail))
+		}
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("X did not return a user"))
+	}
+
+	return &Claims{
+		Issuer:            "https://api.twitter.com/2/oauth2/token",
+		Subject:           user.Data.ID,
+		Name:              user.Data.Name,
+		Nickname:          user.Data.Username,
+		PreferredUsername: user.Data.Username,
+		Profile:           "https://x.com/" + user.Data.Username,
+		// The profile image URL points to a 48x48 thumbnail by default. Removing the "_normal" suffix
+		// yields the original image.
+		Picture: strings.Replace(user.Data.ProfileImageURL, "_normal.", ".", 1),
+	}, nil
+}
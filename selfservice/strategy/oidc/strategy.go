@@ -28,11 +28,14 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/ory/x/jsonx"
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
@@ -50,8 +53,9 @@ import (
 const (
 	RouteBase = "/self-service/methods/oidc"
 
-	RouteAuth     = RouteBase + "/auth/:flow"
-	RouteCallback = RouteBase + "/callback/:provider"
+	RouteAuth              = RouteBase + "/auth/:flow"
+	RouteCallback          = RouteBase + "/callback/:provider"
+	RouteBackChannelLogout = RouteBase + "/backchannel-logout/:provider"
 )
 
 var _ identity.ActiveCredentialsCounter = new(Strategy)
@@ -74,8 +78,11 @@ type dependencies interface {
 	identity.ActiveCredentialsCounterStrategyProvider
 	identity.ManagementProvider
 
+	ProviderPersistenceProvider
+
 	session.ManagementProvider
 	session.HandlerProvider
+	session.PersistenceProvider
 
 	login.HookExecutorProvider
 	login.FlowPersistenceProvider
@@ -97,6 +104,9 @@ type dependencies interface {
 
 	continuity.ManagementProvider
 
+	courier.Provider
+	template.Dependencies
+
 	cipher.Provider
 
 	jsonnetsecure.VMProvider
@@ -115,6 +125,9 @@ type Strategy struct {
 	d         dependencies
 	validator *schema.Validator
 	dec       *decoderx.HTTP
+
+	providers      storedProvidersCache
+	tokenRefreshes singleflight.Group
 }
 
 type authCodeContainer struct {
@@ -122,6 +135,8 @@ type authCodeContainer struct {
 	State            string          `json:"state"`
 	Traits           json.RawMessage `json:"traits"`
 	TransientPayload json.RawMessage `json:"transient_payload"`
+	PKCEVerifier     string          `json:"pkce_verifier,omitempty"`
+	Nonce            string          `json:"nonce,omitempty"`
 }
 
 func generateState(flowID string) string {
@@ -176,6 +191,12 @@ func (s *Strategy) setRoutes(r *x.RouterPublic) {
 		// form fields to query params. This second GET request should have the cookies attached.
 		r.POST(RouteCallback, s.redirectToGET)
 	}
+
+	if handle, _, _ := r.Lookup("POST", RouteBackChannelLogout); handle == nil {
+		// The logout tokens are sent by the provider's server, which has neither a CSRF cookie nor a token.
+		s.d.CSRFHandler().IgnoreGlob(RouteBase + "/backchannel-logout/*")
+		r.POST(RouteBackChannelLogout, strategy.IsDisabled(s.d, s.ID().String(), s.handleBackChannelLogout))
+	}
 }
 
 // Redirect POST request to GET rewriting form fields to query params.
@@ -340,7 +361,7 @@ func (s *Strategy) handleCallback(w http.ResponseWriter, r *http.Request, ps htt
 		}
 	}
 
-	token, err := te.Exchange(r.Context(), code)
+	token, err := te.Exchange(r.Context(), code, cntnr.exchangeOptions()...)
 	if err != nil {
 		s.forwardError(w, r, req, s.handleError(w, r, req, pid, nil, err))
 		return
@@ -357,6 +378,18 @@ func (s *Strategy) handleCallback(w http.ResponseWriter, r *http.Request, ps htt
 		return
 	}
 
+	if nv, ok := provider.(NonceValidator); ok && cntnr.Nonce != "" {
+		if err := nv.ValidateNonce(token, cntnr.Nonce); err != nil {
+			s.forwardError(w, r, req, s.handleError(w, r, req, pid, nil, err))
+			return
+		}
+	}
+
+	if err := s.checkRequirements(r.Context(), provider, claims); err != nil {
+		s.forwardError(w, r, req, s.handleError(w, r, req, pid, nil, err))
+		return
+	}
+
 	switch a := req.(type) {
 	case *login.Flow:
 		if ff, err := s.processLogin(w, r, a, token, claims, provider, cntnr); err != nil {
@@ -408,7 +441,23 @@ func (s *Strategy) populateMethod(r *http.Request, c *container.Container, messa
 	return nil
 }
 
+// Config returns the providers defined in the configuration and those added using the admin API.
 func (s *Strategy) Config(ctx context.Context) (*ConfigurationCollection, error) {
+	c, err := s.staticConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.storedProviders(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Providers = append(c.Providers, stored...)
+	return c, nil
+}
+
+func (s *Strategy) staticConfig(ctx context.Context) (*ConfigurationCollection, error) {
 	var c ConfigurationCollection
 
 	conf := s.d.Config().SelfServiceStrategy(ctx, string(s.ID())).Config
@@ -432,6 +481,22 @@ func (s *Strategy) provider(ctx context.Context, r *http.Request, id string) (Pr
 	}
 }
 
+// ValidateProvider returns a bad request error unless a provider with the given ID is configured or stored.
+func (s *Strategy) ValidateProvider(ctx context.Context, id string) error {
+	c, err := s.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range c.Providers {
+		if p.ID == id {
+			return nil
+		}
+	}
+
+	return errors.WithStack(herodot.ErrBadRequest.WithReasonf(`OpenID Connect Provider "%s" is unknown or has not been configured.`, id))
+}
+
 func (s *Strategy) forwardError(w http.ResponseWriter, r *http.Request, f flow.Flow, err error) {
 	switch ff := f.(type) {
 	case *login.Flow:
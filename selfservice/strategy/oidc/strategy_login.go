@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -35,6 +36,7 @@ import (
 )
 
 var _ login.Strategy = new(Strategy)
+var _ login.IdentifierFirstStrategy = new(Strategy)
 
 func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
 	s.setRoutes(r)
@@ -53,6 +55,79 @@ func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.Au
 	return s.populateMethod(r, l.UI, text.NewInfoLoginWith)
 }
 
+// PopulateLoginMethodIdentifierFirstIdentification shows all providers which are not bound to email domains.
+func (s *Strategy) PopulateLoginMethodIdentifierFirstIdentification(r *http.Request, l *login.Flow) error {
+	if l.Type != flow.TypeBrowser {
+		return nil
+	}
+
+	conf, err := s.Config(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var providers []Configuration
+	for _, p := range conf.Providers {
+		if len(p.Domains) == 0 {
+			providers = append(providers, p)
+		}
+	}
+
+	l.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	AddProviders(l.UI, providers, text.NewInfoLoginWith)
+	return nil
+}
+
+// PopulateLoginMethodIdentifierFirstCredentials shows the providers bound to the domain of the identifier and,
+// if the identity is known, the providers linked to it.
+func (s *Strategy) PopulateLoginMethodIdentifierFirstCredentials(r *http.Request, l *login.Flow, identifier string, i *identity.Identity) error {
+	if l.Type != flow.TypeBrowser {
+		return nil
+	}
+
+	conf, err := s.Config(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var linked identity.CredentialsOIDC
+	if i != nil {
+		if c, ok := i.GetCredentials(s.ID()); ok {
+			if err := json.Unmarshal(c.Config, &linked); err != nil {
+				return errors.WithStack(herodot.ErrInternalServerError.WithReason("The OpenID Connect credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+			}
+		}
+	}
+
+	var domain string
+	if at := strings.LastIndex(identifier, "@"); at >= 0 {
+		domain = identifier[at+1:]
+	}
+
+	var providers []Configuration
+	for _, p := range conf.Providers {
+		if domain != "" && p.HasDomain(domain) {
+			providers = append(providers, p)
+			continue
+		}
+
+		for _, lp := range linked.Providers {
+			if lp.Provider == p.ID {
+				providers = append(providers, p)
+				break
+			}
+		}
+	}
+
+	if len(providers) == 0 {
+		return nil
+	}
+
+	l.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	AddProviders(l.UI, providers, text.NewInfoLoginWith)
+	return nil
+}
+
 // Update Login Flow with OpenID Connect Method
 //
 // swagger:model updateLoginFlowWithOidcMethod
@@ -81,6 +156,7 @@ type UpdateLoginFlowWithOidcMethod struct {
 	// Supported parameters are:
 	// - `login_hint` (string): The `login_hint` parameter suppresses the account chooser and either pre-fills the email box on the sign-in form, or selects the proper session.
 	// - `hd` (string): The `hd` parameter limits the login/registration process to a Google Organization, e.g. `mycollege.edu`.
+	// - `prompt`, `acr_values`, `max_age` and `ui_locales` (string): Only passed on if enabled in the provider's `upstream_parameters` configuration.
 	//
 	// required: false
 	UpstreamParameters json.RawMessage `json:"upstream_parameters"`
@@ -136,8 +212,18 @@ func (s *Strategy) processLogin(w http.ResponseWriter, r *http.Request, a *login
 
 	sess := session.NewInactiveSession()
 	sess.CompletedLoginFor(s.ID(), identity.AuthenticatorAssuranceLevel1)
+	upstreamSubject, upstreamSID := upstreamSession(claims)
+	sess.SetUpstream(s.ID(), provider.Config().ID, upstreamSubject, upstreamSID)
 	for _, c := range o.Providers {
 		if c.Subject == claims.Subject && c.Provider == provider.Config().ID {
+			if err := s.storeTokens(r.Context(), i.ID, c.Provider, c.Subject, token); err != nil {
+				return nil, s.handleError(w, r, a, provider.Config().ID, nil, err)
+			}
+
+			if i, err = s.syncIdentity(r, i, claims, provider); err != nil {
+				return nil, s.handleError(w, r, a, provider.Config().ID, nil, err)
+			}
+
 			if err = s.d.LoginHookExecutor().PostLoginHook(w, r, node.OpenIDConnectGroup, a, i, sess); err != nil {
 				return nil, s.handleError(w, r, a, provider.Config().ID, nil, err)
 			}
@@ -187,12 +273,14 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	}
 
 	state := generateState(f.ID.String())
+	cntnr := &authCodeContainer{
+		State:  state,
+		FlowID: f.ID.String(),
+		Traits: p.Traits,
+	}
+	options := authCodeURLOptions(r.Context(), provider, req, cntnr)
 	if err := s.d.ContinuityManager().Pause(r.Context(), w, r, sessionName,
-		continuity.WithPayload(&authCodeContainer{
-			State:  state,
-			FlowID: f.ID.String(),
-			Traits: p.Traits,
-		}),
+		continuity.WithPayload(cntnr),
 		continuity.WithLifespan(time.Minute*30)); err != nil {
 		return nil, s.handleError(w, r, f, pid, nil, err)
 	}
@@ -207,7 +295,7 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, err
 	}
 
-	codeURL := c.AuthCodeURL(state, append(provider.AuthCodeURLOptions(req), UpstreamParameters(provider, up)...)...)
+	codeURL := c.AuthCodeURL(state, append(options, UpstreamParameters(provider, up)...)...)
 	if x.IsJSONRequest(r) {
 		s.d.Writer().WriteError(w, r, flow.NewBrowserLocationChangeRequiredError(codeURL))
 	} else {
@@ -0,0 +1,224 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/session"
+	"github.com/ory/x/pointerx"
+	"github.com/ory/x/sqlcon"
+)
+
+const (
+	// backChannelLogoutEvent is the member of the `events` claim which identifies a logout token.
+	backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+	// logoutTokenMaxAge is how long after it was issued (`iat`) a logout token is accepted. Used token IDs (`jti`) are
+	// remembered for as long to reject replays.
+	logoutTokenMaxAge = 10 * time.Minute
+)
+
+// LogoutToken is a verified OpenID Connect Back-Channel Logout token. At least one of Subject and SessionID is set.
+type LogoutToken struct {
+	// Subject is the subject at the upstream identity provider whose sessions should be revoked.
+	Subject string
+
+	// SessionID is the session ID (`sid`) at the upstream identity provider whose sessions should be revoked.
+	SessionID string
+
+	// ID is the unique ID (`jti`) of the logout token.
+	ID string
+
+	// IssuedAt is the time (`iat`) the logout token was issued at.
+	IssuedAt time.Time
+}
+
+// parseLogoutToken validates the claims of a logout token whose signature, issuer and audience were already verified
+// as described in https://openid.net/specs/openid-connect-backchannel-1_0.html#Validation.
+func parseLogoutToken(token *gooidc.IDToken) (*LogoutToken, error) {
+	var claims struct {
+		SessionID string                     `json:"sid"`
+		ID        string                     `json:"jti"`
+		Nonce     *string                    `json:"nonce"`
+		Events    map[string]json.RawMessage `json:"events"`
+	}
+	if err := token.Claims(&claims); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
+	}
+
+	if !token.Expiry.IsZero() && token.Expiry.Before(time.Now()) {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token is expired."))
+	}
+
+	if token.IssuedAt.IsZero() {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token must contain the time it was issued at (iat)."))
+	}
+
+	if token.IssuedAt.Before(time.Now().Add(-logoutTokenMaxAge)) {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token was issued too long ago."))
+	}
+
+	if claims.ID == "" {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token must contain a unique ID (jti)."))
+	}
+
+	var event map[string]interface{}
+	if raw, ok := claims.Events[backChannelLogoutEvent]; !ok || json.Unmarshal(raw, &event) != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf(`The logout token must contain the "%s" event.`, backChannelLogoutEvent))
+	}
+
+	// A nonce is forbidden to prevent ID tokens from being used as logout tokens.
+	if claims.Nonce != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token must not contain a nonce."))
+	}
+
+	if token.Subject == "" && claims.SessionID == "" {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token must contain either a subject or a session ID."))
+	}
+
+	return &LogoutToken{Subject: token.Subject, SessionID: claims.SessionID, ID: claims.ID, IssuedAt: token.IssuedAt}, nil
+}
+
+// upstreamSession returns the subject and session ID (`sid`) reported by the provider in the ID token, which are
+// recorded in the session to match back-channel logouts.
+func upstreamSession(claims *Claims) (subject, sid string) {
+	subject = claims.Subject
+	// Some providers (e.g. Microsoft with `subject_source: me`) replace the subject of the ID token, which is the one
+	// used in logout tokens.
+	if sub, ok := claims.RawClaims["sub"].(string); ok && sub != "" {
+		subject = sub
+	}
+	sid, _ = claims.RawClaims["sid"].(string)
+	return subject, sid
+}
+
+// OpenID Connect Back-Channel Logout Parameters
+//
+// swagger:parameters performOidcBackChannelLogout
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type performOidcBackChannelLogout struct {
+	// The ID of the OpenID Connect provider which sends the logout token.
+	//
+	// required: true
+	// in: path
+	Provider string `json:"provider"`
+
+	// in: body
+	// required: true
+	Body performOidcBackChannelLogoutBody
+}
+
+// swagger:model performOidcBackChannelLogoutBody
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type performOidcBackChannelLogoutBody struct {
+	// The logout token issued by the OpenID Connect provider.
+	//
+	// required: true
+	LogoutToken string `json:"logout_token"`
+}
+
+// swagger:route POST /self-service/methods/oidc/backchannel-logout/{provider} frontend performOidcBackChannelLogout
+//
+// # Perform OpenID Connect Back-Channel Logout
+//
+// This endpoint is called by the upstream OpenID Connect provider when the user logged out there. It verifies the
+// logout token and revokes all sessions which were authenticated with the provider for the token's session ID or,
+// if the token has no session ID, its subject.
+//
+// Logout tokens must contain a unique ID (`jti`) and the time they were issued at (`iat`). Tokens issued more than
+// ten minutes ago and tokens whose ID was already used are rejected.
+//
+// See https://openid.net/specs/openid-connect-backchannel-1_0.html
+//
+//	Consumes:
+//	- application/x-www-form-urlencoded
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Responses:
+//	  200: emptyResponse
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (s *Strategy) handleBackChannelLogout(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	w.Header().Set("Cache-Control", "no-store")
+
+	provider, err := s.provider(ctx, r, ps.ByName("provider"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	verifier, ok := provider.(LogoutTokenVerifier)
+	if !ok {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf(`The OpenID Connect provider "%s" does not support back-channel logout.`, provider.Config().ID)))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to parse the request body: %s", err)))
+		return
+	}
+
+	raw := r.PostForm.Get("logout_token")
+	if raw == "" {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("The request is missing the logout_token parameter.")))
+		return
+	}
+
+	token, err := verifier.VerifyLogoutToken(ctx, raw)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := s.d.OIDCProviderPersister().UseOIDCLogoutToken(ctx, provider.Config().ID, token.ID, token.IssuedAt.Add(logoutTokenMaxAge)); errors.Is(err, sqlcon.ErrUniqueViolation) {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token was already used.")))
+		return
+	} else if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	// If the token identifies the upstream session, only sessions started from it are revoked. Otherwise, all
+	// sessions of the subject are revoked.
+	filter := &session.ListSessionsFilter{Active: pointerx.Ptr(true), UpstreamProvider: provider.Config().ID}
+	if token.SessionID != "" {
+		filter.UpstreamSessionID = token.SessionID
+	} else {
+		filter.UpstreamSubject = token.Subject
+	}
+
+	revoked, err := s.d.SessionPersister().RevokeSessions(ctx, filter)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.d.Audit().
+		WithRequest(r).
+		WithField("provider", provider.Config().ID).
+		WithField("upstream_subject", token.Subject).
+		WithField("upstream_sid", token.SessionID).
+		WithField("revoked_sessions", revoked).
+		Info("Sessions were revoked because the user logged out at the OpenID Connect provider.")
+
+	w.WriteHeader(http.StatusOK)
+}
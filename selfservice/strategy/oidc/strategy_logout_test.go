@@ -0,0 +1,193 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+func TestBackChannelLogout(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var upstream *httptest.Server
+	upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                 upstream.URL,
+				"authorization_endpoint": upstream.URL + "/auth",
+				"token_endpoint":         upstream.URL + "/token",
+				"jwks_uri":               upstream.URL + "/jwks",
+			})
+		case "/jwks":
+			_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+				{Key: &key.PublicKey, KeyID: "key", Algorithm: "RS256", Use: "sig"},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/registration.schema.json")
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".enabled", true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".config", &oidc.ConfigurationCollection{Providers: []oidc.Configuration{
+		{ID: "generic", Provider: "generic", ClientID: "client", ClientSecret: "secret", IssuerURL: upstream.URL, Mapper: "file://./stub/oidc.hydra.jsonnet"},
+		{ID: "github", Provider: "github", ClientID: "client", ClientSecret: "secret", Mapper: "file://./stub/oidc.hydra.jsonnet"},
+	}})
+
+	publicTS, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	logoutToken := func(t *testing.T, claims jwt.MapClaims) string {
+		base := jwt.MapClaims{
+			"iss":    upstream.URL,
+			"aud":    "client",
+			"iat":    time.Now().Unix(),
+			"exp":    time.Now().Add(time.Minute).Unix(),
+			"jti":    x.NewUUID().String(),
+			"events": map[string]interface{}{"http://schemas.openid.net/event/backchannel-logout": map[string]interface{}{}},
+		}
+		for k, v := range claims {
+			if v == nil {
+				delete(base, k)
+			} else {
+				base[k] = v
+			}
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, base)
+		token.Header["kid"] = "key"
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+
+	logout := func(t *testing.T, provider, token string) *http.Response {
+		res, err := publicTS.Client().PostForm(publicTS.URL+oidc.RouteBase+"/backchannel-logout/"+provider, url.Values{"logout_token": {token}})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = res.Body.Close() })
+		assert.Equal(t, "no-store", res.Header.Get("Cache-Control"))
+		return res
+	}
+
+	createSession := func(t *testing.T, i *identity.Identity, provider, subject, sid string) *session.Session {
+		s, err := session.NewActiveSession(&http.Request{Header: http.Header{}}, i, conf, time.Now().UTC(), identity.CredentialsTypeOIDC, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		s.SetUpstream(identity.CredentialsTypeOIDC, provider, subject, sid)
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+		return s
+	}
+
+	isActive := func(t *testing.T, s *session.Session) bool {
+		actual, err := reg.SessionPersister().GetSession(ctx, s.ID, session.ExpandNothing)
+		require.NoError(t, err)
+		return actual.Active
+	}
+
+	i := identity.NewIdentity("")
+	i.Traits = identity.Traits(`{"subject":"back-channel@ory.sh"}`)
+	require.NoError(t, reg.IdentityManager().Create(ctx, i))
+
+	subject := x.NewUUID().String()
+
+	t.Run("case=rejects invalid logout tokens", func(t *testing.T) {
+		s := createSession(t, i, "generic", subject, "sid-invalid")
+
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		forged := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"iss": upstream.URL, "aud": "client", "sub": subject, "sid": "sid-invalid"})
+		forged.Header["kid"] = "key"
+		forgedToken, err := forged.SignedString(otherKey)
+		require.NoError(t, err)
+
+		for name, token := range map[string]string{
+			"missing":             "",
+			"forged":              forgedToken,
+			"wrong audience":      logoutToken(t, jwt.MapClaims{"aud": "other", "sid": "sid-invalid"}),
+			"expired":             logoutToken(t, jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix(), "sid": "sid-invalid"}),
+			"missing event":       logoutToken(t, jwt.MapClaims{"events": nil, "sid": "sid-invalid"}),
+			"with nonce":          logoutToken(t, jwt.MapClaims{"nonce": "nonce", "sid": "sid-invalid"}),
+			"without sub and sid": logoutToken(t, jwt.MapClaims{}),
+			"without iat":         logoutToken(t, jwt.MapClaims{"iat": nil, "sid": "sid-invalid"}),
+			"issued too long ago": logoutToken(t, jwt.MapClaims{"iat": time.Now().Add(-time.Hour).Unix(), "sid": "sid-invalid"}),
+			"without jti":         logoutToken(t, jwt.MapClaims{"jti": nil, "sid": "sid-invalid"}),
+		} {
+			t.Run("token="+name, func(t *testing.T) {
+				res := logout(t, "generic", token)
+				assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+			})
+		}
+
+		assert.True(t, isActive(t, s))
+	})
+
+	t.Run("case=rejects unknown providers and providers without back-channel logout", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, logout(t, "unknown", logoutToken(t, jwt.MapClaims{"sub": subject})).StatusCode)
+		assert.Equal(t, http.StatusBadRequest, logout(t, "github", logoutToken(t, jwt.MapClaims{"sub": subject})).StatusCode)
+	})
+
+	t.Run("case=revokes the sessions of the upstream session", func(t *testing.T) {
+		revoked := createSession(t, i, "generic", subject, "sid-1")
+		other := createSession(t, i, "generic", subject, "sid-2")
+		otherProvider := createSession(t, i, "github", subject, "sid-1")
+
+		res := logout(t, "generic", logoutToken(t, jwt.MapClaims{"sub": subject, "sid": "sid-1"}))
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		assert.False(t, isActive(t, revoked))
+		assert.True(t, isActive(t, other))
+		assert.True(t, isActive(t, otherProvider))
+	})
+
+	t.Run("case=revokes all sessions of the subject", func(t *testing.T) {
+		otherSubject := x.NewUUID().String()
+		first := createSession(t, i, "generic", otherSubject, "sid-3")
+		second := createSession(t, i, "generic", otherSubject, "")
+		other := createSession(t, i, "generic", subject, "sid-4")
+
+		res := logout(t, "generic", logoutToken(t, jwt.MapClaims{"sub": otherSubject}))
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		assert.False(t, isActive(t, first))
+		assert.False(t, isActive(t, second))
+		assert.True(t, isActive(t, other))
+	})
+
+	t.Run("case=rejects replayed logout tokens", func(t *testing.T) {
+		token := logoutToken(t, jwt.MapClaims{"sub": subject, "sid": "sid-5"})
+
+		first := createSession(t, i, "generic", subject, "sid-5")
+		assert.Equal(t, http.StatusOK, logout(t, "generic", token).StatusCode)
+		assert.False(t, isActive(t, first))
+
+		second := createSession(t, i, "generic", subject, "sid-5")
+		assert.Equal(t, http.StatusBadRequest, logout(t, "generic", token).StatusCode)
+		assert.True(t, isActive(t, second))
+	})
+}
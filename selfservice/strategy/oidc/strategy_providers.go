@@ -0,0 +1,616 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/jsonschema/v3"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/embedx"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteAdminProviders = "/oidc/providers"
+	RouteAdminProvider  = RouteAdminProviders + "/:id"
+)
+
+// providerSecrets are the keys of the provider configuration which are stored encrypted and never returned.
+var providerSecrets = []string{"client_secret", "apple_private_key"}
+
+var (
+	providerSchema     *jsonschema.Schema
+	providerSchemaErr  error
+	providerSchemaOnce sync.Once
+)
+
+func (s *Strategy) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.GET(RouteAdminProviders, strategy.IsDisabled(s.d, s.ID().String(), s.listOidcProviders))
+	admin.POST(RouteAdminProviders, strategy.IsDisabled(s.d, s.ID().String(), s.createOidcProvider))
+	admin.GET(RouteAdminProvider, strategy.IsDisabled(s.d, s.ID().String(), s.getOidcProvider))
+	admin.PUT(RouteAdminProvider, strategy.IsDisabled(s.d, s.ID().String(), s.updateOidcProvider))
+	admin.DELETE(RouteAdminProvider, strategy.IsDisabled(s.d, s.ID().String(), s.deleteOidcProvider))
+}
+
+func (s *Strategy) RegisterPublicAdminRoutes(public *x.RouterPublic) {
+	s.d.CSRFHandler().IgnoreGlobs(x.AdminPrefix+RouteAdminProviders, x.AdminPrefix+RouteAdminProviders+"/*")
+	public.GET(x.AdminPrefix+RouteAdminProviders, x.RedirectToAdminRoute(s.d))
+	public.POST(x.AdminPrefix+RouteAdminProviders, x.RedirectToAdminRoute(s.d))
+	public.GET(x.AdminPrefix+RouteAdminProvider, x.RedirectToAdminRoute(s.d))
+	public.PUT(x.AdminPrefix+RouteAdminProvider, x.RedirectToAdminRoute(s.d))
+	public.DELETE(x.AdminPrefix+RouteAdminProvider, x.RedirectToAdminRoute(s.d))
+}
+
+// storedProvidersTTL is how long the stored providers are cached. Changes made using the admin API of this instance
+// invalidate the cache right away, other instances pick them up once their cache expired.
+const storedProvidersTTL = time.Minute
+
+// storedProvidersCache caches the decrypted stored providers per network, as they are needed for every request of
+// the OpenID Connect strategy.
+type storedProvidersCache struct {
+	sync.Mutex
+	entries map[uuid.UUID]*storedProvidersCacheEntry
+}
+
+type storedProvidersCacheEntry struct {
+	ids       []string
+	providers []Configuration
+	expiresAt time.Time
+}
+
+// storedProviders returns the providers added using the admin API with their secrets decrypted. Providers which
+// are also defined in the configuration are skipped, as the configuration always takes precedence.
+func (s *Strategy) storedProviders(ctx context.Context, static *ConfigurationCollection) ([]Configuration, error) {
+	entry, err := s.cachedStoredProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]Configuration, 0, len(entry.providers))
+	for k, id := range entry.ids {
+		if static.has(id) {
+			continue
+		}
+		providers = append(providers, entry.providers[k])
+	}
+
+	return providers, nil
+}
+
+func (s *Strategy) cachedStoredProviders(ctx context.Context) (*storedProvidersCacheEntry, error) {
+	nid := s.d.OIDCProviderPersister().NetworkID(ctx)
+
+	s.providers.Lock()
+	defer s.providers.Unlock()
+
+	if entry, ok := s.providers.entries[nid]; ok && time.Now().Before(entry.expiresAt) {
+		return entry, nil
+	}
+
+	stored, err := s.d.OIDCProviderPersister().ListOIDCProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &storedProvidersCacheEntry{
+		ids:       make([]string, 0, len(stored)),
+		providers: make([]Configuration, 0, len(stored)),
+		expiresAt: time.Now().Add(storedProvidersTTL),
+	}
+	for _, sp := range stored {
+		config, err := s.decryptProviderSecrets(ctx, json.RawMessage(sp.Config))
+		if err != nil {
+			return nil, err
+		}
+
+		var c Configuration
+		if err := json.Unmarshal(config, &c); err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the stored OpenID Connect provider %s: %s", sp.ProviderID, err))
+		}
+		entry.ids = append(entry.ids, sp.ProviderID)
+		entry.providers = append(entry.providers, c)
+	}
+
+	if s.providers.entries == nil {
+		s.providers.entries = make(map[uuid.UUID]*storedProvidersCacheEntry)
+	}
+	s.providers.entries[nid] = entry
+	return entry, nil
+}
+
+// invalidateStoredProviders removes the stored providers of the current network from the cache.
+func (s *Strategy) invalidateStoredProviders(ctx context.Context) {
+	nid := s.d.OIDCProviderPersister().NetworkID(ctx)
+
+	s.providers.Lock()
+	defer s.providers.Unlock()
+	delete(s.providers.entries, nid)
+}
+
+func (c *ConfigurationCollection) has(id string) bool {
+	for _, p := range c.Providers {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Strategy) encryptProviderSecrets(ctx context.Context, config json.RawMessage) (_ json.RawMessage, err error) {
+	for _, key := range providerSecrets {
+		value := gjson.GetBytes(config, key).String()
+		if value == "" {
+			continue
+		}
+
+		encrypted, err := s.d.Cipher(ctx).Encrypt(ctx, []byte(value))
+		if err != nil {
+			return nil, err
+		}
+
+		if config, err = sjson.SetBytes(config, key, encrypted); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return config, nil
+}
+
+func (s *Strategy) decryptProviderSecrets(ctx context.Context, config json.RawMessage) (_ json.RawMessage, err error) {
+	for _, key := range providerSecrets {
+		value := gjson.GetBytes(config, key).String()
+		if value == "" {
+			continue
+		}
+
+		decrypted, err := s.d.Cipher(ctx).Decrypt(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+
+		if config, err = sjson.SetBytes(config, key, string(decrypted)); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return config, nil
+}
+
+// withoutSecrets returns a copy of the stored provider which is safe to be returned by the API.
+func withoutSecrets(sp StoredProvider) (_ StoredProvider, err error) {
+	for _, key := range providerSecrets {
+		if sp.Config, err = sjson.DeleteBytes(sp.Config, key); err != nil {
+			return sp, errors.WithStack(err)
+		}
+	}
+	return sp, nil
+}
+
+// validateProvider validates the provider configuration against the configuration schema and checks that the
+// provider can be set up, which runs the OpenID Connect discovery for providers which use it.
+func (s *Strategy) validateProvider(ctx context.Context, config json.RawMessage) (*Configuration, error) {
+	providerSchemaOnce.Do(func() {
+		compiler := jsonschema.NewCompiler()
+		if providerSchemaErr = embedx.AddSchemaResources(compiler, embedx.Config); providerSchemaErr != nil {
+			return
+		}
+		providerSchema, providerSchemaErr = compiler.Compile(ctx, embedx.Config.GetSchemaID()+"#/definitions/selfServiceOIDCProvider")
+	})
+	if providerSchemaErr != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to compile the OpenID Connect provider schema.").WithDebug(providerSchemaErr.Error()))
+	}
+
+	if err := providerSchema.Validate(bytes.NewReader(config)); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The OpenID Connect provider configuration is invalid: %s", err))
+	}
+
+	var c Configuration
+	if err := jsonx.NewStrictDecoder(bytes.NewReader(config)).Decode(&c); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode the OpenID Connect provider configuration: %s", err))
+	}
+
+	provider, err := (&ConfigurationCollection{Providers: []Configuration{c}}).Provider(c.ID, s.d)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := provider.OAuth2(ctx); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to set up the OpenID Connect provider, please check the issuer URL and the provider's discovery document: %s", herodot.ToDefaultError(err, "").Reason()).WithDebug(err.Error()))
+	}
+
+	return &c, nil
+}
+
+func (s *Strategy) decodeProvider(r *http.Request) (json.RawMessage, error) {
+	var config json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode JSON payload: %s", err))
+	}
+	return config, nil
+}
+
+func (s *Strategy) ensureNotStatic(ctx context.Context, id string) error {
+	static, err := s.staticConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if static.has(id) {
+		return errors.WithStack(herodot.ErrConflict.WithReasonf(`The OpenID Connect provider "%s" is defined in the configuration and can not be managed using the admin API.`, id))
+	}
+	return nil
+}
+
+// Stored OpenID Connect Provider List
+//
+// swagger:response listOidcProviders
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listOidcProvidersResponse struct {
+	// in: body
+	Body []StoredProvider
+}
+
+// swagger:route GET /admin/oidc/providers identity listOidcProviders
+//
+// # List OpenID Connect Providers
+//
+// Lists the social sign in providers which were added using the admin API. Providers defined in the
+// configuration are not included. Client secrets and private keys are never returned.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: listOidcProviders
+//	  default: errorGeneric
+func (s *Strategy) listOidcProviders(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	stored, err := s.d.OIDCProviderPersister().ListOIDCProviders(r.Context())
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	providers := make([]StoredProvider, len(stored))
+	for k := range stored {
+		if providers[k], err = withoutSecrets(stored[k]); err != nil {
+			s.d.Writer().WriteError(w, r, err)
+			return
+		}
+	}
+
+	s.d.Writer().Write(w, r, providers)
+}
+
+// Get OpenID Connect Provider Parameters
+//
+// swagger:parameters getOidcProvider deleteOidcProvider
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type getOidcProvider struct {
+	// ID is the provider's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/oidc/providers/{id} identity getOidcProvider
+//
+// # Get an OpenID Connect Provider
+//
+// Returns a social sign in provider which was added using the admin API. Client secrets and private keys are
+// never returned.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: oidcProvider
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (s *Strategy) getOidcProvider(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	stored, err := s.d.OIDCProviderPersister().GetOIDCProvider(r.Context(), ps.ByName("id"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	provider, err := withoutSecrets(*stored)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.d.Writer().Write(w, r, provider)
+}
+
+// Create OpenID Connect Provider Parameters
+//
+// swagger:parameters createOidcProvider
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type createOidcProvider struct {
+	// The provider's configuration in the format of `selfservice.methods.oidc.config.providers`.
+	//
+	// required: true
+	// in: body
+	Body map[string]interface{}
+}
+
+// swagger:route POST /admin/oidc/providers identity createOidcProvider
+//
+// # Create an OpenID Connect Provider
+//
+// Adds a social sign in provider without changing the configuration. The provider can be used right away.
+// The configuration is validated and, for providers using OpenID Connect Discovery, the discovery document
+// of the issuer is fetched. The client secret and private keys are stored encrypted.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  201: oidcProvider
+//	  400: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+func (s *Strategy) createOidcProvider(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	config, err := s.decodeProvider(r)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	c, err := s.validateProvider(ctx, config)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := s.ensureNotStatic(ctx, c.ID); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	encrypted, err := s.encryptProviderSecrets(ctx, config)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	stored := &StoredProvider{ProviderID: c.ID, Config: sqlxx.JSONRawMessage(encrypted)}
+	if err := s.d.OIDCProviderPersister().CreateOIDCProvider(ctx, stored); errors.Is(err, sqlcon.ErrUniqueViolation) {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict.WithReasonf(`The OpenID Connect provider "%s" already exists.`, c.ID)))
+		return
+	} else if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	s.invalidateStoredProviders(ctx)
+
+	s.d.Audit().
+		WithRequest(r).
+		WithField("provider", c.ID).
+		Info("An OpenID Connect provider was created by an administrator.")
+
+	provider, err := withoutSecrets(*stored)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.d.Writer().WriteCreated(w, r, x.AdminPrefix+RouteAdminProviders+"/"+c.ID, provider)
+}
+
+// Update OpenID Connect Provider Parameters
+//
+// swagger:parameters updateOidcProvider
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type updateOidcProvider struct {
+	// ID is the provider's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// The provider's configuration in the format of `selfservice.methods.oidc.config.providers`.
+	//
+	// required: true
+	// in: body
+	Body map[string]interface{}
+}
+
+// swagger:route PUT /admin/oidc/providers/{id} identity updateOidcProvider
+//
+// # Update an OpenID Connect Provider
+//
+// Replaces the configuration of a social sign in provider which was added using the admin API. The change takes
+// effect right away. If the client secret or private key is omitted, the stored one is kept. The ID of the
+// provider can not be changed.
+//
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: oidcProvider
+//	  400: errorGeneric
+//	  404: errorGeneric
+//	  default: errorGeneric
+func (s *Strategy) updateOidcProvider(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	id := ps.ByName("id")
+
+	stored, err := s.d.OIDCProviderPersister().GetOIDCProvider(ctx, id)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	config, err := s.decodeProvider(r)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if given := gjson.GetBytes(config, "id"); !given.Exists() {
+		if config, err = sjson.SetBytes(config, "id", id); err != nil {
+			s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode JSON payload: %s", err)))
+			return
+		}
+	} else if given.String() != id {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The ID of the OpenID Connect provider can not be changed.")))
+		return
+	}
+
+	previous, err := s.decryptProviderSecrets(ctx, json.RawMessage(stored.Config))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	for _, key := range providerSecrets {
+		if gjson.GetBytes(config, key).String() != "" {
+			continue
+		}
+		if secret := gjson.GetBytes(previous, key); secret.String() != "" {
+			if config, err = sjson.SetBytes(config, key, secret.String()); err != nil {
+				s.d.Writer().WriteError(w, r, errors.WithStack(err))
+				return
+			}
+		}
+	}
+
+	if _, err := s.validateProvider(ctx, config); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	encrypted, err := s.encryptProviderSecrets(ctx, config)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	stored.Config = sqlxx.JSONRawMessage(encrypted)
+	if err := s.d.OIDCProviderPersister().UpdateOIDCProvider(ctx, stored); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	s.invalidateStoredProviders(ctx)
+
+	s.d.Audit().
+		WithRequest(r).
+		WithField("provider", id).
+		Info("An OpenID Connect provider was updated by an administrator.")
+
+	provider, err := withoutSecrets(*stored)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.d.Writer().Write(w, r, provider)
+}
+
+// swagger:route DELETE /admin/oidc/providers/{id} identity deleteOidcProvider
+//
+// # Delete an OpenID Connect Provider
+//
+// Removes a social sign in provider which was added using the admin API. Providers which identities have still
+// linked can not be deleted. Remove the OpenID Connect credential items of these identities first.
+//
+//	Produces:
+//	- application/json
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  204: emptyResponse
+//	  404: errorGeneric
+//	  409: errorGeneric
+//	  default: errorGeneric
+func (s *Strategy) deleteOidcProvider(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	id := ps.ByName("id")
+
+	if _, err := s.d.OIDCProviderPersister().GetOIDCProvider(ctx, id); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	linked, err := s.d.OIDCProviderPersister().CountOIDCProviderLinks(ctx, id)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	} else if linked > 0 {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict.
+			WithReasonf(`The OpenID Connect provider "%s" can not be deleted because %d identities have linked it. Remove their OpenID Connect credential items first.`, id, linked).
+			WithDetail("linked_identities", linked)))
+		return
+	}
+
+	if err := s.d.OIDCProviderPersister().DeleteOIDCProvider(ctx, id); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	s.invalidateStoredProviders(ctx)
+
+	s.d.Audit().
+		WithRequest(r).
+		WithField("provider", id).
+		Info("An OpenID Connect provider was deleted by an administrator.")
+
+	w.WriteHeader(http.StatusNoContent)
+}
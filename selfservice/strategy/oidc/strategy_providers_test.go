@@ -0,0 +1,195 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+)
+
+func TestOIDCProviderAdminAPI(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	var upstream *httptest.Server
+	upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                 upstream.URL,
+			"authorization_endpoint": upstream.URL + "/auth",
+			"token_endpoint":         upstream.URL + "/token",
+			"jwks_uri":               upstream.URL + "/jwks",
+		})
+	}))
+	t.Cleanup(upstream.Close)
+
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/registration.schema.json")
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".enabled", true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".config", &oidc.ConfigurationCollection{Providers: []oidc.Configuration{
+		{ID: "static", Provider: "generic", ClientID: "client", ClientSecret: "secret", IssuerURL: upstream.URL, Mapper: "file://./stub/oidc.hydra.jsonnet"},
+	}})
+
+	_, adminTS := testhelpers.NewKratosServer(t, reg)
+	s := reg.LoginStrategies(ctx).MustStrategy(identity.CredentialsTypeOIDC).(*oidc.Strategy)
+
+	do := func(t *testing.T, method, path, body string) (*http.Response, string) {
+		req, err := http.NewRequest(method, adminTS.URL+"/admin/oidc/providers"+path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		raw, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res, string(raw)
+	}
+
+	provider := func(id, issuer, secret string) string {
+		raw, err := json.Marshal(map[string]interface{}{
+			"id":            id,
+			"provider":      "generic",
+			"client_id":     "client-" + id,
+			"client_secret": secret,
+			"issuer_url":    issuer,
+			"mapper_url":    "file://./stub/oidc.hydra.jsonnet",
+		})
+		require.NoError(t, err)
+		return string(raw)
+	}
+
+	configured := func(t *testing.T, id string) *oidc.Configuration {
+		c, err := s.Config(ctx)
+		require.NoError(t, err)
+		for _, p := range c.Providers {
+			if p.ID == id {
+				return &p
+			}
+		}
+		return nil
+	}
+
+	t.Run("case=creates a provider which can be used right away", func(t *testing.T) {
+		res, body := do(t, "POST", "", provider("customer", upstream.URL, "customer-secret"))
+		require.Equal(t, http.StatusCreated, res.StatusCode, body)
+		assert.Equal(t, "customer", gjson.Get(body, "id").String(), body)
+		assert.Equal(t, "client-customer", gjson.Get(body, "config.client_id").String(), body)
+		assert.False(t, gjson.Get(body, "config.client_secret").Exists(), "the secret must not be returned: %s", body)
+
+		stored, err := reg.OIDCProviderPersister().GetOIDCProvider(ctx, "customer")
+		require.NoError(t, err)
+		assert.NotContains(t, string(stored.Config), "customer-secret", "the secret must be stored encrypted")
+
+		c := configured(t, "customer")
+		require.NotNil(t, c)
+		assert.Equal(t, "customer-secret", c.ClientSecret)
+		assert.Equal(t, "client-customer", c.ClientID)
+		require.NotNil(t, configured(t, "static"))
+	})
+
+	t.Run("case=lists and gets providers without secrets", func(t *testing.T) {
+		res, body := do(t, "GET", "", "")
+		require.Equal(t, http.StatusOK, res.StatusCode, body)
+		assert.Equal(t, []interface{}{"customer"}, gjson.Get(body, "#.id").Value(), body)
+		assert.NotContains(t, body, "client_secret")
+
+		res, body = do(t, "GET", "/customer", "")
+		require.Equal(t, http.StatusOK, res.StatusCode, body)
+		assert.Equal(t, "customer", gjson.Get(body, "id").String(), body)
+		assert.NotContains(t, body, "client_secret")
+
+		res, _ = do(t, "GET", "/does-not-exist", "")
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("case=rejects conflicting providers", func(t *testing.T) {
+		res, body := do(t, "POST", "", provider("customer", upstream.URL, "secret"))
+		assert.Equal(t, http.StatusConflict, res.StatusCode, body)
+
+		res, body = do(t, "POST", "", provider("static", upstream.URL, "secret"))
+		assert.Equal(t, http.StatusConflict, res.StatusCode, body)
+		assert.Contains(t, gjson.Get(body, "error.reason").String(), "defined in the configuration", body)
+	})
+
+	t.Run("case=rejects invalid providers", func(t *testing.T) {
+		res, body := do(t, "POST", "", `{"id":"invalid","provider":"generic"}`)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+		assert.Contains(t, gjson.Get(body, "error.reason").String(), "configuration is invalid", body)
+
+		res, body = do(t, "POST", "", provider("undiscoverable", upstream.URL+"/does-not-exist", "secret"))
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+		assert.Contains(t, gjson.Get(body, "error.reason").String(), "discovery document", body)
+
+		assert.Nil(t, configured(t, "invalid"))
+		assert.Nil(t, configured(t, "undiscoverable"))
+	})
+
+	t.Run("case=updates a provider and keeps the secret", func(t *testing.T) {
+		res, body := do(t, "PUT", "/customer", `{"provider":"generic","client_id":"updated-client","issuer_url":"`+upstream.URL+`","mapper_url":"file://./stub/oidc.hydra.jsonnet"}`)
+		require.Equal(t, http.StatusOK, res.StatusCode, body)
+		assert.Equal(t, "updated-client", gjson.Get(body, "config.client_id").String(), body)
+
+		c := configured(t, "customer")
+		require.NotNil(t, c)
+		assert.Equal(t, "updated-client", c.ClientID)
+		assert.Equal(t, "customer-secret", c.ClientSecret)
+
+		res, body = do(t, "PUT", "/customer", provider("renamed", upstream.URL, ""))
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+		res, body = do(t, "PUT", "/does-not-exist", provider("does-not-exist", upstream.URL, ""))
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, body)
+	})
+
+	t.Run("case=rejects deleting a provider which identities linked", func(t *testing.T) {
+		i := identity.NewIdentity("")
+		i.Traits = identity.Traits(`{"subject":"linked@ory.sh"}`)
+		c, err := identity.NewCredentialsOIDC(&identity.CredentialsOIDCEncryptedTokens{}, "customer", "linked-subject")
+		require.NoError(t, err)
+		i.SetCredentials(identity.CredentialsTypeOIDC, *c)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+		res, body := do(t, "DELETE", "/customer", "")
+		assert.Equal(t, http.StatusConflict, res.StatusCode, body)
+		assert.EqualValues(t, 1, gjson.Get(body, "error.details.linked_identities").Int(), body)
+		require.NotNil(t, configured(t, "customer"))
+
+		require.NoError(t, reg.PrivilegedIdentityPool().DeleteIdentity(ctx, i.ID))
+	})
+
+	t.Run("case=deletes a provider", func(t *testing.T) {
+		res, body := do(t, "DELETE", "/customer", "")
+		require.Equal(t, http.StatusNoContent, res.StatusCode, body)
+		assert.Nil(t, configured(t, "customer"))
+
+		res, _ = do(t, "DELETE", "/customer", "")
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("case=caches stored providers", func(t *testing.T) {
+		res, body := do(t, "POST", "", provider("cached", upstream.URL, "cached-secret"))
+		require.Equal(t, http.StatusCreated, res.StatusCode, body)
+		require.NotNil(t, configured(t, "cached"))
+
+		// Changes which bypass the admin API are only picked up once the cache expired.
+		require.NoError(t, reg.OIDCProviderPersister().DeleteOIDCProvider(ctx, "cached"))
+		assert.NotNil(t, configured(t, "cached"))
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/x/stringsx"
+)
+
+// checkRequirements verifies that the claims meet the requirements configured for the provider. It is called
+// before the user signs in, signs up or links the provider, so no identity is created for users which do not
+// meet them.
+func (s *Strategy) checkRequirements(ctx context.Context, provider Provider, claims *Claims) error {
+	c := provider.Config()
+	label := stringsx.Coalesce(c.Label, c.ID)
+
+	if len(c.RequiredDomains) > 0 && !claims.hasDomain(c.RequiredDomains) {
+		return schema.NewOIDCRequirementsNotMetError(label, fmt.Sprintf("Your account must belong to one of the domains %s.", strings.Join(c.RequiredDomains, ", ")))
+	}
+
+	if len(c.RequiredGroups) > 0 && !claims.hasGroup(c.RequiredGroups) {
+		return schema.NewOIDCRequirementsNotMetError(label, fmt.Sprintf("Your account must be a member of one of %s.", strings.Join(c.RequiredGroups, ", ")))
+	}
+
+	if c.ClaimsRequirement != "" {
+		evaluated, err := s.evaluateJsonnet(ctx, c.ClaimsRequirement, claims)
+		if err != nil {
+			return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to evaluate the claims requirement of the OpenID Connect provider: %s", err))
+		}
+
+		if result := gjson.Parse(evaluated); result.Type != gjson.True {
+			return schema.NewOIDCRequirementsNotMetError(label, "Your account does not meet the requirements of this provider.")
+		}
+	}
+
+	return nil
+}
+
+// hasDomain returns true if the hosted domain or, if it is verified, the domain of the email address is one of
+// the domains.
+func (c *Claims) hasDomain(domains []string) bool {
+	var candidates []string
+	if c.HD != "" {
+		candidates = append(candidates, c.HD)
+	}
+	if bool(c.EmailVerified) {
+		if at := strings.LastIndex(c.Email, "@"); at > -1 {
+			candidates = append(candidates, c.Email[at+1:])
+		}
+	}
+
+	for _, candidate := range candidates {
+		for _, d := range domains {
+			if strings.EqualFold(candidate, d) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasGroup returns true if the team (e.g. of Slack), one of the organizations (e.g. of GitHub) or one of the
+// groups claimed by the provider is one of the groups. Organizations are compared case-insensitively, as GitHub
+// organization logins are.
+func (c *Claims) hasGroup(groups []string) bool {
+	organizations := rawClaimStrings(c.RawClaims["organizations"])
+	memberships := rawClaimStrings(c.RawClaims["groups"])
+	if c.Team != "" {
+		memberships = append(memberships, c.Team)
+	}
+
+	for _, g := range groups {
+		for _, o := range organizations {
+			if strings.EqualFold(o, g) {
+				return true
+			}
+		}
+		for _, m := range memberships {
+			if m == g {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rawClaimStrings returns the non-empty strings of a raw claim which is either a string or a list of strings.
+func rawClaimStrings(claim interface{}) (values []string) {
+	var candidates []interface{}
+	switch v := claim.(type) {
+	case string:
+		candidates = append(candidates, v)
+	case []string:
+		for _, m := range v {
+			candidates = append(candidates, m)
+		}
+	case []interface{}:
+		candidates = v
+	}
+
+	for _, m := range candidates {
+		if ms, ok := m.(string); ok && ms != "" {
+			values = append(values, ms)
+		}
+	}
+	return values
+}
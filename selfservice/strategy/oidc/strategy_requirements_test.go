@@ -0,0 +1,141 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/text"
+)
+
+func TestCheckRequirements(t *testing.T) {
+	ctx := context.Background()
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	s := oidc.NewStrategy(reg)
+
+	provider := func(c oidc.Configuration) oidc.Provider {
+		c.ID = "staff"
+		c.Provider = "generic_oauth2"
+		c.Label = "Staff GitHub"
+		return oidc.NewProviderGenericOAuth2(&c, reg)
+	}
+
+	jsonnet := func(code string) string {
+		return "base64://" + base64.StdEncoding.EncodeToString([]byte(code))
+	}
+
+	for k, tc := range []struct {
+		d      string
+		c      oidc.Configuration
+		claims oidc.Claims
+		reason string
+	}{
+		{
+			d:      "no requirements",
+			claims: oidc.Claims{Subject: "foo"},
+		},
+		{
+			d:      "hosted domain matches",
+			c:      oidc.Configuration{RequiredDomains: []string{"ory.sh"}},
+			claims: oidc.Claims{HD: "ory.sh", Email: "foo@gmail.com"},
+		},
+		{
+			d:      "verified email domain matches",
+			c:      oidc.Configuration{RequiredDomains: []string{"example.org", "ory.sh"}},
+			claims: oidc.Claims{Email: "foo@ORY.sh", EmailVerified: true},
+		},
+		{
+			d:      "unverified email domain is not accepted",
+			c:      oidc.Configuration{RequiredDomains: []string{"ory.sh"}},
+			claims: oidc.Claims{Email: "foo@ory.sh"},
+			reason: "Your account must belong to one of the domains ory.sh.",
+		},
+		{
+			d:      "domain does not match",
+			c:      oidc.Configuration{RequiredDomains: []string{"ory.sh"}},
+			claims: oidc.Claims{HD: "example.org", Email: "foo@example.org", EmailVerified: true},
+			reason: "Your account must belong to one of the domains ory.sh.",
+		},
+		{
+			d:      "slack team matches",
+			c:      oidc.Configuration{RequiredGroups: []string{"T0123"}},
+			claims: oidc.Claims{Team: "T0123"},
+		},
+		{
+			d:      "github organization matches",
+			c:      oidc.Configuration{RequiredGroups: []string{"ory"}},
+			claims: oidc.Claims{RawClaims: map[string]interface{}{"organizations": []string{"foo", "ory"}}},
+		},
+		{
+			d:      "github organization matches case-insensitively",
+			c:      oidc.Configuration{RequiredGroups: []string{"Ory"}},
+			claims: oidc.Claims{RawClaims: map[string]interface{}{"organizations": []interface{}{"foo", "ORY"}}},
+		},
+		{
+			d:      "groups claim must match exactly",
+			c:      oidc.Configuration{RequiredGroups: []string{"Admins"}},
+			claims: oidc.Claims{RawClaims: map[string]interface{}{"groups": []interface{}{"admins"}}},
+			reason: "Your account must be a member of one of Admins.",
+		},
+		{
+			d:      "groups claim matches",
+			c:      oidc.Configuration{RequiredGroups: []string{"admins"}},
+			claims: oidc.Claims{RawClaims: map[string]interface{}{"groups": []interface{}{"users", "admins"}}},
+		},
+		{
+			d:      "not a member",
+			c:      oidc.Configuration{RequiredGroups: []string{"ory", "admins"}},
+			claims: oidc.Claims{Team: "T0123", RawClaims: map[string]interface{}{"organizations": []string{"foo"}, "groups": []interface{}{"users"}}},
+			reason: "Your account must be a member of one of ory, admins.",
+		},
+		{
+			d:      "jsonnet requirement is met",
+			c:      oidc.Configuration{ClaimsRequirement: jsonnet(`std.extVar('claims').email_verified && std.endsWith(std.extVar('claims').email, '@ory.sh')`)},
+			claims: oidc.Claims{Email: "foo@ory.sh", EmailVerified: true},
+		},
+		{
+			d:      "jsonnet requirement is not met",
+			c:      oidc.Configuration{ClaimsRequirement: jsonnet(`local c = std.extVar('claims'); std.objectHas(c, 'email_verified') && c.email_verified`)},
+			claims: oidc.Claims{Email: "foo@ory.sh"},
+			reason: "Your account does not meet the requirements of this provider.",
+		},
+		{
+			d:      "jsonnet requirement must be true",
+			c:      oidc.Configuration{ClaimsRequirement: jsonnet(`"true"`)},
+			claims: oidc.Claims{Email: "foo@ory.sh"},
+			reason: "Your account does not meet the requirements of this provider.",
+		},
+	} {
+		t.Run(fmt.Sprintf("case=%d/description=%s", k, tc.d), func(t *testing.T) {
+			err := s.CheckRequirementsForTest(ctx, provider(tc.c), &tc.claims)
+			if tc.reason == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var ve *schema.ValidationError
+			require.ErrorAs(t, err, &ve)
+			require.Len(t, ve.Messages, 1)
+			assert.Equal(t, text.ErrorValidationOIDCRequirementsNotMet, ve.Messages[0].ID)
+			assert.Equal(t, "You are not allowed to sign in with Staff GitHub. "+tc.reason, ve.Messages[0].Text)
+		})
+	}
+
+	t.Run("case=fails if the jsonnet requirement can not be evaluated", func(t *testing.T) {
+		err := s.CheckRequirementsForTest(ctx, provider(oidc.Configuration{ClaimsRequirement: jsonnet(`error "broken"`)}), &oidc.Claims{})
+		require.Error(t, err)
+		var ve *schema.ValidationError
+		assert.False(t, errors.As(err, &ve))
+	})
+}
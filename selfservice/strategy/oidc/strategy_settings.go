@@ -18,6 +18,7 @@ import (
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/selfservice/strategy"
 	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
 
 	"github.com/ory/kratos/session"
 
@@ -41,6 +42,10 @@ var UnknownConnectionValidationError = &jsonschema.ValidationError{
 	Message: "can not unlink non-existing OpenID Connect connection", InstancePtr: "#/"}
 var ConnectionExistValidationError = &jsonschema.ValidationError{
 	Message: "can not link unknown or already existing OpenID Connect connection", InstancePtr: "#/"}
+var UnverifiedEmailValidationError = &jsonschema.ValidationError{
+	Message: "can not link OpenID Connect connection because the provider did not verify its email address", InstancePtr: "#/"}
+var EmailConflictValidationError = &jsonschema.ValidationError{
+	Message: "can not link OpenID Connect connection because its email address belongs to another account", InstancePtr: "#/"}
 
 func (s *Strategy) RegisterSettingsRoutes(router *x.RouterPublic) {}
 
@@ -225,6 +230,7 @@ type updateSettingsFlowWithOidcMethod struct {
 	// Supported parameters are:
 	// - `login_hint` (string): The `login_hint` parameter suppresses the account chooser and either pre-fills the email box on the sign-in form, or selects the proper session.
 	// - `hd` (string): The `hd` parameter limits the login/registration process to a Google Organization, e.g. `mycollege.edu`.
+	// - `prompt`, `acr_values`, `max_age` and `ui_locales` (string): Only passed on if enabled in the provider's `upstream_parameters` configuration.
 	//
 	// required: false
 	UpstreamParameters json.RawMessage `json:"upstream_parameters"`
@@ -335,8 +341,8 @@ func (s *Strategy) initLinkProvider(w http.ResponseWriter, r *http.Request, ctxU
 		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	}
 
-	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(r.Context())).Before(time.Now()) {
-		return s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(settings.NewFlowNeedsReAuth()))
+	if err := s.checkPrivilegedSession(r.Context(), ctxUpdate.Session); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	}
 
 	provider, err := s.provider(r.Context(), r, p.Link)
@@ -355,12 +361,14 @@ func (s *Strategy) initLinkProvider(w http.ResponseWriter, r *http.Request, ctxU
 	}
 
 	state := generateState(ctxUpdate.Flow.ID.String())
+	cntnr := &authCodeContainer{
+		State:  state,
+		FlowID: ctxUpdate.Flow.ID.String(),
+		Traits: p.Traits,
+	}
+	options := authCodeURLOptions(r.Context(), provider, req, cntnr)
 	if err := s.d.ContinuityManager().Pause(r.Context(), w, r, sessionName,
-		continuity.WithPayload(&authCodeContainer{
-			State:  state,
-			FlowID: ctxUpdate.Flow.ID.String(),
-			Traits: p.Traits,
-		}),
+		continuity.WithPayload(cntnr),
 		continuity.WithLifespan(time.Minute*30)); err != nil {
 		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	}
@@ -370,7 +378,7 @@ func (s *Strategy) initLinkProvider(w http.ResponseWriter, r *http.Request, ctxU
 		return err
 	}
 
-	codeURL := c.AuthCodeURL(state, append(provider.AuthCodeURLOptions(req), UpstreamParameters(provider, up)...)...)
+	codeURL := c.AuthCodeURL(state, append(options, UpstreamParameters(provider, up)...)...)
 	if x.IsJSONRequest(r) {
 		s.d.Writer().WriteError(w, r, flow.NewBrowserLocationChangeRequiredError(codeURL))
 	} else {
@@ -383,8 +391,8 @@ func (s *Strategy) initLinkProvider(w http.ResponseWriter, r *http.Request, ctxU
 func (s *Strategy) linkProvider(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, token *oauth2.Token, claims *Claims, provider Provider) error {
 	p := &updateSettingsFlowWithOidcMethod{
 		Link: provider.Config().ID, FlowID: ctxUpdate.Flow.ID.String()}
-	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(r.Context())).Before(time.Now()) {
-		return s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(settings.NewFlowNeedsReAuth()))
+	if err := s.checkPrivilegedSession(r.Context(), ctxUpdate.Session); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	}
 
 	i, err := s.isLinkable(r, ctxUpdate, p.Link)
@@ -392,19 +400,11 @@ func (s *Strategy) linkProvider(w http.ResponseWriter, r *http.Request, ctxUpdat
 		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	}
 
-	var it string
-	if idToken, ok := token.Extra("id_token").(string); ok {
-		if it, err = s.d.Cipher(r.Context()).Encrypt(r.Context(), []byte(idToken)); err != nil {
-			return s.handleSettingsError(w, r, ctxUpdate, p, err)
-		}
-	}
-
-	cat, err := s.d.Cipher(r.Context()).Encrypt(r.Context(), []byte(token.AccessToken))
-	if err != nil {
+	if err := s.checkLinkableClaims(r.Context(), i, claims); err != nil {
 		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	}
 
-	crt, err := s.d.Cipher(r.Context()).Encrypt(r.Context(), []byte(token.RefreshToken))
+	tokens, err := s.encryptTokens(r.Context(), token)
 	if err != nil {
 		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	}
@@ -413,19 +413,21 @@ func (s *Strategy) linkProvider(w http.ResponseWriter, r *http.Request, ctxUpdat
 	creds, err := i.ParseCredentials(s.ID(), &conf)
 	if errors.Is(err, herodot.ErrNotFound) {
 		var err error
-		if creds, err = identity.NewCredentialsOIDC(it, cat, crt, provider.Config().ID, claims.Subject); err != nil {
+		if creds, err = identity.NewCredentialsOIDC(tokens, provider.Config().ID, claims.Subject); err != nil {
 			return s.handleSettingsError(w, r, ctxUpdate, p, err)
 		}
 	} else if err != nil {
 		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	} else {
 		creds.Identifiers = append(creds.Identifiers, identity.OIDCUniqueID(provider.Config().ID, claims.Subject))
-		conf.Providers = append(conf.Providers, identity.CredentialsOIDCProvider{
+		linked := identity.CredentialsOIDCProvider{
 			Subject: claims.Subject, Provider: provider.Config().ID,
-			InitialAccessToken:  cat,
-			InitialRefreshToken: crt,
-			InitialIDToken:      it,
-		})
+			InitialAccessToken:  tokens.AccessToken,
+			InitialRefreshToken: tokens.RefreshToken,
+			InitialIDToken:      tokens.IDToken,
+		}
+		linked.SetTokens(tokens)
+		conf.Providers = append(conf.Providers, linked)
 
 		creds.Config, err = json.Marshal(conf)
 		if err != nil {
@@ -440,12 +442,13 @@ func (s *Strategy) linkProvider(w http.ResponseWriter, r *http.Request, ctxUpdat
 		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	}
 
+	s.notifyConnectionChanged(r.Context(), i, provider.Config().ID, true)
 	return nil
 }
 
 func (s *Strategy) unlinkProvider(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithOidcMethod) error {
-	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(r.Context())).Before(time.Now()) {
-		return s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(settings.NewFlowNeedsReAuth()))
+	if err := s.checkPrivilegedSession(r.Context(), ctxUpdate.Session); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	}
 
 	providers, err := s.Config(r.Context())
@@ -503,9 +506,70 @@ func (s *Strategy) unlinkProvider(w http.ResponseWriter, r *http.Request, ctxUpd
 		return s.handleSettingsError(w, r, ctxUpdate, p, err)
 	}
 
+	s.notifyConnectionChanged(r.Context(), i, p.Unlink, false)
 	return errors.WithStack(flow.ErrCompletedByStrategy)
 }
 
+// checkPrivilegedSession asks the user to re-authenticate unless the session was authenticated within the privileged
+// session max age, using one of the methods required by the linking policy if any. In that case, the user is asked
+// to re-authenticate with the first of these methods which is enabled for login, as re-authenticating with any
+// other method would not satisfy the policy.
+func (s *Strategy) checkPrivilegedSession(ctx context.Context, sess *session.Session) error {
+	conf, err := s.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	maxAge := s.d.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(ctx)
+	if methods := conf.Linking.RequiredAuthenticationMethods; len(methods) > 0 {
+		if sess.AuthenticatedWithin(maxAge, methods...) {
+			return nil
+		}
+
+		strategies := s.d.LoginStrategies(ctx)
+		for _, method := range methods {
+			if _, err := strategies.Strategy(method); err == nil {
+				return errors.WithStack(settings.NewFlowNeedsReAuth().WithRequiredMethod(method))
+			}
+		}
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("None of the authentication methods required to link OpenID Connect providers is enabled for login: %v", methods))
+	}
+
+	if sess.AuthenticatedAt.Add(maxAge).Before(time.Now()) {
+		return errors.WithStack(settings.NewFlowNeedsReAuth())
+	}
+	return nil
+}
+
+// checkLinkableClaims prevents linking a provider whose email address is not verified (if required by the linking
+// policy) or belongs to another identity.
+func (s *Strategy) checkLinkableClaims(ctx context.Context, i *identity.Identity, claims *Claims) error {
+	if claims.Email == "" {
+		return nil
+	}
+
+	conf, err := s.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	if conf.Linking.RequireVerifiedEmail && !bool(claims.EmailVerified) {
+		return errors.WithStack(UnverifiedEmailValidationError)
+	}
+
+	address, err := s.d.PrivilegedIdentityPool().FindVerifiableAddressByValue(ctx, identity.VerifiableAddressTypeEmail, claims.Email)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if address.IdentityID != i.ID {
+		return errors.WithStack(EmailConflictValidationError)
+	}
+	return nil
+}
+
 func (s *Strategy) handleSettingsError(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithOidcMethod, err error) error {
 	if e := new(settings.FlowNeedsReAuth); errors.As(err, &e) {
 		if err := s.d.ContinuityManager().Pause(r.Context(), w, r,
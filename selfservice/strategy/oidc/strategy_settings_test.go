@@ -28,7 +28,11 @@ import (
 
 	"github.com/ory/x/sqlxx"
 
+	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver"
+
+	"github.com/ory/herodot"
+
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
@@ -37,6 +41,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/settings"
 
 	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
 
@@ -715,3 +720,139 @@ func TestPopulateSettingsMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestLinkingPolicy(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	s := oidc.NewStrategy(reg)
+
+	setPolicy := func(t *testing.T, policy oidc.LinkingConfiguration) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".config", &oidc.ConfigurationCollection{Linking: policy})
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".config", &oidc.ConfigurationCollection{})
+		})
+	}
+
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/registration.schema.json")
+	conf.MustSet(ctx, config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, time.Minute*5)
+
+	newIdentity := func(t *testing.T, email string) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"subject":"` + email + `"}`)
+		i.VerifiableAddresses = []identity.VerifiableAddress{{
+			Value: email, Via: identity.VerifiableAddressTypeEmail, Verified: true,
+			Status: identity.VerifiableAddressStatusCompleted,
+		}}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	t.Run("case=privileged session", func(t *testing.T) {
+		sess := &session.Session{
+			AuthenticatedAt: time.Now().Add(-time.Minute),
+			AMR: session.AuthenticationMethods{
+				{Method: identity.CredentialsTypePassword, CompletedAt: time.Now().Add(-time.Hour)},
+				{Method: identity.CredentialsTypeOIDC, CompletedAt: time.Now().Add(-time.Minute)},
+			},
+		}
+
+		disableWebAuthn := func(t *testing.T) {
+			key := config.ViperKeySelfServiceStrategyConfig + "." + string(identity.CredentialsTypeWebAuthn) + ".enabled"
+			enabled := conf.GetProvider(ctx).Bool(key)
+			conf.MustSet(ctx, key, false)
+			t.Cleanup(func() {
+				conf.MustSet(ctx, key, enabled)
+			})
+		}
+
+		t.Run("case=accepts any recent authentication without a policy", func(t *testing.T) {
+			require.NoError(t, s.CheckPrivilegedSessionForTest(ctx, sess))
+		})
+
+		t.Run("case=requires re-authentication of old sessions", func(t *testing.T) {
+			old := *sess
+			old.AuthenticatedAt = time.Now().Add(-time.Hour)
+			assert.ErrorAs(t, s.CheckPrivilegedSessionForTest(ctx, &old), new(*settings.FlowNeedsReAuth))
+		})
+
+		t.Run("case=accepts recent authentication with a required method", func(t *testing.T) {
+			setPolicy(t, oidc.LinkingConfiguration{RequiredAuthenticationMethods: []identity.CredentialsType{identity.CredentialsTypeWebAuthn, identity.CredentialsTypeOIDC}})
+			require.NoError(t, s.CheckPrivilegedSessionForTest(ctx, sess))
+		})
+
+		t.Run("case=requires re-authentication with the required method", func(t *testing.T) {
+			setPolicy(t, oidc.LinkingConfiguration{RequiredAuthenticationMethods: []identity.CredentialsType{identity.CredentialsTypePassword}})
+			var e *settings.FlowNeedsReAuth
+			require.ErrorAs(t, s.CheckPrivilegedSessionForTest(ctx, sess), &e)
+			assert.Equal(t, identity.CredentialsTypePassword, e.RequiredMethod)
+		})
+
+		t.Run("case=requires re-authentication with the first required method which is enabled", func(t *testing.T) {
+			disableWebAuthn(t)
+			setPolicy(t, oidc.LinkingConfiguration{RequiredAuthenticationMethods: []identity.CredentialsType{identity.CredentialsTypeWebAuthn, identity.CredentialsTypePassword}})
+			var e *settings.FlowNeedsReAuth
+			require.ErrorAs(t, s.CheckPrivilegedSessionForTest(ctx, sess), &e)
+			assert.Equal(t, identity.CredentialsTypePassword, e.RequiredMethod)
+		})
+
+		t.Run("case=fails if no required method is enabled", func(t *testing.T) {
+			disableWebAuthn(t)
+			setPolicy(t, oidc.LinkingConfiguration{RequiredAuthenticationMethods: []identity.CredentialsType{identity.CredentialsTypeWebAuthn}})
+			var e *herodot.DefaultError
+			require.ErrorAs(t, s.CheckPrivilegedSessionForTest(ctx, sess), &e)
+			assert.Equal(t, http.StatusInternalServerError, e.CodeField)
+		})
+	})
+
+	t.Run("case=linkable claims", func(t *testing.T) {
+		email := "linking-" + x.NewUUID().String() + "@ory.sh"
+		i := newIdentity(t, email)
+		other := newIdentity(t, "linking-"+x.NewUUID().String()+"@ory.sh")
+
+		t.Run("case=accepts claims without email", func(t *testing.T) {
+			setPolicy(t, oidc.LinkingConfiguration{RequireVerifiedEmail: true})
+			require.NoError(t, s.CheckLinkableClaimsForTest(ctx, i, &oidc.Claims{Subject: "foo"}))
+		})
+
+		t.Run("case=accepts unverified email without a policy", func(t *testing.T) {
+			require.NoError(t, s.CheckLinkableClaimsForTest(ctx, i, &oidc.Claims{Subject: "foo", Email: "unknown-" + email}))
+		})
+
+		t.Run("case=rejects unverified email", func(t *testing.T) {
+			setPolicy(t, oidc.LinkingConfiguration{RequireVerifiedEmail: true})
+			assert.ErrorIs(t, s.CheckLinkableClaimsForTest(ctx, i, &oidc.Claims{Subject: "foo", Email: email}), oidc.UnverifiedEmailValidationError)
+			require.NoError(t, s.CheckLinkableClaimsForTest(ctx, i, &oidc.Claims{Subject: "foo", Email: email, EmailVerified: true}))
+		})
+
+		t.Run("case=accepts the identity's own email", func(t *testing.T) {
+			require.NoError(t, s.CheckLinkableClaimsForTest(ctx, i, &oidc.Claims{Subject: "foo", Email: email, EmailVerified: true}))
+		})
+
+		t.Run("case=rejects email of another identity", func(t *testing.T) {
+			assert.ErrorIs(t, s.CheckLinkableClaimsForTest(ctx, other, &oidc.Claims{Subject: "foo", Email: email, EmailVerified: true}), oidc.EmailConflictValidationError)
+		})
+	})
+
+	t.Run("case=notifies the identity", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyCourierSMTPURL, "smtp://foo@bar@dev.null/")
+		i := newIdentity(t, "linking-"+x.NewUUID().String()+"@ory.sh")
+		i.VerifiableAddresses = append(i.VerifiableAddresses, identity.VerifiableAddress{
+			Value: "unverified-" + x.NewUUID().String() + "@ory.sh", Via: identity.VerifiableAddressTypeEmail,
+		})
+
+		s.NotifyConnectionChangedForTest(ctx, i, "google", true)
+		s.NotifyConnectionChangedForTest(ctx, i, "github", false)
+
+		messages, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 2)
+
+		for k, expected := range []string{"A social sign in provider was linked to your account", "A social sign in provider was unlinked from your account"} {
+			assert.Equal(t, i.VerifiableAddresses[0].Value, messages[k].Recipient)
+			assert.Equal(t, courier.TypeOIDCConnectionChanged, messages[k].TemplateType)
+			assert.Equal(t, expected, messages[k].Subject)
+		}
+		assert.Contains(t, messages[0].Body, "your google account was just linked")
+		assert.Contains(t, messages[1].Body, "your github account was just unlinked")
+	})
+}
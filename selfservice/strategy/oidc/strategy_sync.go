@@ -0,0 +1,127 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+// evaluateJsonnet runs the Jsonnet snippet located at the URL with the claims.
+func (s *Strategy) evaluateJsonnet(ctx context.Context, jsonnetURL string, claims *Claims) (string, error) {
+	fetch := fetcher.NewFetcher(fetcher.WithClient(s.d.HTTPClient(ctx)))
+	jn, err := fetch.Fetch(jsonnetURL)
+	if err != nil {
+		return "", err
+	}
+
+	var jsonClaims bytes.Buffer
+	if err := json.NewEncoder(&jsonClaims).Encode(claims); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	vm, err := s.d.JsonnetVM(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	vm.ExtCode("claims", jsonClaims.String())
+	return vm.EvaluateAnonymousSnippet(jsonnetURL, jn.String())
+}
+
+// syncIdentity runs the Jsonnet mapper again when the identity signs in and merges its output into the identity's
+// traits and metadata according to the provider's sync policy. If the merged identity does not validate against the
+// identity schema or conflicts with another identity, it is left as is and the login continues.
+func (s *Strategy) syncIdentity(r *http.Request, i *identity.Identity, claims *Claims, provider Provider) (*identity.Identity, error) {
+	policy := provider.Config().SyncIdentityOnLogin
+	if policy != IdentitySyncFillEmpty && policy != IdentitySyncOverwrite {
+		return i, nil
+	}
+
+	evaluated, err := s.evaluateJsonnet(r.Context(), provider.Config().Mapper, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonTraits := gjson.Get(evaluated, "identity.traits")
+	if !jsonTraits.IsObject() {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("OpenID Connect Jsonnet mapper did not return an object for key identity.traits. Please check your Jsonnet code!"))
+	}
+
+	synced, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), i.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	traits, changed, err := mergeOnLogin(json.RawMessage(synced.Traits), json.RawMessage(jsonTraits.Raw), policy)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	synced.Traits = identity.Traits(traits)
+
+	for _, m := range []MetadataType{PublicMetadata, AdminMetadata} {
+		metadata := gjson.Get(evaluated, string(m))
+		if !metadata.Exists() {
+			continue
+		} else if !metadata.IsObject() {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("OpenID Connect Jsonnet mapper did not return an object for key %s. Please check your Jsonnet code!", m))
+		}
+
+		target := &synced.MetadataPublic
+		if m == AdminMetadata {
+			target = &synced.MetadataAdmin
+		}
+
+		merged, metadataChanged, err := mergeOnLogin(json.RawMessage(*target), json.RawMessage(metadata.Raw), policy)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		*target = sqlxx.NullJSONRawMessage(merged)
+		changed = changed || metadataChanged
+	}
+
+	if !changed {
+		return i, nil
+	}
+
+	if err := s.d.IdentityValidator().Validate(r.Context(), synced); err != nil {
+		s.d.Logger().
+			WithRequest(r).
+			WithError(err).
+			WithField("oidc_provider", provider.Config().ID).
+			WithSensitiveField("mapper_jsonnet_output", evaluated).
+			Warn("The identity was not updated from the OpenID Connect claims because the result is invalid.")
+		return i, nil
+	}
+
+	if err := s.d.IdentityManager().Update(r.Context(), synced, identity.ManagerAllowWriteProtectedTraits); errors.Is(err, sqlcon.ErrUniqueViolation) {
+		s.d.Logger().
+			WithRequest(r).
+			WithError(err).
+			WithField("oidc_provider", provider.Config().ID).
+			WithSensitiveField("mapper_jsonnet_output", evaluated).
+			Warn("The identity was not updated from the OpenID Connect claims because the result conflicts with another identity.")
+		return i, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	s.d.Logger().
+		WithRequest(r).
+		WithField("oidc_provider", provider.Config().ID).
+		WithField("identity_id", synced.ID).
+		Debug("Updated the identity from the OpenID Connect claims.")
+	return synced.CopyWithoutCredentials(), nil
+}
@@ -68,21 +68,18 @@ func TestStrategy(t *testing.T) {
 	routerP := x.NewRouterPublic()
 	routerA := x.NewRouterAdmin()
 	ts, _ := testhelpers.NewKratosServerWithRouters(t, reg, routerP, routerA)
+	valid := newOIDCProvider(t, ts, remotePublic, remoteAdmin, "valid")
 	invalid := newOIDCProvider(t, ts, remotePublic, remoteAdmin, "invalid-issuer")
-	viperSetProviderConfig(
-		t,
-		conf,
-		newOIDCProvider(t, ts, remotePublic, remoteAdmin, "valid"),
-		oidc.Configuration{
-			Provider:     "generic",
-			ID:           "invalid-issuer",
-			ClientID:     invalid.ClientID,
-			ClientSecret: invalid.ClientSecret,
-			// We replace this URL to cause an issuer validation mismatch.
-			IssuerURL: strings.Replace(remotePublic, "localhost", "127.0.0.1", 1) + "/",
-			Mapper:    "file://./stub/oidc.hydra.jsonnet",
-		},
-	)
+	invalidIssuer := oidc.Configuration{
+		Provider:     "generic",
+		ID:           "invalid-issuer",
+		ClientID:     invalid.ClientID,
+		ClientSecret: invalid.ClientSecret,
+		// We replace this URL to cause an issuer validation mismatch.
+		IssuerURL: strings.Replace(remotePublic, "localhost", "127.0.0.1", 1) + "/",
+		Mapper:    "file://./stub/oidc.hydra.jsonnet",
+	}
+	viperSetProviderConfig(t, conf, valid, invalidIssuer)
 
 	conf.MustSet(ctx, config.ViperKeySelfServiceRegistrationEnabled, true)
 	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/registration.schema.json")
@@ -395,6 +392,61 @@ func TestStrategy(t *testing.T) {
 		})
 	})
 
+	t.Run("case=register and then login syncs the identity", func(t *testing.T) {
+		subject = "register-then-sync@ory.sh"
+		scope = []string{"openid"}
+		claims.traits.website = "https://www.ory.sh/"
+		claims.metadataPublic.picture = "picture-a.png"
+
+		synced := valid
+		synced.SyncIdentityOnLogin = oidc.IdentitySyncOverwrite
+		viperSetProviderConfig(t, conf, synced, invalidIssuer)
+		t.Cleanup(func() {
+			claims = idTokenClaims{}
+			viperSetProviderConfig(t, conf, valid, invalidIssuer)
+		})
+
+		var identityID uuid.UUID
+		expectStored := func(t *testing.T, website, picture string) {
+			i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), identityID)
+			require.NoError(t, err)
+			assert.Equal(t, website, gjson.GetBytes(i.Traits, "website").String(), "%s", i.Traits)
+			assert.Equal(t, picture, gjson.GetBytes(i.MetadataPublic, "picture").String(), "%s", i.MetadataPublic)
+		}
+
+		t.Run("case=should pass registration", func(t *testing.T) {
+			r := newRegistrationFlow(t, returnTS.URL, time.Minute)
+			action := afv(t, r.ID, "valid")
+			res, body := makeRequest(t, "valid", action, url.Values{})
+			ai(t, res, body)
+			identityID = uuid.FromStringOrNil(gjson.GetBytes(body, "identity.id").String())
+		})
+
+		t.Run("case=should update traits and metadata on login", func(t *testing.T) {
+			claims.traits.website = "https://www.ory.sh/kratos"
+			claims.metadataPublic.picture = "picture-b.png"
+
+			r := newLoginFlow(t, returnTS.URL, time.Minute)
+			action := afv(t, r.ID, "valid")
+			res, body := makeRequest(t, "valid", action, url.Values{})
+			ai(t, res, body)
+			expectStored(t, "https://www.ory.sh/kratos", "picture-b.png")
+		})
+
+		t.Run("case=should leave the identity unchanged if the result is invalid", func(t *testing.T) {
+			claims.traits.website = "not a uri"
+			claims.metadataPublic.picture = "picture-c.png"
+
+			r := newLoginFlow(t, returnTS.URL, time.Minute)
+			action := afv(t, r.ID, "valid")
+			res, body := makeRequest(t, "valid", action, url.Values{})
+			assert.Contains(t, res.Request.URL.String(), returnTS.URL)
+			assert.Equal(t, "https://www.ory.sh/kratos", gjson.GetBytes(body, "identity.traits.website").String(), "%s", body)
+			assert.Equal(t, "picture-b.png", gjson.GetBytes(body, "identity.metadata_public.picture").String(), "%s", body)
+			expectStored(t, "https://www.ory.sh/kratos", "picture-b.png")
+		})
+	})
+
 	t.Run("case=login without registered account", func(t *testing.T) {
 		subject = "login-without-register@ory.sh"
 		scope = []string{"openid"}
@@ -0,0 +1,251 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+)
+
+// encryptTokens encrypts the tokens issued by the provider for storing them in the credentials.
+func (s *Strategy) encryptTokens(ctx context.Context, token *oauth2.Token) (_ *identity.CredentialsOIDCEncryptedTokens, err error) {
+	tokens := &identity.CredentialsOIDCEncryptedTokens{ExpiresAt: token.Expiry}
+
+	if idToken, ok := token.Extra("id_token").(string); ok {
+		if tokens.IDToken, err = s.d.Cipher(ctx).Encrypt(ctx, []byte(idToken)); err != nil {
+			return nil, err
+		}
+	}
+
+	if tokens.AccessToken, err = s.d.Cipher(ctx).Encrypt(ctx, []byte(token.AccessToken)); err != nil {
+		return nil, err
+	}
+
+	if tokens.RefreshToken, err = s.d.Cipher(ctx).Encrypt(ctx, []byte(token.RefreshToken)); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// withTokens returns the config of the credentials with the latest tokens of the linked provider replaced.
+func (s *Strategy) withTokens(creds *identity.Credentials, provider, subject string, tokens *identity.CredentialsOIDCEncryptedTokens) (sqlxx.JSONRawMessage, error) {
+	var conf identity.CredentialsOIDC
+	if err := json.Unmarshal(creds.Config, &conf); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var found bool
+	for k := range conf.Providers {
+		if conf.Providers[k].Provider == provider && conf.Providers[k].Subject == subject {
+			conf.Providers[k].SetTokens(tokens)
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReasonf(`The identity has not linked the OpenID Connect provider "%s".`, provider))
+	}
+
+	config, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return config, nil
+}
+
+// setTokens replaces the latest tokens of the linked provider and stores the identity. The identity must have
+// been loaded including its credentials.
+func (s *Strategy) setTokens(ctx context.Context, i *identity.Identity, provider, subject string, tokens *identity.CredentialsOIDCEncryptedTokens) error {
+	creds, ok := i.GetCredentials(s.ID())
+	if !ok {
+		return errors.WithStack(herodot.ErrNotFound.WithReasonf(`The identity has not linked the OpenID Connect provider "%s".`, provider))
+	}
+
+	config, err := s.withTokens(creds, provider, subject, tokens)
+	if err != nil {
+		return err
+	}
+
+	creds.Config = config
+	i.SetCredentials(s.ID(), *creds)
+	return s.d.PrivilegedIdentityPool().UpdateIdentity(ctx, i)
+}
+
+// storeTokens updates the tokens of the linked provider after the identity signed in with it.
+func (s *Strategy) storeTokens(ctx context.Context, identityID uuid.UUID, provider, subject string, token *oauth2.Token) error {
+	tokens, err := s.encryptTokens(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, identityID)
+	if err != nil {
+		return err
+	}
+
+	return s.setTokens(ctx, i, provider, subject, tokens)
+}
+
+// storedUpstreamTokens holds the decrypted tokens of a linked provider as they are stored in the credentials.
+type storedUpstreamTokens struct {
+	creds   *identity.Credentials
+	linked  identity.CredentialsOIDCProvider
+	token   *oauth2.Token
+	idToken string
+}
+
+func (t *storedUpstreamTokens) result() *identity.UpstreamTokens {
+	result := &identity.UpstreamTokens{
+		Provider:    t.linked.Provider,
+		Subject:     t.linked.Subject,
+		AccessToken: t.token.AccessToken,
+		IDToken:     t.idToken,
+	}
+	if !t.token.Expiry.IsZero() {
+		expiresAt := t.token.Expiry.UTC()
+		result.ExpiresAt = &expiresAt
+	}
+	return result
+}
+
+// loadUpstreamTokens loads and decrypts the latest tokens the provider issued to the identity.
+func (s *Strategy) loadUpstreamTokens(ctx context.Context, identityID uuid.UUID, pid string) (*storedUpstreamTokens, error) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, identityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var conf identity.CredentialsOIDC
+	creds, err := i.ParseCredentials(s.ID(), &conf)
+	if errors.Is(err, herodot.ErrNotFound) {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReasonf(`The identity has not linked the OpenID Connect provider "%s".`, pid))
+	} else if err != nil {
+		return nil, err
+	}
+
+	var linked *identity.CredentialsOIDCProvider
+	for k := range conf.Providers {
+		if conf.Providers[k].Provider == pid {
+			linked = &conf.Providers[k]
+			break
+		}
+	}
+
+	if linked == nil {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReasonf(`The identity has not linked the OpenID Connect provider "%s".`, pid))
+	}
+
+	encrypted := linked.LatestTokens()
+	var plaintext [3][]byte
+	for k, ciphertext := range []string{encrypted.IDToken, encrypted.AccessToken, encrypted.RefreshToken} {
+		if plaintext[k], err = s.d.Cipher(ctx).Decrypt(ctx, ciphertext); err != nil {
+			return nil, err
+		}
+	}
+
+	return &storedUpstreamTokens{
+		creds:   creds,
+		linked:  *linked,
+		idToken: string(plaintext[0]),
+		token: &oauth2.Token{
+			AccessToken:  string(plaintext[1]),
+			RefreshToken: string(plaintext[2]),
+			Expiry:       encrypted.ExpiresAt,
+		},
+	}, nil
+}
+
+// UpstreamTokens returns a currently valid access token issued to the identity by the provider. If the stored
+// access token expired, it is refreshed using the refresh token and the new tokens are stored.
+func (s *Strategy) UpstreamTokens(ctx context.Context, identityID uuid.UUID, pid string) (*identity.UpstreamTokens, error) {
+	if !s.d.Config().SelfServiceStrategy(ctx, string(s.ID())).Enabled {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReason("The OpenID Connect strategy is disabled."))
+	}
+
+	stored, err := s.loadUpstreamTokens(ctx, identityID, pid)
+	if err != nil {
+		return nil, err
+	} else if stored.token.Valid() {
+		return stored.result(), nil
+	}
+
+	// Concurrent requests share one refresh, as providers may rotate the refresh token and reject it once used.
+	result, err, _ := s.tokenRefreshes.Do(identityID.String()+"/"+pid, func() (interface{}, error) {
+		return s.refreshUpstreamTokens(ctx, identityID, pid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*identity.UpstreamTokens), nil
+}
+
+// refreshUpstreamTokens refreshes the expired access token and stores the new tokens. The tokens are only stored if
+// they were not changed in the meantime, for example by another instance refreshing them concurrently.
+func (s *Strategy) refreshUpstreamTokens(ctx context.Context, identityID uuid.UUID, pid string) (*identity.UpstreamTokens, error) {
+	stored, err := s.loadUpstreamTokens(ctx, identityID, pid)
+	if err != nil {
+		return nil, err
+	} else if stored.token.Valid() {
+		return stored.result(), nil
+	} else if stored.token.RefreshToken == "" {
+		return nil, errors.WithStack(herodot.ErrConflict.WithReasonf(`The access token issued by the OpenID Connect provider "%s" expired and can not be refreshed because the provider did not issue a refresh token. The user needs to sign in with the provider again.`, pid))
+	}
+
+	provider, err := s.provider(ctx, nil, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := provider.OAuth2(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := c.TokenSource(context.WithValue(ctx, oauth2.HTTPClient, s.d.HTTPClient(ctx).HTTPClient), stored.token).Token()
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrConflict.WithReasonf(`Unable to refresh the access token issued by the OpenID Connect provider "%s". The user needs to sign in with the provider again.`, pid).WithDebug(err.Error()))
+	}
+
+	tokens, err := s.encryptTokens(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := s.withTokens(stored.creds, stored.linked.Provider, stored.linked.Subject, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.d.PrivilegedIdentityPool().CompareAndSwapCredentialsConfig(ctx, identityID, s.ID(), stored.creds.Config, config); errors.Is(err, sqlcon.ErrNoRows) {
+		// Another request stored new tokens in the meantime, which are returned if they are still valid.
+		current, err := s.loadUpstreamTokens(ctx, identityID, pid)
+		if err != nil {
+			return nil, err
+		} else if !current.token.Valid() {
+			return nil, errors.WithStack(herodot.ErrConflict.WithReasonf(`The tokens issued by the OpenID Connect provider "%s" were changed concurrently. Please try again.`, pid))
+		}
+		return current.result(), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	stored.token = token
+	if refreshed, ok := token.Extra("id_token").(string); ok {
+		stored.idToken = refreshed
+	}
+
+	return stored.result(), nil
+}
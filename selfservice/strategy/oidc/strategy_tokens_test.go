@@ -0,0 +1,209 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/x"
+)
+
+func TestUpstreamTokens(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	var refreshed int32
+	var upstream *httptest.Server
+	upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                 upstream.URL,
+				"authorization_endpoint": upstream.URL + "/auth",
+				"token_endpoint":         upstream.URL + "/token",
+				"jwks_uri":               upstream.URL + "/jwks",
+			})
+		case "/token":
+			require.NoError(t, r.ParseForm())
+			if r.PostForm.Get("refresh_token") == "slow-refresh-token" {
+				time.Sleep(100 * time.Millisecond)
+			} else if r.PostForm.Get("refresh_token") != "valid-refresh-token" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"error":"invalid_grant"}`)
+				return
+			}
+
+			atomic.AddInt32(&refreshed, 1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"access_token":"refreshed-access-token","token_type":"bearer","expires_in":3600}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/registration.schema.json")
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".enabled", true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC)+".config", &oidc.ConfigurationCollection{Providers: []oidc.Configuration{
+		{ID: "generic", Provider: "generic", ClientID: "client", ClientSecret: "secret", IssuerURL: upstream.URL, Mapper: "file://./stub/oidc.hydra.jsonnet"},
+	}})
+
+	_, adminTS := testhelpers.NewKratosServer(t, reg)
+
+	encrypt := func(t *testing.T, plaintext string) string {
+		ciphertext, err := reg.Cipher(ctx).Encrypt(ctx, []byte(plaintext))
+		require.NoError(t, err)
+		return ciphertext
+	}
+
+	createIdentity := func(t *testing.T, tokens *identity.CredentialsOIDCEncryptedTokens) *identity.Identity {
+		subject := x.NewUUID().String()
+		i := identity.NewIdentity("")
+		i.Traits = identity.Traits(`{"subject":"` + subject + `@ory.sh"}`)
+		c, err := identity.NewCredentialsOIDC(tokens, "generic", subject)
+		require.NoError(t, err)
+		i.SetCredentials(identity.CredentialsTypeOIDC, *c)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	getTokens := func(t *testing.T, i *identity.Identity, ct identity.CredentialsType, provider string, expectedStatus int) string {
+		res, err := adminTS.Client().Get(fmt.Sprintf("%s/admin/identities/%s/credentials/%s/tokens/%s", adminTS.URL, i.ID, ct, provider))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectedStatus, res.StatusCode, "%s", body)
+		return string(body)
+	}
+
+	t.Run("case=returns a valid access token without refreshing it", func(t *testing.T) {
+		before := atomic.LoadInt32(&refreshed)
+		i := createIdentity(t, &identity.CredentialsOIDCEncryptedTokens{
+			IDToken:      encrypt(t, "id-token"),
+			AccessToken:  encrypt(t, "access-token"),
+			RefreshToken: encrypt(t, "valid-refresh-token"),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		})
+
+		body := getTokens(t, i, identity.CredentialsTypeOIDC, "generic", http.StatusOK)
+		assert.Equal(t, "access-token", gjson.Get(body, "access_token").String(), "%s", body)
+		assert.Equal(t, "id-token", gjson.Get(body, "id_token").String(), "%s", body)
+		assert.Equal(t, "generic", gjson.Get(body, "provider").String(), "%s", body)
+		assert.True(t, gjson.Get(body, "expires_at").Exists(), "%s", body)
+		assert.False(t, gjson.Get(body, "refresh_token").Exists(), "%s", body)
+		assert.Equal(t, before, atomic.LoadInt32(&refreshed))
+	})
+
+	t.Run("case=refreshes and stores an expired access token", func(t *testing.T) {
+		before := atomic.LoadInt32(&refreshed)
+		i := createIdentity(t, &identity.CredentialsOIDCEncryptedTokens{
+			IDToken:      encrypt(t, "id-token"),
+			AccessToken:  encrypt(t, "expired-access-token"),
+			RefreshToken: encrypt(t, "valid-refresh-token"),
+			ExpiresAt:    time.Now().Add(-time.Minute),
+		})
+
+		body := getTokens(t, i, identity.CredentialsTypeOIDC, "generic", http.StatusOK)
+		assert.Equal(t, "refreshed-access-token", gjson.Get(body, "access_token").String(), "%s", body)
+		assert.Equal(t, "id-token", gjson.Get(body, "id_token").String(), "%s", body)
+		assert.Equal(t, before+1, atomic.LoadInt32(&refreshed))
+
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+		require.NoError(t, err)
+		var creds identity.CredentialsOIDC
+		_, err = actual.ParseCredentials(identity.CredentialsTypeOIDC, &creds)
+		require.NoError(t, err)
+		require.Len(t, creds.Providers, 1)
+		require.NotNil(t, creds.Providers[0].TokenExpiresAt)
+		assert.True(t, creds.Providers[0].TokenExpiresAt.After(time.Now()))
+
+		accessToken, err := reg.Cipher(ctx).Decrypt(ctx, creds.Providers[0].AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "refreshed-access-token", string(accessToken))
+		refreshToken, err := reg.Cipher(ctx).Decrypt(ctx, creds.Providers[0].RefreshToken)
+		require.NoError(t, err)
+		assert.Equal(t, "valid-refresh-token", string(refreshToken), "the previous refresh token is kept if no new one is issued")
+		initialAccessToken, err := reg.Cipher(ctx).Decrypt(ctx, creds.Providers[0].InitialAccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "expired-access-token", string(initialAccessToken))
+
+		body = getTokens(t, i, identity.CredentialsTypeOIDC, "generic", http.StatusOK)
+		assert.Equal(t, "refreshed-access-token", gjson.Get(body, "access_token").String(), "%s", body)
+		assert.Equal(t, before+1, atomic.LoadInt32(&refreshed), "the refreshed token is reused")
+	})
+
+	t.Run("case=refreshes an expired access token only once for concurrent requests", func(t *testing.T) {
+		before := atomic.LoadInt32(&refreshed)
+		i := createIdentity(t, &identity.CredentialsOIDCEncryptedTokens{
+			AccessToken:  encrypt(t, "expired-access-token"),
+			RefreshToken: encrypt(t, "slow-refresh-token"),
+			ExpiresAt:    time.Now().Add(-time.Minute),
+		})
+
+		const concurrency = 5
+		var wg sync.WaitGroup
+		tokens := make([]string, concurrency)
+		for k := range tokens {
+			wg.Add(1)
+			go func(k int) {
+				defer wg.Done()
+				res, err := adminTS.Client().Get(fmt.Sprintf("%s/admin/identities/%s/credentials/oidc/tokens/generic", adminTS.URL, i.ID))
+				if err != nil {
+					return
+				}
+				defer res.Body.Close()
+				body, _ := io.ReadAll(res.Body)
+				tokens[k] = gjson.GetBytes(body, "access_token").String()
+			}(k)
+		}
+		wg.Wait()
+
+		for _, token := range tokens {
+			assert.Equal(t, "refreshed-access-token", token)
+		}
+		assert.Equal(t, before+1, atomic.LoadInt32(&refreshed))
+	})
+
+	t.Run("case=fails if the expired access token can not be refreshed", func(t *testing.T) {
+		for _, refreshToken := range []string{"", "revoked-refresh-token"} {
+			i := createIdentity(t, &identity.CredentialsOIDCEncryptedTokens{
+				AccessToken:  encrypt(t, "expired-access-token"),
+				RefreshToken: encrypt(t, refreshToken),
+				ExpiresAt:    time.Now().Add(-time.Minute),
+			})
+
+			body := getTokens(t, i, identity.CredentialsTypeOIDC, "generic", http.StatusConflict)
+			assert.Contains(t, gjson.Get(body, "error.reason").String(), "sign in with the provider again", "%s", body)
+		}
+	})
+
+	t.Run("case=fails for providers which are not linked", func(t *testing.T) {
+		i := createIdentity(t, &identity.CredentialsOIDCEncryptedTokens{AccessToken: encrypt(t, "access-token")})
+		getTokens(t, i, identity.CredentialsTypeOIDC, "google", http.StatusNotFound)
+	})
+
+	t.Run("case=fails for other credential types", func(t *testing.T) {
+		i := createIdentity(t, &identity.CredentialsOIDCEncryptedTokens{AccessToken: encrypt(t, "access-token")})
+		getTokens(t, i, identity.CredentialsTypePassword, "generic", http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/x"
+)
+
+func TestPersister(ctx context.Context, conf *config.Config, p interface {
+	persistence.Persister
+}) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, p := testhelpers.NewNetworkUnlessExisting(t, ctx, p)
+		testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+
+		t.Run("case=providers", func(t *testing.T) {
+			beta := &oidc.StoredProvider{ProviderID: "beta", Config: []byte(`{"id":"beta","provider":"generic"}`)}
+			alpha := &oidc.StoredProvider{ProviderID: "alpha", Config: []byte(`{"id":"alpha","provider":"github"}`)}
+			require.NoError(t, p.CreateOIDCProvider(ctx, beta))
+			require.NoError(t, p.CreateOIDCProvider(ctx, alpha))
+
+			t.Run("case=create conflicts with an existing provider", func(t *testing.T) {
+				err := p.CreateOIDCProvider(ctx, &oidc.StoredProvider{ProviderID: "beta", Config: []byte(`{}`)})
+				assert.ErrorIs(t, err, sqlcon.ErrUniqueViolation)
+			})
+
+			t.Run("case=get", func(t *testing.T) {
+				actual, err := p.GetOIDCProvider(ctx, "beta")
+				require.NoError(t, err)
+				assert.Equal(t, beta.ID, actual.ID)
+				assert.JSONEq(t, `{"id":"beta","provider":"generic"}`, string(actual.Config))
+
+				_, err = p.GetOIDCProvider(ctx, "does-not-exist")
+				assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+
+			t.Run("case=list is ordered by id", func(t *testing.T) {
+				actual, err := p.ListOIDCProviders(ctx)
+				require.NoError(t, err)
+				require.Len(t, actual, 2)
+				assert.Equal(t, "alpha", actual[0].ProviderID)
+				assert.Equal(t, "beta", actual[1].ProviderID)
+			})
+
+			t.Run("case=update", func(t *testing.T) {
+				beta.Config = []byte(`{"id":"beta","provider":"gitlab"}`)
+				require.NoError(t, p.UpdateOIDCProvider(ctx, beta))
+
+				actual, err := p.GetOIDCProvider(ctx, "beta")
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"id":"beta","provider":"gitlab"}`, string(actual.Config))
+			})
+
+			t.Run("case=on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				_, err := other.GetOIDCProvider(ctx, "beta")
+				assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				actual, err := other.ListOIDCProviders(ctx)
+				require.NoError(t, err)
+				assert.Len(t, actual, 0)
+
+				assert.ErrorIs(t, other.UpdateOIDCProvider(ctx, beta), sqlcon.ErrNoRows)
+				assert.ErrorIs(t, other.DeleteOIDCProvider(ctx, "beta"), sqlcon.ErrNoRows)
+				require.NoError(t, other.CreateOIDCProvider(ctx, &oidc.StoredProvider{ProviderID: "beta", Config: []byte(`{}`)}))
+			})
+
+			t.Run("case=delete", func(t *testing.T) {
+				require.NoError(t, p.DeleteOIDCProvider(ctx, "beta"))
+				_, err := p.GetOIDCProvider(ctx, "beta")
+				assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+				assert.ErrorIs(t, p.DeleteOIDCProvider(ctx, "beta"), sqlcon.ErrNoRows)
+			})
+		})
+
+		t.Run("case=count provider links", func(t *testing.T) {
+			link := func(t *testing.T, provider, subject string) {
+				i := identity.NewIdentity("")
+				c, err := identity.NewCredentialsOIDC(&identity.CredentialsOIDCEncryptedTokens{}, provider, subject)
+				require.NoError(t, err)
+				i.SetCredentials(identity.CredentialsTypeOIDC, *c)
+				require.NoError(t, p.CreateIdentity(ctx, i))
+			}
+
+			link(t, "link_a", x.NewUUID().String())
+			link(t, "link_a", x.NewUUID().String())
+			link(t, "linkxa", x.NewUUID().String())
+
+			count, err := p.CountOIDCProviderLinks(ctx, "link_a")
+			require.NoError(t, err)
+			assert.Equal(t, 2, count, "LIKE wildcards in the provider ID must be escaped")
+
+			count, err = p.CountOIDCProviderLinks(ctx, "link")
+			require.NoError(t, err)
+			assert.Equal(t, 0, count)
+
+			_, other := testhelpers.NewNetwork(t, ctx, p)
+			count, err = other.CountOIDCProviderLinks(ctx, "link_a")
+			require.NoError(t, err)
+			assert.Equal(t, 0, count)
+		})
+
+		t.Run("case=use logout token", func(t *testing.T) {
+			jti := x.NewUUID().String()
+			require.NoError(t, p.UseOIDCLogoutToken(ctx, "generic", jti, time.Now().Add(time.Hour)))
+			assert.ErrorIs(t, p.UseOIDCLogoutToken(ctx, "generic", jti, time.Now().Add(time.Hour)), sqlcon.ErrUniqueViolation)
+
+			t.Run("case=other provider", func(t *testing.T) {
+				require.NoError(t, p.UseOIDCLogoutToken(ctx, "other", jti, time.Now().Add(time.Hour)))
+			})
+
+			t.Run("case=other network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				require.NoError(t, other.UseOIDCLogoutToken(ctx, "generic", jti, time.Now().Add(time.Hour)))
+			})
+
+			t.Run("case=expired tokens are removed", func(t *testing.T) {
+				expired := x.NewUUID().String()
+				require.NoError(t, p.UseOIDCLogoutToken(ctx, "generic", expired, time.Now().Add(-time.Minute)))
+				require.NoError(t, p.UseOIDCLogoutToken(ctx, "generic", expired, time.Now().Add(time.Hour)))
+			})
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package otp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/randx"
+
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	internalContextKeyPending = "pending"
+
+	codeLength  = 6
+	maxAttempts = 5
+)
+
+// pendingCode is stored in the internal context of the login flow while a code is awaiting its entry. The
+// attempts are counted by the CodeCounter of the address.
+type pendingCode struct {
+	Via       identity.VerifiableAddressType `json:"via"`
+	Address   string                         `json:"address"`
+	CodeID    uuid.UUID                      `json:"code_id"`
+	CodeHMAC  string                         `json:"code_hmac"`
+	SentAt    time.Time                      `json:"sent_at"`
+	ExpiresAt time.Time                      `json:"expires_at"`
+}
+
+// eligibleAddresses returns the verified addresses of the identity which are able to receive codes.
+// Phone numbers are only eligible if the courier is able to send SMS.
+func (s *Strategy) eligibleAddresses(ctx context.Context, i *identity.Identity) []identity.VerifiableAddress {
+	var addresses []identity.VerifiableAddress
+	for _, a := range i.VerifiableAddresses {
+		if !a.Verified {
+			continue
+		}
+
+		switch a.Via {
+		case identity.VerifiableAddressTypeEmail:
+		case identity.VerifiableAddressTypePhone:
+			if !s.d.Config().CourierSMSEnabled(ctx) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		addresses = append(addresses, a)
+	}
+	return addresses
+}
+
+// enrolledAddresses returns the eligible addresses of the identity to which codes are sent.
+func (s *Strategy) enrolledAddresses(ctx context.Context, i *identity.Identity) ([]identity.VerifiableAddress, error) {
+	conf, err := s.credentialsConfig(i)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []identity.VerifiableAddress
+	for _, a := range s.eligibleAddresses(ctx, i) {
+		if conf.HasAddress(a.Value) {
+			addresses = append(addresses, a)
+		}
+	}
+	return addresses, nil
+}
+
+func (s *Strategy) credentialsConfig(i *identity.Identity) (*identity.CredentialsOTPConfig, error) {
+	var conf identity.CredentialsOTPConfig
+	c, ok := i.GetCredentials(s.ID())
+	if !ok || len(c.Config) == 0 {
+		return &conf, nil
+	}
+
+	if err := json.Unmarshal(c.Config, &conf); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The one-time code addresses could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+	}
+	return &conf, nil
+}
+
+func hmacCode(code string, secret []byte) string {
+	h := hmac.New(sha512.New512_256, secret)
+	_, _ = h.Write([]byte(code))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (s *Strategy) compareCode(ctx context.Context, code, expected string) bool {
+	for _, secret := range s.d.Config().SecretsSession(ctx) {
+		if subtle.ConstantTimeCompare([]byte(hmacCode(code, secret)), []byte(expected)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// sendCode generates a new code, queues it for delivery to the address, and returns the pending state. It fails
+// with ErrCodeThrottled if the previous code was sent to the address less than the resend interval ago.
+func (s *Strategy) sendCode(ctx context.Context, i *identity.Identity, address identity.VerifiableAddress) (*pendingCode, error) {
+	now := time.Now().UTC()
+	counter := &CodeCounter{IdentityID: i.ID, Address: address.Value, CodeID: x.NewUUID(), SentAt: now}
+	if err := s.d.OTPCodeCounterPersister().ResetOTPCodeCounter(ctx, counter, now.Add(-s.d.Config().OTPResendInterval(ctx))); err != nil {
+		return nil, err
+	}
+
+	code := randx.MustString(codeLength, randx.Numeric)
+
+	model, err := x.StructToMap(i)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.d.Courier(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch address.Via {
+	case identity.VerifiableAddressTypePhone:
+		if _, err := c.QueueSMS(ctx, sms.NewOTPMessage(s.d, &sms.OTPMessageModel{
+			To:       address.Value,
+			Code:     code,
+			Identity: model,
+		})); err != nil {
+			return nil, err
+		}
+	default:
+		if _, err := c.QueueEmail(ctx, email.NewOTPMessage(s.d, &email.OTPMessageModel{
+			To:       address.Value,
+			Code:     code,
+			Identity: model,
+		})); err != nil {
+			return nil, err
+		}
+	}
+
+	return &pendingCode{
+		Via:       address.Via,
+		Address:   address.Value,
+		CodeID:    counter.CodeID,
+		CodeHMAC:  hmacCode(code, s.d.Config().SecretsSession(ctx)[0]),
+		SentAt:    now,
+		ExpiresAt: now.Add(s.d.Config().OTPLifespan(ctx)),
+	}, nil
+}
+
+func (s *Strategy) getPendingCode(internalContext []byte) (*pendingCode, error) {
+	raw := gjson.GetBytes(internalContext, flow.PrefixInternalContextKey(s.ID(), internalContextKeyPending))
+	if !raw.IsObject() {
+		return nil, nil
+	}
+
+	var p pendingCode
+	if err := json.Unmarshal([]byte(raw.Raw), &p); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The pending one-time code could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+	}
+	return &p, nil
+}
+
+func (s *Strategy) setPendingCode(internalContext []byte, p *pendingCode) ([]byte, error) {
+	if p == nil {
+		return sjson.DeleteBytes(internalContext, flow.PrefixInternalContextKey(s.ID(), internalContextKeyPending))
+	}
+	return sjson.SetBytes(internalContext, flow.PrefixInternalContextKey(s.ID(), internalContextKeyPending), p)
+}
@@ -0,0 +1,259 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package otp
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+)
+
+func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
+}
+
+func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.AuthenticatorAssuranceLevel, sr *login.Flow) error {
+	// This strategy can only solve AAL2
+	if requestedAAL != identity.AuthenticatorAssuranceLevel2 {
+		return nil
+	}
+
+	// We have done proper validation before so this should never error
+	sess, err := s.d.SessionManager().FetchFromRequest(r.Context(), r)
+	if err != nil {
+		return err
+	}
+
+	id, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), sess.IdentityID)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := s.enrolledAddresses(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	if len(addresses) == 0 {
+		// Identity has no addresses set up for one-time codes
+		return nil
+	}
+
+	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	for _, a := range addresses {
+		sr.UI.GetNodes().Append(NewSendNode(a.Value))
+	}
+
+	return nil
+}
+
+func (s *Strategy) handleLoginError(r *http.Request, f *login.Flow, err error) error {
+	if f != nil {
+		f.UI.Nodes.ResetNodes(node.OTPCode)
+		if f.Type == flow.TypeBrowser {
+			f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		}
+	}
+
+	return err
+}
+
+// Update Login Flow with One-Time Code Method
+//
+// swagger:model updateLoginFlowWithOtpMethod
+type updateLoginFlowWithOtpMethod struct {
+	// Method should be set to "otp" when logging in using the one-time code strategy.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `json:"csrf_token"`
+
+	// Send
+	//
+	// If set, a one-time code is sent to this verified address. Submitting the address
+	// again sends a new code once the resend interval has passed.
+	Send string `json:"otp_send"`
+
+	// Code
+	//
+	// The one-time code which was sent to the address. Required unless `otp_send` is set.
+	Code string `json:"otp_code"`
+}
+
+func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow, identityID uuid.UUID) (i *identity.Identity, err error) {
+	if err := login.CheckAAL(f, identity.AuthenticatorAssuranceLevel2); err != nil {
+		return nil, err
+	}
+
+	var p updateLoginFlowWithOtpMethod
+	if err := s.hd.Decode(r, &p,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.MustHTTPRawJSONSchemaCompiler(loginSchema),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	if len(p.Send) > 0 || p.Method == s.ID().String() {
+		// Sending a code is triggered by the address buttons which do not carry the method
+		p.Method = s.ID().String()
+	} else {
+		return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.ID().String(), p.Method, s.d); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, f.Type, s.d.Config().DisableAPIFlowEnforcement(r.Context()), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	i, err = s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), identityID)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	addresses, err := s.enrolledAddresses(r.Context(), i)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	} else if len(addresses) == 0 {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewNoOTPAddressError()))
+	}
+
+	if len(p.Send) > 0 {
+		return nil, s.loginSendCode(w, r, f, i, addresses, p.Send)
+	}
+
+	return s.loginVerifyCode(r, f, i, addresses, p.Code)
+}
+
+func (s *Strategy) loginSendCode(w http.ResponseWriter, r *http.Request, f *login.Flow, i *identity.Identity, addresses []identity.VerifiableAddress, send string) error {
+	var address *identity.VerifiableAddress
+	for k := range addresses {
+		if addresses[k].Value == send {
+			address = &addresses[k]
+			break
+		}
+	}
+
+	if address == nil {
+		return s.handleLoginError(r, f, errors.WithStack(schema.NewNoOTPAddressError()))
+	}
+
+	f.EnsureInternalContext()
+	pending, err := s.sendCode(r.Context(), i, *address)
+	if errors.Is(err, ErrCodeThrottled) {
+		wait := s.d.Config().OTPResendInterval(r.Context())
+		if counter, err := s.d.OTPCodeCounterPersister().GetOTPCodeCounter(r.Context(), i.ID, address.Value); err == nil {
+			wait = time.Until(counter.SentAt.Add(wait))
+		}
+		return s.handleLoginError(r, f, errors.WithStack(schema.NewOTPResendThrottledError(int(math.Ceil(wait.Seconds())))))
+	} else if err != nil {
+		return s.handleLoginError(r, f, err)
+	}
+
+	f.InternalContext, err = s.setPendingCode(f.InternalContext, pending)
+	if err != nil {
+		return s.handleLoginError(r, f, errors.WithStack(err))
+	}
+
+	var nodes node.Nodes
+	for _, n := range f.UI.Nodes {
+		if n.Group != node.OTPGroup {
+			nodes = append(nodes, n)
+		}
+	}
+	f.UI.Nodes = nodes
+
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.ResetMessages()
+	f.UI.Messages.Add(text.NewInfoSelfServiceLoginOTPSent(address.Value))
+	f.UI.Nodes.Append(NewCodeNode())
+	f.UI.Nodes.Append(NewVerifyNode())
+	f.UI.Nodes.Append(NewResendNode(address.Value))
+
+	if err := s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
+		return s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Could not update flow.").WithDebug(err.Error())))
+	}
+
+	if x.IsJSONRequest(r) {
+		s.d.Writer().WriteCode(w, r, http.StatusBadRequest, f)
+	} else {
+		http.Redirect(w, r, f.AppendTo(s.d.Config().SelfServiceFlowLoginUI(r.Context())).String(), http.StatusSeeOther)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) loginVerifyCode(r *http.Request, f *login.Flow, i *identity.Identity, addresses []identity.VerifiableAddress, code string) (*identity.Identity, error) {
+	f.EnsureInternalContext()
+	pending, err := s.getPendingCode(f.InternalContext)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	} else if pending == nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewOTPCodeInvalidError()))
+	}
+
+	var enrolled bool
+	for _, a := range addresses {
+		if a.Value == pending.Address {
+			enrolled = true
+			break
+		}
+	}
+
+	usable := enrolled && time.Now().Before(pending.ExpiresAt)
+	if usable {
+		// The attempts are counted per address so that they are not reset by starting a new flow.
+		attempts, err := s.d.OTPCodeCounterPersister().IncrementOTPCodeAttempts(r.Context(), i.ID, pending.Address, pending.CodeID)
+		if errors.Is(err, sqlcon.ErrNoRows) {
+			// A newer code was sent to the address, possibly in another flow.
+			usable = false
+		} else if err != nil {
+			return nil, s.handleLoginError(r, f, err)
+		} else {
+			usable = attempts <= maxAttempts
+		}
+	}
+
+	valid := usable && s.compareCode(r.Context(), code, pending.CodeHMAC)
+	if valid || !usable {
+		// The code was used or can no longer be used, a new one has to be requested.
+		pending = nil
+	}
+
+	f.InternalContext, err = s.setPendingCode(f.InternalContext, pending)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(err))
+	}
+
+	if !valid {
+		if err := s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
+			return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Could not update flow.").WithDebug(err.Error())))
+		}
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewOTPCodeInvalidError()))
+	}
+
+	f.Active = s.ID()
+	if err = s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Could not update flow.").WithDebug(err.Error())))
+	}
+
+	return i, nil
+}
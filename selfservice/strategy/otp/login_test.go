@@ -0,0 +1,409 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package otp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	kratos "github.com/ory/kratos/internal/httpclient"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/assertx"
+)
+
+func TestCompleteLogin(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword)+".enabled", false)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOTP)+".enabled", true)
+	conf.MustSet(ctx, config.ViperKeyCourierSMTPURL, "smtp://foo@bar@dev.null/")
+
+	router := x.NewRouterPublic()
+	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
+
+	errTS := testhelpers.NewErrorTestServer(t, reg)
+	uiTS := testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	redirTS := testhelpers.NewRedirSessionEchoTS(t, reg)
+
+	// Overwrite these two to make it more explicit when tests fail
+	conf.MustSet(ctx, config.ViperKeySelfServiceErrorUI, errTS.URL+"/error-ts")
+	conf.MustSet(ctx, config.ViperKeySelfServiceLoginUI, uiTS.URL+"/login-ts")
+
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeySecretsDefault, []string{"not-a-secure-session-key"})
+
+	setConfig := func(t *testing.T, key string, value interface{}) {
+		previous := conf.GetProvider(ctx).Get(key)
+		conf.MustSet(ctx, key, value)
+		t.Cleanup(func() {
+			conf.MustSet(ctx, key, previous)
+		})
+	}
+
+	type flowType string
+	const (
+		flowTypeAPI     flowType = "api"
+		flowTypeBrowser flowType = "browser"
+		flowTypeSPA     flowType = "spa"
+	)
+
+	newClient := func(t *testing.T, ft flowType, id *identity.Identity) *http.Client {
+		if ft == flowTypeAPI {
+			return testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+		}
+		return testhelpers.NewHTTPClientWithIdentitySessionCookie(t, reg, id)
+	}
+
+	initFlow := func(t *testing.T, ft flowType, client *http.Client) *kratos.LoginFlow {
+		if ft == flowTypeAPI {
+			return testhelpers.InitializeLoginFlowViaAPI(t, client, publicTS, false, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel2))
+		}
+		return testhelpers.InitializeLoginFlowViaBrowser(t, client, publicTS, false, ft == flowTypeSPA, false, false, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel2))
+	}
+
+	submit := func(t *testing.T, ft flowType, client *http.Client, f *kratos.LoginFlow, v func(url.Values)) (string, *http.Response) {
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		v(values)
+		if ft == flowTypeAPI {
+			return testhelpers.LoginMakeRequest(t, true, false, f, client, testhelpers.EncodeFormAsJSON(t, true, values))
+		}
+		return testhelpers.LoginMakeRequest(t, false, ft == flowTypeSPA, f, client, values.Encode())
+	}
+
+	send := func(address string) func(url.Values) {
+		return func(v url.Values) {
+			v.Set(node.OTPSend, address)
+		}
+	}
+
+	verify := func(code string) func(url.Values) {
+		return func(v url.Values) {
+			v.Del(node.OTPSend)
+			v.Set("method", identity.CredentialsTypeOTP.String())
+			v.Set(node.OTPCode, code)
+		}
+	}
+
+	checkURL := func(t *testing.T, ft flowType, res *http.Response) {
+		if ft == flowTypeBrowser {
+			assert.Contains(t, res.Request.URL.String(), uiTS.URL+"/login-ts")
+		} else {
+			assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+		}
+	}
+
+	sentCode := func(t *testing.T, recipient string, messageType courier.MessageType) string {
+		messages, err := reg.CourierPersister().NextMessages(context.Background(), 10)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, recipient, messages[0].Recipient)
+		assert.Equal(t, messageType, messages[0].Type)
+		assert.Equal(t, courier.TypeOTP, messages[0].TemplateType)
+
+		code := gjson.GetBytes(messages[0].TemplateData, "Code").String()
+		require.Len(t, code, 6)
+		return code
+	}
+
+	expectNoMessages := func(t *testing.T) {
+		_, err := reg.CourierPersister().NextMessages(context.Background(), 10)
+		require.ErrorIs(t, err, courier.ErrQueueEmpty)
+	}
+
+	checkSent := func(t *testing.T, ft flowType, address, body string, res *http.Response) {
+		checkURL(t, ft, res)
+		if ft != flowTypeBrowser {
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+		}
+		assert.Equal(t, text.NewInfoSelfServiceLoginOTPSent(address).Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+		assert.True(t, gjson.Get(body, "ui.nodes.#(attributes.name==otp_code)").Exists(), "%s", body)
+		assert.Equal(t, address, gjson.Get(body, "ui.nodes.#(attributes.name==otp_send).attributes.value").String(), "%s", body)
+		assert.EqualValues(t, text.InfoNodeLabelResendOTP, gjson.Get(body, "ui.nodes.#(attributes.name==otp_send).meta.label.id").Int(), "%s", body)
+	}
+
+	checkSignedIn := func(t *testing.T, ft flowType, body string, res *http.Response) {
+		prefix := "session."
+		if ft == flowTypeBrowser {
+			assert.Contains(t, res.Request.URL.String(), redirTS.URL+"/return-ts")
+			prefix = ""
+		} else {
+			assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+		}
+
+		assert.True(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, gjson.Get(body, prefix+"authenticator_assurance_level").String(), "%s", body)
+		require.Len(t, gjson.Get(body, prefix+"authentication_methods").Array(), 2, "%s", body)
+		assert.EqualValues(t, identity.CredentialsTypePassword, gjson.Get(body, prefix+"authentication_methods.0.method").String(), "%s", body)
+		assert.EqualValues(t, identity.CredentialsTypeOTP, gjson.Get(body, prefix+"authentication_methods.1.method").String(), "%s", body)
+	}
+
+	checkInvalid := func(t *testing.T, ft flowType, body string, res *http.Response, message *text.Message) {
+		checkURL(t, ft, res)
+		assert.NotEmpty(t, gjson.Get(body, "id").String(), "%s", body)
+		assert.Equal(t, message.Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+	}
+
+	t.Run("case=send buttons are shown for enrolled addresses", func(t *testing.T) {
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail, identity.VerifiableAddressTypePhone)
+
+		f := initFlow(t, flowTypeAPI, newClient(t, flowTypeAPI, id))
+		nodes, err := json.Marshal(f.Ui.Nodes)
+		require.NoError(t, err)
+
+		// Phone numbers require the SMS courier.
+		assert.Len(t, gjson.GetBytes(nodes, "#(attributes.name==otp_send)#").Array(), 1, "%s", nodes)
+		assert.Equal(t, id.VerifiableAddresses[0].Value, gjson.GetBytes(nodes, "#(attributes.name==otp_send).attributes.value").String(), "%s", nodes)
+		assert.Equal(t, text.NewInfoSelfServiceLoginOTPSend(id.VerifiableAddresses[0].Value).Text, gjson.GetBytes(nodes, "#(attributes.name==otp_send).meta.label.text").String(), "%s", nodes)
+
+		t.Run("case=with sms", func(t *testing.T) {
+			setConfig(t, config.ViperKeyCourierSMSEnabled, true)
+
+			f := initFlow(t, flowTypeAPI, newClient(t, flowTypeAPI, id))
+			nodes, err := json.Marshal(f.Ui.Nodes)
+			require.NoError(t, err)
+			assert.Len(t, gjson.GetBytes(nodes, "#(attributes.name==otp_send)#").Array(), 2, "%s", nodes)
+		})
+	})
+
+	t.Run("case=send buttons are not shown when identity has no enrolled addresses", func(t *testing.T) {
+		id := createIdentity(t, reg)
+
+		f := initFlow(t, flowTypeAPI, newClient(t, flowTypeAPI, id))
+		assertx.EqualAsJSON(t, nil, f.Ui.Nodes)
+	})
+
+	t.Run("case=should pass with a code sent via email", func(t *testing.T) {
+		for _, ft := range []flowType{flowTypeAPI, flowTypeBrowser, flowTypeSPA} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+				address := id.VerifiableAddresses[0].Value
+				client := newClient(t, ft, id)
+				f := initFlow(t, ft, client)
+
+				body, res := submit(t, ft, client, f, send(address))
+				checkSent(t, ft, address, body, res)
+				code := sentCode(t, address, courier.MessageTypeEmail)
+
+				body, res = submit(t, ft, client, f, verify(code))
+				checkSignedIn(t, ft, body, res)
+			})
+		}
+	})
+
+	t.Run("case=should pass with a code sent via sms", func(t *testing.T) {
+		setConfig(t, config.ViperKeyCourierSMSEnabled, true)
+
+		id := createIdentity(t, reg, identity.VerifiableAddressTypePhone)
+		address := id.VerifiableAddresses[1].Value
+		client := newClient(t, flowTypeAPI, id)
+		f := initFlow(t, flowTypeAPI, client)
+
+		body, res := submit(t, flowTypeAPI, client, f, send(address))
+		checkSent(t, flowTypeAPI, address, body, res)
+		code := sentCode(t, address, courier.MessageTypePhone)
+
+		body, res = submit(t, flowTypeAPI, client, f, verify(code))
+		checkSignedIn(t, flowTypeAPI, body, res)
+	})
+
+	t.Run("case=should fail if the address is not enrolled", func(t *testing.T) {
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		for _, ft := range []flowType{flowTypeAPI, flowTypeBrowser, flowTypeSPA} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				client := newClient(t, ft, id)
+				f := initFlow(t, ft, client)
+
+				body, res := submit(t, ft, client, f, send(id.VerifiableAddresses[1].Value))
+				checkInvalid(t, ft, body, res, text.NewErrorValidationNoOTPAddress())
+				expectNoMessages(t)
+			})
+		}
+	})
+
+	t.Run("case=should fail if the code is missing", func(t *testing.T) {
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		client := newClient(t, flowTypeAPI, id)
+		f := initFlow(t, flowTypeAPI, client)
+
+		body, res := submit(t, flowTypeAPI, client, f, verify(""))
+		checkURL(t, flowTypeAPI, res)
+		assert.Equal(t, "Property otp_code is missing.", gjson.Get(body, "ui.nodes.#(attributes.name==otp_code).messages.0.text").String(), "%s", body)
+	})
+
+	t.Run("case=should fail with an invalid code", func(t *testing.T) {
+		for _, ft := range []flowType{flowTypeAPI, flowTypeBrowser, flowTypeSPA} {
+			t.Run("type="+string(ft), func(t *testing.T) {
+				id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+				address := id.VerifiableAddresses[0].Value
+				client := newClient(t, ft, id)
+				f := initFlow(t, ft, client)
+
+				body, res := submit(t, ft, client, f, verify("123456"))
+				checkInvalid(t, ft, body, res, text.NewErrorValidationOTPCodeInvalid())
+
+				_, _ = submit(t, ft, client, f, send(address))
+				code := sentCode(t, address, courier.MessageTypeEmail)
+
+				body, res = submit(t, ft, client, f, verify(code+"1"))
+				checkInvalid(t, ft, body, res, text.NewErrorValidationOTPCodeInvalid())
+				assert.True(t, gjson.Get(body, "ui.nodes.#(attributes.name==otp_code)").Exists(), "%s", body)
+
+				body, res = submit(t, ft, client, f, verify(code))
+				checkSignedIn(t, ft, body, res)
+			})
+		}
+	})
+
+	t.Run("case=should invalidate the code after too many attempts", func(t *testing.T) {
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		address := id.VerifiableAddresses[0].Value
+		client := newClient(t, flowTypeAPI, id)
+		f := initFlow(t, flowTypeAPI, client)
+
+		_, _ = submit(t, flowTypeAPI, client, f, send(address))
+		code := sentCode(t, address, courier.MessageTypeEmail)
+
+		for k := 0; k < 5; k++ {
+			body, res := submit(t, flowTypeAPI, client, f, verify("invalid"))
+			checkInvalid(t, flowTypeAPI, body, res, text.NewErrorValidationOTPCodeInvalid())
+		}
+
+		body, res := submit(t, flowTypeAPI, client, f, verify(code))
+		checkInvalid(t, flowTypeAPI, body, res, text.NewErrorValidationOTPCodeInvalid())
+	})
+
+	t.Run("case=should not reset the limits when starting a new flow", func(t *testing.T) {
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		address := id.VerifiableAddresses[0].Value
+		client := newClient(t, flowTypeAPI, id)
+		f := initFlow(t, flowTypeAPI, client)
+
+		_, _ = submit(t, flowTypeAPI, client, f, send(address))
+		code := sentCode(t, address, courier.MessageTypeEmail)
+
+		for k := 0; k < 5; k++ {
+			body, res := submit(t, flowTypeAPI, client, f, verify("invalid"))
+			checkInvalid(t, flowTypeAPI, body, res, text.NewErrorValidationOTPCodeInvalid())
+		}
+
+		other := initFlow(t, flowTypeAPI, client)
+		body, res := submit(t, flowTypeAPI, client, other, send(address))
+		checkURL(t, flowTypeAPI, res)
+		assert.EqualValues(t, text.ErrorValidationOTPResendThrottled, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		expectNoMessages(t)
+
+		body, res = submit(t, flowTypeAPI, client, f, verify(code))
+		checkInvalid(t, flowTypeAPI, body, res, text.NewErrorValidationOTPCodeInvalid())
+	})
+
+	t.Run("case=should only accept the latest code across flows", func(t *testing.T) {
+		setConfig(t, config.ViperKeyOTPResendInterval, "0s")
+
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		address := id.VerifiableAddresses[0].Value
+		client := newClient(t, flowTypeAPI, id)
+		f := initFlow(t, flowTypeAPI, client)
+
+		_, _ = submit(t, flowTypeAPI, client, f, send(address))
+		code := sentCode(t, address, courier.MessageTypeEmail)
+
+		other := initFlow(t, flowTypeAPI, client)
+		_, _ = submit(t, flowTypeAPI, client, other, send(address))
+		latest := sentCode(t, address, courier.MessageTypeEmail)
+
+		body, res := submit(t, flowTypeAPI, client, f, verify(code))
+		checkInvalid(t, flowTypeAPI, body, res, text.NewErrorValidationOTPCodeInvalid())
+
+		body, res = submit(t, flowTypeAPI, client, other, verify(latest))
+		checkSignedIn(t, flowTypeAPI, body, res)
+	})
+
+	t.Run("case=should fail with an expired code", func(t *testing.T) {
+		setConfig(t, config.ViperKeyOTPLifespan, "1ns")
+
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		address := id.VerifiableAddresses[0].Value
+		client := newClient(t, flowTypeAPI, id)
+		f := initFlow(t, flowTypeAPI, client)
+
+		_, _ = submit(t, flowTypeAPI, client, f, send(address))
+		code := sentCode(t, address, courier.MessageTypeEmail)
+
+		body, res := submit(t, flowTypeAPI, client, f, verify(code))
+		checkInvalid(t, flowTypeAPI, body, res, text.NewErrorValidationOTPCodeInvalid())
+	})
+
+	t.Run("case=should throttle resending codes", func(t *testing.T) {
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		address := id.VerifiableAddresses[0].Value
+		client := newClient(t, flowTypeAPI, id)
+		f := initFlow(t, flowTypeAPI, client)
+
+		body, res := submit(t, flowTypeAPI, client, f, send(address))
+		checkSent(t, flowTypeAPI, address, body, res)
+		first := sentCode(t, address, courier.MessageTypeEmail)
+
+		body, res = submit(t, flowTypeAPI, client, f, send(address))
+		checkURL(t, flowTypeAPI, res)
+		assert.EqualValues(t, text.ErrorValidationOTPResendThrottled, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		expectNoMessages(t)
+
+		setConfig(t, config.ViperKeyOTPResendInterval, "0s")
+		body, res = submit(t, flowTypeAPI, client, f, send(address))
+		checkSent(t, flowTypeAPI, address, body, res)
+		second := sentCode(t, address, courier.MessageTypeEmail)
+
+		if first != second {
+			body, res = submit(t, flowTypeAPI, client, f, verify(first))
+			checkInvalid(t, flowTypeAPI, body, res, text.NewErrorValidationOTPCodeInvalid())
+		}
+
+		body, res = submit(t, flowTypeAPI, client, f, verify(second))
+		checkSignedIn(t, flowTypeAPI, body, res)
+	})
+
+	t.Run("case=should fail if CSRF token is invalid", func(t *testing.T) {
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		address := id.VerifiableAddresses[0].Value
+
+		t.Run("type=browser", func(t *testing.T) {
+			client := newClient(t, flowTypeBrowser, id)
+			body, res := submit(t, flowTypeBrowser, client, initFlow(t, flowTypeBrowser, client), func(v url.Values) {
+				v.Del("csrf_token")
+				v.Set(node.OTPSend, address)
+			})
+
+			assert.Contains(t, res.Request.URL.String(), errTS.URL)
+			assert.Equal(t, x.ErrInvalidCSRFToken.Reason(), gjson.Get(body, "reason").String(), body)
+		})
+
+		t.Run("type=spa", func(t *testing.T) {
+			client := newClient(t, flowTypeSPA, id)
+			body, res := submit(t, flowTypeSPA, client, initFlow(t, flowTypeSPA, client), func(v url.Values) {
+				v.Del("csrf_token")
+				v.Set(node.OTPSend, address)
+			})
+
+			assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+			assert.Equal(t, x.ErrInvalidCSRFToken.Reason(), gjson.Get(body, "error.reason").String(), body)
+		})
+
+		expectNoMessages(t)
+	})
+}
@@ -0,0 +1,40 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package otp
+
+import (
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+)
+
+func NewSendNode(address string) *node.Node {
+	return node.NewInputField(node.OTPSend, address, node.OTPGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceLoginOTPSend(address))
+}
+
+func NewResendNode(address string) *node.Node {
+	return node.NewInputField(node.OTPSend, address, node.OTPGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoNodeResendOTP())
+}
+
+func NewCodeNode() *node.Node {
+	return node.NewInputField(node.OTPCode, "", node.OTPGroup, node.InputAttributeTypeText, node.WithRequiredInputAttribute).
+		WithMetaLabel(text.NewInfoNodeLabelVerifyOTP())
+}
+
+func NewVerifyNode() *node.Node {
+	return node.NewInputField("method", identity.CredentialsTypeOTP, node.OTPGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoLoginVerify())
+}
+
+func NewEnableNode(address string) *node.Node {
+	return node.NewInputField(node.OTPEnable, address, node.OTPGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsEnableOTP(address))
+}
+
+func NewDisableNode(address string) *node.Node {
+	return node.NewInputField(node.OTPDisable, address, node.OTPGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsDisableOTP(address))
+}
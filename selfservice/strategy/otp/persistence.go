@@ -0,0 +1,59 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package otp
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+// ErrCodeThrottled is returned if the previous code was sent to the address less than the resend interval ago.
+var ErrCodeThrottled = errors.New("the previous one-time code was sent too recently")
+
+// CodeCounter tracks the latest one-time code sent to an address of an identity. It limits resending and
+// guessing codes across login flows, because the pending code itself is stored in the login flow.
+type CodeCounter struct {
+	ID         uuid.UUID `json:"-" faker:"-" db:"id"`
+	IdentityID uuid.UUID `json:"-" faker:"-" db:"identity_id"`
+	Address    string    `json:"-" db:"address"`
+
+	// CodeID identifies the latest code which was sent to the address.
+	CodeID uuid.UUID `json:"-" faker:"-" db:"code_id"`
+
+	// SentAt is the time when the latest code was sent.
+	SentAt time.Time `json:"-" faker:"-" db:"sent_at"`
+
+	// Attempts is the number of times the latest code was entered.
+	Attempts int `json:"-" db:"attempts"`
+
+	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
+	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+	NID       uuid.UUID `json:"-" faker:"-" db:"nid"`
+}
+
+func (c CodeCounter) TableName(ctx context.Context) string {
+	return "identity_otp_code_counters"
+}
+
+type (
+	CodeCounterPersister interface {
+		// GetOTPCodeCounter returns the counter of the address of the identity.
+		GetOTPCodeCounter(ctx context.Context, identityID uuid.UUID, address string) (*CodeCounter, error)
+
+		// ResetOTPCodeCounter records that the code c.CodeID was sent to the address at c.SentAt and resets the
+		// attempts. It fails with ErrCodeThrottled if the previous code was sent after notBefore.
+		ResetOTPCodeCounter(ctx context.Context, c *CodeCounter, notBefore time.Time) error
+
+		// IncrementOTPCodeAttempts increments and returns the attempts of the code. It fails with
+		// sqlcon.ErrNoRows if the code is no longer the latest code sent to the address.
+		IncrementOTPCodeAttempts(ctx context.Context, identityID uuid.UUID, address string, codeID uuid.UUID) (int, error)
+	}
+
+	CodeCounterPersistenceProvider interface {
+		OTPCodeCounterPersister() CodeCounterPersister
+	}
+)
@@ -0,0 +1,236 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package otp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+)
+
+func (s *Strategy) RegisterSettingsRoutes(_ *x.RouterPublic) {
+}
+
+func (s *Strategy) SettingsStrategyID() string {
+	return identity.CredentialsTypeOTP.String()
+}
+
+// Update Settings Flow with One-Time Code Method
+//
+// swagger:model updateSettingsFlowWithOtpMethod
+type updateSettingsFlowWithOtpMethod struct {
+	// Enable
+	//
+	// Starts sending one-time codes to this verified address when signing in.
+	Enable string `json:"otp_enable"`
+
+	// Disable
+	//
+	// Stops sending one-time codes to this address.
+	Disable string `json:"otp_disable"`
+
+	// CSRFToken is the anti-CSRF token
+	CSRFToken string `json:"csrf_token"`
+
+	// Method
+	//
+	// Should be set to "otp" when trying to enable or disable an address.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Flow is flow ID.
+	//
+	// swagger:ignore
+	Flow string `json:"flow"`
+}
+
+func (p *updateSettingsFlowWithOtpMethod) GetFlowID() uuid.UUID {
+	return x.ParseUUID(p.Flow)
+}
+
+func (p *updateSettingsFlowWithOtpMethod) SetFlowID(rid uuid.UUID) {
+	p.Flow = rid.String()
+}
+
+func (s *Strategy) Settings(w http.ResponseWriter, r *http.Request, f *settings.Flow, ss *session.Session) (*settings.UpdateContext, error) {
+	var p updateSettingsFlowWithOtpMethod
+	ctxUpdate, err := settings.PrepareUpdate(s.d, w, r, f, ss, settings.ContinuityKey(s.SettingsStrategyID()), &p)
+	if errors.Is(err, settings.ErrContinuePreviousAction) {
+		return ctxUpdate, s.continueSettingsFlow(w, r, ctxUpdate, &p)
+	} else if err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if err := s.decodeSettingsFlow(r, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if len(p.Enable) > 0 || len(p.Disable) > 0 {
+		// This method has only the address buttons
+		p.Method = s.SettingsStrategyID()
+		if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+			return nil, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+		}
+	} else {
+		return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	// This does not come from the payload!
+	p.Flow = ctxUpdate.Flow.ID.String()
+	if err := s.continueSettingsFlow(w, r, ctxUpdate, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	return ctxUpdate, nil
+}
+
+func (s *Strategy) decodeSettingsFlow(r *http.Request, dest interface{}) error {
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(settingsSchema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return decoderx.NewHTTP().Decode(r, dest, compiler,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	)
+}
+
+func (s *Strategy) continueSettingsFlow(
+	w http.ResponseWriter, r *http.Request,
+	ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithOtpMethod,
+) error {
+	if len(p.Enable) > 0 || len(p.Disable) > 0 {
+		if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), s.SettingsStrategyID(), s.d); err != nil {
+			return err
+		}
+
+		if err := flow.EnsureCSRF(s.d, r, ctxUpdate.Flow.Type, s.d.Config().DisableAPIFlowEnforcement(r.Context()), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+			return err
+		}
+
+		if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(r.Context())).Before(time.Now()) {
+			return errors.WithStack(settings.NewFlowNeedsReAuth())
+		}
+	} else {
+		return errors.New("ended up in unexpected state")
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), ctxUpdate.Session.Identity.ID)
+	if err != nil {
+		return err
+	}
+
+	conf, err := s.credentialsConfig(i)
+	if err != nil {
+		return err
+	}
+
+	if len(p.Enable) > 0 {
+		var address *identity.VerifiableAddress
+		eligible := s.eligibleAddresses(r.Context(), i)
+		for k := range eligible {
+			if eligible[k].Value == p.Enable {
+				address = &eligible[k]
+				break
+			}
+		}
+
+		if address == nil {
+			return errors.WithStack(schema.NewNoOTPAddressError())
+		}
+
+		if !conf.HasAddress(address.Value) {
+			conf.Addresses = append(conf.Addresses, identity.CredentialsOTPAddress{Via: address.Via, Value: address.Value})
+		}
+	} else {
+		addresses := make([]identity.CredentialsOTPAddress, 0, len(conf.Addresses))
+		for _, a := range conf.Addresses {
+			if a.Value != p.Disable {
+				addresses = append(addresses, a)
+			}
+		}
+		conf.Addresses = addresses
+	}
+
+	if len(conf.Addresses) == 0 {
+		i.DeleteCredentialsType(s.ID())
+	} else {
+		co, err := json.Marshal(conf)
+		if err != nil {
+			return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode one-time code addresses to JSON: %s", err))
+		}
+
+		// We do not really need the identifier, so we add the identity's ID
+		i.SetCredentials(s.ID(), identity.Credentials{Type: s.ID(), Identifiers: []string{i.ID.String()}, Config: co})
+	}
+
+	ctxUpdate.Flow.UI.Nodes.Remove(node.OTPEnable, node.OTPDisable)
+	s.populateSettingsNodes(r.Context(), i, conf, ctxUpdate.Flow)
+
+	if err := s.d.SettingsFlowPersister().UpdateSettingsFlow(r.Context(), ctxUpdate.Flow); err != nil {
+		return err
+	}
+
+	ctxUpdate.UpdateIdentity(i)
+	return nil
+}
+
+func (s *Strategy) populateSettingsNodes(ctx context.Context, i *identity.Identity, conf *identity.CredentialsOTPConfig, f *settings.Flow) {
+	for _, a := range s.eligibleAddresses(ctx, i) {
+		if conf.HasAddress(a.Value) {
+			f.UI.Nodes.Append(NewDisableNode(a.Value))
+		} else {
+			f.UI.Nodes.Append(NewEnableNode(a.Value))
+		}
+	}
+}
+
+func (s *Strategy) PopulateSettingsMethod(r *http.Request, id *identity.Identity, f *settings.Flow) error {
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+
+	confidential, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), id.ID)
+	if err != nil {
+		return err
+	}
+
+	conf, err := s.credentialsConfig(confidential)
+	if err != nil {
+		return err
+	}
+
+	s.populateSettingsNodes(r.Context(), confidential, conf, f)
+	return nil
+}
+
+func (s *Strategy) handleSettingsError(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithOtpMethod, err error) error {
+	// Do not pause flow if the flow type is an API flow as we can't save cookies in those flows.
+	if e := new(settings.FlowNeedsReAuth); errors.As(err, &e) && ctxUpdate.Flow != nil && ctxUpdate.Flow.Type == flow.TypeBrowser {
+		if err := s.d.ContinuityManager().Pause(r.Context(), w, r, settings.ContinuityKey(s.SettingsStrategyID()), settings.ContinuityOptions(p, ctxUpdate.GetSessionIdentity())...); err != nil {
+			return err
+		}
+	}
+
+	if ctxUpdate.Flow != nil {
+		ctxUpdate.Flow.UI.ResetMessages()
+		ctxUpdate.Flow.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+
+	return err
+}
@@ -0,0 +1,215 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package otp_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/assertx"
+)
+
+func TestCompleteSettings(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword)+".enabled", false)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".profile.enabled", false)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOTP)+".enabled", true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceSettingsRequiredAAL, "aal1")
+
+	router := x.NewRouterPublic()
+	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
+
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	uiTS := testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewRedirSessionEchoTS(t, reg)
+	loginTS := testhelpers.NewLoginUIFlowEchoServer(t, reg)
+
+	conf.MustSet(ctx, config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeySecretsDefault, []string{"not-a-secure-session-key"})
+
+	doAPIFlow := func(t *testing.T, v func(url.Values), id *identity.Identity) (string, *http.Response) {
+		apiClient := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		v(values)
+		payload := testhelpers.EncodeFormAsJSON(t, true, values)
+		return testhelpers.SettingsMakeRequest(t, true, false, f, apiClient, payload)
+	}
+
+	doBrowserFlow := func(t *testing.T, spa bool, v func(url.Values), id *identity.Identity) (string, *http.Response) {
+		browserClient := testhelpers.NewHTTPClientWithIdentitySessionCookie(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaBrowser(t, browserClient, spa, publicTS)
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		v(values)
+		return testhelpers.SettingsMakeRequest(t, false, spa, f, browserClient, testhelpers.EncodeFormAsJSON(t, spa, values))
+	}
+
+	enrolledAddresses := func(t *testing.T, id *identity.Identity) []string {
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id.ID)
+		require.NoError(t, err)
+		c, ok := i.GetCredentials(identity.CredentialsTypeOTP)
+		if !ok {
+			return nil
+		}
+		var addresses []string
+		for _, a := range gjson.GetBytes(c.Config, "addresses.#.value").Array() {
+			addresses = append(addresses, a.String())
+		}
+		return addresses
+	}
+
+	t.Run("case=shows enable button for verified email address", func(t *testing.T) {
+		id := createIdentity(t, reg)
+		apiClient := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
+
+		var enable, disable []string
+		for _, n := range f.Ui.Nodes {
+			if n.Group != node.OTPGroup.String() {
+				continue
+			}
+			switch n.Attributes.UiNodeInputAttributes.Name {
+			case node.OTPEnable:
+				enable = append(enable, n.Attributes.UiNodeInputAttributes.Value.(string))
+			case node.OTPDisable:
+				disable = append(disable, n.Attributes.UiNodeInputAttributes.Value.(string))
+			}
+		}
+
+		// Phone numbers are not eligible because SMS is not enabled.
+		assert.Equal(t, []string{id.VerifiableAddresses[0].Value}, enable)
+		assert.Empty(t, disable)
+	})
+
+	t.Run("case=shows disable button for enrolled address", func(t *testing.T) {
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		apiClient := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
+
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		assert.Equal(t, id.VerifiableAddresses[0].Value, values.Get(node.OTPDisable))
+		assert.Empty(t, values.Get(node.OTPEnable))
+	})
+
+	t.Run("case=enables and disables an address", func(t *testing.T) {
+		for _, tc := range []struct {
+			d  string
+			do func(t *testing.T, v func(url.Values), id *identity.Identity) (string, *http.Response)
+		}{
+			{d: "api", do: doAPIFlow},
+			{d: "spa", do: func(t *testing.T, v func(url.Values), id *identity.Identity) (string, *http.Response) {
+				return doBrowserFlow(t, true, v, id)
+			}},
+			{d: "browser", do: func(t *testing.T, v func(url.Values), id *identity.Identity) (string, *http.Response) {
+				return doBrowserFlow(t, false, v, id)
+			}},
+		} {
+			t.Run("type="+tc.d, func(t *testing.T) {
+				id := createIdentity(t, reg)
+				email := id.VerifiableAddresses[0].Value
+
+				actual, res := tc.do(t, func(v url.Values) {
+					v.Set(node.OTPEnable, email)
+				}, id)
+				assert.Equal(t, http.StatusOK, res.StatusCode, actual)
+				assert.EqualValues(t, settings.StateSuccess, gjson.Get(actual, "state").String(), actual)
+				assert.Equal(t, []string{email}, enrolledAddresses(t, id))
+				assert.Equal(t, email, gjson.Get(actual, `ui.nodes.#(attributes.name=="otp_disable").attributes.value`).String(), actual)
+				if tc.d == "browser" {
+					assert.Contains(t, res.Request.URL.String(), uiTS.URL)
+				}
+
+				actual, res = tc.do(t, func(v url.Values) {
+					v.Set(node.OTPDisable, email)
+				}, id)
+				assert.Equal(t, http.StatusOK, res.StatusCode, actual)
+				assert.EqualValues(t, settings.StateSuccess, gjson.Get(actual, "state").String(), actual)
+				assert.Empty(t, enrolledAddresses(t, id))
+				assert.Equal(t, email, gjson.Get(actual, `ui.nodes.#(attributes.name=="otp_enable").attributes.value`).String(), actual)
+			})
+		}
+	})
+
+	t.Run("case=enables phone number if SMS is enabled", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyCourierSMSEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeyCourierSMSEnabled, false)
+		})
+
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		phone := id.VerifiableAddresses[1].Value
+
+		actual, res := doAPIFlow(t, func(v url.Values) {
+			v.Del(node.OTPDisable)
+			v.Set(node.OTPEnable, phone)
+		}, id)
+		assert.Equal(t, http.StatusOK, res.StatusCode, actual)
+		assert.ElementsMatch(t, []string{id.VerifiableAddresses[0].Value, phone}, enrolledAddresses(t, id))
+	})
+
+	t.Run("case=can not enable unknown or ineligible address", func(t *testing.T) {
+		id := createIdentity(t, reg)
+
+		for _, address := range []string{"not-my-address@ory.sh", id.VerifiableAddresses[1].Value} {
+			actual, res := doAPIFlow(t, func(v url.Values) {
+				v.Set(node.OTPEnable, address)
+			}, id)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, actual)
+			assert.EqualValues(t, text.NewErrorValidationNoOTPAddress().Text, gjson.Get(actual, "ui.messages.0.text").String(), actual)
+			assert.Empty(t, enrolledAddresses(t, id))
+		}
+	})
+
+	t.Run("case=can not enable or disable without privileged session", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1ns")
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+		})
+
+		id := createIdentity(t, reg, identity.VerifiableAddressTypeEmail)
+		email := id.VerifiableAddresses[0].Value
+		payload := func(v url.Values) {
+			v.Set(node.OTPDisable, email)
+		}
+
+		t.Run("type=api", func(t *testing.T) {
+			actual, res := doAPIFlow(t, payload, id)
+			assert.Equal(t, http.StatusForbidden, res.StatusCode)
+			assert.Contains(t, gjson.Get(actual, "redirect_browser_to").String(), publicTS.URL+"/self-service/login/browser?refresh=true&return_to=")
+			assert.Equal(t, []string{email}, enrolledAddresses(t, id))
+		})
+
+		t.Run("type=spa", func(t *testing.T) {
+			actual, res := doBrowserFlow(t, true, payload, id)
+			assert.Equal(t, http.StatusForbidden, res.StatusCode)
+			assert.Contains(t, gjson.Get(actual, "redirect_browser_to").String(), publicTS.URL+"/self-service/login/browser?refresh=true&return_to=")
+			assert.Equal(t, []string{email}, enrolledAddresses(t, id))
+		})
+
+		t.Run("type=browser", func(t *testing.T) {
+			actual, res := doBrowserFlow(t, false, payload, id)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Contains(t, res.Request.URL.String(), loginTS.URL+"/login-ts")
+			assertx.EqualAsJSON(t, text.NewInfoLoginReAuth().Text, gjson.Get(actual, "ui.messages.0.text").String(), actual)
+			assert.Equal(t, []string{email}, enrolledAddresses(t, id))
+		})
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package otp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+)
+
+var _ login.Strategy = new(Strategy)
+var _ settings.Strategy = new(Strategy)
+var _ identity.ActiveCredentialsCounter = new(Strategy)
+
+type strategyDependencies interface {
+	x.LoggingProvider
+	x.WriterProvider
+	x.CSRFTokenGeneratorProvider
+	x.CSRFProvider
+
+	config.Provider
+
+	continuity.ManagementProvider
+
+	courier.Provider
+	template.Dependencies
+
+	errorx.ManagementProvider
+
+	login.HooksProvider
+	login.ErrorHandlerProvider
+	login.HookExecutorProvider
+	login.FlowPersistenceProvider
+	login.HandlerProvider
+
+	settings.FlowPersistenceProvider
+	settings.HookExecutorProvider
+	settings.HooksProvider
+	settings.ErrorHandlerProvider
+
+	identity.PrivilegedPoolProvider
+	identity.ValidationProvider
+
+	session.HandlerProvider
+	session.ManagementProvider
+
+	CodeCounterPersistenceProvider
+}
+
+// Strategy sends one-time codes via SMS or email to verified addresses of an identity
+// and accepts them as a second factor.
+type Strategy struct {
+	d  strategyDependencies
+	hd *decoderx.HTTP
+}
+
+func NewStrategy(d strategyDependencies) *Strategy {
+	return &Strategy{
+		d:  d,
+		hd: decoderx.NewHTTP(),
+	}
+}
+
+func (s *Strategy) CountActiveFirstFactorCredentials(cc map[identity.CredentialsType]identity.Credentials) (count int, err error) {
+	return 0, nil
+}
+
+func (s *Strategy) CountActiveMultiFactorCredentials(cc map[identity.CredentialsType]identity.Credentials) (count int, err error) {
+	for _, c := range cc {
+		if c.Type == s.ID() && len(c.Config) > 0 {
+			var conf identity.CredentialsOTPConfig
+			if err = json.Unmarshal(c.Config, &conf); err != nil {
+				return 0, errors.WithStack(err)
+			}
+
+			if len(conf.Addresses) > 0 {
+				count++
+			}
+		}
+	}
+	return
+}
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeOTP
+}
+
+func (s *Strategy) NodeGroup() node.UiNodeGroup {
+	return node.OTPGroup
+}
+
+func (s *Strategy) CompletedAuthenticationMethod(ctx context.Context) session.AuthenticationMethod {
+	return session.AuthenticationMethod{
+		Method: s.ID(),
+		AAL:    identity.AuthenticatorAssuranceLevel2,
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package otp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/selfservice/strategy/otp"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/sqlxx"
+)
+
+var phoneNumbers int32
+
+// createIdentity creates an identity with a verified email address and a verified phone number. One-time
+// codes are sent to the addresses of the given types.
+func createIdentity(t *testing.T, reg driver.Registry, enrolled ...identity.VerifiableAddressType) *identity.Identity {
+	email := x.NewUUID().String() + "@ory.sh"
+	phone := fmt.Sprintf("+1206555%04d", atomic.AddInt32(&phoneNumbers, 1))
+	password := x.NewUUID().String()
+	p, err := reg.Hasher(context.Background()).Generate(context.Background(), []byte(password))
+	require.NoError(t, err)
+
+	i := &identity.Identity{
+		Traits: identity.Traits(fmt.Sprintf(`{"email":"%s","phone":"%s"}`, email, phone)),
+	}
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+
+	now := sqlxx.NullTime(time.Now())
+	i.VerifiableAddresses = []identity.VerifiableAddress{
+		{Value: email, Via: identity.VerifiableAddressTypeEmail, Verified: true, VerifiedAt: &now, Status: identity.VerifiableAddressStatusCompleted, IdentityID: i.ID},
+		{Value: phone, Via: identity.VerifiableAddressTypePhone, Verified: true, VerifiedAt: &now, Status: identity.VerifiableAddressStatusCompleted, IdentityID: i.ID},
+	}
+
+	var conf identity.CredentialsOTPConfig
+	for _, via := range enrolled {
+		for _, a := range i.VerifiableAddresses {
+			if a.Via == via {
+				conf.Addresses = append(conf.Addresses, identity.CredentialsOTPAddress{Via: a.Via, Value: a.Value})
+			}
+		}
+	}
+
+	i.Credentials = map[identity.CredentialsType]identity.Credentials{
+		identity.CredentialsTypePassword: {
+			Type:        identity.CredentialsTypePassword,
+			Identifiers: []string{email},
+			Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(p) + `"}`),
+		},
+	}
+
+	if len(conf.Addresses) > 0 {
+		c, err := json.Marshal(&conf)
+		require.NoError(t, err)
+		i.Credentials[identity.CredentialsTypeOTP] = identity.Credentials{
+			Type:        identity.CredentialsTypeOTP,
+			Identifiers: []string{i.ID.String()},
+			Config:      c,
+		}
+	}
+
+	require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(context.Background(), i))
+	return i
+}
+
+func TestCountActiveCredentials(t *testing.T) {
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	strategy := otp.NewStrategy(reg)
+
+	t.Run("first factor", func(t *testing.T) {
+		actual, err := strategy.CountActiveFirstFactorCredentials(nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, actual)
+	})
+
+	t.Run("multi factor", func(t *testing.T) {
+		for k, tc := range []struct {
+			in       identity.CredentialsCollection
+			expected int
+		}{
+			{
+				in: identity.CredentialsCollection{{
+					Type:   strategy.ID(),
+					Config: []byte{},
+				}},
+				expected: 0,
+			},
+			{
+				in: identity.CredentialsCollection{{
+					Type:   strategy.ID(),
+					Config: []byte(`{"addresses": []}`),
+				}},
+				expected: 0,
+			},
+			{
+				in: identity.CredentialsCollection{{
+					Type:        strategy.ID(),
+					Identifiers: []string{"foo"},
+					Config:      []byte(`{"addresses": [{"via":"email","value":"foo@ory.sh"}]}`),
+				}},
+				expected: 1,
+			},
+			{
+				in:       identity.CredentialsCollection{{}, {}},
+				expected: 0,
+			},
+		} {
+			t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+				cc := map[identity.CredentialsType]identity.Credentials{}
+				for _, c := range tc.in {
+					cc[c.Type] = c
+				}
+
+				actual, err := strategy.CountActiveMultiFactorCredentials(cc)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			})
+		}
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package otp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bxcodec/faker/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/selfservice/strategy/otp"
+	"github.com/ory/kratos/x"
+)
+
+func TestPersister(ctx context.Context, conf *config.Config, p interface {
+	persistence.Persister
+}) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, p := testhelpers.NewNetworkUnlessExisting(t, ctx, p)
+		testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+
+		var i identity.Identity
+		require.NoError(t, faker.FakeData(&i))
+		require.NoError(t, p.CreateIdentity(ctx, &i))
+
+		const address = "otp@ory.sh"
+		interval := time.Minute
+		now := time.Now().UTC().Round(time.Second)
+
+		t.Run("case=counter does not exist", func(t *testing.T) {
+			_, err := p.GetOTPCodeCounter(ctx, i.ID, address)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+			_, err = p.IncrementOTPCodeAttempts(ctx, i.ID, address, x.NewUUID())
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+
+		first := &otp.CodeCounter{IdentityID: i.ID, Address: address, CodeID: x.NewUUID(), SentAt: now.Add(-2 * interval)}
+		require.NoError(t, p.ResetOTPCodeCounter(ctx, first, now.Add(-interval)))
+
+		t.Run("case=attempts are counted per code", func(t *testing.T) {
+			for k := 1; k <= 3; k++ {
+				attempts, err := p.IncrementOTPCodeAttempts(ctx, i.ID, address, first.CodeID)
+				require.NoError(t, err)
+				assert.Equal(t, k, attempts)
+			}
+
+			_, err := p.IncrementOTPCodeAttempts(ctx, i.ID, address, x.NewUUID())
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+
+		second := &otp.CodeCounter{IdentityID: i.ID, Address: address, CodeID: x.NewUUID(), SentAt: now}
+		t.Run("case=sending a new code resets the attempts", func(t *testing.T) {
+			require.NoError(t, p.ResetOTPCodeCounter(ctx, second, now.Add(-interval)))
+
+			actual, err := p.GetOTPCodeCounter(ctx, i.ID, address)
+			require.NoError(t, err)
+			assert.Equal(t, second.CodeID, actual.CodeID)
+			assert.Equal(t, 0, actual.Attempts)
+
+			_, err = p.IncrementOTPCodeAttempts(ctx, i.ID, address, first.CodeID)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+			attempts, err := p.IncrementOTPCodeAttempts(ctx, i.ID, address, second.CodeID)
+			require.NoError(t, err)
+			assert.Equal(t, 1, attempts)
+		})
+
+		t.Run("case=sending is throttled", func(t *testing.T) {
+			third := &otp.CodeCounter{IdentityID: i.ID, Address: address, CodeID: x.NewUUID(), SentAt: now}
+			assert.ErrorIs(t, p.ResetOTPCodeCounter(ctx, third, now.Add(-interval)), otp.ErrCodeThrottled)
+
+			actual, err := p.GetOTPCodeCounter(ctx, i.ID, address)
+			require.NoError(t, err)
+			assert.Equal(t, second.CodeID, actual.CodeID)
+			assert.Equal(t, 1, actual.Attempts)
+		})
+
+		t.Run("case=on another network", func(t *testing.T) {
+			_, other := testhelpers.NewNetwork(t, ctx, p)
+			_, err := other.GetOTPCodeCounter(ctx, i.ID, address)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+			_, err = other.IncrementOTPCodeAttempts(ctx, i.ID, address, second.CodeID)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+	}
+}
@@ -156,3 +156,27 @@ func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.Au
 
 	return nil
 }
+
+func (s *Strategy) PopulateLoginMethodIdentifierFirstIdentification(r *http.Request, sr *login.Flow) error {
+	return nil
+}
+
+// PopulateLoginMethodIdentifierFirstCredentials shows the password field if the identity has a password. If the
+// identity is unknown, the password field is always shown so that the response does not reveal whether an
+// account exists.
+func (s *Strategy) PopulateLoginMethodIdentifierFirstCredentials(r *http.Request, sr *login.Flow, identifier string, i *identity.Identity) error {
+	if i != nil {
+		count, err := s.CountActiveFirstFactorCredentials(i.Credentials)
+		if err != nil {
+			return err
+		} else if count == 0 {
+			return nil
+		}
+	}
+
+	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	sr.UI.SetNode(NewPasswordNode("password", node.InputAttributeAutocompleteCurrentPassword))
+	sr.UI.GetNodes().Append(node.NewInputField("method", "password", node.PasswordGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoLogin()))
+
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 )
 
 var _ login.Strategy = new(Strategy)
+var _ login.IdentifierFirstStrategy = new(Strategy)
 var _ registration.Strategy = new(Strategy)
 var _ identity.ActiveCredentialsCounter = new(Strategy)
 
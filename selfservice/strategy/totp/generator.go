@@ -8,12 +8,16 @@ import (
 	"context"
 	"encoding/base64"
 	"image/png"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
 	stdtotp "github.com/pquerna/otp/totp"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
 )
 
 // rfc4226 recommends:
@@ -33,8 +37,9 @@ func NewKey(ctx context.Context, accountName string, d interface {
 		Issuer:      d.Config().TOTPIssuer(ctx),
 		AccountName: accountName,
 		SecretSize:  secretSize,
-		Digits:      otp.DigitsSix,
-		Period:      30,
+		Digits:      otp.Digits(d.Config().TOTPDigits(ctx)),
+		Period:      uint(d.Config().TOTPPeriod(ctx) / time.Second),
+		Algorithm:   algorithmFromString(d.Config().TOTPAlgorithm(ctx)),
 	})
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -43,6 +48,62 @@ func NewKey(ctx context.Context, accountName string, d interface {
 	return key, err
 }
 
+func algorithmFromString(in string) otp.Algorithm {
+	switch strings.ToUpper(in) {
+	case otp.AlgorithmSHA256.String():
+		return otp.AlgorithmSHA256
+	case otp.AlgorithmSHA512.String():
+		return otp.AlgorithmSHA512
+	default:
+		return otp.AlgorithmSHA1
+	}
+}
+
+// NewCredentialsConfig returns the credentials config for the key.
+func NewCredentialsConfig(key *otp.Key) *identity.CredentialsTOTPConfig {
+	return &identity.CredentialsTOTPConfig{
+		TOTPURL:   key.URL(),
+		Algorithm: key.Algorithm().String(),
+		Digits:    key.Digits().Length(),
+		Period:    uint(key.Period()),
+	}
+}
+
+// ValidateStep checks the passcode against the key and returns the time step for which it was generated.
+//
+// Passcodes are accepted for up to `skew` time steps before and after the one of `t`. The algorithm,
+// digits and period stored in the credentials config take precedence over the ones of the key.
+func ValidateStep(passcode string, key *otp.Key, o *identity.CredentialsTOTPConfig, skew uint, t time.Time) (step uint64, ok bool) {
+	period := key.Period()
+	opts := hotp.ValidateOpts{Digits: key.Digits(), Algorithm: key.Algorithm()}
+	if o != nil {
+		if o.Period > 0 {
+			period = uint64(o.Period)
+		}
+		if o.Digits > 0 {
+			opts.Digits = otp.Digits(o.Digits)
+		}
+		if len(o.Algorithm) > 0 {
+			opts.Algorithm = algorithmFromString(o.Algorithm)
+		}
+	}
+
+	current := uint64(t.Unix()) / period
+
+	// Walk backwards so that the most recent matching step is returned.
+	for i := current + uint64(skew); i+uint64(skew) >= current; i-- {
+		if valid, _ := hotp.ValidateCustom(passcode, i, key.Secret(), opts); valid {
+			return i, true
+		}
+
+		if i == 0 {
+			break
+		}
+	}
+
+	return 0, false
+}
+
 func KeyToHTMLImage(key *otp.Key) (string, error) {
 	var buf bytes.Buffer
 	img, err := key.Image(256, 256)
@@ -5,13 +5,18 @@ package totp_test
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/pquerna/otp"
+	stdtotp "github.com/pquerna/otp/totp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/selfservice/strategy/totp"
 )
@@ -35,3 +40,79 @@ func TestGenerator(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, strings.HasPrefix(img, "data:image/png;base64,"), "image is a base64 encoded png")
 }
+
+func TestGeneratorOptions(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	key, err := totp.NewKey(ctx, "foo", reg)
+	require.NoError(t, err)
+	assert.Equal(t, otp.AlgorithmSHA1, key.Algorithm())
+	assert.Equal(t, otp.DigitsSix, key.Digits())
+	assert.EqualValues(t, 30, key.Period())
+
+	conf.MustSet(ctx, config.ViperKeyTOTPAlgorithm, "sha256")
+	conf.MustSet(ctx, config.ViperKeyTOTPDigits, 8)
+	conf.MustSet(ctx, config.ViperKeyTOTPPeriod, "1m")
+
+	key, err = totp.NewKey(ctx, "foo", reg)
+	require.NoError(t, err)
+	assert.Equal(t, otp.AlgorithmSHA256, key.Algorithm())
+	assert.Equal(t, otp.DigitsEight, key.Digits())
+	assert.EqualValues(t, 60, key.Period())
+
+	o := totp.NewCredentialsConfig(key)
+	assert.Equal(t, "SHA256", o.Algorithm)
+	assert.Equal(t, 8, o.Digits)
+	assert.EqualValues(t, 60, o.Period)
+}
+
+func TestValidateStep(t *testing.T) {
+	ctx := context.Background()
+	_, reg := internal.NewFastRegistryWithMocks(t)
+
+	key, err := totp.NewKey(ctx, "foo", reg)
+	require.NoError(t, err)
+
+	now := time.Unix(1681977600, 0)
+	current := uint64(now.Unix()) / 30
+
+	for k, tc := range []struct {
+		offset time.Duration
+		skew   uint
+		ok     bool
+		step   uint64
+	}{
+		{offset: 0, skew: 0, ok: true, step: current},
+		{offset: -30 * time.Second, skew: 0, ok: false},
+		{offset: -30 * time.Second, skew: 1, ok: true, step: current - 1},
+		{offset: 30 * time.Second, skew: 1, ok: true, step: current + 1},
+		{offset: -60 * time.Second, skew: 1, ok: false},
+		{offset: -60 * time.Second, skew: 2, ok: true, step: current - 2},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			code, err := stdtotp.GenerateCode(key.Secret(), now.Add(tc.offset))
+			require.NoError(t, err)
+
+			step, ok := totp.ValidateStep(code, key, nil, tc.skew, now)
+			require.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.step, step)
+		})
+	}
+
+	t.Run("case=credentials config takes precedence", func(t *testing.T) {
+		code, err := stdtotp.GenerateCodeCustom(key.Secret(), now, stdtotp.ValidateOpts{
+			Period:    60,
+			Digits:    otp.DigitsEight,
+			Algorithm: otp.AlgorithmSHA512,
+		})
+		require.NoError(t, err)
+
+		_, ok := totp.ValidateStep(code, key, nil, 1, now)
+		assert.False(t, ok)
+
+		step, ok := totp.ValidateStep(code, key, &identity.CredentialsTOTPConfig{Algorithm: "SHA512", Digits: 8, Period: 60}, 1, now)
+		require.True(t, ok)
+		assert.Equal(t, uint64(now.Unix())/60, step)
+	})
+}
@@ -6,11 +6,11 @@ package totp
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	"github.com/pquerna/otp"
-	"github.com/pquerna/otp/totp"
 
 	"github.com/ory/herodot"
 	"github.com/ory/kratos/identity"
@@ -21,6 +21,7 @@ import (
 	"github.com/ory/kratos/ui/node"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
 )
 
 func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
@@ -121,8 +122,24 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.handleLoginError(r, f, errors.WithStack(err))
 	}
 
-	if !totp.Validate(p.TOTPCode, key.Secret()) {
+	step, ok := ValidateStep(p.TOTPCode, key, &o, s.d.Config().TOTPSkew(r.Context()), time.Now())
+	if !ok {
 		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewTOTPVerifierWrongError("#/")))
+	} else if step <= o.LastUsedStep {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewTOTPCodeAlreadyUsedError()))
+	}
+
+	o.LastUsedStep = step
+	encoded, err := json.Marshal(&o)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to encode updated TOTP credentials.").WithDebug(err.Error())))
+	}
+
+	// The credentials are only updated if no concurrent request used a code in the meantime.
+	if err := s.d.PrivilegedIdentityPool().CompareAndSwapCredentialsConfig(r.Context(), i.ID, s.ID(), c.Config, encoded); errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewTOTPCodeAlreadyUsedError()))
+	} else if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to update identity.").WithDebug(err.Error())))
 	}
 
 	f.Active = s.ID()
@@ -7,13 +7,16 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ory/x/assertx"
+	"github.com/ory/x/ioutilx"
 
 	"github.com/gofrs/uuid"
 
@@ -302,11 +305,13 @@ func TestCompleteLogin(t *testing.T) {
 	})
 
 	t.Run("case=should pass when TOTP is supplied correctly", func(t *testing.T) {
-		id, _, key := createIdentity(t, reg)
-		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
-		require.NoError(t, err)
-		payload := func(v url.Values) {
-			v.Set("totp_code", code)
+		// Every code can only be used once, so each request needs its own identity.
+		payload := func(t *testing.T, key *otp.Key) func(v url.Values) {
+			code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+			require.NoError(t, err)
+			return func(v url.Values) {
+				v.Set("totp_code", code)
+			}
 		}
 
 		startAt := time.Now()
@@ -330,28 +335,130 @@ func TestCompleteLogin(t *testing.T) {
 		}
 
 		t.Run("type=api", func(t *testing.T) {
-			body, res := doAPIFlow(t, payload, id)
+			id, _, key := createIdentity(t, reg)
+			body, res := doAPIFlow(t, payload(t, key), id)
 			check(t, false, body, res)
 		})
 
 		t.Run("type=browser", func(t *testing.T) {
-			body, res := doBrowserFlow(t, false, payload, id, "")
+			id, _, key := createIdentity(t, reg)
+			body, res := doBrowserFlow(t, false, payload(t, key), id, "")
 			check(t, true, body, res)
 		})
 
 		t.Run("type=browser set return_to", func(t *testing.T) {
+			id, _, key := createIdentity(t, reg)
 			returnTo := "https://www.ory.sh"
-			_, res := doBrowserFlow(t, false, payload, id, returnTo)
+			_, res := doBrowserFlow(t, false, payload(t, key), id, returnTo)
 			t.Log(res.Request.URL.String())
 			assert.Contains(t, res.Request.URL.String(), returnTo)
 		})
 
 		t.Run("type=spa", func(t *testing.T) {
-			body, res := doBrowserFlow(t, true, payload, id, "")
+			id, _, key := createIdentity(t, reg)
+			body, res := doBrowserFlow(t, true, payload(t, key), id, "")
 			check(t, false, body, res)
 		})
 	})
 
+	t.Run("case=should fail when TOTP code is replayed", func(t *testing.T) {
+		id, _, key := createIdentity(t, reg)
+		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+		require.NoError(t, err)
+		payload := func(v url.Values) {
+			v.Set("totp_code", code)
+		}
+
+		body, res := doAPIFlow(t, payload, id)
+		assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+		assert.True(t, gjson.Get(body, "session.active").Bool(), "%s", body)
+
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id.ID)
+		require.NoError(t, err)
+		var o identity.CredentialsTOTPConfig
+		require.NoError(t, json.Unmarshal(actual.Credentials[identity.CredentialsTypeTOTP].Config, &o))
+		assert.NotZero(t, o.LastUsedStep)
+
+		t.Run("type=api", func(t *testing.T) {
+			body, res := doAPIFlow(t, payload, id)
+			checkURL(t, false, res)
+			assert.Equal(t, text.NewErrorValidationTOTPCodeAlreadyUsed().Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+		})
+
+		t.Run("type=browser", func(t *testing.T) {
+			body, res := doBrowserFlow(t, false, payload, id, "")
+			checkURL(t, true, res)
+			assert.Equal(t, text.NewErrorValidationTOTPCodeAlreadyUsed().Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+		})
+	})
+
+	t.Run("case=should accept a TOTP code only once when submitted concurrently", func(t *testing.T) {
+		id, _, key := createIdentity(t, reg)
+		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+		require.NoError(t, err)
+
+		const concurrency = 5
+		clients := make([]*http.Client, concurrency)
+		requests := make([]*http.Request, concurrency)
+		for k := range requests {
+			clients[k] = testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+			f := testhelpers.InitializeLoginFlowViaAPI(t, clients[k], publicTS, false, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel2))
+			values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+			values.Set("method", "totp")
+			values.Set("totp_code", code)
+			requests[k] = testhelpers.NewRequest(t, true, "POST", f.Ui.Action, bytes.NewBufferString(testhelpers.EncodeFormAsJSON(t, true, values)))
+		}
+
+		var wg sync.WaitGroup
+		bodies := make([]string, concurrency)
+		for k := range requests {
+			wg.Add(1)
+			go func(k int) {
+				defer wg.Done()
+				res, err := clients[k].Do(requests[k])
+				if err != nil {
+					return
+				}
+				defer res.Body.Close()
+				bodies[k] = string(ioutilx.MustReadAll(res.Body))
+			}(k)
+		}
+		wg.Wait()
+
+		var accepted int
+		for _, body := range bodies {
+			if gjson.Get(body, "session.active").Bool() {
+				accepted++
+			}
+		}
+		assert.Equal(t, 1, accepted, "%v", bodies)
+	})
+
+	t.Run("case=should pass with configured algorithm, digits and period", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeyTOTPAlgorithm, "sha512")
+		conf.MustSet(ctx, config.ViperKeyTOTPDigits, 8)
+		conf.MustSet(ctx, config.ViperKeyTOTPPeriod, "60s")
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeyTOTPAlgorithm, nil)
+			conf.MustSet(ctx, config.ViperKeyTOTPDigits, nil)
+			conf.MustSet(ctx, config.ViperKeyTOTPPeriod, nil)
+		})
+
+		id, _, key := createIdentity(t, reg)
+		code, err := stdtotp.GenerateCodeCustom(key.Secret(), time.Now(), stdtotp.ValidateOpts{
+			Period:    60,
+			Digits:    otp.DigitsEight,
+			Algorithm: otp.AlgorithmSHA512,
+		})
+		require.NoError(t, err)
+
+		body, res := doAPIFlow(t, func(v url.Values) {
+			v.Set("totp_code", code)
+		}, id)
+		assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+		assert.True(t, gjson.Get(body, "session.active").Bool(), "%s", body)
+	})
+
 	t.Run("case=should fail because totp can not handle AAL1", func(t *testing.T) {
 		apiClient := testhelpers.NewDebugClient(t)
 		f := testhelpers.InitializeLoginFlowViaAPI(t, apiClient, publicTS, false)
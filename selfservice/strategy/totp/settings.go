@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/pquerna/otp"
-	"github.com/pquerna/otp/totp"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 
@@ -182,11 +181,15 @@ func (s *Strategy) continueSettingsFlowAddTOTP(w http.ResponseWriter, r *http.Re
 		return nil, schema.NewRequiredError("#/totp_code", "totp_code")
 	}
 
-	if !totp.Validate(p.ValidationTOTP, key.Secret()) {
+	o := NewCredentialsConfig(key)
+	step, ok := ValidateStep(p.ValidationTOTP, key, o, s.d.Config().TOTPSkew(r.Context()), time.Now())
+	if !ok {
 		return nil, schema.NewTOTPVerifierWrongError("#/totp_code")
 	}
 
-	co, err := json.Marshal(&identity.CredentialsTOTPConfig{TOTPURL: key.URL()})
+	// The code used for pairing must not be usable for signing in.
+	o.LastUsedStep = step
+	co, err := json.Marshal(o)
 	if err != nil {
 		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode totp options to JSON: %s", err))
 	}
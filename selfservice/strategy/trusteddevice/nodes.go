@@ -0,0 +1,20 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package trusteddevice
+
+import (
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/x/pointerx"
+	"github.com/ory/x/stringsx"
+)
+
+func NewRevokeTrustedDeviceNode(d *session.TrustedDevice) *node.Node {
+	return node.NewInputField(node.TrustedDeviceRevoke, d.ID.String(), node.TrustedDeviceGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceRevokeTrustedDevice(
+			stringsx.Coalesce(pointerx.StringR(d.UserAgent), "unknown"),
+			stringsx.Coalesce(pointerx.StringR(d.IPAddress), "unknown"),
+			d.CreatedAt, d.ExpiresAt))
+}
@@ -0,0 +1,188 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package trusteddevice
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+)
+
+func (s *Strategy) RegisterSettingsRoutes(_ *x.RouterPublic) {
+}
+
+func (s *Strategy) SettingsStrategyID() string {
+	return identity.CredentialsTypeTrustedDevice.String()
+}
+
+// Update Settings Flow with Trusted Device Method
+//
+// swagger:model updateSettingsFlowWithTrustedDeviceMethod
+type updateSettingsFlowWithTrustedDeviceMethod struct {
+	// Revoke a Trusted Device
+	//
+	// This must contain the ID of the trusted device.
+	Revoke string `json:"trusted_device_revoke"`
+
+	// CSRFToken is the anti-CSRF token
+	CSRFToken string `json:"csrf_token"`
+
+	// Method
+	//
+	// Should be set to "trusted_device" when trying to revoke a trusted device.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Flow is flow ID.
+	//
+	// swagger:ignore
+	Flow string `json:"flow"`
+}
+
+func (p *updateSettingsFlowWithTrustedDeviceMethod) GetFlowID() uuid.UUID {
+	return x.ParseUUID(p.Flow)
+}
+
+func (p *updateSettingsFlowWithTrustedDeviceMethod) SetFlowID(rid uuid.UUID) {
+	p.Flow = rid.String()
+}
+
+func (s *Strategy) Settings(w http.ResponseWriter, r *http.Request, f *settings.Flow, ss *session.Session) (*settings.UpdateContext, error) {
+	var p updateSettingsFlowWithTrustedDeviceMethod
+	ctxUpdate, err := settings.PrepareUpdate(s.d, w, r, f, ss, settings.ContinuityKey(s.SettingsStrategyID()), &p)
+	if errors.Is(err, settings.ErrContinuePreviousAction) {
+		return ctxUpdate, s.continueSettingsFlow(w, r, ctxUpdate, &p)
+	} else if err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if err := s.decodeSettingsFlow(r, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if len(p.Revoke) > 0 {
+		// This method has only one submit button
+		p.Method = s.SettingsStrategyID()
+		if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+			return nil, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+		}
+	} else {
+		return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	// This does not come from the payload!
+	p.Flow = ctxUpdate.Flow.ID.String()
+	if err := s.continueSettingsFlow(w, r, ctxUpdate, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	return ctxUpdate, nil
+}
+
+func (s *Strategy) decodeSettingsFlow(r *http.Request, dest interface{}) error {
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(settingsSchema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return s.hd.Decode(r, dest, compiler,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	)
+}
+
+func (s *Strategy) continueSettingsFlow(
+	w http.ResponseWriter, r *http.Request,
+	ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithTrustedDeviceMethod,
+) error {
+	if len(p.Revoke) == 0 {
+		return errors.New("ended up in unexpected state")
+	}
+
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), s.SettingsStrategyID(), s.d); err != nil {
+		return err
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, ctxUpdate.Flow.Type, s.d.Config().DisableAPIFlowEnforcement(r.Context()), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return err
+	}
+
+	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config().SelfServiceFlowSettingsPrivilegedSessionMaxAge(r.Context())).Before(time.Now()) {
+		return errors.WithStack(settings.NewFlowNeedsReAuth())
+	}
+
+	if err := s.continueSettingsFlowRevoke(w, r, ctxUpdate, p); err != nil {
+		return err
+	}
+
+	return flow.ErrStrategyAsksToReturnToUI
+}
+
+func (s *Strategy) continueSettingsFlowRevoke(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithTrustedDeviceMethod) error {
+	id, err := uuid.FromString(p.Revoke)
+	if err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("You tried to revoke a trusted device which does not exist."))
+	}
+
+	if err := s.d.SessionPersister().DeleteTrustedDevice(r.Context(), ctxUpdate.Session.IdentityID, id); errors.Is(err, sqlcon.ErrNoRows) {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("You tried to revoke a trusted device which does not exist."))
+	} else if err != nil {
+		return err
+	}
+
+	if err := s.populateTrustedDevices(r, ctxUpdate.Session.IdentityID, ctxUpdate.Flow); err != nil {
+		return err
+	}
+
+	return s.d.SettingsFlowPersister().UpdateSettingsFlow(r.Context(), ctxUpdate.Flow)
+}
+
+func (s *Strategy) populateTrustedDevices(r *http.Request, identityID uuid.UUID, f *settings.Flow) error {
+	devices, err := s.d.SessionPersister().ListTrustedDevices(r.Context(), identityID)
+	if err != nil {
+		return err
+	}
+
+	f.UI.Nodes.Remove(node.TrustedDeviceRevoke)
+	for k := range devices {
+		f.UI.Nodes.Append(NewRevokeTrustedDeviceNode(&devices[k]))
+	}
+
+	return nil
+}
+
+func (s *Strategy) PopulateSettingsMethod(r *http.Request, id *identity.Identity, f *settings.Flow) error {
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	return s.populateTrustedDevices(r, id.ID, f)
+}
+
+func (s *Strategy) handleSettingsError(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *updateSettingsFlowWithTrustedDeviceMethod, err error) error {
+	// Do not pause flow if the flow type is an API flow as we can't save cookies in those flows.
+	if e := new(settings.FlowNeedsReAuth); errors.As(err, &e) && ctxUpdate.Flow != nil && ctxUpdate.Flow.Type == flow.TypeBrowser {
+		if err := s.d.ContinuityManager().Pause(r.Context(), w, r, settings.ContinuityKey(s.SettingsStrategyID()), settings.ContinuityOptions(p, ctxUpdate.GetSessionIdentity())...); err != nil {
+			return err
+		}
+	}
+
+	if ctxUpdate.Flow != nil {
+		ctxUpdate.Flow.UI.ResetMessages()
+		ctxUpdate.Flow.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+
+	return err
+}
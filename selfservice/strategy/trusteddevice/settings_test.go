@@ -0,0 +1,153 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package trusteddevice_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/pointerx"
+)
+
+func createIdentity(t *testing.T, reg driver.Registry) *identity.Identity {
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.Traits = identity.Traits(`{}`)
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+	return i
+}
+
+func trustDevice(t *testing.T, reg driver.Registry, i *identity.Identity, expiresIn time.Duration) *session.TrustedDevice {
+	d := &session.TrustedDevice{
+		IdentityID: i.ID,
+		IPAddress:  pointerx.String("127.0.0.1"),
+		UserAgent:  pointerx.String("Mozilla/5.0"),
+		ExpiresAt:  time.Now().UTC().Add(expiresIn),
+	}
+	require.NoError(t, reg.SessionPersister().CreateTrustedDevice(context.Background(), d))
+	return d
+}
+
+func TestCompleteSettings(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword)+".enabled", false)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".profile.enabled", false)
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeTrustedDevice)+".enabled", true)
+	conf.MustSet(ctx, config.ViperKeySelfServiceSettingsRequiredAAL, "aal1")
+
+	router := x.NewRouterPublic()
+	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
+
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewRedirSessionEchoTS(t, reg)
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+
+	conf.MustSet(ctx, config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeySecretsDefault, []string{"not-a-secure-session-key"})
+
+	revokeNodes := func(t *testing.T, nodes json.RawMessage) []string {
+		var ids []string
+		for _, n := range gjson.ParseBytes(nodes).Array() {
+			if n.Get("attributes.name").String() == node.TrustedDeviceRevoke {
+				assert.Equal(t, node.TrustedDeviceGroup.String(), n.Get("group").String())
+				ids = append(ids, n.Get("attributes.value").String())
+			}
+		}
+		return ids
+	}
+
+	t.Run("case=lists only valid trusted devices", func(t *testing.T) {
+		id := createIdentity(t, reg)
+		valid := trustDevice(t, reg, id, time.Hour)
+		_ = trustDevice(t, reg, id, -time.Hour)
+		_ = trustDevice(t, reg, createIdentity(t, reg), time.Hour)
+
+		browserClient := testhelpers.NewHTTPClientWithIdentitySessionCookie(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaBrowser(t, browserClient, true, publicTS)
+		nodes, err := json.Marshal(f.Ui.Nodes)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{valid.ID.String()}, revokeNodes(t, nodes))
+	})
+
+	t.Run("case=revokes a trusted device", func(t *testing.T) {
+		for _, spa := range []bool{true, false} {
+			id := createIdentity(t, reg)
+			revoked := trustDevice(t, reg, id, time.Hour)
+			kept := trustDevice(t, reg, id, time.Hour)
+
+			browserClient := testhelpers.NewHTTPClientWithIdentitySessionCookie(t, reg, id)
+			f := testhelpers.InitializeSettingsFlowViaBrowser(t, browserClient, spa, publicTS)
+			values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+			values.Set(node.TrustedDeviceRevoke, revoked.ID.String())
+
+			actual, res := testhelpers.SettingsMakeRequest(t, false, spa, f, browserClient, testhelpers.EncodeFormAsJSON(t, spa, values))
+			assert.Equal(t, http.StatusOK, res.StatusCode, actual)
+			assert.Equal(t, []string{kept.ID.String()}, revokeNodes(t, json.RawMessage(gjson.Get(actual, "ui.nodes").Raw)), actual)
+
+			devices, err := reg.SessionPersister().ListTrustedDevices(ctx, id.ID)
+			require.NoError(t, err)
+			require.Len(t, devices, 1)
+			assert.Equal(t, kept.ID, devices[0].ID)
+		}
+	})
+
+	t.Run("case=can not revoke a device of another identity", func(t *testing.T) {
+		id := createIdentity(t, reg)
+		other := trustDevice(t, reg, createIdentity(t, reg), time.Hour)
+
+		browserClient := testhelpers.NewHTTPClientWithIdentitySessionCookie(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaBrowser(t, browserClient, true, publicTS)
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set(node.TrustedDeviceRevoke, other.ID.String())
+
+		actual, res := testhelpers.SettingsMakeRequest(t, false, true, f, browserClient, testhelpers.EncodeFormAsJSON(t, true, values))
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, actual)
+		assert.Contains(t, gjson.Get(actual, "ui.messages.0.text").String(), "You tried to revoke a trusted device which does not exist.", actual)
+
+		_, err := reg.SessionPersister().GetTrustedDevice(ctx, other.IdentityID, other.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("case=requires a privileged session", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1ns")
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+		})
+
+		id := createIdentity(t, reg)
+		d := trustDevice(t, reg, id, time.Hour)
+
+		browserClient := testhelpers.NewHTTPClientWithIdentitySessionCookie(t, reg, id)
+		f := testhelpers.InitializeSettingsFlowViaBrowser(t, browserClient, true, publicTS)
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set(node.TrustedDeviceRevoke, d.ID.String())
+
+		actual, res := testhelpers.SettingsMakeRequest(t, false, true, f, browserClient, testhelpers.EncodeFormAsJSON(t, true, values))
+		assert.Equal(t, http.StatusForbidden, res.StatusCode, actual)
+		assert.Equal(t, settings.NewFlowNeedsReAuth().ID(), gjson.Get(actual, "error.id").String(), actual)
+
+		_, err := reg.SessionPersister().GetTrustedDevice(ctx, id.ID, d.ID)
+		require.NoError(t, err)
+	})
+}
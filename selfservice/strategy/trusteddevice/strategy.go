@@ -0,0 +1,53 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package trusteddevice
+
+import (
+	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+)
+
+var _ settings.Strategy = new(Strategy)
+
+type strategyDependencies interface {
+	x.LoggingProvider
+	x.CSRFTokenGeneratorProvider
+	x.CSRFProvider
+
+	config.Provider
+
+	continuity.ManagementProvider
+
+	settings.FlowPersistenceProvider
+
+	session.PersistenceProvider
+	session.ManagementProvider
+}
+
+// Strategy lets identities list and revoke the browsers they trust in the settings flow.
+type Strategy struct {
+	d  strategyDependencies
+	hd *decoderx.HTTP
+}
+
+func NewStrategy(d strategyDependencies) *Strategy {
+	return &Strategy{
+		d:  d,
+		hd: decoderx.NewHTTP(),
+	}
+}
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeTrustedDevice
+}
+
+func (s *Strategy) NodeGroup() node.UiNodeGroup {
+	return node.TrustedDeviceGroup
+}
@@ -0,0 +1,265 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package webauthn
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/duo-labs/webauthn/metadata"
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+
+	"github.com/ory/kratos/schema"
+)
+
+// authenticatorMetadata holds the parsed entries of a FIDO Metadata Service (MDS3) blob keyed by AAGUID.
+type authenticatorMetadata map[uuid.UUID]metadata.MetadataTOCPayloadEntry
+
+// metadataCache caches the parsed metadata blobs because they are large and only change when the
+// configuration changes.
+type metadataCache struct {
+	sync.Mutex
+	entries map[string]authenticatorMetadata
+}
+
+func (s *Strategy) authenticatorMetadata(ctx context.Context) (authenticatorMetadata, error) {
+	conf := s.d.Config().WebAuthnAttestation(ctx)
+	if conf.MetadataBlob == "" {
+		return nil, nil
+	}
+
+	s.mds.Lock()
+	defer s.mds.Unlock()
+
+	key := conf.MetadataBlob + "\n" + conf.MetadataTrustAnchor
+	if m, ok := s.mds.entries[key]; ok {
+		return m, nil
+	}
+
+	f := fetcher.NewFetcher(fetcher.WithClient(s.d.HTTPClient(ctx)))
+	blob, err := f.Fetch(conf.MetadataBlob)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to load the WebAuthn metadata blob.").WithDebug(err.Error()))
+	}
+
+	var roots *x509.CertPool
+	if conf.MetadataTrustAnchor != "" {
+		anchor, err := f.Fetch(conf.MetadataTrustAnchor)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to load the WebAuthn metadata trust anchor.").WithDebug(err.Error()))
+		}
+
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(anchor.Bytes()) {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The WebAuthn metadata trust anchor does not contain a PEM encoded certificate."))
+		}
+	}
+
+	m, err := parseMetadataBlob(blob, roots)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.mds.entries == nil {
+		s.mds.entries = make(map[string]authenticatorMetadata)
+	}
+	s.mds.entries[key] = m
+	return m, nil
+}
+
+// parseMetadataBlob decodes a MDS3 blob. If roots is set, the signature of the blob must chain up to one of them.
+func parseMetadataBlob(blob io.Reader, roots *x509.CertPool) (authenticatorMetadata, error) {
+	raw, err := io.ReadAll(blob)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	jws, err := jose.ParseSigned(string(bytes.TrimSpace(raw)))
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to parse the WebAuthn metadata blob.").WithDebug(err.Error()))
+	}
+
+	payload := jws.UnsafePayloadWithoutVerification()
+	if roots != nil {
+		if len(jws.Signatures) != 1 {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The WebAuthn metadata blob must contain exactly one signature."))
+		}
+
+		chains, err := jws.Signatures[0].Protected.Certificates(x509.VerifyOptions{Roots: roots})
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The WebAuthn metadata blob is not signed by the trust anchor.").WithDebug(err.Error()))
+		}
+
+		if payload, err = jws.Verify(chains[0][0].PublicKey); err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The signature of the WebAuthn metadata blob is invalid.").WithDebug(err.Error()))
+		}
+	}
+
+	var toc metadata.MetadataTOCPayload
+	if err := json.Unmarshal(payload, &toc); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the WebAuthn metadata blob.").WithDebug(err.Error()))
+	}
+
+	m := make(authenticatorMetadata, len(toc.Entries))
+	for _, entry := range toc.Entries {
+		// Only FIDO2 authenticators are identified by an AAGUID.
+		if id, err := uuid.FromString(entry.AaGUID); err == nil {
+			m[id] = entry
+		}
+	}
+
+	return m, nil
+}
+
+// authenticatorName returns the name of the authenticator from the metadata blob or an empty string
+// if it is not known.
+func (s *Strategy) authenticatorName(ctx context.Context, aaguid []byte) string {
+	m, err := s.authenticatorMetadata(ctx)
+	if err != nil {
+		s.d.Logger().WithError(err).Warn("Unable to load the WebAuthn metadata blob.")
+		return ""
+	}
+
+	entry, ok := m[uuid.FromBytesOrNil(aaguid)]
+	if !ok {
+		return ""
+	}
+
+	return entry.MetadataStatement.Description
+}
+
+// verifyAttestation enforces the configured attestation policy on a newly created credential.
+//
+// The AAGUID is chosen by the authenticator, so it is only trusted once the attestation certificate chains up
+// to the roots of the metadata statement. The AAGUID lists are therefore only applied after the trust path
+// has been verified.
+func (s *Strategy) verifyAttestation(ctx context.Context, response *protocol.ParsedCredentialCreationData, credential *webauthn.Credential) error {
+	conf := s.d.Config().WebAuthnAttestation(ctx)
+	attestation := response.Response.AttestationObject
+	aaguid := uuid.FromBytesOrNil(credential.Authenticator.AAGUID)
+
+	if conf.Conveyance == protocol.PreferDirectAttestation || conf.Conveyance == "enterprise" {
+		if attestation.Format == "none" || len(attestation.AttStatement) == 0 {
+			return schema.NewWebAuthnAuthenticatorNotAllowedError("the authenticator did not provide an attestation statement")
+		}
+	}
+
+	m, err := s.authenticatorMetadata(ctx)
+	if err != nil {
+		return err
+	} else if m == nil {
+		if len(conf.AllowedAAGUIDs) > 0 || len(conf.DeniedAAGUIDs) > 0 {
+			s.d.Logger().Error("WebAuthn AAGUID lists are configured without a metadata blob, so attestations can not be verified and all authenticators are rejected.")
+			return schema.NewWebAuthnAuthenticatorNotAllowedError("the attestation of the authenticator can not be verified")
+		}
+		return nil
+	}
+
+	entry, ok := m[aaguid]
+	if !ok {
+		return schema.NewWebAuthnAuthenticatorNotAllowedError("the authenticator AAGUID %s is not listed in the metadata", aaguid)
+	}
+
+	if err := verifyAttestationTrustPath(attestation, &entry.MetadataStatement); err != nil {
+		return err
+	}
+
+	if containsAAGUID(conf.DeniedAAGUIDs, aaguid) {
+		return schema.NewWebAuthnAuthenticatorNotAllowedError("the authenticator AAGUID %s is denied", aaguid)
+	}
+
+	if len(conf.AllowedAAGUIDs) > 0 && !containsAAGUID(conf.AllowedAAGUIDs, aaguid) {
+		return schema.NewWebAuthnAuthenticatorNotAllowedError("the authenticator AAGUID %s is not allowed", aaguid)
+	}
+
+	var certified bool
+	for _, report := range entry.StatusReports {
+		if metadata.IsUndesiredAuthenticatorStatus(metadata.AuthenticatorStatus(report.Status)) {
+			return schema.NewWebAuthnAuthenticatorNotAllowedError("the authenticator AAGUID %s has the status %s", aaguid, report.Status)
+		}
+		certified = certified || strings.HasPrefix(report.Status, "FIDO_CERTIFIED")
+	}
+
+	if conf.RequireCertified && !certified {
+		return schema.NewWebAuthnAuthenticatorNotAllowedError("the authenticator AAGUID %s is not FIDO certified", aaguid)
+	}
+
+	return nil
+}
+
+// verifyAttestationTrustPath verifies that the attestation certificate chains up to one of the
+// attestation root certificates of the authenticator's metadata statement.
+func verifyAttestationTrustPath(attestation protocol.AttestationObject, statement *metadata.MetadataStatement) error {
+	x5c, ok := attestation.AttStatement["x5c"].([]interface{})
+	if !ok || len(x5c) == 0 {
+		// Self attestation and "none" attestation do not have a trust path, so the AAGUID could be made up.
+		return schema.NewWebAuthnAuthenticatorNotAllowedError("the authenticator did not provide a verifiable attestation")
+	}
+
+	certificates := make([]*x509.Certificate, len(x5c))
+	for k, raw := range x5c {
+		der, ok := raw.([]byte)
+		if !ok {
+			return schema.NewWebAuthnAuthenticatorNotAllowedError("the attestation certificate chain is malformed")
+		}
+
+		certificate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return schema.NewWebAuthnAuthenticatorNotAllowedError("the attestation certificate chain is malformed")
+		}
+		certificates[k] = certificate
+	}
+
+	roots, intermediates := x509.NewCertPool(), x509.NewCertPool()
+	for _, encoded := range statement.AttestationRootCertificates {
+		der, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			// Some metadata statements contain PEM encoded certificates.
+			if block, _ := pem.Decode([]byte(encoded)); block != nil {
+				der = block.Bytes
+			}
+		}
+
+		if root, err := x509.ParseCertificate(der); err == nil {
+			roots.AddCert(root)
+		}
+	}
+
+	for _, intermediate := range certificates[1:] {
+		intermediates.AddCert(intermediate)
+	}
+
+	if _, err := certificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return schema.NewWebAuthnAuthenticatorNotAllowedError("the attestation certificate is not trusted by the metadata: %s", err)
+	}
+
+	return nil
+}
+
+func containsAAGUID(list []string, aaguid uuid.UUID) bool {
+	for _, entry := range list {
+		if uuid.FromStringOrNil(entry) == aaguid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package webauthn_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/duo-labs/webauthn/protocol"
+	webauthnlib "github.com/duo-labs/webauthn/webauthn"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/strategy/webauthn"
+)
+
+func newCertificate(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certificate, key
+}
+
+func TestAttestationPolicy(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	enableWebAuthn(conf)
+	s := webauthn.NewStrategy(reg)
+
+	certified := uuid.Must(uuid.NewV4())
+	revoked := uuid.Must(uuid.NewV4())
+	uncertified := uuid.Must(uuid.NewV4())
+
+	attestationRoot, attestationRootKey := newCertificate(t, "attestation root", nil, nil)
+	attestationLeaf, _ := newCertificate(t, "attestation", attestationRoot, attestationRootKey)
+	untrustedRoot, untrustedRootKey := newCertificate(t, "untrusted root", nil, nil)
+	untrustedLeaf, _ := newCertificate(t, "untrusted", untrustedRoot, untrustedRootKey)
+
+	mdsRoot, mdsRootKey := newCertificate(t, "mds root", nil, nil)
+	mdsSigner, mdsSignerKey := newCertificate(t, "mds signer", mdsRoot, mdsRootKey)
+
+	entry := func(aaguid uuid.UUID, description string, statuses ...string) map[string]interface{} {
+		reports := make([]map[string]interface{}, len(statuses))
+		for k, status := range statuses {
+			reports[k] = map[string]interface{}{"status": status}
+		}
+		return map[string]interface{}{
+			"aaguid":        aaguid.String(),
+			"statusReports": reports,
+			"metadataStatement": map[string]interface{}{
+				"aaguid":                      aaguid.String(),
+				"description":                 description,
+				"attestationRootCertificates": []string{base64.StdEncoding.EncodeToString(attestationRoot.Raw)},
+			},
+		}
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"no":         1,
+		"nextUpdate": "2099-01-01",
+		"entries": []interface{}{
+			entry(certified, "Certified Key", "FIDO_CERTIFIED_L1"),
+			entry(revoked, "Revoked Key", "FIDO_CERTIFIED", "REVOKED"),
+			entry(uncertified, "Uncertified Key", "NOT_FIDO_CERTIFIED"),
+		},
+	})
+	require.NoError(t, err)
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: mdsSignerKey},
+		(&jose.SignerOptions{}).WithHeader("x5c", []string{base64.StdEncoding.EncodeToString(mdsSigner.Raw)}),
+	)
+	require.NoError(t, err)
+	signed, err := signer.Sign(payload)
+	require.NoError(t, err)
+	blob, err := signed.CompactSerialize()
+	require.NoError(t, err)
+
+	encodePEM := func(c *x509.Certificate) string {
+		return "base64://" + base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw}))
+	}
+
+	setPolicy := func(t *testing.T, values map[string]interface{}) {
+		for k, v := range values {
+			conf.MustSet(ctx, k, v)
+		}
+		t.Cleanup(func() {
+			for k := range values {
+				conf.MustSet(ctx, k, nil)
+			}
+		})
+	}
+
+	verify := func(aaguid uuid.UUID, format string, x5c ...*x509.Certificate) error {
+		statement := map[string]interface{}{}
+		if len(x5c) > 0 {
+			chain := make([]interface{}, len(x5c))
+			for k, c := range x5c {
+				chain[k] = c.Raw
+			}
+			statement["x5c"] = chain
+		}
+
+		return s.VerifyAttestationForTest(ctx, &protocol.ParsedCredentialCreationData{
+			Response: protocol.ParsedAttestationResponse{
+				AttestationObject: protocol.AttestationObject{Format: format, AttStatement: statement},
+			},
+		}, &webauthnlib.Credential{Authenticator: webauthnlib.Authenticator{AAGUID: aaguid.Bytes()}})
+	}
+
+	assertNotAllowed := func(t *testing.T, err error) {
+		var e *schema.ValidationError
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, "#/webauthn_register", e.InstancePtr)
+	}
+
+	t.Run("case=accepts any authenticator by default", func(t *testing.T) {
+		require.NoError(t, verify(uuid.Nil, "none"))
+	})
+
+	t.Run("case=requires an attestation statement for direct conveyance", func(t *testing.T) {
+		setPolicy(t, map[string]interface{}{config.ViperKeyWebAuthnAttestationConveyance: "direct"})
+		assertNotAllowed(t, verify(certified, "none"))
+		require.NoError(t, verify(certified, "packed", attestationLeaf))
+	})
+
+	t.Run("case=rejects all authenticators if the AAGUID lists can not be verified", func(t *testing.T) {
+		setPolicy(t, map[string]interface{}{config.ViperKeyWebAuthnAttestationAllowedAAGUIDs: []string{certified.String()}})
+		assertNotAllowed(t, verify(certified, "none"))
+		assertNotAllowed(t, verify(certified, "packed", attestationLeaf))
+	})
+
+	t.Run("case=verifies against the metadata blob", func(t *testing.T) {
+		setPolicy(t, map[string]interface{}{
+			config.ViperKeyWebAuthnAttestationMetadataBlob:        "base64://" + base64.StdEncoding.EncodeToString([]byte(blob)),
+			config.ViperKeyWebAuthnAttestationMetadataTrustAnchor: encodePEM(mdsRoot),
+		})
+
+		require.NoError(t, verify(certified, "packed", attestationLeaf))
+		require.NoError(t, verify(uncertified, "packed", attestationLeaf))
+		assertNotAllowed(t, verify(certified, "packed", untrustedLeaf))
+		assertNotAllowed(t, verify(revoked, "packed", attestationLeaf))
+		assertNotAllowed(t, verify(uuid.Must(uuid.NewV4()), "packed", attestationLeaf))
+
+		assert.Equal(t, "Certified Key", s.AuthenticatorNameForTest(ctx, certified.Bytes()))
+		assert.Empty(t, s.AuthenticatorNameForTest(ctx, uuid.Nil.Bytes()))
+
+		t.Run("case=rejects attestations without a trust path", func(t *testing.T) {
+			assertNotAllowed(t, verify(certified, "none"))
+			assertNotAllowed(t, verify(certified, "packed"))
+		})
+
+		t.Run("case=enforces the AAGUID lists", func(t *testing.T) {
+			setPolicy(t, map[string]interface{}{
+				config.ViperKeyWebAuthnAttestationAllowedAAGUIDs: []string{certified.String(), revoked.String()},
+				config.ViperKeyWebAuthnAttestationDeniedAAGUIDs:  []string{revoked.String()},
+			})
+			require.NoError(t, verify(certified, "packed", attestationLeaf))
+			assertNotAllowed(t, verify(certified, "none"))
+			assertNotAllowed(t, verify(certified, "packed", untrustedLeaf))
+			assertNotAllowed(t, verify(revoked, "packed", attestationLeaf))
+			assertNotAllowed(t, verify(uncertified, "packed", attestationLeaf))
+		})
+
+		t.Run("case=requires certification", func(t *testing.T) {
+			setPolicy(t, map[string]interface{}{config.ViperKeyWebAuthnAttestationMetadataRequireCertified: true})
+			require.NoError(t, verify(certified, "packed", attestationLeaf))
+			assertNotAllowed(t, verify(uncertified, "packed", attestationLeaf))
+		})
+	})
+
+	t.Run("case=rejects a metadata blob which is not signed by the trust anchor", func(t *testing.T) {
+		setPolicy(t, map[string]interface{}{
+			config.ViperKeyWebAuthnAttestationMetadataBlob:        "base64://" + base64.StdEncoding.EncodeToString([]byte(blob)),
+			config.ViperKeyWebAuthnAttestationMetadataTrustAnchor: encodePEM(untrustedRoot),
+		})
+
+		var e *herodot.DefaultError
+		require.ErrorAs(t, verify(certified, "packed", attestationLeaf), &e)
+		assert.Contains(t, e.Reason(), "not signed by the trust anchor")
+	})
+}
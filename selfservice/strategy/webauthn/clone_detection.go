@@ -0,0 +1,127 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package webauthn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/duo-labs/webauthn/webauthn"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/x"
+)
+
+// updateSignCount persists the signature counter reported by the authenticator and enforces the
+// clone detection policy if the counter did not increase.
+func (s *Strategy) updateSignCount(r *http.Request, i *identity.Identity, c *identity.Credentials, o *identity.CredentialsWebAuthnConfig, credential *webauthn.Credential, signCount uint32) error {
+	ctx := r.Context()
+
+	var stored *identity.CredentialWebAuthn
+	for k := range o.Credentials {
+		if bytes.Equal(o.Credentials[k].ID, credential.ID) {
+			stored = &o.Credentials[k]
+			break
+		}
+	}
+
+	if stored == nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to find the WebAuthn credential which was used to sign in."))
+	}
+
+	var changed bool
+	policy := s.d.Config().WebAuthnCloneDetection(ctx)
+	if credential.Authenticator.CloneWarning && policy != config.WebAuthnCloneDetectionIgnore {
+		if !stored.Authenticator.CloneWarning {
+			stored.Authenticator.CloneWarning = true
+			changed = true
+
+			if err := s.notifyCloneWarning(ctx, i, stored); err != nil {
+				return err
+			}
+
+			s.d.Audit().
+				WithRequest(r).
+				WithField("identity_id", i.ID).
+				WithField("policy", policy).
+				Warn("The signature counter of a WebAuthn credential did not increase which indicates that the security key was cloned.")
+		}
+
+		if policy == config.WebAuthnCloneDetectionReject {
+			if changed {
+				if err := s.persistCredentials(ctx, i, c, o); err != nil {
+					return err
+				}
+			}
+			return errors.WithStack(schema.NewWebAuthnCloneDetectedError())
+		}
+	}
+
+	// The reported counter is persisted even if the credential was flagged, so that the next login is
+	// compared against the latest counter instead of the one from before the warning.
+	if stored.Authenticator.SignCount != signCount {
+		stored.Authenticator.SignCount = signCount
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return s.persistCredentials(ctx, i, c, o)
+}
+
+func (s *Strategy) persistCredentials(ctx context.Context, i *identity.Identity, c *identity.Credentials, o *identity.CredentialsWebAuthnConfig) error {
+	encoded, err := json.Marshal(o)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to encode the WebAuthn credentials.").WithDebug(err.Error()))
+	}
+
+	c.Config = encoded
+	i.SetCredentials(s.ID(), *c)
+
+	if err := s.d.PrivilegedIdentityPool().UpdateIdentity(ctx, i); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to update identity.").WithDebug(err.Error()))
+	}
+
+	return nil
+}
+
+// notifyCloneWarning informs the verified email addresses of the identity that one of its security keys
+// was flagged as cloned.
+func (s *Strategy) notifyCloneWarning(ctx context.Context, i *identity.Identity, credential *identity.CredentialWebAuthn) error {
+	model, err := x.StructToMap(i)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.d.Courier(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, address := range i.VerifiableAddresses {
+		if !address.Verified || address.Via != identity.VerifiableAddressTypeEmail {
+			continue
+		}
+
+		if _, err := c.QueueEmail(ctx, email.NewWebAuthnCloneWarning(s.d, &email.WebAuthnCloneWarningModel{
+			To:          address.Value,
+			DisplayName: credential.DisplayName,
+			Identity:    model,
+		})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
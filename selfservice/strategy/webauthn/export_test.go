@@ -0,0 +1,19 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package webauthn
+
+import (
+	"context"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
+)
+
+func (s *Strategy) VerifyAttestationForTest(ctx context.Context, response *protocol.ParsedCredentialCreationData, credential *webauthn.Credential) error {
+	return s.verifyAttestation(ctx, response, credential)
+}
+
+func (s *Strategy) AuthenticatorNameForTest(ctx context.Context, aaguid []byte) string {
+	return s.authenticatorName(ctx, aaguid)
+}
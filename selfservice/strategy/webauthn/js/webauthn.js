@@ -27,38 +27,80 @@
       .replace(/=/g, '');
   }
 
+  // Holds the abort controller of a pending conditional mediation request. Only one
+  // WebAuthn request may be pending at a time, so it is aborted before a modal login.
+  let __oryWebAuthnConditionalAbort = null
+
+  function __oryWebAuthnPrepareLogin(opt) {
+    opt.publicKey.challenge = __oryWebAuthnBufferDecode(opt.publicKey.challenge);
+    if (opt.publicKey.allowCredentials) {
+      opt.publicKey.allowCredentials = opt.publicKey.allowCredentials.map(function (value) {
+        return {
+          ...value,
+          id: __oryWebAuthnBufferDecode(value.id)
+        }
+      });
+    }
+    return opt
+  }
+
+  function __oryWebAuthnSubmitLogin(credential, resultQuerySelector, triggerQuerySelector) {
+    document.querySelector(resultQuerySelector).value = JSON.stringify({
+      id: credential.id,
+      rawId: __oryWebAuthnBufferEncode(credential.rawId),
+      type: credential.type,
+      response: {
+        authenticatorData: __oryWebAuthnBufferEncode(credential.response.authenticatorData),
+        clientDataJSON: __oryWebAuthnBufferEncode(credential.response.clientDataJSON),
+        signature: __oryWebAuthnBufferEncode(credential.response.signature),
+        userHandle: __oryWebAuthnBufferEncode(credential.response.userHandle),
+      },
+    })
+
+    document.querySelector(triggerQuerySelector).closest('form').submit()
+  }
+
   function __oryWebAuthnLogin(opt, resultQuerySelector = '*[name="webauthn_login"]', triggerQuerySelector = '*[name="webauthn_login_trigger"]') {
     if (!window.PublicKeyCredential) {
       alert('This browser does not support WebAuthn!');
     }
 
-    opt.publicKey.challenge = __oryWebAuthnBufferDecode(opt.publicKey.challenge);
-    opt.publicKey.allowCredentials = opt.publicKey.allowCredentials.map(function (value) {
-      return {
-        ...value,
-        id: __oryWebAuthnBufferDecode(value.id)
-      }
-    });
-
-    navigator.credentials.get(opt).then(function (credential) {
-      document.querySelector(resultQuerySelector).value = JSON.stringify({
-        id: credential.id,
-        rawId: __oryWebAuthnBufferEncode(credential.rawId),
-        type: credential.type,
-        response: {
-          authenticatorData: __oryWebAuthnBufferEncode(credential.response.authenticatorData),
-          clientDataJSON: __oryWebAuthnBufferEncode(credential.response.clientDataJSON),
-          signature: __oryWebAuthnBufferEncode(credential.response.signature),
-          userHandle: __oryWebAuthnBufferEncode(credential.response.userHandle),
-        },
-      })
+    if (__oryWebAuthnConditionalAbort) {
+      __oryWebAuthnConditionalAbort.abort()
+      __oryWebAuthnConditionalAbort = null
+    }
 
-      document.querySelector(triggerQuerySelector).closest('form').submit()
+    navigator.credentials.get(__oryWebAuthnPrepareLogin(opt)).then(function (credential) {
+      __oryWebAuthnSubmitLogin(credential, resultQuerySelector, triggerQuerySelector)
     }).catch((err) => {
       alert(err)
     })
   }
 
+  function __oryWebAuthnConditionalLogin(opt, resultQuerySelector = '*[name="webauthn_login"]', triggerQuerySelector = '*[name="webauthn_login_trigger"]') {
+    if (!window.PublicKeyCredential || !PublicKeyCredential.isConditionalMediationAvailable) {
+      return
+    }
+
+    PublicKeyCredential.isConditionalMediationAvailable().then(function (available) {
+      if (!available) {
+        return
+      }
+
+      __oryWebAuthnConditionalAbort = new AbortController()
+      return navigator.credentials.get({
+        ...__oryWebAuthnPrepareLogin(opt),
+        mediation: 'conditional',
+        signal: __oryWebAuthnConditionalAbort.signal,
+      }).then(function (credential) {
+        __oryWebAuthnSubmitLogin(credential, resultQuerySelector, triggerQuerySelector)
+      })
+    }).catch(() => {
+      // Conditional mediation is an enhancement. It is aborted when the user starts a modal login
+      // and fails silently otherwise, so the regular login remains usable.
+    })
+  }
+
   function __oryWebAuthnRegistration(opt, resultQuerySelector = '*[name="webauthn_register"]', triggerQuerySelector = '*[name="webauthn_register_trigger"]') {
     if (!window.PublicKeyCredential) {
       alert('This browser does not support WebAuthn!');
@@ -94,6 +136,7 @@
   }
 
   window['__oryWebAuthnLogin'] = __oryWebAuthnLogin
+  window['__oryWebAuthnConditionalLogin'] = __oryWebAuthnConditionalLogin
   window['__oryWebAuthnRegistration'] = __oryWebAuthnRegistration
   window['__oryWebAuthnInitialized'] = true
 })()
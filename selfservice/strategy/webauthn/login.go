@@ -4,6 +4,7 @@
 package webauthn
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -72,6 +73,31 @@ func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.Au
 	return nil
 }
 
+// PopulateLoginMethodIdentifierFirstIdentification offers passkeys, which do not require an identifier.
+func (s *Strategy) PopulateLoginMethodIdentifierFirstIdentification(r *http.Request, sr *login.Flow) error {
+	if sr.Type != flow.TypeBrowser || !s.d.Config().WebAuthnForPasswordless(r.Context()) {
+		return nil
+	}
+
+	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	return s.populateLoginMethodForDiscoverable(r, sr)
+}
+
+// PopulateLoginMethodIdentifierFirstCredentials starts the login with the passwordless security keys of the identity.
+func (s *Strategy) PopulateLoginMethodIdentifierFirstCredentials(r *http.Request, sr *login.Flow, identifier string, i *identity.Identity) error {
+	if sr.Type != flow.TypeBrowser || !s.d.Config().WebAuthnForPasswordless(r.Context()) || i == nil {
+		return nil
+	}
+
+	if err := s.populateLoginMethod(r, sr, i, text.NewInfoSelfServiceLoginContinue(), identity.AuthenticatorAssuranceLevel1); errors.Is(err, ErrNoCredentials) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *Strategy) populateLoginMethodForPasswordless(r *http.Request, sr *login.Flow) error {
 	if sr.IsForced() {
 		identifier, id, _ := flowhelpers.GuessForcedLoginIdentifier(r, s.d, sr, s.ID())
@@ -91,8 +117,54 @@ func (s *Strategy) populateLoginMethodForPasswordless(r *http.Request, sr *login
 	}
 
 	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-	sr.UI.SetNode(node.NewInputField("identifier", "", node.DefaultGroup, node.InputAttributeTypeText, node.WithRequiredInputAttribute).WithMetaLabel(text.NewInfoNodeLabelID()))
+	sr.UI.SetNode(node.NewInputField("identifier", "", node.DefaultGroup, node.InputAttributeTypeText, node.WithRequiredInputAttribute, node.WithInputAttributes(func(a *node.InputAttributes) {
+		a.Autocomplete = node.InputAttributeAutocompleteUsernameWebAuthn
+	})).WithMetaLabel(text.NewInfoNodeLabelID()))
 	sr.UI.GetNodes().Append(node.NewInputField("method", "webauthn", node.WebAuthnGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoLoginPasswordlessWebAuthn()))
+
+	return s.populateLoginMethodForDiscoverable(r, sr)
+}
+
+// populateLoginMethodForDiscoverable adds a passkey login which does not require an identifier. The assertion
+// is started with an empty allow-list so that the authenticator offers all discoverable credentials of
+// the relying party, and the identity is resolved from the returned user handle.
+func (s *Strategy) populateLoginMethodForDiscoverable(r *http.Request, sr *login.Flow) error {
+	web, err := s.newWebAuthn(r.Context())
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initiate WebAuth.").WithDebug(err.Error()))
+	}
+
+	challenge, err := protocol.CreateChallenge()
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initiate WebAuth login.").WithDebug(err.Error()))
+	}
+
+	options := protocol.CredentialAssertion{Response: protocol.PublicKeyCredentialRequestOptions{
+		Challenge:        challenge,
+		Timeout:          web.Config.Timeout,
+		RelyingPartyID:   web.Config.RPID,
+		UserVerification: web.Config.AuthenticatorSelection.UserVerification,
+	}}
+
+	// The session data intentionally does not contain a user ID. This is how we tell discoverable logins apart.
+	sr.InternalContext, err = sjson.SetBytes(sr.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeySessionData), &webauthn.SessionData{
+		Challenge:        base64.RawURLEncoding.EncodeToString(challenge),
+		UserVerification: options.Response.UserVerification,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	injectWebAuthnOptions, err := json.Marshal(options)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	sr.UI.Nodes.Upsert(NewWebAuthnScript(urlx.AppendPaths(s.d.Config().SelfPublicURL(r.Context()), webAuthnRoute).String(), jsOnLoad))
+	sr.UI.SetNode(NewWebAuthnPasskeyLoginTrigger(string(injectWebAuthnOptions)).
+		WithMetaLabel(text.NewInfoSelfServiceLoginPasskey()))
+	sr.UI.Nodes.Upsert(NewWebAuthnLoginInput())
+
 	return nil
 }
 
@@ -172,9 +244,8 @@ func (s *Strategy) handleLoginError(r *http.Request, f *login.Flow, err error) e
 //
 // swagger:model updateLoginFlowWithWebAuthnMethod
 type updateLoginFlowWithWebAuthnMethod struct {
-	// Identifier is the email or username of the user trying to log in.
-	//
-	// required: true
+	// Identifier is the email or username of the user trying to log in. It can be omitted when signing
+	// in with a passkey, because the identity is then resolved from the user handle of the WebAuthn response.
 	Identifier string `json:"identifier"`
 
 	// Method should be set to "webAuthn" when logging in using the WebAuthn strategy.
@@ -235,6 +306,10 @@ func (s *Strategy) loginPasswordless(w http.ResponseWriter, r *http.Request, f *
 		return nil, s.handleLoginError(r, f, err)
 	}
 
+	if len(p.Login) > 0 && s.isDiscoverableLogin(f) {
+		return s.loginDiscoverable(w, r, f, p)
+	}
+
 	if p.Identifier == "" {
 		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrBadRequest.WithReason("identifier is required")))
 	}
@@ -279,6 +354,30 @@ func (s *Strategy) loginPasswordless(w http.ResponseWriter, r *http.Request, f *
 	return s.loginAuthenticate(w, r, f, i.ID, p, identity.AuthenticatorAssuranceLevel1)
 }
 
+func (s *Strategy) isDiscoverableLogin(f *login.Flow) bool {
+	sessionData := gjson.GetBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeySessionData))
+	return sessionData.IsObject() && len(sessionData.Get("user_id").String()) == 0
+}
+
+func (s *Strategy) loginDiscoverable(w http.ResponseWriter, r *http.Request, f *login.Flow, p *updateLoginFlowWithWebAuthnMethod) (*identity.Identity, error) {
+	webAuthnResponse, err := protocol.ParseCredentialRequestResponseBody(strings.NewReader(p.Login))
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to parse WebAuthn response.").WithDebug(err.Error())))
+	}
+
+	if len(webAuthnResponse.Response.UserHandle) == 0 {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrBadRequest.WithReason("The WebAuthn response does not contain a user handle. Please use a passkey or sign in with your identifier.")))
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().FindByWebAuthnUserHandle(r.Context(), webAuthnResponse.Response.UserHandle)
+	if err != nil {
+		time.Sleep(x.RandomDelay(s.d.Config().HasherArgon2(r.Context()).ExpectedDuration, s.d.Config().HasherArgon2(r.Context()).ExpectedDeviation))
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewNoWebAuthnCredentials()))
+	}
+
+	return s.loginAuthenticate(w, r, f, i.ID, p, identity.AuthenticatorAssuranceLevel1)
+}
+
 func (s *Strategy) loginAuthenticate(_ http.ResponseWriter, r *http.Request, f *login.Flow, identityID uuid.UUID, p *updateLoginFlowWithWebAuthnMethod, aal identity.AuthenticatorAssuranceLevel) (*identity.Identity, error) {
 	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), identityID)
 	if err != nil {
@@ -310,15 +409,25 @@ func (s *Strategy) loginAuthenticate(_ http.ResponseWriter, r *http.Request, f *
 		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected WebAuthN in internal context to be an object but got: %s", err)))
 	}
 
+	if len(webAuthnSess.UserID) == 0 {
+		// Discoverable logins are not bound to a user when they are initiated.
+		webAuthnSess.UserID = o.UserHandle
+	}
+
 	webAuthCreds := o.Credentials.ToWebAuthnFiltered(aal)
 	if f.IsForced() {
 		webAuthCreds = o.Credentials.ToWebAuthn()
 	}
 
-	if _, err := web.ValidateLogin(&wrappedUser{id: o.UserHandle, c: webAuthCreds}, webAuthnSess, webAuthnResponse); err != nil {
+	credential, err := web.ValidateLogin(&wrappedUser{id: o.UserHandle, c: webAuthCreds}, webAuthnSess, webAuthnResponse)
+	if err != nil {
 		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewWebAuthnVerifierWrongError("#/")))
 	}
 
+	if err := s.updateSignCount(r, i, c, &o, credential, webAuthnResponse.Response.AuthenticatorData.Counter); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
 	// Remove the WebAuthn URL from the internal context now that it is set!
 	f.InternalContext, err = sjson.DeleteBytes(f.InternalContext, flow.PrefixInternalContextKey(s.ID(), InternalContextKeySessionData))
 	if err != nil {
@@ -28,7 +28,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
 
+	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
@@ -290,7 +292,21 @@ func TestCompleteLogin(t *testing.T) {
 		t.Run("case=webauthn button exists", func(t *testing.T) {
 			client := testhelpers.NewClientWithCookies(t)
 			f := testhelpers.InitializeLoginFlowViaBrowser(t, client, publicTS, false, true, false, false)
-			testhelpers.SnapshotTExcept(t, f.Ui.Nodes, []string{"0.attributes.value"})
+			testhelpers.SnapshotTExcept(t, f.Ui.Nodes, []string{
+				"0.attributes.value",
+				"2.attributes.onclick",
+				"2.attributes.onload",
+				"4.attributes.nonce",
+				"4.attributes.src",
+			})
+
+			nodes, err := json.Marshal(f.Ui.Nodes)
+			require.NoError(t, err)
+			assert.Equal(t, "username webauthn", gjson.GetBytes(nodes, "#(attributes.name==identifier).attributes.autocomplete").String(), "%s", nodes)
+			trigger := gjson.GetBytes(nodes, "#(attributes.name==webauthn_login_trigger).attributes")
+			assert.Contains(t, trigger.Get("onclick").String(), "window.__oryWebAuthnLogin(", "%s", nodes)
+			assert.Contains(t, trigger.Get("onload").String(), "window.__oryWebAuthnConditionalLogin(", "%s", nodes)
+			assert.NotContains(t, trigger.Get("onclick").String(), "allowCredentials", "discoverable logins must not restrict the credentials")
 		})
 
 		t.Run("case=webauthn shows error if user tries to sign in but no such user exists", func(t *testing.T) {
@@ -456,6 +472,217 @@ func TestCompleteLogin(t *testing.T) {
 				run(t, true)
 			})
 		})
+
+		t.Run("case=detects cloned security keys", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeySessionWhoAmIAAL, "aal1")
+			conf.MustSet(ctx, config.ViperKeyCourierSMTPURL, "smtp://foo@bar@dev.null/")
+			t.Cleanup(func() {
+				conf.MustSet(ctx, config.ViperKeyWebAuthnCloneDetection, nil)
+			})
+
+			// The fixture response reports a signature counter of 10.
+			withSignCount := func(t *testing.T, count int, cloneWarning bool) []byte {
+				c, err := sjson.SetBytes(loginFixtureSuccessV1PasswordlessCredentials, "credentials.0.authenticator.sign_count", count)
+				require.NoError(t, err)
+				c, err = sjson.SetBytes(c, "credentials.0.authenticator.clone_warning", cloneWarning)
+				require.NoError(t, err)
+				return c
+			}
+
+			run := func(t *testing.T, spa bool, credentials []byte) (*identity.Identity, string) {
+				id := createIdentityWithWebAuthn(t, identity.Credentials{Config: credentials, Version: 1})
+				id.VerifiableAddresses = []identity.VerifiableAddress{{
+					Value:      loginFixtureSuccessEmail,
+					Via:        identity.VerifiableAddressTypeEmail,
+					Verified:   true,
+					Status:     identity.VerifiableAddressStatusCompleted,
+					IdentityID: id.ID,
+				}}
+				require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(context.Background(), id))
+
+				body, _, _ := submitWebAuthnLoginWithClient(t, spa, id, loginFixtureSuccessV1PasswordlessContext, testhelpers.NewClientWithCookies(t), func(values url.Values) {
+					values.Set("identifier", loginFixtureSuccessEmail)
+					values.Set(node.WebAuthnLogin, string(loginFixtureSuccessV1PasswordlessResponse))
+				}, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel1))
+				return id, body
+			}
+
+			storedAuthenticator := func(t *testing.T, id *identity.Identity) gjson.Result {
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), id.ID)
+				require.NoError(t, err)
+				c, ok := actual.GetCredentials(identity.CredentialsTypeWebAuthn)
+				require.True(t, ok)
+				return gjson.GetBytes(c.Config, "credentials.0.authenticator")
+			}
+
+			expectCloneWarning := func(t *testing.T) {
+				messages, err := reg.CourierPersister().NextMessages(context.Background(), 10)
+				require.NoError(t, err)
+				require.Len(t, messages, 1)
+				assert.Equal(t, loginFixtureSuccessEmail, messages[0].Recipient)
+				assert.Equal(t, courier.TypeWebAuthnCloneWarning, messages[0].TemplateType)
+				assert.Contains(t, messages[0].Body, "some-key")
+			}
+
+			expectNoMessages := func(t *testing.T) {
+				_, err := reg.CourierPersister().NextMessages(context.Background(), 10)
+				require.ErrorIs(t, err, courier.ErrQueueEmpty)
+			}
+
+			for _, f := range []string{"browser", "spa"} {
+				spa := f == "spa"
+				prefix := ""
+				if spa {
+					prefix = "session."
+				}
+
+				t.Run("type="+f, func(t *testing.T) {
+					t.Run("case=persists the signature counter", func(t *testing.T) {
+						conf.MustSet(ctx, config.ViperKeyWebAuthnCloneDetection, config.WebAuthnCloneDetectionReject)
+						id, body := run(t, spa, withSignCount(t, 3, false))
+						assert.True(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+
+						actual := storedAuthenticator(t, id)
+						assert.EqualValues(t, 10, actual.Get("sign_count").Int(), "%s", actual)
+						assert.False(t, actual.Get("clone_warning").Bool(), "%s", actual)
+						expectNoMessages(t)
+					})
+
+					t.Run("policy=ignore", func(t *testing.T) {
+						conf.MustSet(ctx, config.ViperKeyWebAuthnCloneDetection, config.WebAuthnCloneDetectionIgnore)
+						id, body := run(t, spa, withSignCount(t, 20, false))
+						assert.True(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+
+						actual := storedAuthenticator(t, id)
+						assert.EqualValues(t, 10, actual.Get("sign_count").Int(), "%s", actual)
+						assert.False(t, actual.Get("clone_warning").Bool(), "%s", actual)
+						expectNoMessages(t)
+					})
+
+					t.Run("policy=warn", func(t *testing.T) {
+						conf.MustSet(ctx, config.ViperKeyWebAuthnCloneDetection, config.WebAuthnCloneDetectionWarn)
+						id, body := run(t, spa, withSignCount(t, 20, false))
+						assert.True(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+
+						actual := storedAuthenticator(t, id)
+						assert.EqualValues(t, 10, actual.Get("sign_count").Int(), "%s", actual)
+						assert.True(t, actual.Get("clone_warning").Bool(), "%s", actual)
+						expectCloneWarning(t)
+					})
+
+					t.Run("policy=warn/case=flagged keys keep persisting the signature counter", func(t *testing.T) {
+						conf.MustSet(ctx, config.ViperKeyWebAuthnCloneDetection, config.WebAuthnCloneDetectionWarn)
+						id, body := run(t, spa, withSignCount(t, 3, true))
+						assert.True(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+
+						actual := storedAuthenticator(t, id)
+						assert.EqualValues(t, 10, actual.Get("sign_count").Int(), "%s", actual)
+						assert.True(t, actual.Get("clone_warning").Bool(), "%s", actual)
+						expectNoMessages(t)
+					})
+
+					t.Run("policy=reject", func(t *testing.T) {
+						conf.MustSet(ctx, config.ViperKeyWebAuthnCloneDetection, config.WebAuthnCloneDetectionReject)
+						id, body := run(t, spa, withSignCount(t, 20, false))
+						assert.False(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+						assert.Equal(t, text.NewErrorValidationWebAuthnCloneDetected().Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+
+						actual := storedAuthenticator(t, id)
+						assert.True(t, actual.Get("clone_warning").Bool(), "%s", actual)
+						expectCloneWarning(t)
+					})
+
+					t.Run("policy=reject/case=flagged keys can not be used even if the counter increases", func(t *testing.T) {
+						conf.MustSet(ctx, config.ViperKeyWebAuthnCloneDetection, config.WebAuthnCloneDetectionReject)
+						_, body := run(t, spa, withSignCount(t, 3, true))
+						assert.False(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+						assert.Equal(t, text.NewErrorValidationWebAuthnCloneDetected().Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+						expectNoMessages(t)
+					})
+				})
+			}
+		})
+
+		t.Run("case=succeeds with usernameless passkey login", func(t *testing.T) {
+			// A discoverable login is started without a user and without allowed credentials.
+			discoverableContext, err := sjson.DeleteBytes(loginFixtureSuccessV1PasswordlessContext, "webauthn_session_data.user_id")
+			require.NoError(t, err)
+			discoverableContext, err = sjson.DeleteBytes(discoverableContext, "webauthn_session_data.allowed_credentials")
+			require.NoError(t, err)
+
+			// The user handle is not covered by the signature, which allows us to replay the fixture.
+			discoverableResponse, err := sjson.SetBytes(loginFixtureSuccessV1PasswordlessResponse, "response.userHandle", "9dG2o6S7RPeRYfT4d-_prQ")
+			require.NoError(t, err)
+
+			run := func(t *testing.T, spa bool) {
+				conf.MustSet(ctx, config.ViperKeySessionWhoAmIAAL, "aal1")
+				id := createIdentityWithWebAuthn(t, identity.Credentials{
+					Config:  loginFixtureSuccessV1PasswordlessCredentials,
+					Version: 1,
+				})
+
+				browserClient := testhelpers.NewClientWithCookies(t)
+				body, res, _ := submitWebAuthnLoginWithClient(t, spa, id, discoverableContext, browserClient, func(values url.Values) {
+					values.Del("identifier")
+					values.Del("method")
+					values.Set(node.WebAuthnLogin, string(discoverableResponse))
+				}, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel1))
+
+				prefix := ""
+				if spa {
+					assert.Contains(t, res.Request.URL.String(), publicTS.URL+login.RouteSubmitFlow)
+					prefix = "session."
+				} else {
+					assert.Contains(t, res.Request.URL.String(), redirTS.URL)
+				}
+
+				assert.True(t, gjson.Get(body, prefix+"active").Bool(), "%s", body)
+				assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, gjson.Get(body, prefix+"authenticator_assurance_level").String(), "%s", body)
+				assert.EqualValues(t, id.ID.String(), gjson.Get(body, prefix+"identity.id").String(), "%s", body)
+			}
+
+			t.Run("type=browser", func(t *testing.T) {
+				run(t, false)
+			})
+
+			t.Run("type=spa", func(t *testing.T) {
+				run(t, true)
+			})
+
+			t.Run("case=fails if the user handle is unknown", func(t *testing.T) {
+				unknownResponse, err := sjson.SetBytes(discoverableResponse, "response.userHandle", "dW5rbm93bg")
+				require.NoError(t, err)
+
+				id := createIdentityWithWebAuthn(t, identity.Credentials{
+					Config:  loginFixtureSuccessV1PasswordlessCredentials,
+					Version: 1,
+				})
+
+				body, res, _ := submitWebAuthnLoginWithClient(t, true, id, discoverableContext, testhelpers.NewClientWithCookies(t), func(values url.Values) {
+					values.Del("identifier")
+					values.Del("method")
+					values.Set(node.WebAuthnLogin, string(unknownResponse))
+				}, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel1))
+
+				checkURL(t, false, res)
+				assert.Equal(t, text.NewErrorValidationSuchNoWebAuthnUser().Text, gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+			})
+
+			t.Run("case=fails without a user handle", func(t *testing.T) {
+				id := createIdentityWithWebAuthn(t, identity.Credentials{
+					Config:  loginFixtureSuccessV1PasswordlessCredentials,
+					Version: 1,
+				})
+
+				body, _, _ := submitWebAuthnLoginWithClient(t, true, id, discoverableContext, testhelpers.NewClientWithCookies(t), func(values url.Values) {
+					values.Del("identifier")
+					values.Del("method")
+					values.Set(node.WebAuthnLogin, string(loginFixtureSuccessV1PasswordlessResponse))
+				}, testhelpers.InitFlowWithAAL(identity.AuthenticatorAssuranceLevel1))
+
+				assert.Contains(t, gjson.Get(body, "ui.messages.0.text").String(), "does not contain a user handle", "%s", body)
+			})
+		})
 	})
 
 	t.Run("flow=mfa", func(t *testing.T) {
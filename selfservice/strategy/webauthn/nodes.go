@@ -40,6 +40,14 @@ func NewWebAuthnLoginTrigger(options string) *node.Node {
 		}))
 }
 
+func NewWebAuthnPasskeyLoginTrigger(options string) *node.Node {
+	return node.NewInputField(node.WebAuthnLoginTrigger, "", node.WebAuthnGroup,
+		node.InputAttributeTypeButton, node.WithInputAttributes(func(a *node.InputAttributes) {
+			a.OnClick = "window.__oryWebAuthnLogin(" + options + ")"
+			a.OnLoad = "window.__oryWebAuthnConditionalLogin(" + options + ")"
+		}))
+}
+
 func NewWebAuthnLoginInput() *node.Node {
 	return node.NewInputField(node.WebAuthnLogin, "", node.WebAuthnGroup,
 		node.InputAttributeTypeHidden)
@@ -50,8 +58,13 @@ func NewWebAuthnConnectionName() *node.Node {
 		WithMetaLabel(text.NewInfoSelfServiceRegisterWebAuthnDisplayName())
 }
 
-func NewWebAuthnUnlink(c *identity.CredentialWebAuthn) *node.Node {
+func NewWebAuthnUnlink(c *identity.CredentialWebAuthn, authenticatorName string) *node.Node {
+	label := text.NewInfoSelfServiceRemoveWebAuthn(stringsx.Coalesce(c.DisplayName, "unnamed"), c.AddedAt)
+	if authenticatorName != "" {
+		label = text.NewInfoSelfServiceRemoveWebAuthnAuthenticator(stringsx.Coalesce(c.DisplayName, "unnamed"), authenticatorName, c.AddedAt)
+	}
+
 	return node.NewInputField(node.WebAuthnRemove, fmt.Sprintf("%x", c.ID), node.WebAuthnGroup,
 		node.InputAttributeTypeSubmit).
-		WithMetaLabel(text.NewInfoSelfServiceRemoveWebAuthn(stringsx.Coalesce(c.DisplayName, "unnamed"), c.AddedAt))
+		WithMetaLabel(label)
 }
@@ -147,6 +147,10 @@ func (s *Strategy) Register(w http.ResponseWriter, r *http.Request, f *registrat
 		return s.handleRegistrationError(w, r, f, &p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to create WebAuthn credential: %s", err)))
 	}
 
+	if err := s.verifyAttestation(r.Context(), webAuthnResponse, credential); err != nil {
+		return s.handleRegistrationError(w, r, f, &p, err)
+	}
+
 	var cc identity.CredentialsWebAuthnConfig
 	wc := identity.CredentialFromWebAuthn(credential, true)
 	wc.AddedAt = time.Now().UTC().Round(time.Second)
@@ -203,7 +207,9 @@ func (s *Strategy) PopulateRegistrationMethod(r *http.Request, f *registration.F
 	}
 
 	webauthID := x.NewUUID()
-	option, sessionData, err := web.BeginRegistration(&wrappedUser{id: webauthID[:]})
+	// Discoverable credentials can be used without an identifier. Authenticators which can not store them still
+	// register a credential, which is then used by entering the identifier first.
+	option, sessionData, err := web.BeginRegistration(&wrappedUser{id: webauthID[:]}, webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred))
 	if err != nil {
 		return errors.WithStack(err)
 	}
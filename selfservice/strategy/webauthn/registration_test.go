@@ -30,6 +30,7 @@ import (
 	"github.com/ory/kratos/ui/node"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/assertx"
+	"github.com/ory/x/sqlcon"
 )
 
 var (
@@ -143,6 +144,7 @@ func TestRegistration(t *testing.T) {
 					"6.attributes.nonce",
 					"6.attributes.src",
 				})
+				ensureReplacement(t, "5", f.Ui, `"residentKey":"preferred"`)
 			})
 		}
 	})
@@ -397,6 +399,38 @@ func TestRegistration(t *testing.T) {
 			}
 		})
 
+		t.Run("case=rejects authenticators which violate the attestation policy", func(t *testing.T) {
+			testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/registration.schema.json")
+
+			for name, policy := range map[string]map[string]interface{}{
+				"denied":     {config.ViperKeyWebAuthnAttestationDeniedAAGUIDs: []string{uuid.Nil.String()}},
+				"not listed": {config.ViperKeyWebAuthnAttestationAllowedAAGUIDs: []string{x.NewUUID().String()}},
+				"direct":     {config.ViperKeyWebAuthnAttestationConveyance: "direct"},
+			} {
+				t.Run("policy="+name, func(t *testing.T) {
+					for k, v := range policy {
+						conf.MustSet(ctx, k, v)
+					}
+					t.Cleanup(func() {
+						for k := range policy {
+							conf.MustSet(ctx, k, nil)
+						}
+					})
+
+					for _, f := range flows {
+						t.Run("type="+f, func(t *testing.T) {
+							email := testhelpers.RandomEmail()
+							actual, _, _ := makeRegistration(t, f, values(email))
+							assert.Equal(t, text.NewErrorValidationWebAuthnAuthenticatorNotAllowed().Text, gjson.Get(actual, "ui.nodes.#(attributes.name==webauthn_register).messages.0.text").String(), "%s", actual)
+
+							_, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypeWebAuthn, email)
+							require.ErrorIs(t, err, sqlcon.ErrNoRows)
+						})
+					}
+				})
+			}
+		})
+
 		t.Run("case=reset previous form errors", func(t *testing.T) {
 			conf.MustSet(ctx, config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter, identity.CredentialsTypeWebAuthn.String()), []config.SelfServiceHook{{Name: "session"}})
 			testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/registration.schema.json")
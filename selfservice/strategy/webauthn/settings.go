@@ -247,11 +247,15 @@ func (s *Strategy) continueSettingsFlowAdd(w http.ResponseWriter, r *http.Reques
 		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to get webAuthn config.").WithDebug(err.Error()))
 	}
 
-	credential, err := web.CreateCredential(&wrappedUser{id: ctxUpdate.Session.IdentityID[:]}, webAuthnSess, webAuthnResponse)
+	credential, err := web.CreateCredential(&wrappedUser{id: webAuthnSess.UserID}, webAuthnSess, webAuthnResponse)
 	if err != nil {
 		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to create WebAuthn credential: %s", err))
 	}
 
+	if err := s.verifyAttestation(r.Context(), webAuthnResponse, credential); err != nil {
+		return err
+	}
+
 	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), ctxUpdate.Session.IdentityID)
 	if err != nil {
 		return err
@@ -268,7 +272,10 @@ func (s *Strategy) continueSettingsFlowAdd(w http.ResponseWriter, r *http.Reques
 	wc.AddedAt = time.Now().UTC().Round(time.Second)
 	wc.DisplayName = p.RegisterDisplayName
 	wc.IsPasswordless = s.d.Config().WebAuthnForPasswordless(r.Context())
-	cc.UserHandle = ctxUpdate.Session.IdentityID[:]
+	if len(cc.UserHandle) == 0 {
+		// Keep the existing user handle because discoverable credentials resolve the identity with it.
+		cc.UserHandle = webAuthnSess.UserID
+	}
 
 	cc.Credentials = append(cc.Credentials, *wc)
 	co, err := json.Marshal(cc)
@@ -339,11 +346,16 @@ func (s *Strategy) PopulateSettingsMethod(r *http.Request, id *identity.Identity
 		return err
 	}
 
+	userHandle := id.ID[:]
 	if webAuthns, err := s.identityListWebAuthn(confidentialIdentity); errors.Is(err, sqlcon.ErrNoRows) {
 		// Do nothing
 	} else if err != nil {
 		return err
 	} else {
+		if len(webAuthns.UserHandle) > 0 {
+			userHandle = webAuthns.UserHandle
+		}
+
 		for k := range webAuthns.Credentials {
 			// We only show the option to remove a credential, if it is not the last one when passwordless,
 			// or, if it is for MFA we show it always.
@@ -352,7 +364,7 @@ func (s *Strategy) PopulateSettingsMethod(r *http.Request, id *identity.Identity
 				// Do not remove this node because it is the last credential the identity can sign in with.
 				continue
 			}
-			f.UI.Nodes.Append(NewWebAuthnUnlink(cred))
+			f.UI.Nodes.Append(NewWebAuthnUnlink(cred, s.authenticatorName(r.Context(), cred.Authenticator.AAGUID)))
 		}
 	}
 
@@ -361,7 +373,13 @@ func (s *Strategy) PopulateSettingsMethod(r *http.Request, id *identity.Identity
 		return err
 	}
 
-	option, sessionData, err := web.BeginRegistration(&wrappedUser{id: id.ID[:]})
+	var opts []webauthn.RegistrationOption
+	if s.d.Config().WebAuthnForPasswordless(r.Context()) {
+		// Credentials which are not discoverable are used by entering the identifier first.
+		opts = append(opts, webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred))
+	}
+
+	option, sessionData, err := web.BeginRegistration(&wrappedUser{id: userHandle}, opts...)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -12,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/identity"
@@ -26,6 +28,7 @@ import (
 )
 
 var _ login.Strategy = new(Strategy)
+var _ login.IdentifierFirstStrategy = new(Strategy)
 var _ settings.Strategy = new(Strategy)
 var _ identity.ActiveCredentialsCounter = new(Strategy)
 
@@ -34,11 +37,15 @@ type registrationStrategyDependencies interface {
 	x.WriterProvider
 	x.CSRFTokenGeneratorProvider
 	x.CSRFProvider
+	x.HTTPClientProvider
 
 	config.Provider
 
 	continuity.ManagementProvider
 
+	courier.Provider
+	template.Dependencies
+
 	errorx.ManagementProvider
 	hash.HashProvider
 
@@ -69,8 +76,9 @@ type registrationStrategyDependencies interface {
 }
 
 type Strategy struct {
-	d  registrationStrategyDependencies
-	hd *decoderx.HTTP
+	d   registrationStrategyDependencies
+	hd  *decoderx.HTTP
+	mds metadataCache
 }
 
 func NewStrategy(d registrationStrategyDependencies) *Strategy {
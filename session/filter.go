@@ -0,0 +1,185 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"encoding/hex"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/kratos/identity"
+)
+
+// ListSessionsFilter narrows down the sessions returned by ListSessions and
+// affected by RevokeSessions. Zero values are ignored.
+type ListSessionsFilter struct {
+	// Active filters sessions by their state.
+	Active *bool
+
+	// IdentityID only matches sessions belonging to this identity.
+	IdentityID uuid.UUID
+
+	// AAL only matches sessions with this authenticator assurance level.
+	AAL identity.AuthenticatorAssuranceLevel
+
+	// AuthenticationMethod only matches sessions which completed this method.
+	AuthenticationMethod identity.CredentialsType
+
+	// CreatedAfter and CreatedBefore bound the session's creation time.
+	CreatedAfter, CreatedBefore time.Time
+
+	// AuthenticatedAfter and AuthenticatedBefore bound the session's authentication time.
+	AuthenticatedAfter, AuthenticatedBefore time.Time
+
+	// IPNetwork only matches sessions with at least one device inside this network.
+	IPNetwork *net.IPNet
+
+	// UserAgent only matches sessions with at least one device whose user agent
+	// contains this string.
+	UserAgent string
+
+	// UpstreamProvider, UpstreamSubject and UpstreamSessionID only match sessions
+	// which were authenticated at this upstream identity provider with this
+	// subject and session ID.
+	UpstreamProvider, UpstreamSubject, UpstreamSessionID string
+}
+
+// IsEmpty returns true if the filter does not narrow down the sessions beyond
+// their state. Such a filter matches every (active or inactive) session in the
+// network.
+func (f *ListSessionsFilter) IsEmpty() bool {
+	return f.IdentityID == uuid.Nil &&
+		f.AAL == "" &&
+		f.AuthenticationMethod == "" &&
+		f.CreatedAfter.IsZero() && f.CreatedBefore.IsZero() &&
+		f.AuthenticatedAfter.IsZero() && f.AuthenticatedBefore.IsZero() &&
+		f.IPNetwork == nil &&
+		f.UserAgent == "" &&
+		f.UpstreamProvider == "" && f.UpstreamSubject == "" && f.UpstreamSessionID == ""
+}
+
+// ParseListSessionsFilter parses the session filter from the URL query.
+func ParseListSessionsFilter(q url.Values) (*ListSessionsFilter, error) {
+	var f ListSessionsFilter
+
+	if raw := q.Get("active"); raw != "" {
+		active, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithError("could not parse parameter active"))
+		}
+		f.Active = &active
+	}
+
+	if raw := q.Get("identity_id"); raw != "" {
+		id, err := uuid.FromString(raw)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithError("could not parse parameter identity_id").WithDebug(err.Error()))
+		}
+		f.IdentityID = id
+	}
+
+	if raw := q.Get("aal"); raw != "" {
+		switch aal := identity.AuthenticatorAssuranceLevel(raw); aal {
+		case identity.AuthenticatorAssuranceLevel1, identity.AuthenticatorAssuranceLevel2, identity.AuthenticatorAssuranceLevel3:
+			f.AAL = aal
+		default:
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithErrorf("parameter aal must be one of aal1, aal2, or aal3 but got: %s", raw))
+		}
+	}
+
+	if raw := q.Get("authentication_method"); raw != "" {
+		method, ok := identity.ParseCredentialsType(raw)
+		if !ok {
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithErrorf("could not parse parameter authentication_method: %s", raw))
+		}
+		f.AuthenticationMethod = method
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{
+		{name: "created_after", dst: &f.CreatedAfter},
+		{name: "created_before", dst: &f.CreatedBefore},
+		{name: "authenticated_after", dst: &f.AuthenticatedAfter},
+		{name: "authenticated_before", dst: &f.AuthenticatedBefore},
+	} {
+		raw := q.Get(p.name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithErrorf("could not parse parameter %s as RFC 3339 timestamp", p.name).WithDebug(err.Error()))
+		}
+		*p.dst = t.UTC()
+	}
+
+	if raw := q.Get("ip_address"); raw != "" {
+		network, err := ParseIPNetwork(raw)
+		if err != nil {
+			return nil, err
+		}
+		f.IPNetwork = network
+	}
+
+	f.UserAgent = q.Get("user_agent")
+
+	return &f, nil
+}
+
+// ParseIPNetwork parses either a single IP address or a CIDR range. A single
+// IP address is returned as a network containing only that address.
+func ParseIPNetwork(raw string) (*net.IPNet, error) {
+	if strings.Contains(raw, "/") {
+		_, network, err := net.ParseCIDR(raw)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithErrorf("could not parse CIDR range: %s", raw).WithDebug(err.Error()))
+		}
+		return network, nil
+	}
+
+	ip := net.ParseIP(raw)
+	if ip == nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithErrorf("could not parse IP address: %s", raw))
+	}
+
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// IPAddressKey returns a representation of the IP address which sorts like the
+// address itself, so that a network can be matched with a range query. IPv4
+// addresses are mapped into the IPv6 address space. It returns nil if the
+// address can not be parsed.
+func IPAddressKey(raw string) *string {
+	ip := net.ParseIP(raw)
+	if ip == nil {
+		return nil
+	}
+	key := hex.EncodeToString(ip.To16())
+	return &key
+}
+
+// IPNetworkKeyRange returns the smallest and largest IPAddressKey inside the
+// network.
+func IPNetworkKeyRange(network *net.IPNet) (first, last string) {
+	start := network.IP.Mask(network.Mask)
+	mask := network.Mask[len(network.Mask)-len(start):]
+	end := make(net.IP, len(start))
+	for i := range start {
+		end[i] = start[i] | ^mask[i]
+	}
+	return hex.EncodeToString(start.To16()), hex.EncodeToString(end.To16())
+}
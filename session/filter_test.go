@@ -0,0 +1,118 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+)
+
+func TestParseListSessionsFilter(t *testing.T) {
+	t.Run("case=empty", func(t *testing.T) {
+		f, err := session.ParseListSessionsFilter(url.Values{})
+		require.NoError(t, err)
+		assert.True(t, f.IsEmpty())
+	})
+
+	t.Run("case=active alone does not narrow down sessions", func(t *testing.T) {
+		f, err := session.ParseListSessionsFilter(url.Values{"active": {"true"}})
+		require.NoError(t, err)
+		assert.True(t, f.IsEmpty())
+	})
+
+	t.Run("case=all fields", func(t *testing.T) {
+		f, err := session.ParseListSessionsFilter(url.Values{
+			"active":                {"false"},
+			"identity_id":           {"8f1b6f05-3f7c-4a0e-9e4f-4b5f1a3c2d1e"},
+			"aal":                   {"aal2"},
+			"authentication_method": {"webauthn"},
+			"created_after":         {"2023-01-01T00:00:00Z"},
+			"authenticated_before":  {"2023-01-02T00:00:00+01:00"},
+			"ip_address":            {"10.0.0.0/8"},
+			"user_agent":            {"Firefox"},
+		})
+		require.NoError(t, err)
+		assert.False(t, f.IsEmpty())
+		assert.False(t, *f.Active)
+		assert.Equal(t, "8f1b6f05-3f7c-4a0e-9e4f-4b5f1a3c2d1e", f.IdentityID.String())
+		assert.Equal(t, identity.AuthenticatorAssuranceLevel2, f.AAL)
+		assert.Equal(t, identity.CredentialsTypeWebAuthn, f.AuthenticationMethod)
+		assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), f.CreatedAfter)
+		assert.Equal(t, time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC), f.AuthenticatedBefore)
+		assert.Equal(t, "10.0.0.0/8", f.IPNetwork.String())
+		assert.Equal(t, "Firefox", f.UserAgent)
+	})
+
+	for _, q := range []url.Values{
+		{"active": {"maybe"}},
+		{"identity_id": {"not-a-uuid"}},
+		{"aal": {"aal0"}},
+		{"authentication_method": {"carrier-pigeon"}},
+		{"created_before": {"yesterday"}},
+		{"ip_address": {"10.0.0.0/33"}},
+	} {
+		t.Run("case=rejects "+q.Encode(), func(t *testing.T) {
+			_, err := session.ParseListSessionsFilter(q)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseIPNetwork(t *testing.T) {
+	for _, tc := range []struct {
+		in, expected string
+	}{
+		{in: "192.0.2.1", expected: "192.0.2.1/32"},
+		{in: "2001:db8::1", expected: "2001:db8::1/128"},
+		{in: "192.0.2.0/24", expected: "192.0.2.0/24"},
+		{in: "192.0.2.77/24", expected: "192.0.2.0/24"},
+	} {
+		t.Run("case="+tc.in, func(t *testing.T) {
+			actual, err := session.ParseIPNetwork(tc.in)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+
+	_, err := session.ParseIPNetwork("example.org")
+	require.Error(t, err)
+}
+
+func TestIPNetworkKeyRange(t *testing.T) {
+	for _, tc := range []struct {
+		network string
+		inside  []string
+		outside []string
+	}{
+		{network: "10.0.0.0/8", inside: []string{"10.0.0.0", "10.20.30.40", "10.255.255.255"}, outside: []string{"9.255.255.255", "11.0.0.0", "2001:db8::1"}},
+		{network: "192.0.2.1", inside: []string{"192.0.2.1", "::ffff:192.0.2.1"}, outside: []string{"192.0.2.2"}},
+		{network: "2001:db8::/32", inside: []string{"2001:db8::1", "2001:db8:ffff::1"}, outside: []string{"2001:db9::", "10.0.0.1"}},
+	} {
+		t.Run("case="+tc.network, func(t *testing.T) {
+			network, err := session.ParseIPNetwork(tc.network)
+			require.NoError(t, err)
+			first, last := session.IPNetworkKeyRange(network)
+
+			for _, ip := range tc.inside {
+				key := session.IPAddressKey(ip)
+				require.NotNil(t, key)
+				assert.True(t, first <= *key && *key <= last, ip)
+			}
+			for _, ip := range tc.outside {
+				key := session.IPAddressKey(ip)
+				require.NotNil(t, key)
+				assert.False(t, first <= *key && *key <= last, ip)
+			}
+		})
+	}
+
+	assert.Nil(t, session.IPAddressKey("example.org"))
+}
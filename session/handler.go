@@ -25,6 +25,8 @@ import (
 	"github.com/ory/herodot"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/x"
 )
 
@@ -36,6 +38,7 @@ type (
 		x.LoggingProvider
 		x.CSRFProvider
 		config.Provider
+		errorx.ManagementProvider
 	}
 	HandlerProvider interface {
 		SessionHandler() *Handler
@@ -59,12 +62,15 @@ const (
 	RouteCollection = "/sessions"
 	RouteWhoami     = RouteCollection + "/whoami"
 	RouteSession    = RouteCollection + "/:id"
+
+	RouteUnrecognizedLogin = RouteCollection + "/unrecognized"
 )
 
 const (
 	AdminRouteIdentity           = "/identities"
 	AdminRouteIdentitiesSessions = AdminRouteIdentity + "/:id/sessions"
 	AdminRouteSessionExtendId    = RouteSession + "/extend"
+	AdminRouteSessionsRevoke     = RouteCollection + "/revoke"
 )
 
 func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
@@ -75,6 +81,7 @@ func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 	admin.GET(AdminRouteIdentitiesSessions, h.listIdentitySessions)
 	admin.DELETE(AdminRouteIdentitiesSessions, h.deleteIdentitySessions)
 	admin.PATCH(AdminRouteSessionExtendId, h.adminSessionExtend)
+	admin.POST(AdminRouteSessionsRevoke, h.adminRevokeSessions)
 
 	admin.DELETE(RouteCollection, x.RedirectToPublicRoute(h.r))
 }
@@ -87,6 +94,8 @@ func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 	h.r.CSRFHandler().IgnoreGlob(RouteCollection + "/*")
 	h.r.CSRFHandler().IgnoreGlob(RouteCollection + "/*/extend")
 	h.r.CSRFHandler().IgnoreGlob(AdminRouteIdentity + "/*/sessions")
+	// The unrecognized login token is a secret which is only known to the recipient of the message.
+	h.r.CSRFHandler().IgnorePath(RouteUnrecognizedLogin)
 
 	for _, m := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodConnect, http.MethodOptions, http.MethodTrace} {
 		public.Handle(m, RouteWhoami, h.whoami)
@@ -95,6 +104,8 @@ func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 	public.DELETE(RouteCollection, h.deleteMySessions)
 	public.DELETE(RouteSession, h.deleteMySession)
 	public.GET(RouteCollection, h.listMySessions)
+	public.GET(RouteUnrecognizedLogin, h.confirmUnrecognizedLogin)
+	public.POST(RouteUnrecognizedLogin, h.revokeUnrecognizedLogin)
 
 	public.DELETE(AdminRouteIdentitiesSessions, x.RedirectToAdminRoute(h.r))
 }
@@ -119,6 +130,21 @@ type toSession struct {
 	//
 	// in: header
 	Cookie string `json:"Cookie"`
+
+	// Maximum Authentication Age
+	//
+	// If set, the session must have been authenticated within the given number of seconds. If `required_amr` is
+	// set as well, one of the required methods must have been completed within this time.
+	//
+	// in: query
+	MaxAge int64 `json:"max_age"`
+
+	// Required Authentication Methods
+	//
+	// If set, the session must have been authenticated with one of the given methods, for example `webauthn`.
+	//
+	// in: query
+	RequiredAMR []string `json:"required_amr"`
 }
 
 // swagger:route GET /sessions/whoami frontend toSession
@@ -174,6 +200,12 @@ type toSession struct {
 //
 // - `session_inactive`: No active session was found in the request (e.g. no Ory Session Cookie / Ory Session Token).
 // - `session_aal2_required`: An active session was found but it does not fulfil the Authenticator Assurance Level, implying that the session must (e.g.) authenticate the second factor.
+// - `session_refresh_required`: An active session was found but it does not fulfil the `max_age` or `required_amr` requirements, implying that the session must re-authenticate.
+//
+// Use the `max_age` and `required_amr` query parameters to require step-up authentication for sensitive operations,
+// for example `?max_age=300&required_amr=webauthn` to require that the identity signed in using WebAuthn within the
+// last five minutes. If the requirements are not met, the `redirect_browser_to` field of the error points to a login
+// flow which re-authenticates the identity with the required method.
 //
 //	Produces:
 //	- application/json
@@ -199,11 +231,22 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		return
 	}
 
+	opts, err := stepUpOptionsFromRequest(r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
 	var aalErr *ErrAALNotSatisfied
-	if err := h.r.SessionManager().DoesSessionSatisfy(r, s, c.SessionWhoAmIAAL(r.Context())); errors.As(err, &aalErr) {
+	var stepUpErr *ErrStepUpRequired
+	if err := h.r.SessionManager().DoesSessionSatisfy(r, s, c.SessionWhoAmIAAL(r.Context()), opts...); errors.As(err, &aalErr) {
 		h.r.Audit().WithRequest(r).WithError(err).Info("Session was found but AAL is not satisfied for calling this endpoint.")
 		h.r.Writer().WriteError(w, r, err)
 		return
+	} else if errors.As(err, &stepUpErr) {
+		h.r.Audit().WithRequest(r).WithError(err).Info("Session was found but step-up requirements are not satisfied for calling this endpoint.")
+		h.r.Writer().WriteError(w, r, err)
+		return
 	} else if err != nil {
 		h.r.Audit().WithRequest(r).WithError(err).Info("No valid session cookie found.")
 		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("Unable to determine AAL."))
@@ -230,6 +273,29 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, ps httprouter.P
 	h.r.Writer().Write(w, r, s)
 }
 
+func stepUpOptionsFromRequest(r *http.Request) ([]SatisfyOption, error) {
+	var opts []SatisfyOption
+	query := r.URL.Query()
+
+	if raw := query.Get("max_age"); raw != "" {
+		maxAge, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || maxAge <= 0 {
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The max_age parameter must be a positive number of seconds but got: %s", raw))
+		}
+		opts = append(opts, WithMaxAge(time.Duration(maxAge)*time.Second))
+	}
+
+	for _, raw := range query["required_amr"] {
+		method, ok := identity.ParseCredentialsType(raw)
+		if !ok {
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The required_amr parameter contains an unknown authentication method: %s", raw))
+		}
+		opts = append(opts, WithRequiredAuthenticationMethods(method))
+	}
+
+	return opts, nil
+}
+
 // Delete Identity Session Parameters
 //
 // swagger:parameters deleteIdentitySessions
@@ -286,19 +352,82 @@ func (h *Handler) deleteIdentitySessions(w http.ResponseWriter, r *http.Request,
 type listSessionsRequest struct {
 	keysetpagination.RequestParameters
 
+	listSessionsFilter
+
+	// ExpandOptions is a query parameter encoded list of all properties that must be expanded in the Session.
+	// If no value is provided, the expandable properties are skipped.
+	//
+	// required: false
+	// enum: identity,devices
+	// in: query
+	ExpandOptions []string `json:"expand"`
+}
+
+// Session Filter Parameters
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type listSessionsFilter struct {
 	// Active is a boolean flag that filters out sessions based on the state. If no value is provided, all sessions are returned.
 	//
 	// required: false
 	// in: query
 	Active bool `json:"active"`
 
-	// ExpandOptions is a query parameter encoded list of all properties that must be expanded in the Session.
-	// If no value is provided, the expandable properties are skipped.
+	// IdentityID only returns sessions of the given identity.
 	//
 	// required: false
-	// enum: identity,devices
 	// in: query
-	ExpandOptions []string `json:"expand"`
+	IdentityID string `json:"identity_id"`
+
+	// AAL only returns sessions with the given authenticator assurance level.
+	//
+	// required: false
+	// enum: aal1,aal2,aal3
+	// in: query
+	AAL string `json:"aal"`
+
+	// AuthenticationMethod only returns sessions which completed the given authentication method.
+	//
+	// required: false
+	// in: query
+	AuthenticationMethod string `json:"authentication_method"`
+
+	// CreatedAfter only returns sessions created at or after the given RFC 3339 timestamp.
+	//
+	// required: false
+	// in: query
+	CreatedAfter string `json:"created_after"`
+
+	// CreatedBefore only returns sessions created before the given RFC 3339 timestamp.
+	//
+	// required: false
+	// in: query
+	CreatedBefore string `json:"created_before"`
+
+	// AuthenticatedAfter only returns sessions authenticated at or after the given RFC 3339 timestamp.
+	//
+	// required: false
+	// in: query
+	AuthenticatedAfter string `json:"authenticated_after"`
+
+	// AuthenticatedBefore only returns sessions authenticated before the given RFC 3339 timestamp.
+	//
+	// required: false
+	// in: query
+	AuthenticatedBefore string `json:"authenticated_before"`
+
+	// IPAddress only returns sessions with a device matching the given IP address or CIDR range (e.g. `10.0.0.0/8`).
+	//
+	// required: false
+	// in: query
+	IPAddress string `json:"ip_address"`
+
+	// UserAgent only returns sessions with a device whose user agent contains the given string (case-insensitive).
+	//
+	// required: false
+	// in: query
+	UserAgent string `json:"user_agent"`
 }
 
 // Session List Response
@@ -321,7 +450,9 @@ type listSessionsResponse struct {
 //
 // # List All Sessions
 //
-// Listing all sessions that exist.
+// Listing all sessions that exist. The result can be narrowed down by identity, authenticator
+// assurance level, authentication method, creation and authentication time, as well as device
+// IP address (or CIDR range) and user agent.
 //
 //	Schemes: http, https
 //
@@ -333,18 +464,12 @@ type listSessionsResponse struct {
 //	  400: errorGeneric
 //	  default: errorGeneric
 func (h *Handler) adminListSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	activeRaw := r.URL.Query().Get("active")
-	activeBool, err := strconv.ParseBool(activeRaw)
-	if activeRaw != "" && err != nil {
-		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest.WithError("could not parse parameter active"))
+	filter, err := ParseListSessionsFilter(r.URL.Query())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
-	var active *bool
-	if activeRaw != "" {
-		active = &activeBool
-	}
-
 	// Parse request pagination parameters
 	opts, err := keysetpagination.Parse(r.URL.Query(), keysetpagination.NewStringPageToken)
 	if err != nil {
@@ -364,7 +489,7 @@ func (h *Handler) adminListSessions(w http.ResponseWriter, r *http.Request, ps h
 		}
 	}
 
-	sess, total, nextPage, err := h.r.SessionPersister().ListSessions(r.Context(), active, opts, expandables)
+	sess, total, nextPage, err := h.r.SessionPersister().ListSessions(r.Context(), filter, opts, expandables)
 	if err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
@@ -375,6 +500,66 @@ func (h *Handler) adminListSessions(w http.ResponseWriter, r *http.Request, ps h
 	h.r.Writer().Write(w, r, sess)
 }
 
+// Revoke Sessions Parameters
+//
+// swagger:parameters revokeSessions
+//
+//nolint:deadcode,unused
+//lint:ignore U1000 Used to generate Swagger and OpenAPI definitions
+type revokeSessions struct {
+	listSessionsFilter
+}
+
+// Revoked Session Count
+//
+// swagger:model revokedSessions
+type revokedSessions struct {
+	// The number of sessions that were revoked.
+	Count int `json:"count"`
+}
+
+// swagger:route POST /admin/sessions/revoke identity revokeSessions
+//
+// # Revoke Sessions Matching a Filter
+//
+// Calling this endpoint deactivates all sessions matching the given filter, for example all sessions
+// created from a compromised IP range. Session data is not deleted. At least one filter besides active must be provided.
+//
+//	Schemes: http, https
+//
+//	Security:
+//	  oryAccessToken:
+//
+//	Responses:
+//	  200: revokedSessions
+//	  400: errorGeneric
+//	  default: errorGeneric
+func (h *Handler) adminRevokeSessions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	filter, err := ParseListSessionsFilter(r.URL.Query())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if filter.IsEmpty() {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("At least one filter besides active must be provided to revoke sessions.")))
+		return
+	}
+
+	n, err := h.r.SessionPersister().RevokeSessions(r.Context(), filter)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Audit().
+		WithRequest(r).
+		WithField("revoked_sessions", n).
+		Info("Revoked sessions matching filter.")
+
+	h.r.Writer().WriteCode(w, r, http.StatusOK, &revokedSessions{Count: n})
+}
+
 // Session Get Request
 //
 // The request object for getting a session in an administrative context.
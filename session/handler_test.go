@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"testing"
@@ -35,8 +36,10 @@ import (
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/internal/testhelpers"
 	. "github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/ioutilx"
+	"github.com/ory/x/pointerx"
 	"github.com/ory/x/urlx"
 )
 
@@ -774,6 +777,64 @@ func TestHandlerAdminSessionManagement(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("case=should filter and revoke sessions", func(t *testing.T) {
+		client := testhelpers.NewClientWithCookies(t)
+		var i *identity.Identity
+		require.NoError(t, faker.FakeData(&i))
+		require.NoError(t, reg.Persister().CreateIdentity(ctx, i))
+
+		sess := make([]Session, 3)
+		for j, ip := range []string{"203.0.113.7", "203.0.113.99", "198.51.100.1"} {
+			require.NoError(t, faker.FakeData(&sess[j]))
+			sess[j].Identity = i
+			sess[j].Active = true
+			sess[j].ExpiresAt = time.Now().Add(time.Hour)
+			sess[j].Devices = []Device{{IPAddress: pointerx.String(ip)}}
+			require.NoError(t, reg.SessionPersister().UpsertSession(ctx, &sess[j]))
+		}
+
+		t.Run("list by cidr", func(t *testing.T) {
+			req, _ := http.NewRequest("GET", ts.URL+"/admin/sessions?identity_id="+i.ID.String()+"&ip_address=203.0.113.0/24", nil)
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			body := ioutilx.MustReadAll(res.Body)
+			assert.Equal(t, "2", res.Header.Get("X-Total-Count"), "%s", body)
+			assert.ElementsMatch(t, []string{sess[0].ID.String(), sess[1].ID.String()}, []string{gjson.GetBytes(body, "0.id").String(), gjson.GetBytes(body, "1.id").String()})
+		})
+
+		for _, query := range []string{"ip_address=not-an-ip", "aal=aal9", "created_after=yesterday", "identity_id=foo"} {
+			t.Run("list rejects "+query, func(t *testing.T) {
+				req, _ := http.NewRequest("GET", ts.URL+"/admin/sessions?"+query, nil)
+				res, err := client.Do(req)
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+			})
+		}
+
+		t.Run("revoke requires a filter", func(t *testing.T) {
+			req, _ := http.NewRequest("POST", ts.URL+"/admin/sessions/revoke", nil)
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		})
+
+		t.Run("revoke by cidr", func(t *testing.T) {
+			req, _ := http.NewRequest("POST", ts.URL+"/admin/sessions/revoke?identity_id="+i.ID.String()+"&ip_address=203.0.113.0/24", nil)
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			assert.EqualValues(t, 2, gjson.GetBytes(ioutilx.MustReadAll(res.Body), "count").Int())
+
+			for j := range sess {
+				actual, err := reg.SessionPersister().GetSession(ctx, sess[j].ID, ExpandNothing)
+				require.NoError(t, err)
+				assert.Equal(t, j == 2, actual.Active)
+			}
+		})
+	})
 }
 
 func TestHandlerSelfServiceSessionManagement(t *testing.T) {
@@ -919,6 +980,81 @@ func TestHandlerSelfServiceSessionManagement(t *testing.T) {
 		}
 	})
 
+	t.Run("case=should revoke unrecognized login", func(t *testing.T) {
+		conf.MustSet(ctx, config.ViperKeySelfServiceRecoveryEnabled, true)
+		conf.MustSet(ctx, config.ViperKeySelfServiceRecoveryUI, "https://www.ory.sh/recovery")
+		conf.MustSet(ctx, config.ViperKeySelfServiceLoginUI, "https://www.ory.sh/login")
+		conf.MustSet(ctx, config.ViperKeySelfServiceErrorUI, "https://www.ory.sh/error")
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeySelfServiceRecoveryEnabled, false)
+		})
+
+		_, i, _ := setup(t)
+
+		unrecognized := Session{}
+		require.NoError(t, faker.FakeData(&unrecognized))
+		unrecognized.Identity = i
+		unrecognized.Active = true
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, &unrecognized))
+
+		link, err := NewUnrecognizedLoginURL(ctx, conf, &unrecognized)
+		require.NoError(t, err)
+
+		client := testhelpers.NewNoRedirectClientWithCookies(t)
+		revoke := func(t *testing.T, token string) *http.Response {
+			res, err := client.PostForm(ts.URL+RouteUnrecognizedLogin, url.Values{"token": {token}})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = res.Body.Close() })
+			return res
+		}
+
+		t.Run("case=link only renders a confirmation", func(t *testing.T) {
+			res, err := client.Get(ts.URL + RouteUnrecognizedLogin + "?" + link.RawQuery)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Contains(t, res.Header.Get("Content-Type"), "text/html")
+			assert.Equal(t, "no-store", res.Header.Get("Cache-Control"))
+
+			body := x.MustReadAll(res.Body)
+			assert.Contains(t, string(body), `method="POST"`)
+			assert.Contains(t, string(body), `value="`+link.Query().Get("token")+`"`)
+
+			actual, err := reg.SessionPersister().GetSession(ctx, unrecognized.ID, ExpandNothing)
+			require.NoError(t, err)
+			assert.True(t, actual.Active)
+		})
+
+		t.Run("case=redirects to recovery", func(t *testing.T) {
+			res := revoke(t, link.Query().Get("token"))
+			require.Equal(t, http.StatusSeeOther, res.StatusCode)
+			assert.Equal(t, "https://www.ory.sh/recovery", res.Header.Get("Location"))
+
+			actual, err := reg.SessionPersister().GetSession(ctx, unrecognized.ID, ExpandNothing)
+			require.NoError(t, err)
+			assert.False(t, actual.Active)
+		})
+
+		t.Run("case=redirects to login if recovery is disabled", func(t *testing.T) {
+			conf.MustSet(ctx, config.ViperKeySelfServiceRecoveryEnabled, false)
+
+			res := revoke(t, link.Query().Get("token"))
+			require.Equal(t, http.StatusSeeOther, res.StatusCode)
+			assert.Equal(t, "https://www.ory.sh/login", res.Header.Get("Location"))
+		})
+
+		t.Run("case=rejects tampered token", func(t *testing.T) {
+			res, err := client.Get(ts.URL + RouteUnrecognizedLogin + "?token=" + link.Query().Get("token") + "x")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusSeeOther, res.StatusCode)
+			assert.Contains(t, res.Header.Get("Location"), "https://www.ory.sh/error")
+
+			res = revoke(t, link.Query().Get("token")+"x")
+			require.Equal(t, http.StatusSeeOther, res.StatusCode)
+			assert.Contains(t, res.Header.Get("Location"), "https://www.ory.sh/error")
+		})
+	})
+
 	t.Run("case=whoami should not issue cookie for up to date session", func(t *testing.T) {
 		client, _, _ := setup(t)
 
@@ -949,6 +1085,56 @@ func TestHandlerSelfServiceSessionManagement(t *testing.T) {
 		}
 	})
 
+	t.Run("case=whoami should enforce step-up requirements", func(t *testing.T) {
+		client, _, sess := setup(t)
+		sess.AMR = AuthenticationMethods{{Method: identity.CredentialsTypePassword, AAL: identity.AuthenticatorAssuranceLevel1, CompletedAt: time.Now().UTC()}}
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, sess))
+
+		whoami := func(t *testing.T, query string) (*http.Response, []byte) {
+			res, err := client.Get(ts.URL + "/sessions/whoami?" + query)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			return res, ioutilx.MustReadAll(res.Body)
+		}
+
+		t.Run("case=satisfied", func(t *testing.T) {
+			for _, query := range []string{"max_age=300", "required_amr=password", "max_age=300&required_amr=password&required_amr=webauthn"} {
+				res, body := whoami(t, query)
+				assert.Equal(t, http.StatusOK, res.StatusCode, "%s: %s", query, body)
+			}
+		})
+
+		t.Run("case=requires method", func(t *testing.T) {
+			res, body := whoami(t, "required_amr=webauthn")
+			require.Equal(t, http.StatusForbidden, res.StatusCode, "%s", body)
+			assert.Equal(t, text.ErrIDNeedsPrivilegedSession, gjson.GetBytes(body, "error.id").String(), "%s", body)
+
+			redirect := urlx.ParseOrPanic(gjson.GetBytes(body, "redirect_browser_to").String())
+			assert.Equal(t, "/self-service/login/browser", redirect.Path)
+			assert.Equal(t, "true", redirect.Query().Get("refresh"))
+			assert.Equal(t, "webauthn", redirect.Query().Get("required_method"))
+		})
+
+		t.Run("case=requires recent authentication", func(t *testing.T) {
+			sess.AMR[0].CompletedAt = time.Now().Add(-time.Hour).UTC()
+			require.NoError(t, reg.SessionPersister().UpsertSession(ctx, sess))
+
+			res, body := whoami(t, "max_age=300&required_amr=password")
+			require.Equal(t, http.StatusForbidden, res.StatusCode, "%s", body)
+			assert.Equal(t, "password", urlx.ParseOrPanic(gjson.GetBytes(body, "redirect_browser_to").String()).Query().Get("required_method"))
+
+			res, body = whoami(t, "required_amr=password")
+			assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		})
+
+		t.Run("case=rejects invalid parameters", func(t *testing.T) {
+			for _, query := range []string{"max_age=abc", "max_age=-1", "required_amr=unknown"} {
+				res, body := whoami(t, query)
+				assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s: %s", query, body)
+			}
+		})
+	})
+
 	t.Run("case=whoami should not issue cookie if request is token based", func(t *testing.T) {
 		_, _, session := setup(t)
 
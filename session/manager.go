@@ -7,6 +7,9 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"time"
+
+	"github.com/ory/kratos/identity"
 
 	"github.com/ory/kratos/text"
 
@@ -98,6 +101,67 @@ func NewErrAALNotSatisfied(redirectTo string) *ErrAALNotSatisfied {
 	}
 }
 
+// ErrStepUpRequired is returned when an active session was found but it was not authenticated recently enough or not
+// with one of the required authentication methods.
+type ErrStepUpRequired struct {
+	*herodot.DefaultError `json:"error"`
+	RedirectTo            string `json:"redirect_browser_to"`
+}
+
+func (e *ErrStepUpRequired) EnhanceJSONError() interface{} {
+	return e
+}
+
+// NewErrStepUpRequired creates a new ErrStepUpRequired.
+func NewErrStepUpRequired(redirectTo string) *ErrStepUpRequired {
+	return &ErrStepUpRequired{
+		RedirectTo: redirectTo,
+		DefaultError: &herodot.DefaultError{
+			IDField:     text.ErrIDNeedsPrivilegedSession,
+			StatusField: http.StatusText(http.StatusForbidden),
+			ErrorField:  "Session does not fulfill the requested authentication requirements",
+			ReasonField: "An active session was found but it was not authenticated recently enough or not with the required authentication method. Please re-authenticate to resolve this issue.",
+			CodeField:   http.StatusForbidden,
+			DetailsField: map[string]interface{}{
+				"redirect_browser_to": redirectTo,
+			},
+		},
+	}
+}
+
+type (
+	satisfyOptions struct {
+		maxAge  time.Duration
+		methods []identity.CredentialsType
+	}
+
+	// SatisfyOption adds step-up requirements to DoesSessionSatisfy.
+	SatisfyOption func(o *satisfyOptions)
+)
+
+// WithMaxAge requires the session to have been authenticated within the given duration.
+func WithMaxAge(maxAge time.Duration) SatisfyOption {
+	return func(o *satisfyOptions) {
+		o.maxAge = maxAge
+	}
+}
+
+// WithRequiredAuthenticationMethods requires the session to have been authenticated with one of the given methods.
+// If combined with WithMaxAge, one of the methods must have been completed within the max age.
+func WithRequiredAuthenticationMethods(methods ...identity.CredentialsType) SatisfyOption {
+	return func(o *satisfyOptions) {
+		o.methods = append(o.methods, methods...)
+	}
+}
+
+func newSatisfyOptions(opts []SatisfyOption) *satisfyOptions {
+	var o satisfyOptions
+	for _, f := range opts {
+		f(&o)
+	}
+	return &o
+}
+
 // Manager handles identity sessions.
 type Manager interface {
 	// UpsertAndIssueCookie stores a session in the database and issues a cookie by calling IssueCookie.
@@ -119,11 +183,17 @@ type Manager interface {
 	// PurgeFromRequest removes an HTTP session.
 	PurgeFromRequest(context.Context, http.ResponseWriter, *http.Request) error
 
-	// DoesSessionSatisfy answers if a session is satisfying the AAL.
-	DoesSessionSatisfy(r *http.Request, sess *Session, requestedAAL string) error
+	// DoesSessionSatisfy answers if a session is satisfying the AAL and the optional step-up requirements.
+	DoesSessionSatisfy(r *http.Request, sess *Session, requestedAAL string, opts ...SatisfyOption) error
 
 	// SessionAddAuthenticationMethods adds one or more authentication method to the session.
 	SessionAddAuthenticationMethods(ctx context.Context, sid uuid.UUID, methods ...AuthenticationMethod) error
+
+	// IssueTrustedDeviceCookie marks the browser as trusted by the session's identity and issues a cookie for it.
+	IssueTrustedDeviceCookie(context.Context, http.ResponseWriter, *http.Request, *Session) error
+
+	// FetchTrustedDevice returns the trusted device of the request if the browser is trusted by the given identity.
+	FetchTrustedDevice(ctx context.Context, r *http.Request, identityID uuid.UUID) (*TrustedDevice, error)
 }
 
 type ManagementProvider interface {
@@ -256,10 +256,34 @@ func (s *ManagerHTTP) PurgeFromRequest(ctx context.Context, w http.ResponseWrite
 	return nil
 }
 
-func (s *ManagerHTTP) DoesSessionSatisfy(r *http.Request, sess *Session, requestedAAL string) (err error) {
+func (s *ManagerHTTP) DoesSessionSatisfy(r *http.Request, sess *Session, requestedAAL string, opts ...SatisfyOption) (err error) {
 	ctx, span := s.r.Tracer(r.Context()).Tracer().Start(r.Context(), "sessions.ManagerHTTP.DoesSessionSatisfy")
 	defer otelx.End(span, &err)
 
+	if err := s.doesSessionSatisfyAAL(ctx, r, sess, requestedAAL); err != nil {
+		return err
+	}
+
+	o := newSatisfyOptions(opts)
+	if o.maxAge == 0 && len(o.methods) == 0 {
+		return nil
+	}
+
+	if sess.AuthenticatedWithin(o.maxAge, o.methods...) {
+		return nil
+	}
+
+	// The login flow can only require a single method, so we ask for the first one.
+	query := url.Values{"refresh": {"true"}}
+	if len(o.methods) > 0 {
+		query.Set("required_method", o.methods[0].String())
+	}
+
+	return NewErrStepUpRequired(
+		urlx.CopyWithQuery(urlx.AppendPaths(s.r.Config().SelfPublicURL(ctx), "/self-service/login/browser"), query).String())
+}
+
+func (s *ManagerHTTP) doesSessionSatisfyAAL(ctx context.Context, r *http.Request, sess *Session, requestedAAL string) (err error) {
 	sess.SetAuthenticatorAssuranceLevel()
 	switch requestedAAL {
 	case string(identity.AuthenticatorAssuranceLevel1):
@@ -298,6 +322,15 @@ func (s *ManagerHTTP) DoesSessionSatisfy(r *http.Request, sess *Session, request
 			return nil
 		}
 
+		if available == identity.AuthenticatorAssuranceLevel2 {
+			// A browser trusted by the identity satisfies the second factor.
+			if _, err := s.FetchTrustedDevice(ctx, r, sess.IdentityID); err == nil {
+				return nil
+			} else if !errors.Is(err, herodot.ErrNotFound) {
+				return err
+			}
+		}
+
 		return NewErrAALNotSatisfied(
 			urlx.CopyWithQuery(urlx.AppendPaths(s.r.Config().SelfPublicURL(ctx), "/self-service/login/browser"), url.Values{"aal": {"aal2"}}).String())
 	}
@@ -320,3 +353,74 @@ func (s *ManagerHTTP) SessionAddAuthenticationMethods(ctx context.Context, sid u
 	sess.SetAuthenticatorAssuranceLevel()
 	return s.r.SessionPersister().UpsertSession(ctx, sess)
 }
+
+func (s *ManagerHTTP) IssueTrustedDeviceCookie(ctx context.Context, w http.ResponseWriter, r *http.Request, sess *Session) (err error) {
+	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.IssueTrustedDeviceCookie")
+	defer otelx.End(span, &err)
+
+	device := NewTrustedDevice(r, sess.IdentityID, s.r.Config().TrustedDeviceLifespan(ctx))
+	if err := s.r.SessionPersister().CreateTrustedDevice(ctx, device); err != nil {
+		return err
+	}
+
+	cookie, err := s.r.CookieManager(ctx).Get(r, TrustedDeviceCookieName)
+	// The cookie might be signed with a rotated secret, in which case we overwrite it.
+	if err != nil && cookie == nil {
+		return errors.WithStack(err)
+	}
+
+	if s.r.Config().SessionPath(ctx) != "" {
+		cookie.Options.Path = s.r.Config().SessionPath(ctx)
+	}
+
+	if domain := s.r.Config().SessionDomain(ctx); domain != "" {
+		cookie.Options.Domain = domain
+	}
+
+	if s.r.Config().SessionSameSiteMode(ctx) != 0 {
+		cookie.Options.SameSite = s.r.Config().SessionSameSiteMode(ctx)
+	}
+
+	cookie.Options.MaxAge = int(time.Until(device.ExpiresAt).Seconds())
+	cookie.Values["device_id"] = device.ID.String()
+	cookie.Values["identity_id"] = device.IdentityID.String()
+
+	if err := cookie.Save(r, w); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (s *ManagerHTTP) FetchTrustedDevice(ctx context.Context, r *http.Request, identityID uuid.UUID) (_ *TrustedDevice, err error) {
+	ctx, span := s.r.Tracer(ctx).Tracer().Start(ctx, "sessions.ManagerHTTP.FetchTrustedDevice")
+	defer otelx.End(span, &err)
+
+	if !s.r.Config().SelfServiceStrategy(ctx, identity.CredentialsTypeTrustedDevice.String()).Enabled {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReason("Trusted devices are disabled."))
+	}
+
+	cookie, err := s.r.CookieManager(ctx).Get(r, TrustedDeviceCookieName)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReason("This device is not trusted.").WithDebug(err.Error()))
+	}
+
+	rawIdentityID, _ := cookie.Values["identity_id"].(string)
+	rawDeviceID, _ := cookie.Values["device_id"].(string)
+	if rawIdentityID != identityID.String() {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReason("This device is not trusted."))
+	}
+
+	device, err := s.r.SessionPersister().GetTrustedDevice(ctx, identityID, x.ParseUUID(rawDeviceID))
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		// The trust was revoked.
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReason("This device is not trusted."))
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !device.IsValid() {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReason("The trust of this device has expired."))
+	}
+
+	return device, nil
+}
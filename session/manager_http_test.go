@@ -20,6 +20,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ory/herodot"
+
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
@@ -571,3 +573,80 @@ func TestDoesSessionSatisfy(t *testing.T) {
 		})
 	}
 }
+
+func TestTrustedDevice(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".trusted_device.enabled", true)
+	conf.MustSet(ctx, config.ViperKeyTrustedDeviceLifespan, "1h")
+
+	i := createAAL2Identity(t, reg)
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+	req := x.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil)
+	s := session.NewInactiveSession()
+	s.CompletedLoginFor(identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+	require.NoError(t, s.Activate(req, i, conf, time.Now().UTC()))
+
+	trust := func(t *testing.T) (*http.Request, *session.TrustedDevice) {
+		w := httptest.NewRecorder()
+		require.NoError(t, reg.SessionManager().IssueTrustedDeviceCookie(ctx, w, req, s))
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, session.TrustedDeviceCookieName, cookies[0].Name)
+		assert.InDelta(t, time.Hour.Seconds(), float64(cookies[0].MaxAge), 5)
+
+		trusted := x.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil)
+		trusted.AddCookie(cookies[0])
+
+		device, err := reg.SessionManager().FetchTrustedDevice(ctx, trusted, i.ID)
+		require.NoError(t, err)
+		return trusted, device
+	}
+
+	t.Run("case=untrusted device requires second factor", func(t *testing.T) {
+		_, err := reg.SessionManager().FetchTrustedDevice(ctx, req, i.ID)
+		assert.ErrorIs(t, err, herodot.ErrNotFound)
+
+		err = reg.SessionManager().DoesSessionSatisfy(req, s, config.HighestAvailableAAL)
+		assert.ErrorAs(t, err, new(*session.ErrAALNotSatisfied))
+	})
+
+	t.Run("case=trusted device satisfies second factor", func(t *testing.T) {
+		trusted, device := trust(t)
+		assert.Equal(t, i.ID, device.IdentityID)
+		assert.True(t, device.IsValid())
+
+		require.NoError(t, reg.SessionManager().DoesSessionSatisfy(trusted, s, config.HighestAvailableAAL))
+	})
+
+	t.Run("case=trust is bound to the identity", func(t *testing.T) {
+		trusted, _ := trust(t)
+		_, err := reg.SessionManager().FetchTrustedDevice(ctx, trusted, x.NewUUID())
+		assert.ErrorIs(t, err, herodot.ErrNotFound)
+	})
+
+	t.Run("case=revoked trust requires second factor", func(t *testing.T) {
+		trusted, device := trust(t)
+		require.NoError(t, reg.SessionPersister().DeleteTrustedDevice(ctx, i.ID, device.ID))
+
+		_, err := reg.SessionManager().FetchTrustedDevice(ctx, trusted, i.ID)
+		assert.ErrorIs(t, err, herodot.ErrNotFound)
+
+		err = reg.SessionManager().DoesSessionSatisfy(trusted, s, config.HighestAvailableAAL)
+		assert.ErrorAs(t, err, new(*session.ErrAALNotSatisfied))
+	})
+
+	t.Run("case=trust is ignored when disabled", func(t *testing.T) {
+		trusted, _ := trust(t)
+		conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".trusted_device.enabled", false)
+		t.Cleanup(func() {
+			conf.MustSet(ctx, config.ViperKeySelfServiceStrategyConfig+".trusted_device.enabled", true)
+		})
+
+		err := reg.SessionManager().DoesSessionSatisfy(trusted, s, config.HighestAvailableAAL)
+		assert.ErrorAs(t, err, new(*session.ErrAALNotSatisfied))
+	})
+}
@@ -26,8 +26,8 @@ type Persister interface {
 	// GetSession retrieves a session from the store.
 	GetSession(ctx context.Context, sid uuid.UUID, expandables Expandables) (*Session, error)
 
-	// ListSessions retrieves all sessions.
-	ListSessions(ctx context.Context, active *bool, paginatorOpts []keysetpagination.Option, expandables Expandables) ([]Session, int64, *keysetpagination.Paginator, error)
+	// ListSessions retrieves all sessions matching the filter.
+	ListSessions(ctx context.Context, filter *ListSessionsFilter, paginatorOpts []keysetpagination.Option, expandables Expandables) ([]Session, int64, *keysetpagination.Paginator, error)
 
 	// ListSessionsByIdentity retrieves sessions for an identity from the store.
 	ListSessionsByIdentity(ctx context.Context, iID uuid.UUID, active *bool, page, perPage int, except uuid.UUID, expandables Expandables) ([]Session, int64, error)
@@ -67,6 +67,41 @@ type Persister interface {
 
 	// RevokeSessionsIdentityExcept marks all except the given session of an identity inactive. It returns the number of sessions that were revoked.
 	RevokeSessionsIdentityExcept(ctx context.Context, iID, sID uuid.UUID) (int, error)
+
+	// RevokeSessions marks all sessions matching the filter inactive. It returns the number of sessions that were revoked.
+	RevokeSessions(ctx context.Context, filter *ListSessionsFilter) (int, error)
+
+	// HasDevice returns true if any session of the identity was used from a device with the IP address and user agent
+	// of the given device.
+	HasDevice(ctx context.Context, iID uuid.UUID, d *Device) (bool, error)
+
+	TrustedDevicePersister
+	RevocationNotificationPersister
+}
+
+type TrustedDevicePersister interface {
+	// CreateTrustedDevice stores a trusted device.
+	CreateTrustedDevice(ctx context.Context, d *TrustedDevice) error
+
+	// GetTrustedDevice retrieves a trusted device of the given identity.
+	GetTrustedDevice(ctx context.Context, identityID, id uuid.UUID) (*TrustedDevice, error)
+
+	// ListTrustedDevices retrieves all trusted devices of the given identity which have not yet expired.
+	ListTrustedDevices(ctx context.Context, identityID uuid.UUID) ([]TrustedDevice, error)
+
+	// DeleteTrustedDevice removes a trusted device of the given identity.
+	DeleteTrustedDevice(ctx context.Context, identityID, id uuid.UUID) error
+
+	// DeleteTrustedDevicesByIdentity removes all trusted devices of the given identity.
+	DeleteTrustedDevicesByIdentity(ctx context.Context, identityID uuid.UUID) error
+}
+
+type RevocationNotificationPersister interface {
+	// NextRevocationNotifications marks up to limit queued revocation notifications as processing and returns them.
+	NextRevocationNotifications(ctx context.Context, limit uint8) ([]RevocationNotification, error)
+
+	// UpdateRevocationNotification stores the delivery status of a revocation notification.
+	UpdateRevocationNotification(ctx context.Context, n *RevocationNotification) error
 }
 
 type DevicePersister interface {
@@ -0,0 +1,71 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// RevocationNotificationStatus is the delivery status of a revocation notification.
+type RevocationNotificationStatus string
+
+const (
+	RevocationNotificationStatusQueued     RevocationNotificationStatus = "queued"
+	RevocationNotificationStatusProcessing RevocationNotificationStatus = "processing"
+	RevocationNotificationStatusSent       RevocationNotificationStatus = "sent"
+	RevocationNotificationStatusAbandoned  RevocationNotificationStatus = "abandoned"
+)
+
+// RevocationNotificationProcessingTimeout is the time after which a notification which is still processing is
+// assumed to belong to a dispatcher which stopped, for example because the process crashed. Such notifications
+// are dispatched again.
+const RevocationNotificationProcessingTimeout = 5 * time.Minute
+
+// RevocationNotification tells a back-channel receiver that a session was revoked or deleted.
+//
+// Notifications are queued in the same transaction which revokes the session and are delivered
+// by the RevocationNotifier.
+type RevocationNotification struct {
+	ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+	// SessionID is the ID of the revoked session. The session itself might no longer exist.
+	SessionID uuid.UUID `json:"session_id" faker:"-" db:"session_id"`
+
+	// IdentityID is the ID of the identity the revoked session belonged to.
+	IdentityID uuid.UUID `json:"identity_id" faker:"-" db:"identity_id"`
+
+	// Receiver is the URL the notification is sent to.
+	Receiver string `json:"receiver" db:"receiver"`
+
+	Status RevocationNotificationStatus `json:"status" db:"status"`
+
+	// SendCount is the number of delivery attempts.
+	SendCount int `json:"send_count" db:"send_count"`
+
+	// LastError is the error of the last failed delivery attempt.
+	LastError string `json:"last_error" db:"last_error"`
+
+	// CreatedAt is the time the session was revoked.
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+
+	NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+}
+
+func (n RevocationNotification) TableName(ctx context.Context) string {
+	return "session_revocation_notifications"
+}
+
+func (n *RevocationNotification) GetID() uuid.UUID {
+	return n.ID
+}
+
+func (n *RevocationNotification) GetNID() uuid.UUID {
+	return n.NID
+}
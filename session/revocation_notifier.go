@@ -0,0 +1,225 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/ory/x/fetcher"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+)
+
+// BackChannelLogoutEvent is the event identifier of the OpenID Connect Back-Channel Logout specification.
+const BackChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+type (
+	revocationNotifierDependencies interface {
+		config.Provider
+		PersistenceProvider
+		x.HTTPClientProvider
+		x.LoggingProvider
+	}
+	RevocationNotifierProvider interface {
+		SessionRevocationNotifier() *RevocationNotifier
+	}
+	// RevocationNotifier delivers queued revocation notifications to the configured back-channel receivers.
+	//
+	// Each notification is a `POST` request with a `logout_token` form parameter. The logout token follows
+	// the OpenID Connect Back-Channel Logout 1.0 specification, so receivers such as API gateways can use
+	// existing implementations to evict cached sessions.
+	RevocationNotifier struct {
+		r revocationNotifierDependencies
+
+		// The signing key is only loaded once per configured location.
+		keyMu       sync.Mutex
+		key         *jose.JSONWebKey
+		keyLocation string
+	}
+)
+
+func NewRevocationNotifier(r revocationNotifierDependencies) *RevocationNotifier {
+	return &RevocationNotifier{r: r}
+}
+
+// Work dispatches the queue until the context is canceled.
+func (n *RevocationNotifier) Work(ctx context.Context) error {
+	for {
+		if err := n.DispatchQueue(ctx); err != nil {
+			n.r.Logger().WithError(err).Error("Unable to dispatch session revocation notifications.")
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// DispatchQueue sends the next batch of queued notifications. Failed deliveries are queued again
+// until they exceed the configured number of retries, after which they are abandoned.
+func (n *RevocationNotifier) DispatchQueue(ctx context.Context) error {
+	notifications, err := n.r.SessionPersister().NextRevocationNotifications(ctx, 10)
+	if err != nil {
+		return err
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	key, err := n.signingKey(ctx)
+	if err != nil {
+		// Put the notifications back so that they are delivered once the key is available.
+		for k := range notifications {
+			notifications[k].Status = RevocationNotificationStatusQueued
+			if err := n.r.SessionPersister().UpdateRevocationNotification(ctx, &notifications[k]); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+
+	maxRetries := n.r.Config().SessionRevocationNotificationRetries(ctx)
+	for k := range notifications {
+		notification := &notifications[k]
+		logger := n.r.Logger().
+			WithField("notification_id", notification.ID).
+			WithField("session_id", notification.SessionID).
+			WithField("receiver", notification.Receiver)
+
+		notification.SendCount++
+		if err := n.deliver(ctx, key, notification); err != nil {
+			notification.LastError = err.Error()
+			notification.Status = RevocationNotificationStatusQueued
+			if notification.SendCount > maxRetries {
+				notification.Status = RevocationNotificationStatusAbandoned
+				logger.WithError(err).Warnf("Session revocation notification was abandoned because it did not deliver after %d attempts.", notification.SendCount)
+			} else {
+				logger.WithError(err).Debug("Unable to deliver session revocation notification, it will be retried.")
+			}
+		} else {
+			notification.LastError = ""
+			notification.Status = RevocationNotificationStatusSent
+			logger.Debug("Delivered session revocation notification.")
+		}
+
+		if err := n.r.SessionPersister().UpdateRevocationNotification(ctx, notification); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *RevocationNotifier) signingKey(ctx context.Context) (*jose.JSONWebKey, error) {
+	location := n.r.Config().SessionRevocationNotificationJWKSURL(ctx)
+	if location == "" {
+		return nil, errors.Errorf("configuration key %s must be set to sign session revocation notifications", config.ViperKeySessionRevocationNotificationJWKSURL)
+	}
+
+	n.keyMu.Lock()
+	defer n.keyMu.Unlock()
+	if n.key != nil && n.keyLocation == location {
+		return n.key, nil
+	}
+
+	raw, err := fetcher.NewFetcher(fetcher.WithClient(n.r.HTTPClient(ctx))).Fetch(location)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var set jose.JSONWebKeySet
+	if err := json.NewDecoder(raw).Decode(&set); err != nil {
+		return nil, errors.Wrap(err, "unable to decode the session revocation notification JSON Web Key Set")
+	}
+
+	if len(set.Keys) == 0 {
+		return nil, errors.New("the session revocation notification JSON Web Key Set does not contain any keys")
+	}
+
+	key := set.Keys[0]
+	if key.IsPublic() {
+		return nil, errors.New("the session revocation notification JSON Web Key Set must contain a private key")
+	}
+	if key.Algorithm == "" {
+		return nil, errors.New(`the session revocation notification signing key must define the "alg" parameter`)
+	}
+
+	n.key, n.keyLocation = &key, location
+	return &key, nil
+}
+
+// NewLogoutToken returns a signed logout token for the notification.
+func (n *RevocationNotifier) NewLogoutToken(ctx context.Context, key *jose.JSONWebKey, notification *RevocationNotification) (string, error) {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.SignatureAlgorithm(key.Algorithm), Key: key},
+		(&jose.SignerOptions{}).WithType("logout+jwt"),
+	)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	now := time.Now().UTC()
+	token, err := jwt.Signed(signer).
+		Claims(&jwt.Claims{
+			ID:       notification.ID.String(),
+			Issuer:   n.r.Config().SelfPublicURL(ctx).String(),
+			Subject:  notification.IdentityID.String(),
+			Audience: jwt.Audience{notification.Receiver},
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(2 * time.Minute)),
+		}).
+		Claims(map[string]interface{}{
+			"sid":    notification.SessionID.String(),
+			"events": map[string]interface{}{BackChannelLogoutEvent: map[string]interface{}{}},
+		}).
+		CompactSerialize()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return token, nil
+}
+
+func (n *RevocationNotifier) deliver(ctx context.Context, key *jose.JSONWebKey, notification *RevocationNotification) error {
+	token, err := n.NewLogoutToken(ctx, key, notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "POST", notification.Receiver, strings.NewReader(url.Values{"logout_token": {token}}.Encode()))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := n.r.HTTPClient(ctx).Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("receiver responded with status code %d", res.StatusCode)
+	}
+
+	return nil
+}
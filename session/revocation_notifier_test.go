@@ -0,0 +1,166 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/session"
+)
+
+func TestRevocationNotifier(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+	conf.MustSet(ctx, config.ViperKeyPublicBaseURL, "https://www.ory.sh/")
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks, err := json.Marshal(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: privateKey, KeyID: "revocation", Algorithm: string(jose.RS256), Use: "sig"}}})
+	require.NoError(t, err)
+	conf.MustSet(ctx, config.ViperKeySessionRevocationNotificationJWKSURL, "base64://"+base64.StdEncoding.EncodeToString(jwks))
+	conf.MustSet(ctx, config.ViperKeySessionRevocationNotificationRetries, 1)
+
+	var (
+		lock     sync.Mutex
+		tokens   []string
+		failures int
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tokens = append(tokens, r.PostFormValue("logout_token"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(receiver.Close)
+	conf.MustSet(ctx, config.ViperKeySessionRevocationNotificationReceivers, []string{receiver.URL})
+
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+	revoke := func(t *testing.T) *session.Session {
+		s, err := session.NewActiveSession(&http.Request{}, i, conf, time.Now(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+		require.NoError(t, reg.SessionPersister().RevokeSession(ctx, i.ID, s.ID))
+		return s
+	}
+
+	reset := func(t *testing.T, f int) {
+		lock.Lock()
+		defer lock.Unlock()
+		tokens, failures = nil, f
+	}
+
+	t.Run("case=sends a signed logout token", func(t *testing.T) {
+		reset(t, 0)
+		s := revoke(t)
+		require.NoError(t, reg.SessionRevocationNotifier().DispatchQueue(ctx))
+
+		require.Len(t, tokens, 1)
+		token, err := jwt.ParseSigned(tokens[0])
+		require.NoError(t, err)
+		require.Len(t, token.Headers, 1)
+		assert.Equal(t, "revocation", token.Headers[0].KeyID)
+		assert.EqualValues(t, "logout+jwt", token.Headers[0].ExtraHeaders[jose.HeaderType])
+
+		var claims jwt.Claims
+		var custom struct {
+			SessionID string                     `json:"sid"`
+			Events    map[string]json.RawMessage `json:"events"`
+		}
+		require.NoError(t, token.Claims(&privateKey.PublicKey, &claims, &custom))
+		require.NoError(t, claims.Validate(jwt.Expected{
+			Issuer:   "https://www.ory.sh/",
+			Subject:  i.ID.String(),
+			Audience: jwt.Audience{receiver.URL},
+			Time:     time.Now(),
+		}))
+		assert.NotEmpty(t, claims.ID)
+		assert.Equal(t, s.ID.String(), custom.SessionID)
+		assert.Contains(t, custom.Events, session.BackChannelLogoutEvent)
+
+		require.NoError(t, reg.SessionRevocationNotifier().DispatchQueue(ctx))
+		assert.Len(t, tokens, 1, "notifications must only be sent once")
+	})
+
+	t.Run("case=retries failed deliveries", func(t *testing.T) {
+		reset(t, 1)
+		revoke(t)
+
+		require.NoError(t, reg.SessionRevocationNotifier().DispatchQueue(ctx))
+		assert.Empty(t, tokens)
+
+		require.NoError(t, reg.SessionRevocationNotifier().DispatchQueue(ctx))
+		assert.Len(t, tokens, 1)
+	})
+
+	t.Run("case=abandons notifications after the maximum retries", func(t *testing.T) {
+		reset(t, 2)
+		revoke(t)
+
+		require.NoError(t, reg.SessionRevocationNotifier().DispatchQueue(ctx))
+		require.NoError(t, reg.SessionRevocationNotifier().DispatchQueue(ctx))
+		assert.Empty(t, tokens)
+
+		actual, err := reg.SessionPersister().NextRevocationNotifications(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+
+	t.Run("case=loads the signing key only once", func(t *testing.T) {
+		reset(t, 0)
+		location := filepath.Join(t.TempDir(), "jwks.json")
+		require.NoError(t, os.WriteFile(location, jwks, 0600))
+		conf.MustSet(ctx, config.ViperKeySessionRevocationNotificationJWKSURL, "file://"+location)
+
+		revoke(t)
+		require.NoError(t, reg.SessionRevocationNotifier().DispatchQueue(ctx))
+		require.Len(t, tokens, 1)
+
+		require.NoError(t, os.WriteFile(location, []byte("not a key set"), 0600))
+		revoke(t)
+		require.NoError(t, reg.SessionRevocationNotifier().DispatchQueue(ctx))
+		assert.Len(t, tokens, 2)
+	})
+
+	t.Run("case=keeps notifications queued without a signing key", func(t *testing.T) {
+		reset(t, 0)
+		conf.MustSet(ctx, config.ViperKeySessionRevocationNotificationJWKSURL, "")
+		revoke(t)
+
+		require.Error(t, reg.SessionRevocationNotifier().DispatchQueue(ctx))
+		assert.Empty(t, tokens)
+
+		actual, err := reg.SessionPersister().NextRevocationNotifications(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, 0, actual[0].SendCount)
+	})
+}
@@ -52,6 +52,10 @@ type Device struct {
 	// IPAddress of the client
 	IPAddress *string `json:"ip_address" faker:"ptr_ipv4" db:"ip_address"`
 
+	// IPAddressKey is the sortable representation of IPAddress used to match
+	// networks. See IPAddressKey.
+	IPAddressKey *string `json:"-" faker:"-" db:"ip_address_key"`
+
 	// UserAgent of the client
 	UserAgent *string `json:"user_agent" faker:"-" db:"user_agent"`
 
@@ -158,6 +162,41 @@ func (s *Session) CompletedLoginFor(method identity.CredentialsType, aal identit
 	s.AMR = append(s.AMR, AuthenticationMethod{Method: method, AAL: aal, CompletedAt: time.Now().UTC()})
 }
 
+// SetUpstream records the upstream identity provider's subject and session ID on the most recent authentication with
+// the given method. They are used to revoke the session when the user logs out at the upstream identity provider.
+func (s *Session) SetUpstream(method identity.CredentialsType, provider, subject, sid string) {
+	for k := len(s.AMR) - 1; k >= 0; k-- {
+		if s.AMR[k].Method == method {
+			s.AMR[k].Provider = provider
+			s.AMR[k].UpstreamSubject = subject
+			s.AMR[k].UpstreamSessionID = sid
+			return
+		}
+	}
+}
+
+// AuthenticatedWithin returns true if the session completed one of the given methods (or any method if none is given)
+// within maxAge. A maxAge of zero accepts authentications of any age.
+func (s *Session) AuthenticatedWithin(maxAge time.Duration, methods ...identity.CredentialsType) bool {
+	for _, amr := range s.AMR {
+		if maxAge > 0 && time.Since(amr.CompletedAt) > maxAge {
+			continue
+		}
+
+		if len(methods) == 0 {
+			return true
+		}
+
+		for _, m := range methods {
+			if amr.Method == m {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (s *Session) SetAuthenticatorAssuranceLevel() {
 	if len(s.AMR) == 0 {
 		// No AMR is set
@@ -309,6 +348,42 @@ type AuthenticationMethod struct {
 
 	// When the authentication challenge was completed.
 	CompletedAt time.Time `json:"completed_at"`
+
+	// The ID of the upstream identity provider used for the authentication (e.g. when using the `oidc` method).
+	Provider string `json:"provider,omitempty"`
+
+	// The subject at the upstream identity provider. It is only stored to match back-channel logouts and not
+	// exposed in the API.
+	UpstreamSubject string `json:"-"`
+
+	// The session ID (`sid`) at the upstream identity provider, if it reported one. It is only stored to match
+	// back-channel logouts and not exposed in the API.
+	UpstreamSessionID string `json:"-"`
+}
+
+// storedAuthenticationMethod is the representation of an AuthenticationMethod in the database, which unlike the API
+// representation includes the upstream subject and session ID.
+type storedAuthenticationMethod struct {
+	authenticationMethod
+	UpstreamSubject   string `json:"upstream_subject,omitempty"`
+	UpstreamSessionID string `json:"upstream_sid,omitempty"`
+}
+
+type authenticationMethod AuthenticationMethod
+
+func (n AuthenticationMethod) stored() storedAuthenticationMethod {
+	return storedAuthenticationMethod{
+		authenticationMethod: authenticationMethod(n),
+		UpstreamSubject:      n.UpstreamSubject,
+		UpstreamSessionID:    n.UpstreamSessionID,
+	}
+}
+
+func (n storedAuthenticationMethod) method() AuthenticationMethod {
+	m := AuthenticationMethod(n.authenticationMethod)
+	m.UpstreamSubject = n.UpstreamSubject
+	m.UpstreamSessionID = n.UpstreamSessionID
+	return m
 }
 
 // Scan implements the Scanner interface.
@@ -317,12 +392,18 @@ func (n *AuthenticationMethod) Scan(value interface{}) error {
 	if len(v) == 0 {
 		return nil
 	}
-	return errors.WithStack(json.Unmarshal([]byte(v), n))
+
+	var stored storedAuthenticationMethod
+	if err := json.Unmarshal([]byte(v), &stored); err != nil {
+		return errors.WithStack(err)
+	}
+	*n = stored.method()
+	return nil
 }
 
 // Value implements the driver Valuer interface.
 func (n AuthenticationMethod) Value() (driver.Value, error) {
-	value, err := json.Marshal(n)
+	value, err := json.Marshal(n.stored())
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -335,12 +416,35 @@ func (n *AuthenticationMethods) Scan(value interface{}) error {
 	if len(v) == 0 {
 		return nil
 	}
-	return errors.WithStack(json.Unmarshal([]byte(v), n))
+
+	var stored []storedAuthenticationMethod
+	if err := json.Unmarshal([]byte(v), &stored); err != nil {
+		return errors.WithStack(err)
+	}
+	if stored == nil {
+		*n = nil
+		return nil
+	}
+
+	methods := make(AuthenticationMethods, len(stored))
+	for k := range stored {
+		methods[k] = stored[k].method()
+	}
+	*n = methods
+	return nil
 }
 
 // Value implements the driver Valuer interface.
 func (n AuthenticationMethods) Value() (driver.Value, error) {
-	value, err := json.Marshal(n)
+	var stored []storedAuthenticationMethod
+	if n != nil {
+		stored = make([]storedAuthenticationMethod, len(n))
+		for k := range n {
+			stored[k] = n[k].stored()
+		}
+	}
+
+	value, err := json.Marshal(stored)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -5,6 +5,7 @@ package session_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
@@ -59,6 +61,45 @@ func TestSession(t *testing.T) {
 		assert.EqualValues(t, identity.CredentialsTypeRecoveryCode, s.AMR[2].Method)
 	})
 
+	t.Run("case=upstream session is stored but not exposed", func(t *testing.T) {
+		s := session.NewInactiveSession()
+		s.CompletedLoginFor(identity.CredentialsTypeOIDC, identity.AuthenticatorAssuranceLevel1)
+		s.SetUpstream(identity.CredentialsTypeOIDC, "generic", "upstream-subject", "upstream-sid")
+
+		out, err := json.Marshal(s)
+		require.NoError(t, err)
+		assert.Equal(t, "generic", gjson.GetBytes(out, "authentication_methods.0.provider").String())
+		assert.False(t, gjson.GetBytes(out, "authentication_methods.0.upstream_subject").Exists())
+		assert.False(t, gjson.GetBytes(out, "authentication_methods.0.upstream_sid").Exists())
+
+		stored, err := s.AMR.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "upstream-subject", gjson.Get(stored.(string), "0.upstream_subject").String())
+		assert.Equal(t, "upstream-sid", gjson.Get(stored.(string), "0.upstream_sid").String())
+
+		var actual session.AuthenticationMethods
+		require.NoError(t, actual.Scan(stored))
+		assert.Equal(t, s.AMR, actual)
+	})
+
+	t.Run("case=authenticated within", func(t *testing.T) {
+		s := session.NewInactiveSession()
+		assert.False(t, s.AuthenticatedWithin(0))
+
+		s.AMR = session.AuthenticationMethods{
+			{Method: identity.CredentialsTypePassword, AAL: identity.AuthenticatorAssuranceLevel1, CompletedAt: time.Now().Add(-time.Hour)},
+			{Method: identity.CredentialsTypeTOTP, AAL: identity.AuthenticatorAssuranceLevel2, CompletedAt: time.Now().Add(-time.Minute)},
+		}
+
+		assert.True(t, s.AuthenticatedWithin(0))
+		assert.True(t, s.AuthenticatedWithin(5*time.Minute))
+		assert.False(t, s.AuthenticatedWithin(30*time.Second))
+		assert.True(t, s.AuthenticatedWithin(0, identity.CredentialsTypePassword))
+		assert.False(t, s.AuthenticatedWithin(5*time.Minute, identity.CredentialsTypePassword))
+		assert.True(t, s.AuthenticatedWithin(5*time.Minute, identity.CredentialsTypePassword, identity.CredentialsTypeTOTP))
+		assert.False(t, s.AuthenticatedWithin(0, identity.CredentialsTypeWebAuthn))
+	})
+
 	t.Run("case=activate", func(t *testing.T) {
 		req := x.NewTestHTTPRequest(t, "GET", "/sessions/whoami", nil)
 
@@ -5,6 +5,7 @@ package test
 
 import (
 	"context"
+	"net"
 	"testing"
 	"time"
 
@@ -282,7 +283,7 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			} {
 				t.Run("case=all "+tc.desc, func(t *testing.T) {
 					paginatorOpts := make([]keysetpagination.Option, 0)
-					actual, total, nextPage, err := l.ListSessions(ctx, tc.active, paginatorOpts, session.ExpandEverything)
+					actual, total, nextPage, err := l.ListSessions(ctx, &session.ListSessionsFilter{Active: tc.active}, paginatorOpts, session.ExpandEverything)
 					require.NoError(t, err, "%+v", err)
 
 					require.Equal(t, len(tc.expected), len(actual))
@@ -306,7 +307,7 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 
 			t.Run("case=all sessions pagination only one page", func(t *testing.T) {
 				paginatorOpts := make([]keysetpagination.Option, 0)
-				actual, total, page, err := l.ListSessions(ctx, nil, paginatorOpts, session.ExpandEverything)
+				actual, total, page, err := l.ListSessions(ctx, new(session.ListSessionsFilter), paginatorOpts, session.ExpandEverything)
 				require.NoError(t, err)
 
 				require.Equal(t, 6, len(actual))
@@ -319,7 +320,7 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			t.Run("case=all sessions pagination multiple pages", func(t *testing.T) {
 				paginatorOpts := make([]keysetpagination.Option, 0)
 				paginatorOpts = append(paginatorOpts, keysetpagination.WithSize(3))
-				firstPageItems, total, page1, err := l.ListSessions(ctx, nil, paginatorOpts, session.ExpandEverything)
+				firstPageItems, total, page1, err := l.ListSessions(ctx, new(session.ListSessionsFilter), paginatorOpts, session.ExpandEverything)
 				require.NoError(t, err)
 				require.Equal(t, int64(6), total)
 				assert.Len(t, firstPageItems, 3)
@@ -329,7 +330,7 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 				assert.Equal(t, 3, page1.Size())
 
 				// Validate secondPageItems page
-				secondPageItems, total, page2, err := l.ListSessions(ctx, nil, page1.ToOptions(), session.ExpandEverything)
+				secondPageItems, total, page2, err := l.ListSessions(ctx, new(session.ListSessionsFilter), page1.ToOptions(), session.ExpandEverything)
 				require.NoError(t, err)
 
 				acutalIDs := make([]uuid.UUID, 0)
@@ -537,6 +538,102 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			}
 		})
 
+		t.Run("case=filter and revoke sessions", func(t *testing.T) {
+			_, l := testhelpers.NewNetwork(t, ctx, p)
+
+			var i1, i2 identity.Identity
+			require.NoError(t, faker.FakeData(&i1))
+			require.NoError(t, faker.FakeData(&i2))
+			require.NoError(t, l.CreateIdentity(ctx, &i1))
+			require.NoError(t, l.CreateIdentity(ctx, &i2))
+
+			now := time.Now().UTC().Round(time.Second)
+			newSession := func(i *identity.Identity, aal identity.AuthenticatorAssuranceLevel, method identity.CredentialsType, authenticatedAt time.Time, ip, ua string) session.Session {
+				var s session.Session
+				require.NoError(t, faker.FakeData(&s))
+				s.Identity = i
+				s.Active = true
+				s.ExpiresAt = now.Add(time.Hour)
+				s.AuthenticatedAt = authenticatedAt
+				s.AuthenticatorAssuranceLevel = aal
+				s.AMR = session.AuthenticationMethods{{Method: method, AAL: aal, CompletedAt: authenticatedAt}}
+				s.SetUpstream(identity.CredentialsTypeOIDC, "generic", "upstream-subject", "upstream-sid")
+				s.Devices = []session.Device{{IPAddress: pointerx.String(ip), UserAgent: pointerx.String(ua)}}
+				require.NoError(t, l.UpsertSession(ctx, &s))
+				return s
+			}
+
+			sessions := []session.Session{
+				newSession(&i1, identity.AuthenticatorAssuranceLevel1, identity.CredentialsTypePassword, now.Add(-time.Hour), "10.0.0.1", "Mozilla/5.0 Firefox/110.0"),
+				newSession(&i1, identity.AuthenticatorAssuranceLevel2, identity.CredentialsTypeWebAuthn, now, "10.0.1.7", "Mozilla/5.0 Chrome/111.0"),
+				newSession(&i2, identity.AuthenticatorAssuranceLevel1, identity.CredentialsTypeOIDC, now, "192.168.1.1", "curl/7.88.1"),
+			}
+
+			for _, tc := range []struct {
+				desc     string
+				filter   session.ListSessionsFilter
+				expected []session.Session
+			}{
+				{desc: "identity", filter: session.ListSessionsFilter{IdentityID: i2.ID}, expected: sessions[2:]},
+				{desc: "aal", filter: session.ListSessionsFilter{AAL: identity.AuthenticatorAssuranceLevel2}, expected: sessions[1:2]},
+				{desc: "method", filter: session.ListSessionsFilter{AuthenticationMethod: identity.CredentialsTypeOIDC}, expected: sessions[2:]},
+				{desc: "authenticated before", filter: session.ListSessionsFilter{AuthenticatedBefore: now.Add(-time.Minute)}, expected: sessions[:1]},
+				{desc: "authenticated after", filter: session.ListSessionsFilter{AuthenticatedAfter: now.Add(-time.Minute)}, expected: sessions[1:]},
+				{desc: "ip address", filter: session.ListSessionsFilter{IPNetwork: mustParseIPNetwork(t, "192.168.1.1")}, expected: sessions[2:]},
+				{desc: "cidr", filter: session.ListSessionsFilter{IPNetwork: mustParseIPNetwork(t, "10.0.0.0/16")}, expected: sessions[:2]},
+				{desc: "cidr without match", filter: session.ListSessionsFilter{IPNetwork: mustParseIPNetwork(t, "172.16.0.0/12")}},
+				{desc: "user agent", filter: session.ListSessionsFilter{UserAgent: "chrome"}, expected: sessions[1:2]},
+				{desc: "user agent with wildcard", filter: session.ListSessionsFilter{UserAgent: "%"}},
+				{desc: "combined", filter: session.ListSessionsFilter{IdentityID: i1.ID, IPNetwork: mustParseIPNetwork(t, "10.0.0.0/8"), AAL: identity.AuthenticatorAssuranceLevel1}, expected: sessions[:1]},
+				{desc: "upstream session", filter: session.ListSessionsFilter{UpstreamProvider: "generic", UpstreamSessionID: "upstream-sid"}, expected: sessions[2:]},
+				{desc: "upstream subject", filter: session.ListSessionsFilter{UpstreamProvider: "generic", UpstreamSubject: "upstream-subject"}, expected: sessions[2:]},
+				{desc: "upstream session of another provider", filter: session.ListSessionsFilter{UpstreamProvider: "other", UpstreamSessionID: "upstream-sid"}},
+			} {
+				t.Run("case=list by "+tc.desc, func(t *testing.T) {
+					actual, total, _, err := l.ListSessions(ctx, &tc.filter, nil, session.ExpandNothing)
+					require.NoError(t, err)
+
+					expectedIDs := make([]uuid.UUID, len(tc.expected))
+					for k := range tc.expected {
+						expectedIDs[k] = tc.expected[k].ID
+					}
+					actualIDs := make([]uuid.UUID, len(actual))
+					for k := range actual {
+						actualIDs[k] = actual[k].ID
+					}
+					assert.Equal(t, int64(len(tc.expected)), total)
+					assert.ElementsMatch(t, expectedIDs, actualIDs)
+				})
+			}
+
+			t.Run("case=revoke requires a filter", func(t *testing.T) {
+				_, err := l.RevokeSessions(ctx, new(session.ListSessionsFilter))
+				require.Error(t, err)
+
+				_, err = l.RevokeSessions(ctx, &session.ListSessionsFilter{Active: pointerx.Bool(true)})
+				require.Error(t, err)
+			})
+
+			t.Run("case=revoke on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				n, err := other.RevokeSessions(ctx, &session.ListSessionsFilter{IPNetwork: mustParseIPNetwork(t, "10.0.0.0/8")})
+				require.NoError(t, err)
+				assert.Equal(t, 0, n)
+			})
+
+			t.Run("case=revoke by cidr", func(t *testing.T) {
+				n, err := l.RevokeSessions(ctx, &session.ListSessionsFilter{IPNetwork: mustParseIPNetwork(t, "10.0.0.0/8")})
+				require.NoError(t, err)
+				assert.Equal(t, 2, n)
+
+				for k, s := range sessions {
+					actual, err := l.GetSession(ctx, s.ID, session.ExpandNothing)
+					require.NoError(t, err)
+					assert.Equal(t, k == 2, actual.Active)
+				}
+			})
+		})
+
 		t.Run("case=delete session for", func(t *testing.T) {
 			var expected1 session.Session
 			var expected2 session.Session
@@ -566,6 +663,222 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			require.Error(t, err)
 		})
 
+		t.Run("case=trusted devices", func(t *testing.T) {
+			_, l := testhelpers.NewNetwork(t, ctx, p)
+
+			var i identity.Identity
+			require.NoError(t, faker.FakeData(&i))
+			require.NoError(t, l.CreateIdentity(ctx, &i))
+
+			active := &session.TrustedDevice{IdentityID: i.ID, IPAddress: pointerx.String("127.0.0.1"), UserAgent: pointerx.String("Mozilla/5.0"), ExpiresAt: time.Now().UTC().Add(time.Hour)}
+			expired := &session.TrustedDevice{IdentityID: i.ID, ExpiresAt: time.Now().UTC().Add(-time.Hour)}
+			require.NoError(t, l.CreateTrustedDevice(ctx, active))
+			require.NoError(t, l.CreateTrustedDevice(ctx, expired))
+
+			t.Run("case=get", func(t *testing.T) {
+				actual, err := l.GetTrustedDevice(ctx, i.ID, active.ID)
+				require.NoError(t, err)
+				assert.Equal(t, active.ID, actual.ID)
+				assert.Equal(t, "127.0.0.1", *actual.IPAddress)
+				assert.Equal(t, "Mozilla/5.0", *actual.UserAgent)
+				assert.True(t, actual.IsValid())
+
+				actual, err = l.GetTrustedDevice(ctx, i.ID, expired.ID)
+				require.NoError(t, err)
+				assert.False(t, actual.IsValid())
+
+				_, err = l.GetTrustedDevice(ctx, x.NewUUID(), active.ID)
+				assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+
+			t.Run("case=list only returns valid devices", func(t *testing.T) {
+				actual, err := l.ListTrustedDevices(ctx, i.ID)
+				require.NoError(t, err)
+				require.Len(t, actual, 1)
+				assert.Equal(t, active.ID, actual[0].ID)
+			})
+
+			t.Run("case=on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				_, err := other.GetTrustedDevice(ctx, i.ID, active.ID)
+				assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				actual, err := other.ListTrustedDevices(ctx, i.ID)
+				require.NoError(t, err)
+				assert.Len(t, actual, 0)
+
+				assert.ErrorIs(t, other.DeleteTrustedDevice(ctx, i.ID, active.ID), sqlcon.ErrNoRows)
+				require.NoError(t, other.DeleteTrustedDevicesByIdentity(ctx, i.ID))
+
+				_, err = l.GetTrustedDevice(ctx, i.ID, active.ID)
+				require.NoError(t, err)
+			})
+
+			t.Run("case=delete", func(t *testing.T) {
+				assert.ErrorIs(t, l.DeleteTrustedDevice(ctx, x.NewUUID(), active.ID), sqlcon.ErrNoRows)
+				require.NoError(t, l.DeleteTrustedDevice(ctx, i.ID, active.ID))
+				_, err := l.GetTrustedDevice(ctx, i.ID, active.ID)
+				assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				require.NoError(t, l.DeleteTrustedDevicesByIdentity(ctx, i.ID))
+				_, err = l.GetTrustedDevice(ctx, i.ID, expired.ID)
+				assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+		})
+
+		t.Run("case=has device", func(t *testing.T) {
+			_, l := testhelpers.NewNetwork(t, ctx, p)
+
+			var i1, i2 identity.Identity
+			require.NoError(t, faker.FakeData(&i1))
+			require.NoError(t, faker.FakeData(&i2))
+			require.NoError(t, l.CreateIdentity(ctx, &i1))
+			require.NoError(t, l.CreateIdentity(ctx, &i2))
+
+			var s session.Session
+			require.NoError(t, faker.FakeData(&s))
+			s.Identity = &i1
+			s.Devices = []session.Device{
+				{IPAddress: pointerx.String("10.0.0.1"), UserAgent: pointerx.String("Mozilla/5.0 Firefox/110.0")},
+				{IPAddress: pointerx.String("10.0.0.2")},
+			}
+			require.NoError(t, l.UpsertSession(ctx, &s))
+
+			for _, tc := range []struct {
+				desc     string
+				identity uuid.UUID
+				device   session.Device
+				expected bool
+			}{
+				{desc: "same device", identity: i1.ID, device: session.Device{IPAddress: pointerx.String("10.0.0.1"), UserAgent: pointerx.String("Mozilla/5.0 Firefox/110.0")}, expected: true},
+				{desc: "device without user agent", identity: i1.ID, device: session.Device{IPAddress: pointerx.String("10.0.0.2")}, expected: true},
+				{desc: "other ip address", identity: i1.ID, device: session.Device{IPAddress: pointerx.String("10.0.0.3"), UserAgent: pointerx.String("Mozilla/5.0 Firefox/110.0")}},
+				{desc: "other user agent", identity: i1.ID, device: session.Device{IPAddress: pointerx.String("10.0.0.1"), UserAgent: pointerx.String("Mozilla/5.0 Chrome/111.0")}},
+				{desc: "other identity", identity: i2.ID, device: session.Device{IPAddress: pointerx.String("10.0.0.1"), UserAgent: pointerx.String("Mozilla/5.0 Firefox/110.0")}},
+			} {
+				t.Run("case="+tc.desc, func(t *testing.T) {
+					actual, err := l.HasDevice(ctx, tc.identity, &tc.device)
+					require.NoError(t, err)
+					assert.Equal(t, tc.expected, actual)
+				})
+			}
+
+			t.Run("case=on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				actual, err := other.HasDevice(ctx, i1.ID, &s.Devices[0])
+				require.NoError(t, err)
+				assert.False(t, actual)
+			})
+		})
+
+		t.Run("case=revocation notifications", func(t *testing.T) {
+			_, l := testhelpers.NewNetwork(t, ctx, p)
+			conf.MustSet(ctx, config.ViperKeySessionRevocationNotificationReceivers, []string{"https://gateway-a.ory.sh/logout", "https://gateway-b.ory.sh/logout"})
+			t.Cleanup(func() {
+				conf.MustSet(ctx, config.ViperKeySessionRevocationNotificationReceivers, []string{})
+			})
+
+			var i identity.Identity
+			require.NoError(t, faker.FakeData(&i))
+			require.NoError(t, l.CreateIdentity(ctx, &i))
+
+			newSession := func(t *testing.T) *session.Session {
+				var s session.Session
+				require.NoError(t, faker.FakeData(&s))
+				s.Identity = &i
+				s.Active = true
+				s.ExpiresAt = time.Now().Add(time.Hour).UTC()
+				require.NoError(t, l.UpsertSession(ctx, &s))
+				return &s
+			}
+
+			next := func(t *testing.T) []session.RevocationNotification {
+				n, err := l.NextRevocationNotifications(ctx, 10)
+				require.NoError(t, err)
+				return n
+			}
+
+			t.Run("case=queues a notification per receiver", func(t *testing.T) {
+				s := newSession(t)
+				require.NoError(t, l.RevokeSession(ctx, i.ID, s.ID))
+
+				actual := next(t)
+				require.Len(t, actual, 2)
+				for _, n := range actual {
+					assert.Equal(t, s.ID, n.SessionID)
+					assert.Equal(t, i.ID, n.IdentityID)
+					assert.Equal(t, session.RevocationNotificationStatusProcessing, n.Status)
+				}
+				assert.ElementsMatch(t, []string{"https://gateway-a.ory.sh/logout", "https://gateway-b.ory.sh/logout"}, []string{actual[0].Receiver, actual[1].Receiver})
+
+				assert.Empty(t, next(t), "processing notifications must not be returned again")
+
+				// Notifications of a dispatcher which stopped while processing them are dispatched again.
+				require.NoError(t, p.GetConnection(ctx).RawQuery(
+					"UPDATE session_revocation_notifications SET updated_at = ? WHERE id = ?",
+					time.Now().UTC().Add(-session.RevocationNotificationProcessingTimeout-time.Minute), actual[1].ID,
+				).Exec())
+				reclaimed := next(t)
+				require.Len(t, reclaimed, 1)
+				assert.Equal(t, actual[1].ID, reclaimed[0].ID)
+				assert.Empty(t, next(t))
+
+				actual[0].Status = session.RevocationNotificationStatusQueued
+				actual[0].SendCount = 1
+				actual[0].LastError = "connection refused"
+				require.NoError(t, l.UpdateRevocationNotification(ctx, &actual[0]))
+
+				requeued := next(t)
+				require.Len(t, requeued, 1)
+				assert.Equal(t, actual[0].ID, requeued[0].ID)
+				assert.Equal(t, 1, requeued[0].SendCount)
+				assert.Equal(t, "connection refused", requeued[0].LastError)
+			})
+
+			t.Run("case=does not notify about inactive sessions", func(t *testing.T) {
+				s := newSession(t)
+				require.NoError(t, l.RevokeSession(ctx, i.ID, s.ID))
+				require.Len(t, next(t), 2)
+
+				require.NoError(t, l.DeleteSession(ctx, s.ID))
+				assert.Empty(t, next(t))
+			})
+
+			t.Run("case=notifies about deleted and bulk revoked sessions", func(t *testing.T) {
+				s1, s2, s3 := newSession(t), newSession(t), newSession(t)
+
+				require.NoError(t, l.DeleteSessionByToken(ctx, s1.Token))
+				require.Len(t, next(t), 2)
+
+				_, err := l.RevokeSessionsIdentityExcept(ctx, i.ID, s3.ID)
+				require.NoError(t, err)
+				actual := next(t)
+				require.Len(t, actual, 2)
+				assert.Equal(t, s2.ID, actual[0].SessionID)
+
+				count, err := l.RevokeSessions(ctx, &session.ListSessionsFilter{IdentityID: i.ID, Active: pointerx.Bool(true)})
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+				actual = next(t)
+				require.Len(t, actual, 2)
+				assert.Equal(t, s3.ID, actual[0].SessionID)
+			})
+
+			t.Run("case=on another network", func(t *testing.T) {
+				newSession(t)
+				require.NoError(t, l.DeleteSessionsByIdentity(ctx, i.ID))
+
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				actual, err := other.NextRevocationNotifications(ctx, 10)
+				require.NoError(t, err)
+				assert.Empty(t, actual)
+
+				n := next(t)
+				require.Len(t, n, 2)
+				assert.ErrorIs(t, other.UpdateRevocationNotification(ctx, &n[0]), sqlcon.ErrNoRows)
+			})
+		})
+
 		t.Run("network isolation", func(t *testing.T) {
 			nid1, p := testhelpers.NewNetwork(t, ctx, p)
 			nid2, _ := testhelpers.NewNetwork(t, ctx, p)
@@ -591,3 +904,9 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 		})
 	}
 }
+
+func mustParseIPNetwork(t *testing.T, raw string) *net.IPNet {
+	network, err := session.ParseIPNetwork(raw)
+	require.NoError(t, err)
+	return network
+}
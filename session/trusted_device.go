@@ -0,0 +1,80 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/stringsx"
+)
+
+// TrustedDeviceCookieName is the name of the cookie which marks a browser as trusted.
+const TrustedDeviceCookieName = "ory_kratos_trusted_device"
+
+// Trusted Device
+//
+// A trusted device is a browser in which the identity completed a second factor and asked
+// to be remembered. While the trust is valid, the second factor is not requested again on that browser.
+//
+// swagger:model trustedDevice
+type TrustedDevice struct {
+	// Trusted device ID
+	//
+	// required: true
+	ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+	// IdentityID is the ID of the identity which trusts this device.
+	IdentityID uuid.UUID `json:"-" faker:"-" db:"identity_id"`
+
+	// IPAddress of the client when the device was trusted
+	IPAddress *string `json:"ip_address" faker:"ptr_ipv4" db:"ip_address"`
+
+	// UserAgent of the client when the device was trusted
+	UserAgent *string `json:"user_agent" faker:"-" db:"user_agent"`
+
+	// ExpiresAt is the time when the trust expires.
+	//
+	// required: true
+	ExpiresAt time.Time `json:"expires_at" faker:"time_type" db:"expires_at"`
+
+	// CreatedAt is the time when the device was trusted.
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+
+	NID uuid.UUID `json:"-"  faker:"-" db:"nid"`
+}
+
+func (m TrustedDevice) TableName(ctx context.Context) string {
+	return "session_trusted_devices"
+}
+
+// NewTrustedDevice creates a new trusted device for the identity using the client information of the request.
+func NewTrustedDevice(r *http.Request, identityID uuid.UUID, lifespan time.Duration) *TrustedDevice {
+	d := &TrustedDevice{
+		IdentityID: identityID,
+		IPAddress:  stringsx.GetPointer(httpx.ClientIP(r)),
+		ExpiresAt:  time.Now().UTC().Add(lifespan),
+	}
+
+	if agent := r.Header["User-Agent"]; len(agent) > 0 {
+		d.UserAgent = stringsx.GetPointer(strings.Join(agent, " "))
+	}
+
+	return d
+}
+
+// IsValid returns true if the trust has not yet expired.
+func (m *TrustedDevice) IsValid() bool {
+	return m.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,141 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/securecookie"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/driver/config"
+)
+
+const unrecognizedLoginTokenName = "ory_kratos_unrecognized_login"
+
+type unrecognizedLogin struct {
+	SessionID  uuid.UUID
+	IdentityID uuid.UUID
+}
+
+func unrecognizedLoginCodecs(ctx context.Context, c *config.Config) []securecookie.Codec {
+	var keys [][]byte
+	for _, k := range c.SecretsSession(ctx) {
+		encrypt := sha256.Sum256(k)
+		keys = append(keys, k, encrypt[:])
+	}
+
+	codecs := securecookie.CodecsFromPairs(keys...)
+	for _, codec := range codecs {
+		if sc, ok := codec.(*securecookie.SecureCookie); ok {
+			// The link must not outlive the session it revokes.
+			sc.MaxAge(int(c.SessionLifespan(ctx).Seconds()))
+		}
+	}
+	return codecs
+}
+
+// NewUnrecognizedLoginURL returns the link which revokes the given session and starts account recovery if the
+// identity does not recognize the login. The link is signed and encrypted using the session secrets.
+func NewUnrecognizedLoginURL(ctx context.Context, c *config.Config, s *Session) (*url.URL, error) {
+	token, err := securecookie.EncodeMulti(unrecognizedLoginTokenName, &unrecognizedLogin{
+		SessionID:  s.ID,
+		IdentityID: s.IdentityID,
+	}, unrecognizedLoginCodecs(ctx, c)...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return urlx.CopyWithQuery(
+		urlx.AppendPaths(c.SelfPublicURL(ctx), RouteUnrecognizedLogin),
+		url.Values{"token": {token}},
+	), nil
+}
+
+func parseUnrecognizedLoginToken(ctx context.Context, c *config.Config, token string) (*unrecognizedLogin, error) {
+	var l unrecognizedLogin
+	if err := securecookie.DecodeMulti(unrecognizedLoginTokenName, token, &l, unrecognizedLoginCodecs(ctx, c)...); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The link is invalid or has expired. Please sign in and review your active sessions instead."))
+	}
+	return &l, nil
+}
+
+var unrecognizedLoginConfirmation = template.Must(template.New("").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Sign out unrecognized login</title>
+</head>
+<body>
+<h1>Was this not you?</h1>
+<p>Confirm to sign out the session of the login you do not recognize. You will then be able to secure your account.</p>
+<form method="POST" action="{{ .Action }}">
+<input type="hidden" name="token" value="{{ .Token }}">
+<button type="submit">Sign out session</button>
+</form>
+</body>
+</html>
+`))
+
+// confirmUnrecognizedLogin is linked in the messages sent by the `notify_new_device` login hook. It does not revoke
+// anything by itself, as mail scanners and link previews follow links in messages. Instead, it renders a page which
+// asks the identity to confirm the revocation.
+func (h *Handler) confirmUnrecognizedLogin(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	token := r.URL.Query().Get("token")
+	if _, err := parseUnrecognizedLoginToken(ctx, h.r.Config(), token); err != nil {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	if err := unrecognizedLoginConfirmation.Execute(w, struct{ Action, Token string }{
+		Action: urlx.AppendPaths(h.r.Config().SelfPublicURL(ctx), RouteUnrecognizedLogin).String(),
+		Token:  token,
+	}); err != nil {
+		h.r.Logger().WithError(err).Error("Unable to render the unrecognized login confirmation.")
+	}
+}
+
+// revokeUnrecognizedLogin is submitted from the page rendered by confirmUnrecognizedLogin. It revokes the session
+// which was issued by the unrecognized login and redirects the browser to the account recovery UI or, if recovery is
+// disabled, to the login UI.
+func (h *Handler) revokeUnrecognizedLogin(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	l, err := parseUnrecognizedLoginToken(ctx, h.r.Config(), r.PostFormValue("token"))
+	if err != nil {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	if err := h.r.SessionPersister().RevokeSession(ctx, l.IdentityID, l.SessionID); err != nil {
+		h.r.SelfServiceErrorManager().Forward(ctx, w, r, err)
+		return
+	}
+
+	h.r.Audit().
+		WithRequest(r).
+		WithField("identity_id", l.IdentityID).
+		WithField("session_id", l.SessionID).
+		Info("Session was revoked because the identity did not recognize the login.")
+
+	returnTo := h.r.Config().SelfServiceFlowLoginUI(ctx)
+	if h.r.Config().SelfServiceFlowRecoveryEnabled(ctx) {
+		returnTo = h.r.Config().SelfServiceFlowRecoveryUI(ctx)
+	}
+
+	http.Redirect(w, r, returnTo.String(), http.StatusSeeOther)
+}
@@ -23,6 +23,11 @@ const (
 	InfoSelfServiceLoginContinueWebAuthn                         // 1010011
 	InfoSelfServiceLoginWebAuthnPasswordless                     // 1010012
 	InfoSelfServiceLoginContinue                                 // 1010013
+	InfoSelfServiceLoginPasskey                                  // 1010014
+	InfoSelfServiceLoginOTPSend                                  // 1010015
+	InfoSelfServiceLoginOTPSent                                  // 1010016
+	InfoSelfServiceLoginHOTPLabel                                // 1010017
+	InfoLoginHOTP                                                // 1010018
 )
 
 const (
@@ -61,6 +66,13 @@ const (
 	InfoSelfServiceSettingsDisableLookup
 	InfoSelfServiceSettingsTOTPSecretLabel
 	InfoSelfServiceSettingsRemoveWebAuthn
+	InfoSelfServiceSettingsRevokeTrustedDevice
+	InfoSelfServiceSettingsRemoveWebAuthnAuthenticator
+	InfoSelfServiceSettingsEnableOTP
+	InfoSelfServiceSettingsDisableOTP
+	InfoSelfServiceSettingsMFAEnrollmentPending
+	InfoSelfServiceSettingsMFAEnrollmentOverdue
+	InfoSelfServiceSettingsUpdateUnlinkHOTP
 )
 
 const (
@@ -71,16 +83,17 @@ const (
 )
 
 const (
-	InfoNodeLabel              ID = 1070000 + iota // 1070000
-	InfoNodeLabelInputPassword                     // 1070001
-	InfoNodeLabelGenerated                         // 1070002
-	InfoNodeLabelSave                              // 1070003
-	InfoNodeLabelID                                // 1070004
-	InfoNodeLabelSubmit                            // 1070005
-	InfoNodeLabelVerifyOTP                         // 1070006
-	InfoNodeLabelEmail                             // 1070007
-	InfoNodeLabelResendOTP                         // 1070008
-	InfoNodeLabelContinue                          // 1070009
+	InfoNodeLabel               ID = 1070000 + iota // 1070000
+	InfoNodeLabelInputPassword                      // 1070001
+	InfoNodeLabelGenerated                          // 1070002
+	InfoNodeLabelSave                               // 1070003
+	InfoNodeLabelID                                 // 1070004
+	InfoNodeLabelSubmit                             // 1070005
+	InfoNodeLabelVerifyOTP                          // 1070006
+	InfoNodeLabelEmail                              // 1070007
+	InfoNodeLabelResendOTP                          // 1070008
+	InfoNodeLabelContinue                           // 1070009
+	InfoNodeLabelRememberDevice                     // 1070010
 )
 
 const (
@@ -119,6 +132,16 @@ const (
 	ErrorValidationUniqueItems
 	ErrorValidationWrongType
 	ErrorValidationDuplicateCredentialsOnOIDCLink
+	ErrorValidationWebAuthnAuthenticatorNotAllowed
+	ErrorValidationWebAuthnCloneDetected
+	ErrorValidationNoOTPAddress
+	ErrorValidationOTPCodeInvalid
+	ErrorValidationOTPResendThrottled
+	ErrorValidationTOTPCodeAlreadyUsed
+	ErrorValidationNoHOTPDevice
+	ErrorValidationHOTPResyncRequired
+	ErrorValidationAccountNotFound
+	ErrorValidationOIDCRequirementsNotMet
 )
 
 const (
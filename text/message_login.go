@@ -183,3 +183,51 @@ func NewInfoSelfServiceLoginContinue() *Message {
 		Type: Info,
 	}
 }
+
+func NewInfoSelfServiceLoginPasskey() *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginPasskey,
+		Text: "Sign in with passkey",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceLoginOTPSend(address string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginOTPSend,
+		Text: fmt.Sprintf("Send code to %s", address),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"address": address,
+		}),
+	}
+}
+
+func NewInfoSelfServiceLoginOTPSent(address string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginOTPSent,
+		Text: fmt.Sprintf("A sign in code has been sent to %s.", address),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"address": address,
+		}),
+	}
+}
+
+func NewInfoLoginHOTPLabel() *Message {
+	return &Message{
+		ID:      InfoSelfServiceLoginHOTPLabel,
+		Type:    Info,
+		Text:    "Hardware token code",
+		Context: context(nil),
+	}
+}
+
+func NewInfoLoginHOTP() *Message {
+	return &Message{
+		ID:      InfoLoginHOTP,
+		Text:    "Use hardware token",
+		Type:    Info,
+		Context: context(map[string]interface{}{}),
+	}
+}
@@ -74,3 +74,11 @@ func NewInfoNodeResendOTP() *Message {
 		Type: Info,
 	}
 }
+
+func NewInfoNodeLabelRememberDevice() *Message {
+	return &Message{
+		ID:   InfoNodeLabelRememberDevice,
+		Text: "Remember this device",
+		Type: Info,
+	}
+}
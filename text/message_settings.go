@@ -183,3 +183,82 @@ func NewInfoSelfServiceRemoveWebAuthn(name string, createdAt time.Time) *Message
 		}),
 	}
 }
+
+func NewInfoSelfServiceRemoveWebAuthnAuthenticator(name, authenticator string, createdAt time.Time) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsRemoveWebAuthnAuthenticator,
+		Text: fmt.Sprintf("Remove security key \"%s\" (%s)", name, authenticator),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"display_name":       name,
+			"authenticator_name": authenticator,
+			"added_at":           createdAt,
+		}),
+	}
+}
+
+func NewInfoSelfServiceRevokeTrustedDevice(userAgent, ipAddress string, createdAt, expiresAt time.Time) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsRevokeTrustedDevice,
+		Text: fmt.Sprintf("Revoke trusted device \"%s\" (%s)", userAgent, ipAddress),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"user_agent": userAgent,
+			"ip_address": ipAddress,
+			"added_at":   createdAt,
+			"expires_at": expiresAt,
+		}),
+	}
+}
+
+func NewInfoSelfServiceSettingsEnableOTP(address string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsEnableOTP,
+		Text: fmt.Sprintf("Send sign in codes to %s", address),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"address": address,
+		}),
+	}
+}
+
+func NewInfoSelfServiceSettingsDisableOTP(address string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsDisableOTP,
+		Text: fmt.Sprintf("Stop sending sign in codes to %s", address),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"address": address,
+		}),
+	}
+}
+
+func NewInfoSelfServiceSettingsMFAEnrollmentPending(deadline time.Time) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsMFAEnrollmentPending,
+		Text: fmt.Sprintf("Please set up a second factor before %s.", deadline.UTC().Format(time.RFC1123)),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"deadline": deadline,
+		}),
+	}
+}
+
+func NewInfoSelfServiceSettingsMFAEnrollmentOverdue(deadline time.Time) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsMFAEnrollmentOverdue,
+		Text: "You have to set up a second factor before you can continue.",
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"deadline": deadline,
+		}),
+	}
+}
+
+func NewInfoSelfServiceSettingsUpdateUnlinkHOTP() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsUpdateUnlinkHOTP,
+		Text: "Unlink hardware token",
+		Type: Info,
+	}
+}
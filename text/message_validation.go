@@ -182,6 +182,15 @@ func NewErrorValidationTOTPVerifierWrong() *Message {
 	}
 }
 
+func NewErrorValidationTOTPCodeAlreadyUsed() *Message {
+	return &Message{
+		ID:      ErrorValidationTOTPCodeAlreadyUsed,
+		Text:    "This authentication code has already been used, please wait for the next one.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
 func NewErrorValidationLookupAlreadyUsed() *Message {
 	return &Message{
 		ID:      ErrorValidationLookupAlreadyUsed,
@@ -251,3 +260,89 @@ func NewErrorValidationSuchNoWebAuthnUser() *Message {
 		Context: context(nil),
 	}
 }
+
+func NewErrorValidationWebAuthnCloneDetected() *Message {
+	return &Message{
+		ID:      ErrorValidationWebAuthnCloneDetected,
+		Text:    "This security key might have been cloned and can no longer be used. Please sign in with a different method and register the security key again.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationWebAuthnAuthenticatorNotAllowed() *Message {
+	return &Message{
+		ID:      ErrorValidationWebAuthnAuthenticatorNotAllowed,
+		Text:    "This security key is not permitted. Please use a different security key.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationNoOTPAddress() *Message {
+	return &Message{
+		ID:      ErrorValidationNoOTPAddress,
+		Text:    "You have no address set up to receive sign in codes.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationOTPCodeInvalid() *Message {
+	return &Message{
+		ID:      ErrorValidationOTPCodeInvalid,
+		Text:    "The sign in code is invalid or has expired. Please request a new code.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationOTPResendThrottled(seconds int) *Message {
+	return &Message{
+		ID:   ErrorValidationOTPResendThrottled,
+		Text: fmt.Sprintf("Please wait %d seconds before requesting another code.", seconds),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"seconds": seconds,
+		}),
+	}
+}
+
+func NewErrorValidationNoHOTPDevice() *Message {
+	return &Message{
+		ID:      ErrorValidationNoHOTPDevice,
+		Text:    "You have no hardware token set up.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationHOTPResyncRequired() *Message {
+	return &Message{
+		ID:      ErrorValidationHOTPResyncRequired,
+		Text:    "Your hardware token is out of sync. Please enter the next code of your hardware token.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationAccountNotFound() *Message {
+	return &Message{
+		ID:      ErrorValidationAccountNotFound,
+		Text:    "This account does not exist or has no login method configured.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationOIDCRequirementsNotMet(provider, reason string) *Message {
+	return &Message{
+		ID:   ErrorValidationOIDCRequirementsNotMet,
+		Text: fmt.Sprintf("You are not allowed to sign in with %s. %s", provider, reason),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"provider": provider,
+			"reason":   reason,
+		}),
+	}
+}
@@ -21,12 +21,13 @@ const (
 )
 
 const (
-	InputAttributeAutocompleteEmail           UiNodeInputAttributeAutocomplete = "email"
-	InputAttributeAutocompleteTel             UiNodeInputAttributeAutocomplete = "tel"
-	InputAttributeAutocompleteUrl             UiNodeInputAttributeAutocomplete = "url"
-	InputAttributeAutocompleteCurrentPassword UiNodeInputAttributeAutocomplete = "current-password"
-	InputAttributeAutocompleteNewPassword     UiNodeInputAttributeAutocomplete = "new-password"
-	InputAttributeAutocompleteOneTimeCode     UiNodeInputAttributeAutocomplete = "one-time-code"
+	InputAttributeAutocompleteEmail            UiNodeInputAttributeAutocomplete = "email"
+	InputAttributeAutocompleteTel              UiNodeInputAttributeAutocomplete = "tel"
+	InputAttributeAutocompleteUrl              UiNodeInputAttributeAutocomplete = "url"
+	InputAttributeAutocompleteCurrentPassword  UiNodeInputAttributeAutocomplete = "current-password"
+	InputAttributeAutocompleteNewPassword      UiNodeInputAttributeAutocomplete = "new-password"
+	InputAttributeAutocompleteOneTimeCode      UiNodeInputAttributeAutocomplete = "one-time-code"
+	InputAttributeAutocompleteUsernameWebAuthn UiNodeInputAttributeAutocomplete = "username webauthn"
 )
 
 // swagger:enum UiNodeInputAttributeType
@@ -93,6 +94,10 @@ type InputAttributes struct {
 	// used for WebAuthn.
 	OnClick string `json:"onclick,omitempty"`
 
+	// OnLoad may contain javascript which should be executed once the node was rendered. This is
+	// primarily used for the WebAuthn conditional mediation (autofill) UI.
+	OnLoad string `json:"onload,omitempty"`
+
 	// NodeType represents this node's types. It is a mirror of `node.type` and
 	// is primarily used to allow compatibility with OpenAPI 3.0.  In this struct it technically always is "input".
 	//
@@ -28,3 +28,20 @@ const (
 	WebAuthnRemove              = "webauthn_remove"
 	WebAuthnScript              = "webauthn_script"
 )
+
+const (
+	TrustedDeviceRemember = "trusted_device_remember"
+	TrustedDeviceRevoke   = "trusted_device_revoke"
+)
+
+const (
+	OTPSend    = "otp_send"
+	OTPCode    = "otp_code"
+	OTPEnable  = "otp_enable"
+	OTPDisable = "otp_disable"
+)
+
+const (
+	HOTPCode   = "hotp_code"
+	HOTPUnlink = "hotp_unlink"
+)
@@ -21,6 +21,8 @@ import (
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/strategy/code"
 	"github.com/ory/kratos/selfservice/strategy/link"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/selfservice/strategy/otp"
 	"github.com/ory/kratos/session"
 )
 
@@ -32,6 +34,8 @@ func CleanSQL(t *testing.T, c *pop.Connection) {
 		new(courier.Message).TableName(ctx),
 
 		new(session.Device).TableName(ctx),
+		new(session.TrustedDevice).TableName(ctx),
+		new(session.RevocationNotification).TableName(ctx),
 		new(session.Session).TableName(ctx),
 		new(login.Flow).TableName(ctx),
 		new(registration.Flow).TableName(ctx),
@@ -41,6 +45,9 @@ func CleanSQL(t *testing.T, c *pop.Connection) {
 		new(link.VerificationToken).TableName(ctx),
 		new(code.RecoveryCode).TableName(ctx),
 		new(code.VerificationCode).TableName(ctx),
+		new(oidc.StoredProvider).TableName(ctx),
+		new(oidc.UsedLogoutToken).TableName(ctx),
+		new(otp.CodeCounter).TableName(ctx),
 
 		new(recovery.Flow).TableName(ctx),
 